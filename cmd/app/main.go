@@ -4,18 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata"
 
-	validator "github.com/go-playground/validator/v10"
 	"github.com/lumoshiveacademy/todolist/database"
+	"github.com/lumoshiveacademy/todolist/graph"
+	"github.com/lumoshiveacademy/todolist/grpcserver"
 	"github.com/lumoshiveacademy/todolist/handler"
 	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/clock"
 	appConfig "github.com/lumoshiveacademy/todolist/package/config"
 	appLogger "github.com/lumoshiveacademy/todolist/package/logger"
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/lumoshiveacademy/todolist/package/pubsub"
+	"github.com/lumoshiveacademy/todolist/package/validation"
 	"github.com/lumoshiveacademy/todolist/repository"
 	"github.com/lumoshiveacademy/todolist/router"
 	"github.com/lumoshiveacademy/todolist/service"
@@ -39,16 +49,214 @@ func main() {
 		logger.Fatal("database connection failed", zap.Error(err))
 	}
 
-	if err := db.AutoMigrate(&model.TodoList{}); err != nil {
+	if err := db.AutoMigrate(
+		&model.Workspace{},
+		&model.WorkspaceMember{},
+		&model.TodoList{},
+		&model.ShareLink{},
+		&model.User{},
+		&model.RefreshToken{},
+		&model.RevokedAccessToken{},
+		&model.APIKey{},
+		&model.MFARecoveryCode{},
+		&model.UserToken{},
+		&model.AuditLog{},
+		&model.TodoListRevision{},
+		&model.TodoItem{},
+		&model.Reminder{},
+		&model.Notification{},
+		&model.Tag{},
+		&model.TodoListTag{},
+		&model.Attachment{},
+		&model.Comment{},
+		&model.CalendarFeed{},
+	); err != nil {
 		logger.Fatal("auto migrate failed", zap.Error(err))
 	}
+	if err := database.EnforceAppendOnly(db, "audit_logs"); err != nil {
+		logger.Fatal("audit log protection failed", zap.Error(err))
+	}
+
+	location, err := time.LoadLocation(cfg.Database.Timezone)
+	if err != nil {
+		logger.Fatal("invalid database timezone", zap.Error(err))
+	}
 
-	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate := validation.New()
 	todoListRepository := repository.NewTodoListRepository(db)
-	todoListService := service.NewTodoListService(todoListRepository, logger)
+	transactor := repository.NewTransactor(db)
+	auditLogRepository := repository.NewAuditLogRepository(db)
+	auditService := service.NewAuditService(auditLogRepository, logger)
+	auditHandler := handler.NewAuditHandler(auditService, validate, logger)
+	todoListRevisionRepository := repository.NewTodoListRevisionRepository(db)
+	todoItemRepository := repository.NewTodoItemRepository(db)
+	reminderRepository := repository.NewReminderRepository(db)
+	tagRepository := repository.NewTagRepository(db)
+	attachmentRepository := repository.NewAttachmentRepository(db)
+	commentRepository := repository.NewCommentRepository(db)
+	shareLinkRepository := repository.NewShareLinkRepository(db)
+	todoListEvents := pubsub.New[model.TodoListEvent](16)
+	blobStore := newBlobStore(cfg.Attachment)
+	todoListService := service.NewTodoListService(todoListRepository, todoItemRepository, todoListRevisionRepository, tagRepository, attachmentRepository, commentRepository, shareLinkRepository, auditLogRepository, blobStore, transactor, todoListEvents, clock.Real{}, logger)
 	todoListHandler := handler.NewTodoListHandler(todoListService, validate, logger)
+	graphSchema, err := graph.NewSchema(todoListService, todoListEvents, validate, graph.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	}, logger)
+	if err != nil {
+		logger.Fatal("graphql schema setup failed", zap.Error(err))
+	}
+	graphQLHandler := handler.NewGraphQLHandler(graphSchema, logger)
+	tagService := service.NewTagService(tagRepository, todoListRepository, auditLogRepository, transactor, logger)
+	tagHandler := handler.NewTagHandler(tagService, validate, logger)
+	todoItemService := service.NewTodoItemService(todoItemRepository, todoListRepository, reminderRepository, attachmentRepository, commentRepository, auditLogRepository, blobStore, transactor, clock.Real{}, location, logger)
+	todoItemHandler := handler.NewTodoItemHandler(todoItemService, validate, logger)
+	reminderService := service.NewReminderService(reminderRepository, todoItemRepository, logger)
+	reminderHandler := handler.NewReminderHandler(reminderService, validate, logger)
+	attachmentService := service.NewAttachmentService(
+		attachmentRepository,
+		todoListRepository,
+		todoItemRepository,
+		blobStore,
+		service.AttachmentLimits{
+			MaxSize:      int64(cfg.Attachment.MaxSize),
+			AllowedTypes: cfg.Attachment.AllowedTypes,
+		},
+		cfg.Attachment.URLSecret,
+		time.Duration(cfg.Attachment.URLTTL)*time.Second,
+		clock.Real{},
+		logger,
+	)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, int64(cfg.Attachment.MaxSize), logger)
+	notificationRepository := repository.NewNotificationRepository(db)
+	notificationService := service.NewNotificationService(notificationRepository, clock.Real{}, logger)
+	notificationHandler := handler.NewNotificationHandler(notificationService, logger)
+	shareLinkService := service.NewShareLinkService(shareLinkRepository, todoListRepository, clock.Real{}, logger)
+	shareLinkHandler := handler.NewShareLinkHandler(shareLinkService, validate, logger)
+	calendarFeedRepository := repository.NewCalendarFeedRepository(db)
+	calendarService := service.NewCalendarService(calendarFeedRepository, todoItemRepository, reminderRepository, clock.Real{}, location, logger)
+	calendarHandler := handler.NewCalendarHandler(calendarService, logger)
+	workspaceRepository := repository.NewWorkspaceRepository(db)
+	workspaceService := service.NewWorkspaceService(workspaceRepository, transactor, logger)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceService, validate, logger)
 
-	httpRouter := router.New(todoListHandler, logger, cfg.JWT.Secret, cfg.JWT.Issuer)
+	tokenVerifier, err := auth.NewVerifier(cfg.JWT, logger)
+	if err != nil {
+		logger.Fatal("jwt verifier setup failed", zap.Error(err))
+	}
+	tokenSigner := auth.NewSigner(cfg.JWT)
+	userRepository := repository.NewUserRepository(db)
+	commentService := service.NewCommentService(
+		commentRepository,
+		todoListRepository,
+		todoItemRepository,
+		userRepository,
+		notificationRepository,
+		transactor,
+		clock.Real{},
+		logger,
+	)
+	commentHandler := handler.NewCommentHandler(commentService, validate, logger)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	revokedTokenRepository := repository.NewRevokedTokenRepository(db)
+	recoveryCodeRepository := repository.NewMFARecoveryCodeRepository(db)
+	userTokenRepository := repository.NewUserTokenRepository(db)
+	mail := newMailer(cfg.Mail, logger)
+	accountService := service.NewAccountService(
+		userRepository,
+		userTokenRepository,
+		refreshTokenRepository,
+		mail,
+		cfg.App.BaseURL,
+		clock.Real{},
+		logger,
+	)
+	accountHandler := handler.NewAccountHandler(accountService, validate, logger)
+	authService := service.NewAuthService(
+		userRepository,
+		workspaceRepository,
+		refreshTokenRepository,
+		revokedTokenRepository,
+		recoveryCodeRepository,
+		accountService,
+		transactor,
+		tokenSigner,
+		time.Duration(cfg.JWT.RefreshTTL)*time.Second,
+		clock.Real{},
+		logger,
+	)
+	authHandler := handler.NewAuthHandler(authService, validate, logger)
+	apiKeyRepository := repository.NewAPIKeyRepository(db)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepository, userRepository, clock.Real{}, logger)
+	apiKeyHandler := handler.NewAPIKeyHandler(apiKeyService, validate, logger)
+	reminderScheduler := service.NewReminderScheduler(
+		reminderRepository,
+		todoItemRepository,
+		userRepository,
+		map[string]notify.Notifier{
+			model.ReminderChannelWebhook: &notify.Webhook{
+				Client: notify.NewWebhookClient(time.Duration(cfg.Reminder.WebhookTimeout) * time.Second),
+				Secret: cfg.Reminder.WebhookSecret,
+			},
+			model.ReminderChannelEmail: &notify.Email{Mailer: mail, BaseURL: cfg.App.BaseURL},
+			model.ReminderChannelInbox: service.NewInboxNotifier(notificationRepository),
+		},
+		transactor,
+		clock.Real{},
+		cfg.Reminder.BatchSize,
+		cfg.Reminder.MaxAttempts,
+		logger,
+	)
+	rankRebalancer := service.NewRankRebalancer(
+		todoItemRepository,
+		todoListRepository,
+		transactor,
+		cfg.Rank.MaxLength,
+		cfg.Rank.BatchSize,
+		logger,
+	)
+	listArchiver := service.NewListArchiver(
+		todoListRepository,
+		auditLogRepository,
+		transactor,
+		clock.Real{},
+		cfg.Archive.BatchSize,
+		logger,
+	)
+
+	httpRouter := router.New(
+		todoListHandler,
+		todoItemHandler,
+		shareLinkHandler,
+		workspaceHandler,
+		authHandler,
+		accountHandler,
+		apiKeyHandler,
+		auditHandler,
+		reminderHandler,
+		notificationHandler,
+		tagHandler,
+		attachmentHandler,
+		commentHandler,
+		calendarHandler,
+		graphQLHandler,
+		workspaceService,
+		tokenVerifier,
+		authService,
+		apiKeyService,
+		router.Options{ValidateResponses: cfg.App.Debug, MaxBodySize: int64(cfg.App.MaxBodySize)},
+		logger,
+	)
+	grpcServer := grpcserver.New(
+		todoListService,
+		validate,
+		workspaceService,
+		tokenVerifier,
+		authService,
+		apiKeyService,
+		grpcserver.Options{Reflection: cfg.GRPC.Reflection},
+		logger,
+	)
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.App.Port),
 		Handler:      httpRouter,
@@ -60,6 +268,24 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	go tokenVerifier.Start(ctx)
+	go pruneRevokedTokens(ctx, revokedTokenRepository, logger)
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		reminderScheduler.Start(ctx, time.Duration(cfg.Reminder.PollInterval)*time.Second)
+	}()
+	rebalancerDone := make(chan struct{})
+	go func() {
+		defer close(rebalancerDone)
+		rankRebalancer.Start(ctx, time.Duration(cfg.Rank.RebalanceInterval)*time.Second)
+	}()
+	archiverDone := make(chan struct{})
+	go func() {
+		defer close(archiverDone)
+		listArchiver.Start(ctx, time.Duration(cfg.Archive.Interval)*time.Second)
+	}()
+
 	go func() {
 		logger.Info("starting http server", zap.Int("port", cfg.App.Port))
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -67,6 +293,17 @@ func main() {
 		}
 	}()
 
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPC.Port))
+	if err != nil {
+		logger.Fatal("grpc listen failed", zap.Error(err))
+	}
+	go func() {
+		logger.Info("starting grpc server", zap.Int("port", cfg.GRPC.Port))
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Fatal("grpc server error", zap.Error(err))
+		}
+	}()
+
 	<-ctx.Done()
 	logger.Info("shutdown signal received")
 
@@ -76,6 +313,34 @@ func main() {
 	if err := server.Shutdown(shutdownCtx); err != nil {
 		logger.Error("server shutdown failed", zap.Error(err))
 	}
+	grpcStopped := make(chan struct{})
+	go func() {
+		defer close(grpcStopped)
+		grpcServer.GracefulStop()
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Warn("grpc server did not stop in time")
+		grpcServer.Stop()
+	}
+
+	// Let an in-flight reminder batch commit before the database is closed.
+	select {
+	case <-schedulerDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("reminder scheduler did not stop in time")
+	}
+	select {
+	case <-rebalancerDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("rank rebalancer did not stop in time")
+	}
+	select {
+	case <-archiverDone:
+	case <-shutdownCtx.Done():
+		logger.Warn("list archiver did not stop in time")
+	}
 
 	if sqlDB, err := db.DB(); err == nil {
 		_ = sqlDB.Close()
@@ -83,3 +348,55 @@ func main() {
 
 	logger.Info("server shutdown complete")
 }
+
+// newMailer selects the mail transport configured by MAIL_DRIVER.
+func newMailer(cfg appConfig.MailConfig, logger *zap.Logger) mailer.Mailer {
+	if cfg.Driver == "smtp" {
+		return &mailer.SMTP{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.From,
+		}
+	}
+	return &mailer.File{Dir: cfg.FileDir, From: cfg.From, Logger: logger}
+}
+
+// newBlobStore selects the attachment storage configured by
+// ATTACHMENT_DRIVER.
+func newBlobStore(cfg appConfig.AttachmentConfig) blob.Store {
+	if cfg.Driver == "s3" {
+		return &blob.S3{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3PathStyle,
+			Client:          &http.Client{Timeout: time.Minute},
+			Clock:           clock.Real{},
+		}
+	}
+	return &blob.Local{Dir: cfg.LocalDir}
+}
+
+// pruneRevokedTokens periodically removes denylist entries whose access
+// tokens have expired and would be rejected anyway.
+func pruneRevokedTokens(ctx context.Context, revokedTokens repository.RevokedTokenRepository, logger *zap.Logger) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			deleted, err := revokedTokens.DeleteExpired(ctx, now)
+			if err != nil {
+				logger.Warn("prune revoked access tokens failed", zap.Error(err))
+				continue
+			}
+			logger.Debug("pruned revoked access tokens", zap.Int64("deleted", deleted))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// EnforceAppendOnly installs a trigger that rejects UPDATE, DELETE and
+// TRUNCATE on table, so rows can only ever be inserted. It is idempotent and
+// meant to run after migrations.
+func EnforceAppendOnly(db *gorm.DB, table string) error {
+	quoted := db.Statement.Quote(table)
+	statements := []string{
+		`CREATE OR REPLACE FUNCTION reject_append_only_change() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'table % is append-only', TG_TABLE_NAME;
+END;
+$$ LANGUAGE plpgsql`,
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s_append_only ON %s", table, quoted),
+		fmt.Sprintf("CREATE TRIGGER %s_append_only BEFORE UPDATE OR DELETE ON %s FOR EACH ROW EXECUTE FUNCTION reject_append_only_change()", table, quoted),
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %s_append_only_truncate ON %s", table, quoted),
+		fmt.Sprintf("CREATE TRIGGER %s_append_only_truncate BEFORE TRUNCATE ON %s FOR EACH STATEMENT EXECUTE FUNCTION reject_append_only_change()", table, quoted),
+	}
+	for _, statement := range statements {
+		if err := db.Exec(statement).Error; err != nil {
+			return fmt.Errorf("enforce append-only %s: %w", table, err)
+		}
+	}
+	return nil
+}
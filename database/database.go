@@ -37,7 +37,8 @@ func New(cfg config.DatabaseConfig, logger *zap.Logger) (*gorm.DB, error) {
 	})
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger:         gormLogger,
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("open database: %w", err)
@@ -0,0 +1,214 @@
+// Package graph serves the todo list API as GraphQL. Queries and mutations
+// call TodoListService, subscriptions stream the changes it publishes, and
+// lookups that would otherwise run once per list are batched per operation.
+package graph
+
+import (
+	"context"
+	"errors"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// Error codes reported in the "code" extension of GraphQL errors.
+const (
+	CodeBadUserInput    = "BAD_USER_INPUT"
+	CodeForbidden       = "FORBIDDEN"
+	CodeNotFound        = "NOT_FOUND"
+	CodeConflict        = "CONFLICT"
+	CodeQueryTooComplex = "QUERY_TOO_COMPLEX"
+	CodeInternal        = "INTERNAL"
+)
+
+// Error is a GraphQL error with a machine-readable code and, for invalid
+// input, the message of each offending field.
+type Error struct {
+	Message string
+	Code    string
+	Fields  map[string]string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.Code}
+	if len(e.Fields) > 0 {
+		extensions["fields"] = e.Fields
+	}
+	return extensions
+}
+
+// Subscriber streams the todo list changes of all workspaces until ctx is
+// done, such as a pubsub.Broker.
+type Subscriber interface {
+	Subscribe(ctx context.Context) <-chan model.TodoListEvent
+}
+
+// Request is a GraphQL request as sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Operation is a parsed and validated request that is ready to run.
+type Operation struct {
+	request    Request
+	document   *ast.Document
+	definition *ast.OperationDefinition
+}
+
+// IsSubscription reports whether the operation is a subscription, whose
+// results arrive as a stream.
+func (o *Operation) IsSubscription() bool {
+	return o.definition.Operation == ast.OperationTypeSubscription
+}
+
+// IsMutation reports whether the operation is a mutation.
+func (o *Operation) IsMutation() bool {
+	return o.definition.Operation == ast.OperationTypeMutation
+}
+
+// Schema executes GraphQL operations against the todo list API.
+type Schema struct {
+	schema    graphql.Schema
+	todoLists service.TodoListService
+	limits    Limits
+}
+
+// NewSchema builds the GraphQL schema. Operations exceeding limits are
+// rejected before they run.
+func NewSchema(
+	todoLists service.TodoListService,
+	events Subscriber,
+	validate *validator.Validate,
+	limits Limits,
+	logger *zap.Logger,
+) (*Schema, error) {
+	r := &resolver{
+		todoLists: todoLists,
+		events:    events,
+		validate:  validate,
+		logger:    logger,
+	}
+	schema, err := graphql.NewSchema(r.config())
+	if err != nil {
+		return nil, err
+	}
+	return &Schema{
+		schema:    schema,
+		todoLists: todoLists,
+		limits:    limits,
+	}, nil
+}
+
+// Prepare parses and validates req and checks it against the limits.
+func (s *Schema) Prepare(req Request) (*Operation, []gqlerrors.FormattedError) {
+	document, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		return nil, gqlerrors.FormatErrors(err)
+	}
+	if result := graphql.ValidateDocument(&s.schema, document, nil); !result.IsValid {
+		return nil, result.Errors
+	}
+
+	var definition *ast.OperationDefinition
+	fragments := make(map[string]*ast.FragmentDefinition)
+	for _, node := range document.Definitions {
+		switch node := node.(type) {
+		case *ast.OperationDefinition:
+			if req.OperationName == "" || (node.Name != nil && node.Name.Value == req.OperationName) {
+				if definition != nil {
+					return nil, formatError(&Error{
+						Message: "operationName is required when the document has several operations",
+						Code:    CodeBadUserInput,
+					})
+				}
+				definition = node
+			}
+		case *ast.FragmentDefinition:
+			fragments[node.Name.Value] = node
+		}
+	}
+	if definition == nil {
+		return nil, formatError(&Error{
+			Message: "unknown operation " + req.OperationName,
+			Code:    CodeBadUserInput,
+		})
+	}
+	if err := s.limits.check(definition, fragments, req.Variables); err != nil {
+		return nil, formatError(err)
+	}
+	return &Operation{request: req, document: document, definition: definition}, nil
+}
+
+// formatError formats an error raised outside of execution, keeping its
+// extensions.
+func formatError(err *Error) []gqlerrors.FormattedError {
+	return gqlerrors.FormatErrors(&gqlerrors.Error{Message: err.Message, OriginalError: err})
+}
+
+// Execute runs a query or mutation.
+func (s *Schema) Execute(ctx context.Context, op *Operation) *graphql.Result {
+	return graphql.Execute(s.params(ctx, op))
+}
+
+// Subscribe runs a subscription. Results arrive on the returned channel,
+// which is closed once ctx is done.
+func (s *Schema) Subscribe(ctx context.Context, op *Operation) chan *graphql.Result {
+	return graphql.ExecuteSubscription(s.params(ctx, op))
+}
+
+func (s *Schema) params(ctx context.Context, op *Operation) graphql.ExecuteParams {
+	return graphql.ExecuteParams{
+		Schema:        s.schema,
+		AST:           op.document,
+		OperationName: op.request.OperationName,
+		Args:          op.request.Variables,
+		Context:       withLoaders(ctx, s.todoLists),
+	}
+}
+
+// failure converts an error returned by TodoListService into a GraphQL
+// error, logging unexpected ones and hiding their details.
+func (r *resolver) failure(err error, message string) error {
+	var graphErr *Error
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &graphErr):
+		return graphErr
+	case errors.Is(err, service.ErrForbidden):
+		return &Error{Message: "forbidden", Code: CodeForbidden}
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		return &Error{Message: "todo list not found", Code: CodeNotFound}
+	case errors.Is(err, repository.ErrRevisionNotFound):
+		return &Error{Message: "revision not found", Code: CodeNotFound}
+	case errors.Is(err, service.ErrNotTemplate):
+		return &Error{Message: "todo list is not a template", Code: CodeConflict}
+	case errors.Is(err, service.ErrMissingTemplateVariables):
+		return &Error{Message: err.Error(), Code: CodeBadUserInput, Fields: map[string]string{"variables": err.Error()}}
+	case errors.As(err, &validationErrs):
+		fields := make(map[string]string, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			fields[lowerFirst(validationErr.Field())] = validationErr.Error()
+		}
+		return &Error{Message: "invalid input", Code: CodeBadUserInput, Fields: fields}
+	default:
+		r.logger.Error(message, zap.Error(err))
+		return &Error{Message: message, Code: CodeInternal}
+	}
+}
@@ -0,0 +1,329 @@
+package graph_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/lumoshiveacademy/todolist/graph"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/pubsub"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type graphFixture struct {
+	todoLists *mocks.TodoListServiceMock
+	events    *pubsub.Broker[model.TodoListEvent]
+	schema    *graph.Schema
+}
+
+func newGraphFixture(t *testing.T, limits graph.Limits) graphFixture {
+	t.Helper()
+
+	f := graphFixture{
+		todoLists: new(mocks.TodoListServiceMock),
+		events:    pubsub.New[model.TodoListEvent](4),
+	}
+	schema, err := graph.NewSchema(f.todoLists, f.events, validation.New(), limits, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	f.schema = schema
+	return f
+}
+
+var defaultLimits = graph.Limits{MaxDepth: 8, MaxComplexity: 200}
+
+func memberContext(workspaceID uuid.UUID, roles ...string) context.Context {
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Roles:            roles,
+	})
+	return tenant.WithWorkspaceID(ctx, workspaceID)
+}
+
+// run prepares and executes req, returning the result encoded as JSON.
+func (f graphFixture) run(t *testing.T, ctx context.Context, req graph.Request) string {
+	t.Helper()
+
+	op, errs := f.schema.Prepare(req)
+	var result interface{} = map[string]interface{}{"errors": errs}
+	if errs == nil {
+		result = f.schema.Execute(ctx, op)
+	}
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// sameIDs matches a batch of ids in any order; graphql-go resolves the
+// fields of a selection set in no particular order.
+func sameIDs(want ...uuid.UUID) func([]uuid.UUID) bool {
+	return func(got []uuid.UUID) bool {
+		if len(got) != len(want) {
+			return false
+		}
+		for _, id := range want {
+			if !slices.Contains(got, id) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+func TestSchema_TodoLists(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	id := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	dueAt := time.Date(2026, time.November, 1, 9, 0, 0, 0, time.UTC)
+	f.todoLists.On("ListTodoLists", mock.Anything, model.TodoListFilter{
+		DueFilter:       model.DueFilter{Status: model.StatusOpen},
+		Tags:            []string{"home"},
+		IncludeArchived: true,
+	}).Return([]model.TodoListResponse{{
+		ID:       id,
+		OwnerID:  "user-1",
+		Title:    "Groceries",
+		Status:   model.StatusOpen,
+		DueAt:    &dueAt,
+		Progress: &model.Progress{Done: 1, Total: 3},
+	}}, nil)
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{
+		Query: `{ todoLists(status: "open", tags: ["home"], includeArchived: true) { id ownerId title dueAt archivedAt tags { name } progress { done total } } }`,
+	})
+	require.JSONEq(t, `{"data":{"todoLists":[{
+		"id":"11111111-1111-1111-1111-111111111111","ownerId":"user-1","title":"Groceries",
+		"dueAt":"2026-11-01T09:00:00Z","archivedAt":null,"tags":[],"progress":{"done":1,"total":3}
+	}]}}`, body)
+}
+
+func TestSchema_BatchesListAndRevisionLookups(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	f.todoLists.On("GetTodoLists", mock.Anything, mock.MatchedBy(sameIDs(first, second, missing))).Return([]model.TodoListResponse{
+		{ID: first, Title: "Groceries"},
+		{ID: second, Title: "Chores"},
+	}, nil).Once()
+	f.todoLists.On("ListRevisionsByTodoLists", mock.Anything, mock.MatchedBy(sameIDs(first, second))).Return(map[uuid.UUID][]model.TodoListRevisionResponse{
+		first: {{Number: 2, Title: "Groceries"}, {Number: 1, Title: "Shopping"}},
+	}, nil).Once()
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{
+		Query: `query ($first: ID!, $second: ID!, $missing: ID!) {
+			first: todoList(id: $first) { ...list }
+			second: todoList(id: $second) { ...list }
+			missing: todoList(id: $missing) { ...list }
+		}
+		fragment list on TodoList { title revisions { number } }`,
+		Variables: map[string]interface{}{"first": first.String(), "second": second.String(), "missing": missing.String()},
+	})
+	require.JSONEq(t, `{"data":{
+		"first":{"title":"Groceries","revisions":[{"number":2},{"number":1}]},
+		"second":{"title":"Chores","revisions":[]},
+		"missing":null
+	}}`, body)
+	f.todoLists.AssertExpectations(t)
+	f.todoLists.AssertNotCalled(t, "GetTodoList", mock.Anything, mock.Anything)
+	f.todoLists.AssertNotCalled(t, "ListRevisions", mock.Anything, mock.Anything)
+}
+
+func TestSchema_RejectsOperationsOverTheLimits(t *testing.T) {
+	f := newGraphFixture(t, graph.Limits{MaxDepth: 2, MaxComplexity: 4})
+
+	tests := []struct {
+		name    string
+		query   string
+		message string
+	}{
+		{
+			name:    "depth",
+			query:   `{ todoLists { revisions { number } } }`,
+			message: "query depth 3 exceeds the limit of 2",
+		},
+		{
+			name:    "complexity through fragments",
+			query:   `{ a: todoLists(first: 1) { ...f } b: todoLists(first: 1) { ...f } } fragment f on TodoList { id title }`,
+			message: "query complexity 6 exceeds the limit of 4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := f.schema.Prepare(graph.Request{Query: tt.query})
+			require.Len(t, errs, 1)
+			require.Equal(t, tt.message, errs[0].Message)
+			require.Equal(t, graph.CodeQueryTooComplex, errs[0].Extensions["code"])
+		})
+	}
+
+	_, errs := f.schema.Prepare(graph.Request{Query: `{ __schema { types { name fields { name type { name ofType { name } } } } } }`})
+	require.Empty(t, errs)
+}
+
+func TestSchema_WeightsListsByFirst(t *testing.T) {
+	f := newGraphFixture(t, graph.Limits{MaxDepth: 8, MaxComplexity: 100})
+	query := `query ($n: Int) { todoLists(first: $n) { revisions(first: 4) { number } } }`
+
+	_, errs := f.schema.Prepare(graph.Request{Query: query, Variables: map[string]interface{}{"n": 5}})
+	require.Empty(t, errs, "1 + 5 * (1 + 4 * 1) = 26")
+	_, errs = f.schema.Prepare(graph.Request{Query: query})
+	require.Empty(t, errs, "lists without first count as 10 entries: 1 + 10 * 5 = 51")
+
+	_, errs = f.schema.Prepare(graph.Request{Query: query, Variables: map[string]interface{}{"n": 50}})
+	require.Len(t, errs, 1)
+	require.Equal(t, "query complexity 251 exceeds the limit of 100", errs[0].Message)
+}
+
+func TestSchema_MeasuresNestedFragmentsOnce(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	// Each fragment spreads the next one twice, so walking every spread
+	// would visit the last fragment 2^40 times.
+	var query strings.Builder
+	query.WriteString("{ ...f0 }\n")
+	const levels = 40
+	for i := 0; i < levels; i++ {
+		fmt.Fprintf(&query, "fragment f%d on Query { ...f%d ...f%d }\n", i, i+1, i+1)
+	}
+	fmt.Fprintf(&query, "fragment f%d on Query { todoLists(first: 1) { id } }\n", levels)
+
+	done := make(chan []gqlerrors.FormattedError, 1)
+	go func() {
+		_, errs := f.schema.Prepare(graph.Request{Query: query.String()})
+		done <- errs
+	}()
+	select {
+	case errs := <-done:
+		require.Len(t, errs, 1)
+		require.Equal(t, graph.CodeQueryTooComplex, errs[0].Extensions["code"])
+	case <-time.After(5 * time.Second):
+		t.Fatal("measuring nested fragments took too long")
+	}
+}
+
+func TestSchema_TruncatesListsToFirst(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	f.todoLists.On("ListTodoLists", mock.Anything, model.TodoListFilter{}).Return([]model.TodoListResponse{
+		{Title: "Groceries"}, {Title: "Chores"}, {Title: "Garden"},
+	}, nil)
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{Query: `{ todoLists(first: 2) { title } }`})
+	require.JSONEq(t, `{"data":{"todoLists":[{"title":"Groceries"},{"title":"Chores"}]}}`, body)
+
+	body = f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{Query: `{ todoLists(first: 0) { title } }`})
+	require.Contains(t, body, `"first":"must be between 1 and 100"`)
+}
+
+func TestSchema_RejectsInvalidDocuments(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	_, errs := f.schema.Prepare(graph.Request{Query: `{ todoLists { unknown } }`})
+	require.NotEmpty(t, errs)
+
+	_, errs = f.schema.Prepare(graph.Request{Query: `query a { todoLists { id } } query b { todoLists { id } }`})
+	require.Len(t, errs, 1)
+	require.Equal(t, graph.CodeBadUserInput, errs[0].Extensions["code"])
+}
+
+func TestSchema_MutationsRequireWritePermission(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleViewer), graph.Request{
+		Query: `mutation { createTodoList(input: {title: "Groceries"}) { id } }`,
+	})
+	require.Contains(t, body, `"extensions":{"code":"FORBIDDEN"}`)
+	f.todoLists.AssertNotCalled(t, "CreateTodoList", mock.Anything, mock.Anything)
+}
+
+func TestSchema_CreateTodoList(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	id := uuid.New()
+	f.todoLists.On("CreateTodoList", mock.Anything, model.CreateTodoListRequest{Title: "Groceries", Priority: model.PriorityHigh}).
+		Return(model.TodoListResponse{ID: id, Title: "Groceries", Priority: model.PriorityHigh}, nil)
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{
+		Query: `mutation { createTodoList(input: {title: "Groceries", priority: "high"}) { id priority } }`,
+	})
+	require.JSONEq(t, `{"data":{"createTodoList":{"id":"`+id.String()+`","priority":"high"}}}`, body)
+}
+
+func TestSchema_MutationErrors(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	id := uuid.New()
+	f.todoLists.On("ArchiveTodoList", mock.Anything, id).Return(model.TodoListResponse{}, repository.ErrTodoListNotFound)
+
+	body := f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{
+		Query:     `mutation ($id: ID!) { archiveTodoList(id: $id) { id } }`,
+		Variables: map[string]interface{}{"id": id.String()},
+	})
+	require.Contains(t, body, `"message":"todo list not found"`)
+	require.Contains(t, body, `"code":"NOT_FOUND"`)
+
+	body = f.run(t, memberContext(uuid.New(), auth.RoleMember), graph.Request{
+		Query: `mutation { createTodoList(input: {title: "Go", priority: "whenever"}) { id } }`,
+	})
+	require.Contains(t, body, `"code":"BAD_USER_INPUT"`)
+	require.Contains(t, body, `"title":`)
+	require.Contains(t, body, `"priority":`)
+	f.todoLists.AssertNotCalled(t, "CreateTodoList", mock.Anything, mock.Anything)
+}
+
+func TestSchema_SubscriptionStreamsWorkspaceChanges(t *testing.T) {
+	f := newGraphFixture(t, defaultLimits)
+
+	workspaceID, listID := uuid.New(), uuid.New()
+	ctx, cancel := context.WithCancel(memberContext(workspaceID, auth.RoleMember))
+	defer cancel()
+
+	op, errs := f.schema.Prepare(graph.Request{
+		Query:     `subscription ($id: ID) { todoListChanged(id: $id) { type todoList { id title } } }`,
+		Variables: map[string]interface{}{"id": listID.String()},
+	})
+	require.Nil(t, errs)
+	require.True(t, op.IsSubscription())
+	results := f.schema.Subscribe(ctx, op)
+
+	// The subscription registers with the broker asynchronously.
+	event := model.TodoListEvent{Type: model.TodoListEventUpdated, WorkspaceID: workspaceID, TodoList: model.TodoListResponse{ID: listID, Title: "Groceries"}}
+	var result *graphql.Result
+	require.Eventually(t, func() bool {
+		f.events.Publish(model.TodoListEvent{Type: model.TodoListEventUpdated, WorkspaceID: uuid.New(), TodoList: model.TodoListResponse{ID: listID}})
+		f.events.Publish(model.TodoListEvent{Type: model.TodoListEventCreated, WorkspaceID: workspaceID, TodoList: model.TodoListResponse{ID: uuid.New()}})
+		f.events.Publish(event)
+		select {
+		case result = <-results:
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, time.Second, time.Millisecond)
+
+	body, err := json.Marshal(result)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"data":{"todoListChanged":{"type":"UPDATED","todoList":{"id":"`+listID.String()+`","title":"Groceries"}}}}`, string(body))
+
+	cancel()
+	require.Eventually(t, func() bool {
+		for range results {
+		}
+		return true
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,160 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+const (
+	// maxFirst is the largest page a list field's first argument may ask for.
+	maxFirst = 100
+	// defaultListWeight is the number of entries assumed for a list field
+	// queried without first.
+	defaultListWeight = 10
+	// maxCost caps measured costs so that weighting them by a page size
+	// cannot overflow.
+	maxCost = math.MaxInt32 / maxFirst
+)
+
+// listFields are the fields that return pages bounded by their first
+// argument.
+var listFields = map[string]bool{
+	"todoLists":         true,
+	"revisions":         true,
+	"todoListRevisions": true,
+}
+
+// Limits bound the cost of an operation before it runs. MaxDepth caps how
+// deeply selections nest, MaxComplexity the number of fields selected in
+// total, counting fragments once per use and the selections under a list
+// field once per entry its first argument asks for. Introspection fields are
+// exempt so that schema explorers keep working.
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// check reports an error when operation, run with variables, exceeds the
+// limits.
+func (l Limits) check(operation *ast.OperationDefinition, fragments map[string]*ast.FragmentDefinition, variables map[string]interface{}) *Error {
+	m := &measurer{fragments: fragments, variables: variables, memo: make(map[string]cost)}
+	total := m.measure(operation.SelectionSet)
+	if total.depth > l.MaxDepth {
+		return &Error{
+			Message: fmt.Sprintf("query depth %d exceeds the limit of %d", total.depth, l.MaxDepth),
+			Code:    CodeQueryTooComplex,
+		}
+	}
+	if total.complexity > l.MaxComplexity {
+		return &Error{
+			Message: fmt.Sprintf("query complexity %d exceeds the limit of %d", total.complexity, l.MaxComplexity),
+			Code:    CodeQueryTooComplex,
+		}
+	}
+	return nil
+}
+
+type cost struct {
+	depth, complexity int
+}
+
+// measurer computes the cost of selection sets. Each fragment is measured
+// once and its cost reused at every spread, so documents that spread
+// fragments into each other are measured in linear time.
+// Validation has already rejected fragment cycles and unknown fragments.
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	memo      map[string]cost
+}
+
+// measure returns the depth and weighted number of fields of a selection
+// set.
+func (m *measurer) measure(selectionSet *ast.SelectionSet) cost {
+	var total cost
+	if selectionSet == nil {
+		return total
+	}
+	for _, selection := range selectionSet.Selections {
+		var c cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name.Value, "__") {
+				continue
+			}
+			c = m.measure(selection.SelectionSet)
+			if listFields[selection.Name.Value] {
+				c.complexity = saturate(c.complexity * m.first(selection))
+			}
+			c.depth++
+			c.complexity = saturate(c.complexity + 1)
+		case *ast.InlineFragment:
+			c = m.measure(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			c = m.fragment(selection.Name.Value)
+		}
+		total.depth = max(total.depth, c.depth)
+		total.complexity = saturate(total.complexity + c.complexity)
+	}
+	return total
+}
+
+func (m *measurer) fragment(name string) cost {
+	if c, ok := m.memo[name]; ok {
+		return c
+	}
+	var c cost
+	if fragment, ok := m.fragments[name]; ok {
+		c = m.measure(fragment.SelectionSet)
+	}
+	m.memo[name] = c
+	return c
+}
+
+// first returns the page size field asks for, read from a literal or a
+// variable, or defaultListWeight when it does not say. Values out of range
+// count as maxFirst; the resolver rejects them.
+func (m *measurer) first(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "first" {
+			continue
+		}
+		var value interface{}
+		switch v := argument.Value.(type) {
+		case *ast.IntValue:
+			value = v.Value
+		case *ast.Variable:
+			value = m.variables[v.Name.Value]
+		}
+		var n int
+		switch value := value.(type) {
+		case nil:
+			return defaultListWeight
+		case string:
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return maxFirst
+			}
+			n = parsed
+		case float64:
+			n = int(value)
+		case int:
+			n = value
+		default:
+			return maxFirst
+		}
+		if n < 1 || n > maxFirst {
+			return maxFirst
+		}
+		return n
+	}
+	return defaultListWeight
+}
+
+func saturate(n int) int {
+	return min(n, maxCost)
+}
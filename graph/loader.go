@@ -0,0 +1,92 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/service"
+)
+
+// batchLoader collects the keys that resolvers ask for and fetches them with
+// one call once the first of them is needed. Resolvers return the thunk from
+// load; graphql-go resolves thunks breadth first, so every field at one level
+// of the response shares a batch. A new batch starts once the previous one
+// has been fetched, so results are never reused across subscription events.
+type batchLoader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu    sync.Mutex
+	batch *batch[K, V]
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	queued  map[K]bool
+	fetched bool
+	values  map[K]V
+	err     error
+}
+
+// load queues key for the current batch and returns a thunk reporting its
+// value and whether it was found.
+func (l *batchLoader[K, V]) load(ctx context.Context, key K) func() (V, bool, error) {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil || b.fetched {
+		b = &batch[K, V]{queued: make(map[K]bool)}
+		l.batch = b
+	}
+	if !b.queued[key] {
+		b.queued[key] = true
+		b.keys = append(b.keys, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, bool, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !b.fetched {
+			b.values, b.err = l.fetch(ctx, b.keys)
+			b.fetched = true
+		}
+		value, ok := b.values[key]
+		return value, ok, b.err
+	}
+}
+
+// loaders batches the lookups of one GraphQL operation.
+type loaders struct {
+	todoLists *batchLoader[uuid.UUID, model.TodoListResponse]
+	revisions *batchLoader[uuid.UUID, []model.TodoListRevisionResponse]
+}
+
+type loadersKey struct{}
+
+// withLoaders returns a copy of ctx carrying fresh loaders backed by
+// todoLists.
+func withLoaders(ctx context.Context, todoLists service.TodoListService) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{
+		todoLists: &batchLoader[uuid.UUID, model.TodoListResponse]{
+			fetch: func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]model.TodoListResponse, error) {
+				responses, err := todoLists.GetTodoLists(ctx, ids)
+				if err != nil {
+					return nil, err
+				}
+				byID := make(map[uuid.UUID]model.TodoListResponse, len(responses))
+				for _, response := range responses {
+					byID[response.ID] = response
+				}
+				return byID, nil
+			},
+		},
+		revisions: &batchLoader[uuid.UUID, []model.TodoListRevisionResponse]{
+			fetch: todoLists.ListRevisionsByTodoLists,
+		},
+	})
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
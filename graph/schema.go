@@ -0,0 +1,637 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// resolver implements the fields of the schema on top of TodoListService.
+type resolver struct {
+	todoLists service.TodoListService
+	events    Subscriber
+	validate  *validator.Validate
+	logger    *zap.Logger
+}
+
+var tagType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Tag",
+	Fields: graphql.Fields{
+		"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+		"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"color":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+	},
+})
+
+var progressType = graphql.NewObject(graphql.ObjectConfig{
+	Name:        "Progress",
+	Description: "Number of done items out of all items.",
+	Fields: graphql.Fields{
+		"done":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"total": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+	},
+})
+
+var revisionType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TodoListRevision",
+	Fields: graphql.Fields{
+		"number":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		"title":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"description":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"authorId":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		"restoredFrom": &graphql.Field{Type: graphql.Int},
+		"createdAt":    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+	},
+})
+
+var eventTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TodoListEventType",
+	Values: graphql.EnumValueConfigMap{
+		"CREATED": &graphql.EnumValueConfig{Value: model.TodoListEventCreated},
+		"UPDATED": &graphql.EnumValueConfig{Value: model.TodoListEventUpdated},
+		"DELETED": &graphql.EnumValueConfig{Value: model.TodoListEventDeleted},
+	},
+})
+
+var createInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name: "CreateTodoListInput",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"priority":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dueAt":       &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+	},
+})
+
+var updateInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "UpdateTodoListInput",
+	Description: "Omitted status and priority keep their current values; an omitted dueAt clears it.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"title":       &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"description": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"status":      &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"priority":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+		"dueAt":       &graphql.InputObjectFieldConfig{Type: graphql.DateTime},
+	},
+})
+
+var variableInputType = graphql.NewInputObject(graphql.InputObjectConfig{
+	Name:        "TemplateVariableInput",
+	Description: "The value of a {{name}} placeholder of a template.",
+	Fields: graphql.InputObjectConfigFieldMap{
+		"name":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+		"value": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+	},
+})
+
+// idArgument is the required id argument shared by most fields.
+var idArgument = graphql.FieldConfigArgument{
+	"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+}
+
+// firstArgument limits a list field to its first entries. The query
+// complexity counts the selections under the field once per entry.
+var firstArgument = &graphql.ArgumentConfig{
+	Type:        graphql.Int,
+	Description: fmt.Sprintf("Return at most this many entries, between 1 and %d. Without it, all entries are returned and the field counts as %d entries towards the query complexity.", maxFirst, defaultListWeight),
+}
+
+func (r *resolver) config() graphql.SchemaConfig {
+	todoListType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TodoList",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"ownerId":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"title":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"status":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"priority":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"dueAt":       &graphql.Field{Type: graphql.DateTime},
+			"tags": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tagType))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if tags := p.Source.(model.TodoListResponse).Tags; tags != nil {
+						return tags, nil
+					}
+					return []model.TagResponse{}, nil
+				},
+			},
+			"rank":       &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"progress":   &graphql.Field{Type: progressType},
+			"isTemplate": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"archivedAt": &graphql.Field{Type: graphql.DateTime},
+			"createdAt":  &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"updatedAt":  &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"revisions": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(revisionType))),
+				Description: "Revision history, newest first. Loaded for all requested lists at once.",
+				Args:        graphql.FieldConfigArgument{"first": firstArgument},
+				Resolve:     r.revisions,
+			},
+		},
+	})
+	todoListEventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TodoListEvent",
+		Fields: graphql.Fields{
+			"type":     &graphql.Field{Type: graphql.NewNonNull(eventTypeEnum)},
+			"todoList": &graphql.Field{Type: graphql.NewNonNull(todoListType)},
+		},
+	})
+	nonNullList := graphql.NewNonNull(todoListType)
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"todoLists": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.NewList(nonNullList)),
+				Description: "The workspace's lists, or its templates, in sidebar order.",
+				Args: graphql.FieldConfigArgument{
+					"status":          &graphql.ArgumentConfig{Type: graphql.String},
+					"priority":        &graphql.ArgumentConfig{Type: graphql.String},
+					"overdue":         &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"dueBefore":       &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"dueAfter":        &graphql.ArgumentConfig{Type: graphql.DateTime},
+					"tags":            &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String))},
+					"match":           &graphql.ArgumentConfig{Type: graphql.String},
+					"templates":       &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"includeArchived": &graphql.ArgumentConfig{Type: graphql.Boolean},
+					"first":           firstArgument,
+				},
+				Resolve: r.listTodoLists,
+			},
+			"todoList": &graphql.Field{
+				Type:        todoListType,
+				Description: "A list by ID, or null when it does not exist. Lookups in one operation share a query.",
+				Args:        idArgument,
+				Resolve:     r.todoList,
+			},
+			"todoListRevisions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(revisionType))),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"first": firstArgument,
+				},
+				Resolve: r.listRevisions,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTodoList": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createInputType)},
+				},
+				Resolve: r.mutation("could not create todo list", r.createTodoList),
+			},
+			"updateTodoList": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateInputType)},
+				},
+				Resolve: r.mutation("could not update todo list", r.updateTodoList),
+			},
+			"deleteTodoList": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.ID),
+				Description: "Deletes a list with its items and returns its ID.",
+				Args:        idArgument,
+				Resolve:     r.mutation("could not delete todo list", r.deleteTodoList),
+			},
+			"moveTodoList": &graphql.Field{
+				Type:        nonNullList,
+				Description: "Places a list after or before another one, or last when neither is given.",
+				Args: graphql.FieldConfigArgument{
+					"id":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"afterId":  &graphql.ArgumentConfig{Type: graphql.ID},
+					"beforeId": &graphql.ArgumentConfig{Type: graphql.ID},
+				},
+				Resolve: r.mutation("could not move todo list", r.moveTodoList),
+			},
+			"duplicateTodoList": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"title": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: r.mutation("could not duplicate todo list", r.duplicateTodoList),
+			},
+			"setTodoListTemplate": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"id":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"isTemplate": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+				},
+				Resolve: r.mutation("could not update todo list template", r.setTemplate),
+			},
+			"instantiateTemplate": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"id":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"title":     &graphql.ArgumentConfig{Type: graphql.String},
+					"variables": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(variableInputType))},
+				},
+				Resolve: r.mutation("could not instantiate template", r.instantiateTemplate),
+			},
+			"archiveTodoList": &graphql.Field{
+				Type:    nonNullList,
+				Args:    idArgument,
+				Resolve: r.mutation("could not archive todo list", r.byID(r.todoLists.ArchiveTodoList)),
+			},
+			"unarchiveTodoList": &graphql.Field{
+				Type:    nonNullList,
+				Args:    idArgument,
+				Resolve: r.mutation("could not unarchive todo list", r.byID(r.todoLists.UnarchiveTodoList)),
+			},
+			"restoreTodoListRevision": &graphql.Field{
+				Type: nonNullList,
+				Args: graphql.FieldConfigArgument{
+					"id":     &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+					"number": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: r.mutation("could not restore todo list", r.restoreRevision),
+			},
+		},
+	})
+
+	subscription := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"todoListChanged": &graphql.Field{
+				Type:        graphql.NewNonNull(todoListEventType),
+				Description: "Changes to the workspace's lists, or only to the list with the given ID.",
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.ID},
+				},
+				Subscribe: r.subscribeTodoListChanged,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+			},
+		},
+	})
+
+	return graphql.SchemaConfig{
+		Query:        query,
+		Mutation:     mutation,
+		Subscription: subscription,
+	}
+}
+
+func (r *resolver) listTodoLists(p graphql.ResolveParams) (interface{}, error) {
+	filter := model.TodoListFilter{
+		DueFilter: model.DueFilter{
+			Status:    stringArg(p.Args, "status"),
+			Priority:  stringArg(p.Args, "priority"),
+			Overdue:   boolArg(p.Args, "overdue"),
+			DueBefore: timeArg(p.Args, "dueBefore"),
+			DueAfter:  timeArg(p.Args, "dueAfter"),
+		},
+		Tags:            stringsArg(p.Args, "tags"),
+		Match:           stringArg(p.Args, "match"),
+		Templates:       boolArg(p.Args, "templates"),
+		IncludeArchived: boolArg(p.Args, "includeArchived"),
+	}
+	if err := r.validate.StructCtx(p.Context, filter); err != nil {
+		return nil, r.failure(err, "could not fetch todo lists")
+	}
+	first, err := firstArg(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	todoLists, err := r.todoLists.ListTodoLists(p.Context, filter)
+	if err != nil {
+		return nil, r.failure(err, "could not fetch todo lists")
+	}
+	return truncate(todoLists, first), nil
+}
+
+// todoList resolves through the operation's loader, so that every list
+// requested at one level of the response is fetched with a single query.
+func (r *resolver) todoList(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFromContext(p.Context).todoLists.load(p.Context, id)
+	return func() (interface{}, error) {
+		todoList, ok, err := thunk()
+		if err != nil {
+			return nil, r.failure(err, "could not fetch todo list")
+		}
+		if !ok {
+			return nil, nil
+		}
+		return todoList, nil
+	}, nil
+}
+
+// revisions resolves TodoList.revisions through the operation's loader, so
+// that the revisions of all listed lists are fetched with a single query.
+func (r *resolver) revisions(p graphql.ResolveParams) (interface{}, error) {
+	todoList := p.Source.(model.TodoListResponse)
+	first, err := firstArg(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	thunk := loadersFromContext(p.Context).revisions.load(p.Context, todoList.ID)
+	return func() (interface{}, error) {
+		revisions, _, err := thunk()
+		if err != nil {
+			return nil, r.failure(err, "could not fetch revisions")
+		}
+		if revisions == nil {
+			revisions = []model.TodoListRevisionResponse{}
+		}
+		return truncate(revisions, first), nil
+	}, nil
+}
+
+func (r *resolver) listRevisions(p graphql.ResolveParams) (interface{}, error) {
+	id, err := idArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+	first, err := firstArg(p.Args)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := r.todoLists.ListRevisions(p.Context, id)
+	if err != nil {
+		return nil, r.failure(err, "could not fetch revisions")
+	}
+	return truncate(revisions, first), nil
+}
+
+// mutation wraps a mutation resolver with the write permission check that
+// the REST routes get from middleware, and maps its errors.
+func (r *resolver) mutation(message string, run func(ctx context.Context, args map[string]interface{}) (interface{}, error)) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		claims, ok := auth.ClaimsFromContext(p.Context)
+		if !ok || !claims.HasPermission(auth.PermissionTodoListsWrite) {
+			return nil, r.failure(service.ErrForbidden, message)
+		}
+		result, err := run(p.Context, p.Args)
+		if err != nil {
+			return nil, r.failure(err, message)
+		}
+		return result, nil
+	}
+}
+
+// byID adapts a service method taking only the list's ID to a mutation.
+func (r *resolver) byID(call func(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error)) func(context.Context, map[string]interface{}) (interface{}, error) {
+	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+		id, err := idArg(args, "id")
+		if err != nil {
+			return nil, err
+		}
+		return call(ctx, id)
+	}
+}
+
+func (r *resolver) createTodoList(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	input, _ := args["input"].(map[string]interface{})
+	req := model.CreateTodoListRequest{
+		Title:       stringArg(input, "title"),
+		Description: stringArg(input, "description"),
+		Priority:    stringArg(input, "priority"),
+		DueAt:       timeArg(input, "dueAt"),
+	}
+	if err := r.validate.StructCtx(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.todoLists.CreateTodoList(ctx, req)
+}
+
+func (r *resolver) updateTodoList(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	input, _ := args["input"].(map[string]interface{})
+	req := model.UpdateTodoListRequest{
+		Title:       stringArg(input, "title"),
+		Description: stringArg(input, "description"),
+		Status:      stringArg(input, "status"),
+		Priority:    stringArg(input, "priority"),
+		DueAt:       timeArg(input, "dueAt"),
+	}
+	if err := r.validate.StructCtx(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.todoLists.UpdateTodoList(ctx, id, req)
+}
+
+func (r *resolver) deleteTodoList(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	if err := r.todoLists.DeleteTodoList(ctx, id); err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (r *resolver) moveTodoList(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	var req model.MoveTodoListRequest
+	if req.AfterID, err = optionalIDArg(args, "afterId"); err != nil {
+		return nil, err
+	}
+	if req.BeforeID, err = optionalIDArg(args, "beforeId"); err != nil {
+		return nil, err
+	}
+	if err := r.validate.StructCtx(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.todoLists.MoveTodoList(ctx, id, req)
+}
+
+func (r *resolver) duplicateTodoList(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := model.DuplicateTodoListRequest{Title: stringArg(args, "title")}
+	if err := r.validate.StructCtx(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.todoLists.DuplicateTodoList(ctx, id, req)
+}
+
+func (r *resolver) setTemplate(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	return r.todoLists.SetTemplate(ctx, id, boolArg(args, "isTemplate"))
+}
+
+func (r *resolver) instantiateTemplate(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	req := model.InstantiateTemplateRequest{
+		Title:     stringArg(args, "title"),
+		Variables: make(map[string]string),
+	}
+	variables, _ := args["variables"].([]interface{})
+	for _, variable := range variables {
+		variable, _ := variable.(map[string]interface{})
+		req.Variables[stringArg(variable, "name")] = stringArg(variable, "value")
+	}
+	if err := r.validate.StructCtx(ctx, req); err != nil {
+		return nil, err
+	}
+	return r.todoLists.InstantiateTemplate(ctx, id, req)
+}
+
+func (r *resolver) restoreRevision(ctx context.Context, args map[string]interface{}) (interface{}, error) {
+	id, err := idArg(args, "id")
+	if err != nil {
+		return nil, err
+	}
+	number, _ := args["number"].(int)
+	if number < 1 {
+		return nil, &Error{Message: "revision number must be positive", Code: CodeBadUserInput}
+	}
+	return r.todoLists.RestoreRevision(ctx, id, number)
+}
+
+// subscribeTodoListChanged streams the changes to lists of the caller's
+// workspace until the subscription's context is done.
+func (r *resolver) subscribeTodoListChanged(p graphql.ResolveParams) (interface{}, error) {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(p.Context)
+	if !ok {
+		return nil, r.failure(service.ErrForbidden, "could not subscribe")
+	}
+	only, err := optionalIDArg(p.Args, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	events := r.events.Subscribe(p.Context)
+	results := make(chan interface{})
+	go func() {
+		defer close(results)
+		for event := range events {
+			if event.WorkspaceID != workspaceID || (only != nil && event.TodoList.ID != *only) {
+				continue
+			}
+			select {
+			case results <- event:
+			case <-p.Context.Done():
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+func idArg(args map[string]interface{}, name string) (uuid.UUID, error) {
+	value, _ := args[name].(string)
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, &Error{
+			Message: "invalid " + name,
+			Code:    CodeBadUserInput,
+			Fields:  map[string]string{name: "must be a UUID"},
+		}
+	}
+	return id, nil
+}
+
+func optionalIDArg(args map[string]interface{}, name string) (*uuid.UUID, error) {
+	if args[name] == nil {
+		return nil, nil
+	}
+	id, err := idArg(args, name)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// firstArg returns the first argument, or 0 when it is absent.
+func firstArg(args map[string]interface{}) (int, error) {
+	value, ok := args["first"].(int)
+	if !ok {
+		return 0, nil
+	}
+	if value < 1 || value > maxFirst {
+		return 0, &Error{
+			Message: "invalid first",
+			Code:    CodeBadUserInput,
+			Fields:  map[string]string{"first": fmt.Sprintf("must be between 1 and %d", maxFirst)},
+		}
+	}
+	return value, nil
+}
+
+// truncate returns the first n entries of values, or all of them when n is 0.
+func truncate[T any](values []T, n int) []T {
+	if n > 0 && len(values) > n {
+		return values[:n]
+	}
+	return values
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	value, _ := args[name].(string)
+	return value
+}
+
+func stringsArg(args map[string]interface{}, name string) []string {
+	values, _ := args[name].([]interface{})
+	var result []string
+	for _, value := range values {
+		if value, ok := value.(string); ok {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func boolArg(args map[string]interface{}, name string) bool {
+	value, _ := args[name].(bool)
+	return value
+}
+
+func timeArg(args map[string]interface{}, name string) *time.Time {
+	value, ok := args[name].(time.Time)
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+// lowerFirst turns a Go field name into the camel-cased name of the GraphQL
+// argument it was read from.
+func lowerFirst(name string) string {
+	first, size := utf8.DecodeRuneInString(name)
+	if strings.HasSuffix(name, "ID") {
+		name = strings.TrimSuffix(name, "ID") + "Id"
+	}
+	return string(unicode.ToLower(first)) + name[size:]
+}
@@ -0,0 +1,83 @@
+package grpcserver
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failure converts an error returned by validation or TodoListService into a
+// status error, logging unexpected ones and hiding their details.
+func (s *todoListServer) failure(err error, message string) error {
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make(map[string]string, len(validationErrs))
+		for _, validationErr := range validationErrs {
+			fields[snakeCase(validationErr.Field())] = validationErr.Error()
+		}
+		return invalidArgument(fields)
+	case errors.Is(err, service.ErrForbidden):
+		return status.Error(codes.PermissionDenied, "forbidden")
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		return status.Error(codes.NotFound, "todo list not found")
+	case errors.Is(err, repository.ErrRevisionNotFound):
+		return status.Error(codes.NotFound, "revision not found")
+	case errors.Is(err, service.ErrNotTemplate):
+		return status.Error(codes.FailedPrecondition, "todo list is not a template")
+	case errors.Is(err, service.ErrMissingTemplateVariables):
+		return invalidArgument(map[string]string{"variables": err.Error()})
+	default:
+		s.logger.Error(message, zap.Error(err))
+		return status.Error(codes.Internal, message)
+	}
+}
+
+// invalidArgument builds an InvalidArgument status whose BadRequest detail
+// lists the message of each offending field, named as in the .proto file.
+func invalidArgument(fields map[string]string) error {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, name := range names {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       name,
+			Description: fields[name],
+		})
+	}
+	st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid request")
+	}
+	return st.Err()
+}
+
+// snakeCase converts a Go field name such as "AfterID" to its proto field
+// name, "after_id".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,173 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/middleware"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Metadata keys read by the authentication interceptors. gRPC lowercases
+// metadata keys, so these match the HTTP headers of the same name.
+const (
+	metadataAuthorization = "authorization"
+	metadataWorkspaceID   = "x-workspace-id"
+)
+
+// authenticator applies the HTTP Authentication, Workspace and
+// RequirePermission middleware to gRPC calls.
+type authenticator struct {
+	verifier   middleware.TokenVerifier
+	denylist   middleware.TokenDenylist
+	apiKeys    middleware.APIKeyAuthenticator
+	workspaces middleware.WorkspaceFinder
+	logger     *zap.Logger
+}
+
+func (a *authenticator) unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns a copy of ctx carrying the caller's claims and
+// workspace, or the status error to fail the call with.
+func (a *authenticator) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if isPublic(fullMethod) {
+		return ctx, nil
+	}
+	permission, ok := methodPermissions[fullMethod]
+	if !ok {
+		a.logger.Error("grpc method has no permission", zap.String("method", fullMethod))
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	claims, err := middleware.Authenticate(ctx, firstValue(md, metadataAuthorization), a.verifier, a.denylist, a.apiKeys, a.logger)
+	if err != nil {
+		if errors.Is(err, middleware.ErrUnauthenticated) {
+			return nil, status.Error(codes.Unauthenticated, "unauthorized")
+		}
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+	ctx = auth.WithClaims(ctx, claims)
+
+	workspaceID, err := middleware.ResolveWorkspace(ctx, a.workspaces, firstValue(md, metadataWorkspaceID), a.logger)
+	if err != nil {
+		switch {
+		case errors.Is(err, middleware.ErrWorkspaceRequired), errors.Is(err, middleware.ErrInvalidWorkspace):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, middleware.ErrWorkspaceMismatch), errors.Is(err, middleware.ErrNotMember):
+			return nil, status.Error(codes.PermissionDenied, "forbidden")
+		case errors.Is(err, repository.ErrWorkspaceNotFound):
+			return nil, status.Error(codes.NotFound, "workspace not found")
+		case errors.Is(err, middleware.ErrMFARequired):
+			return nil, status.Error(codes.PermissionDenied, "mfa required")
+		default:
+			return nil, status.Error(codes.Internal, "could not resolve workspace")
+		}
+	}
+	ctx = tenant.WithWorkspaceID(ctx, workspaceID)
+
+	if !claims.HasPermission(permission) {
+		return nil, status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return ctx, nil
+}
+
+// isPublic reports whether fullMethod belongs to the health or reflection
+// services, which load balancers and tooling call without credentials.
+func isPublic(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") ||
+		strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// serverStream overrides the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// recoverUnary turns panics into Internal errors, like middleware.Recovery.
+func recoverUnary(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Error("panic recovered", zap.Any("error", rec), zap.String("method", info.FullMethod))
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoverStream(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				logger.Error("panic recovered", zap.Any("error", rec), zap.String("method", info.FullMethod))
+				err = status.Error(codes.Internal, "internal server error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// logUnary logs each call, like middleware.Logger.
+func logUnary(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func logStream(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+func logCall(logger *zap.Logger, method string, start time.Time, err error) {
+	logger.Info("grpc call completed",
+		zap.String("method", method),
+		zap.String("code", status.Code(err).String()),
+		zap.Duration("duration", time.Since(start)),
+	)
+}
@@ -0,0 +1,81 @@
+// Package grpcserver serves the todo list API over gRPC. It shares
+// TodoListService with the REST and GraphQL APIs, and authenticates calls
+// with the same credentials and workspace rules as the HTTP middleware.
+package grpcserver
+
+import (
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/middleware"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	todolistv1 "github.com/lumoshiveacademy/todolist/proto/todolist/v1"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// methodPermissions lists the permission each RPC requires. Calls to methods
+// missing here are refused, except for the public health and reflection
+// services.
+var methodPermissions = map[string]string{
+	todolistv1.TodoListService_CreateTodoList_FullMethodName:      auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_ListTodoLists_FullMethodName:       auth.PermissionTodoListsRead,
+	todolistv1.TodoListService_ListTemplates_FullMethodName:       auth.PermissionTodoListsRead,
+	todolistv1.TodoListService_GetTodoList_FullMethodName:         auth.PermissionTodoListsRead,
+	todolistv1.TodoListService_UpdateTodoList_FullMethodName:      auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_DeleteTodoList_FullMethodName:      auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_MoveTodoList_FullMethodName:        auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_DuplicateTodoList_FullMethodName:   auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_MarkTemplate_FullMethodName:        auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_UnmarkTemplate_FullMethodName:      auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_InstantiateTemplate_FullMethodName: auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_ArchiveTodoList_FullMethodName:     auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_UnarchiveTodoList_FullMethodName:   auth.PermissionTodoListsWrite,
+	todolistv1.TodoListService_ListRevisions_FullMethodName:       auth.PermissionTodoListsRead,
+	todolistv1.TodoListService_GetRevision_FullMethodName:         auth.PermissionTodoListsRead,
+	todolistv1.TodoListService_RestoreRevision_FullMethodName:     auth.PermissionTodoListsWrite,
+}
+
+// Options configure New.
+type Options struct {
+	// Reflection registers the server reflection service.
+	Reflection bool
+}
+
+// New builds a gRPC server exposing TodoListService together with the
+// standard health service, and the reflection service when enabled.
+func New(
+	todoLists service.TodoListService,
+	validate *validator.Validate,
+	workspaceFinder middleware.WorkspaceFinder,
+	tokenVerifier middleware.TokenVerifier,
+	tokenDenylist middleware.TokenDenylist,
+	apiKeyAuthenticator middleware.APIKeyAuthenticator,
+	options Options,
+	logger *zap.Logger,
+) *grpc.Server {
+	authenticator := &authenticator{
+		verifier:   tokenVerifier,
+		denylist:   tokenDenylist,
+		apiKeys:    apiKeyAuthenticator,
+		workspaces: workspaceFinder,
+		logger:     logger,
+	}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoverUnary(logger), logUnary(logger), authenticator.unary),
+		grpc.ChainStreamInterceptor(recoverStream(logger), logStream(logger), authenticator.stream),
+	)
+
+	todolistv1.RegisterTodoListServiceServer(server, &todoListServer{
+		service:  todoLists,
+		validate: validate,
+		logger:   logger,
+	})
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	if options.Reflection {
+		reflection.Register(server)
+	}
+	return server
+}
@@ -0,0 +1,255 @@
+package grpcserver_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/grpcserver"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/config"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	todolistv1 "github.com/lumoshiveacademy/todolist/proto/todolist/v1"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	testSecret = "test-secret"
+	testIssuer = "todolist"
+)
+
+type serverFixture struct {
+	todoLists  *mocks.TodoListServiceMock
+	workspaces *mocks.WorkspaceServiceMock
+	conn       *grpc.ClientConn
+	client     todolistv1.TodoListServiceClient
+}
+
+func newServerFixture(t *testing.T) serverFixture {
+	t.Helper()
+
+	logger := zaptest.NewLogger(t)
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Secret:     testSecret,
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{testIssuer},
+		Audiences:  []string{testIssuer},
+	}, logger)
+	require.NoError(t, err)
+
+	denylist := new(mocks.AuthServiceMock)
+	denylist.On("IsAccessTokenRevoked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	f := serverFixture{
+		todoLists:  new(mocks.TodoListServiceMock),
+		workspaces: new(mocks.WorkspaceServiceMock),
+	}
+	f.workspaces.On("GetWorkspace", mock.Anything, mock.Anything).Return(model.WorkspaceResponse{}, nil).Maybe()
+	f.workspaces.On("IsMember", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Maybe()
+
+	server := grpcserver.New(
+		f.todoLists,
+		validation.New(),
+		f.workspaces,
+		verifier,
+		denylist,
+		new(mocks.APIKeyServiceMock),
+		grpcserver.Options{Reflection: true},
+		logger,
+	)
+	listener := bufconn.Listen(1 << 20)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	f.conn, err = grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = f.conn.Close() })
+	f.client = todolistv1.NewTodoListServiceClient(f.conn)
+	return f
+}
+
+// callContext returns a context sending a token for roles and a workspace as
+// metadata.
+func callContext(t *testing.T, roles ...string) context.Context {
+	t.Helper()
+	return metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer "+signToken(t, roles),
+		"x-workspace-id", uuid.NewString(),
+	)
+}
+
+func signToken(t *testing.T, roles []string) string {
+	t.Helper()
+
+	claims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   "user-1",
+			Issuer:    testIssuer,
+			Audience:  jwt.ClaimStrings{testIssuer},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Roles: roles,
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func TestServer_RequiresCredentials(t *testing.T) {
+	f := newServerFixture(t)
+
+	_, err := f.client.ListTodoLists(context.Background(), &todolistv1.ListTodoListsRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer invalid")
+	_, err = f.client.ListTodoLists(ctx, &todolistv1.ListTodoListsRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	f.todoLists.AssertNotCalled(t, "ListTodoLists", mock.Anything, mock.Anything)
+}
+
+func TestServer_RequiresWorkspace(t *testing.T) {
+	f := newServerFixture(t)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+signToken(t, []string{auth.RoleMember}))
+	_, err := f.client.ListTodoLists(ctx, &todolistv1.ListTodoListsRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, "workspace is required", status.Convert(err).Message())
+}
+
+func TestServer_EnforcesPermissions(t *testing.T) {
+	f := newServerFixture(t)
+	f.todoLists.On("ListTodoLists", mock.Anything, model.TodoListFilter{}).Return([]model.TodoListResponse{{ID: uuid.New(), Title: "Groceries"}}, nil)
+
+	resp, err := f.client.ListTodoLists(callContext(t, auth.RoleViewer), &todolistv1.ListTodoListsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetTodoLists(), 1)
+	require.Equal(t, "Groceries", resp.GetTodoLists()[0].GetTitle())
+
+	_, err = f.client.CreateTodoList(callContext(t, auth.RoleViewer), &todolistv1.CreateTodoListRequest{Title: "Groceries"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	f.todoLists.AssertNotCalled(t, "CreateTodoList", mock.Anything, mock.Anything)
+}
+
+func TestServer_CreateTodoList(t *testing.T) {
+	f := newServerFixture(t)
+
+	id := uuid.New()
+	dueAt := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	f.todoLists.On("CreateTodoList", mock.Anything, mock.MatchedBy(func(req model.CreateTodoListRequest) bool {
+		return req.Title == "Groceries" && req.Priority == model.PriorityHigh && req.DueAt != nil && req.DueAt.Equal(dueAt)
+	})).Return(model.TodoListResponse{ID: id, Title: "Groceries", Priority: model.PriorityHigh, DueAt: &dueAt}, nil)
+
+	todoList, err := f.client.CreateTodoList(callContext(t, auth.RoleMember), &todolistv1.CreateTodoListRequest{
+		Title:    "Groceries",
+		Priority: model.PriorityHigh,
+		DueAt:    timestamppb.New(dueAt),
+	})
+	require.NoError(t, err)
+	require.Equal(t, id.String(), todoList.GetId())
+	require.True(t, todoList.GetDueAt().AsTime().Equal(dueAt))
+	require.Nil(t, todoList.GetArchivedAt())
+}
+
+func TestServer_MapsErrorsToStatusCodes(t *testing.T) {
+	f := newServerFixture(t)
+
+	missing := uuid.New()
+	f.todoLists.On("GetTodoList", mock.Anything, missing).Return(model.TodoListResponse{}, repository.ErrTodoListNotFound)
+
+	_, err := f.client.GetTodoList(callContext(t, auth.RoleMember), &todolistv1.GetTodoListRequest{Id: missing.String()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Equal(t, "todo list not found", status.Convert(err).Message())
+
+	tests := []struct {
+		name   string
+		call   func(ctx context.Context) error
+		fields []string
+	}{
+		{
+			name: "invalid id",
+			call: func(ctx context.Context) error {
+				_, err := f.client.GetTodoList(ctx, &todolistv1.GetTodoListRequest{Id: "nope"})
+				return err
+			},
+			fields: []string{"id"},
+		},
+		{
+			name: "invalid payload",
+			call: func(ctx context.Context) error {
+				_, err := f.client.CreateTodoList(ctx, &todolistv1.CreateTodoListRequest{Title: "Go", Priority: "whenever"})
+				return err
+			},
+			fields: []string{"priority", "title"},
+		},
+		{
+			name: "after and before",
+			call: func(ctx context.Context) error {
+				after, before := uuid.NewString(), uuid.NewString()
+				_, err := f.client.MoveTodoList(ctx, &todolistv1.MoveTodoListRequest{Id: uuid.NewString(), AfterId: &after, BeforeId: &before})
+				return err
+			},
+			fields: []string{"after_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call(callContext(t, auth.RoleMember))
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+			var fields []string
+			for _, detail := range status.Convert(err).Details() {
+				for _, violation := range detail.(*errdetails.BadRequest).GetFieldViolations() {
+					fields = append(fields, violation.GetField())
+				}
+			}
+			require.Equal(t, tt.fields, fields)
+		})
+	}
+	f.todoLists.AssertNotCalled(t, "CreateTodoList", mock.Anything, mock.Anything)
+	f.todoLists.AssertNotCalled(t, "MoveTodoList", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestServer_HealthAndReflectionArePublic(t *testing.T) {
+	f := newServerFixture(t)
+
+	health, err := healthpb.NewHealthClient(f.conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_SERVING, health.GetStatus())
+
+	stream, err := reflectionpb.NewServerReflectionClient(f.conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	require.Contains(t, services, todolistv1.TodoListService_ServiceDesc.ServiceName)
+	require.Contains(t, services, healthpb.Health_ServiceDesc.ServiceName)
+}
@@ -0,0 +1,345 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	todolistv1 "github.com/lumoshiveacademy/todolist/proto/todolist/v1"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// todoListServer implements todolistv1.TodoListServiceServer on top of
+// TodoListService. Requests are validated with the same rules as the REST
+// payloads they mirror.
+type todoListServer struct {
+	todolistv1.UnimplementedTodoListServiceServer
+
+	service  service.TodoListService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+func (s *todoListServer) CreateTodoList(ctx context.Context, req *todolistv1.CreateTodoListRequest) (*todolistv1.TodoList, error) {
+	create := model.CreateTodoListRequest{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Priority:    req.GetPriority(),
+		DueAt:       fromTimestamp(req.GetDueAt()),
+	}
+	if err := s.validate.StructCtx(ctx, create); err != nil {
+		return nil, s.failure(err, "could not create todo list")
+	}
+
+	todoList, err := s.service.CreateTodoList(ctx, create)
+	if err != nil {
+		return nil, s.failure(err, "could not create todo list")
+	}
+	return toTodoList(todoList), nil
+}
+
+func (s *todoListServer) ListTodoLists(ctx context.Context, req *todolistv1.ListTodoListsRequest) (*todolistv1.ListTodoListsResponse, error) {
+	return s.list(ctx, req, false)
+}
+
+func (s *todoListServer) ListTemplates(ctx context.Context, req *todolistv1.ListTodoListsRequest) (*todolistv1.ListTodoListsResponse, error) {
+	return s.list(ctx, req, true)
+}
+
+func (s *todoListServer) list(ctx context.Context, req *todolistv1.ListTodoListsRequest, templates bool) (*todolistv1.ListTodoListsResponse, error) {
+	filter := model.TodoListFilter{
+		DueFilter: model.DueFilter{
+			Status:    req.GetStatus(),
+			Priority:  req.GetPriority(),
+			Overdue:   req.GetOverdue(),
+			DueBefore: fromTimestamp(req.GetDueBefore()),
+			DueAfter:  fromTimestamp(req.GetDueAfter()),
+		},
+		Tags:            req.GetTags(),
+		Match:           req.GetMatch(),
+		Templates:       templates,
+		IncludeArchived: req.GetIncludeArchived(),
+	}
+	if err := s.validate.StructCtx(ctx, filter); err != nil {
+		return nil, s.failure(err, "could not fetch todo lists")
+	}
+
+	todoLists, err := s.service.ListTodoLists(ctx, filter)
+	if err != nil {
+		return nil, s.failure(err, "could not fetch todo lists")
+	}
+	resp := &todolistv1.ListTodoListsResponse{TodoLists: make([]*todolistv1.TodoList, 0, len(todoLists))}
+	for _, todoList := range todoLists {
+		resp.TodoLists = append(resp.TodoLists, toTodoList(todoList))
+	}
+	return resp, nil
+}
+
+func (s *todoListServer) GetTodoList(ctx context.Context, req *todolistv1.GetTodoListRequest) (*todolistv1.TodoList, error) {
+	return s.byID(ctx, req.GetId(), "could not fetch todo list", s.service.GetTodoList)
+}
+
+func (s *todoListServer) UpdateTodoList(ctx context.Context, req *todolistv1.UpdateTodoListRequest) (*todolistv1.TodoList, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	update := model.UpdateTodoListRequest{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Status:      req.GetStatus(),
+		Priority:    req.GetPriority(),
+		DueAt:       fromTimestamp(req.GetDueAt()),
+	}
+	if err := s.validate.StructCtx(ctx, update); err != nil {
+		return nil, s.failure(err, "could not update todo list")
+	}
+
+	todoList, err := s.service.UpdateTodoList(ctx, id, update)
+	if err != nil {
+		return nil, s.failure(err, "could not update todo list")
+	}
+	return toTodoList(todoList), nil
+}
+
+func (s *todoListServer) DeleteTodoList(ctx context.Context, req *todolistv1.DeleteTodoListRequest) (*emptypb.Empty, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteTodoList(ctx, id); err != nil {
+		return nil, s.failure(err, "could not delete todo list")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *todoListServer) MoveTodoList(ctx context.Context, req *todolistv1.MoveTodoListRequest) (*todolistv1.TodoList, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	var move model.MoveTodoListRequest
+	if req.AfterId != nil {
+		afterID, err := parseID("after_id", req.GetAfterId())
+		if err != nil {
+			return nil, err
+		}
+		move.AfterID = &afterID
+	}
+	if req.BeforeId != nil {
+		beforeID, err := parseID("before_id", req.GetBeforeId())
+		if err != nil {
+			return nil, err
+		}
+		move.BeforeID = &beforeID
+	}
+	if err := s.validate.StructCtx(ctx, move); err != nil {
+		return nil, s.failure(err, "could not move todo list")
+	}
+
+	todoList, err := s.service.MoveTodoList(ctx, id, move)
+	if err != nil {
+		return nil, s.failure(err, "could not move todo list")
+	}
+	return toTodoList(todoList), nil
+}
+
+func (s *todoListServer) DuplicateTodoList(ctx context.Context, req *todolistv1.DuplicateTodoListRequest) (*todolistv1.TodoList, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	duplicate := model.DuplicateTodoListRequest{Title: req.GetTitle()}
+	if err := s.validate.StructCtx(ctx, duplicate); err != nil {
+		return nil, s.failure(err, "could not duplicate todo list")
+	}
+
+	todoList, err := s.service.DuplicateTodoList(ctx, id, duplicate)
+	if err != nil {
+		return nil, s.failure(err, "could not duplicate todo list")
+	}
+	return toTodoList(todoList), nil
+}
+
+func (s *todoListServer) MarkTemplate(ctx context.Context, req *todolistv1.MarkTemplateRequest) (*todolistv1.TodoList, error) {
+	return s.byID(ctx, req.GetId(), "could not update template", func(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+		return s.service.SetTemplate(ctx, id, true)
+	})
+}
+
+func (s *todoListServer) UnmarkTemplate(ctx context.Context, req *todolistv1.UnmarkTemplateRequest) (*todolistv1.TodoList, error) {
+	return s.byID(ctx, req.GetId(), "could not update template", func(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+		return s.service.SetTemplate(ctx, id, false)
+	})
+}
+
+func (s *todoListServer) InstantiateTemplate(ctx context.Context, req *todolistv1.InstantiateTemplateRequest) (*todolistv1.TodoList, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	instantiate := model.InstantiateTemplateRequest{
+		Title:     req.GetTitle(),
+		Variables: req.GetVariables(),
+	}
+	if err := s.validate.StructCtx(ctx, instantiate); err != nil {
+		return nil, s.failure(err, "could not instantiate template")
+	}
+
+	todoList, err := s.service.InstantiateTemplate(ctx, id, instantiate)
+	if err != nil {
+		return nil, s.failure(err, "could not instantiate template")
+	}
+	return toTodoList(todoList), nil
+}
+
+func (s *todoListServer) ArchiveTodoList(ctx context.Context, req *todolistv1.ArchiveTodoListRequest) (*todolistv1.TodoList, error) {
+	return s.byID(ctx, req.GetId(), "could not archive todo list", s.service.ArchiveTodoList)
+}
+
+func (s *todoListServer) UnarchiveTodoList(ctx context.Context, req *todolistv1.UnarchiveTodoListRequest) (*todolistv1.TodoList, error) {
+	return s.byID(ctx, req.GetId(), "could not unarchive todo list", s.service.UnarchiveTodoList)
+}
+
+func (s *todoListServer) ListRevisions(ctx context.Context, req *todolistv1.ListRevisionsRequest) (*todolistv1.ListRevisionsResponse, error) {
+	id, err := parseID("id", req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := s.service.ListRevisions(ctx, id)
+	if err != nil {
+		return nil, s.failure(err, "could not fetch revisions")
+	}
+	resp := &todolistv1.ListRevisionsResponse{Revisions: make([]*todolistv1.TodoListRevision, 0, len(revisions))}
+	for _, revision := range revisions {
+		resp.Revisions = append(resp.Revisions, toRevision(revision))
+	}
+	return resp, nil
+}
+
+func (s *todoListServer) GetRevision(ctx context.Context, req *todolistv1.GetRevisionRequest) (*todolistv1.TodoListRevision, error) {
+	id, number, err := parseRevision(req.GetId(), req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	revision, err := s.service.GetRevision(ctx, id, number)
+	if err != nil {
+		return nil, s.failure(err, "could not fetch revision")
+	}
+	return toRevision(revision), nil
+}
+
+func (s *todoListServer) RestoreRevision(ctx context.Context, req *todolistv1.RestoreRevisionRequest) (*todolistv1.TodoList, error) {
+	id, number, err := parseRevision(req.GetId(), req.GetNumber())
+	if err != nil {
+		return nil, err
+	}
+	todoList, err := s.service.RestoreRevision(ctx, id, number)
+	if err != nil {
+		return nil, s.failure(err, "could not restore revision")
+	}
+	return toTodoList(todoList), nil
+}
+
+// byID runs an RPC that takes nothing but the list ID.
+func (s *todoListServer) byID(
+	ctx context.Context,
+	rawID, message string,
+	call func(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error),
+) (*todolistv1.TodoList, error) {
+	id, err := parseID("id", rawID)
+	if err != nil {
+		return nil, err
+	}
+	todoList, err := call(ctx, id)
+	if err != nil {
+		return nil, s.failure(err, message)
+	}
+	return toTodoList(todoList), nil
+}
+
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, invalidArgument(map[string]string{field: "must be a valid UUID"})
+	}
+	return id, nil
+}
+
+func parseRevision(rawID string, number int32) (uuid.UUID, int, error) {
+	id, err := parseID("id", rawID)
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	if number < 1 {
+		return uuid.Nil, 0, invalidArgument(map[string]string{"number": "must be positive"})
+	}
+	return id, int(number), nil
+}
+
+func toTodoList(todoList model.TodoListResponse) *todolistv1.TodoList {
+	msg := &todolistv1.TodoList{
+		Id:          todoList.ID.String(),
+		OwnerId:     todoList.OwnerID,
+		Title:       todoList.Title,
+		Description: todoList.Description,
+		Status:      todoList.Status,
+		Priority:    todoList.Priority,
+		DueAt:       toTimestamp(todoList.DueAt),
+		Rank:        todoList.Rank,
+		IsTemplate:  todoList.IsTemplate,
+		ArchivedAt:  toTimestamp(todoList.ArchivedAt),
+		CreatedAt:   timestamppb.New(todoList.CreatedAt),
+		UpdatedAt:   timestamppb.New(todoList.UpdatedAt),
+	}
+	for _, tag := range todoList.Tags {
+		msg.Tags = append(msg.Tags, &todolistv1.Tag{
+			Id:        tag.ID.String(),
+			Name:      tag.Name,
+			Color:     tag.Color,
+			CreatedAt: timestamppb.New(tag.CreatedAt),
+		})
+	}
+	if todoList.Progress != nil {
+		msg.Progress = &todolistv1.Progress{
+			Done:  int32(todoList.Progress.Done),
+			Total: int32(todoList.Progress.Total),
+		}
+	}
+	return msg
+}
+
+func toRevision(revision model.TodoListRevisionResponse) *todolistv1.TodoListRevision {
+	msg := &todolistv1.TodoListRevision{
+		Number:      int32(revision.Number),
+		Title:       revision.Title,
+		Description: revision.Description,
+		AuthorId:    revision.AuthorID,
+		CreatedAt:   timestamppb.New(revision.CreatedAt),
+	}
+	if revision.RestoredFrom != nil {
+		restoredFrom := int32(*revision.RestoredFrom)
+		msg.RestoredFrom = &restoredFrom
+	}
+	return msg
+}
+
+func toTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
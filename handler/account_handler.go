@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// AccountHandler exposes HTTP handlers for password reset and email verification.
+type AccountHandler struct {
+	service  service.AccountService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewAccountHandler constructs an AccountHandler.
+func NewAccountHandler(service service.AccountService, validate *validator.Validate, logger *zap.Logger) *AccountHandler {
+	return &AccountHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// ForgotPassword handles POST /auth/forgot-password requests. It answers 202
+// whether or not the email belongs to an account.
+func (h *AccountHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid forgot password payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("forgot password validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	if err := h.service.ForgotPassword(r.Context(), req); err != nil {
+		h.logger.Error("forgot password failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not send password reset email",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusAccepted, response.Success(map[string]string{
+		"message": "if the email is registered, a password reset link has been sent",
+	}))
+}
+
+// ResetPassword handles POST /auth/reset-password requests.
+func (h *AccountHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req model.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid reset password payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("reset password validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	if err := h.service.ResetPassword(r.Context(), req); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+				"message": "invalid or expired token",
+			}))
+			return
+		}
+		h.logger.Error("reset password failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not reset password",
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail handles POST /auth/verify-email requests.
+func (h *AccountHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req model.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid verify email payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("verify email validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	if err := h.service.VerifyEmail(r.Context(), req); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+				"message": "invalid or expired token",
+			}))
+			return
+		}
+		h.logger.Error("verify email failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not verify email",
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ResendVerification handles POST /auth/verify-email/resend requests. It
+// answers 202 whether or not the email belongs to an unverified account.
+func (h *AccountHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	var req model.ResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid resend verification payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("resend verification validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	if err := h.service.ResendVerification(r.Context(), req); err != nil {
+		h.logger.Error("resend verification failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not send verification email",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusAccepted, response.Success(map[string]string{
+		"message": "if the email belongs to an unverified account, a verification link has been sent",
+	}))
+}
@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAccountHandler_ForgotPassword_Accepted(t *testing.T) {
+	serviceMock := new(mocks.AccountServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAccountHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	serviceMock.On("ForgotPassword", mock.Anything, model.ForgotPasswordRequest{Email: "ada@example.com"}).Return(nil)
+
+	rr := httptest.NewRecorder()
+	h.ForgotPassword(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/forgot-password",
+		bytes.NewReader([]byte(`{"email":"ada@example.com"}`))))
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAccountHandler_ResetPassword_InvalidToken(t *testing.T) {
+	serviceMock := new(mocks.AccountServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAccountHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	req := model.ResetPasswordRequest{Token: "expired", Password: "battery staple"}
+	serviceMock.On("ResetPassword", mock.Anything, req).Return(service.ErrInvalidUserToken)
+
+	rr := httptest.NewRecorder()
+	h.ResetPassword(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/reset-password",
+		bytes.NewReader([]byte(`{"token":"expired","password":"battery staple"}`))))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAccountHandler_ResetPassword_ShortPassword(t *testing.T) {
+	serviceMock := new(mocks.AccountServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAccountHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	rr := httptest.NewRecorder()
+	h.ResetPassword(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/reset-password",
+		bytes.NewReader([]byte(`{"token":"abc","password":"short"}`))))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything)
+}
+
+func TestAccountHandler_VerifyEmail(t *testing.T) {
+	serviceMock := new(mocks.AccountServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAccountHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	serviceMock.On("VerifyEmail", mock.Anything, model.VerifyEmailRequest{Token: "abc"}).Return(nil)
+
+	rr := httptest.NewRecorder()
+	h.VerifyEmail(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/verify-email",
+		bytes.NewReader([]byte(`{"token":"abc"}`))))
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
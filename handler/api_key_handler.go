@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler exposes HTTP handlers for personal API keys.
+type APIKeyHandler struct {
+	service  service.APIKeyService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewAPIKeyHandler constructs an APIKeyHandler.
+func NewAPIKeyHandler(service service.APIKeyService, validate *validator.Validate, logger *zap.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /api-keys requests.
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid api key create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("api key create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	apiKey, err := h.service.CreateAPIKey(r.Context(), req)
+	if err != nil {
+		if h.writeScopeError(w, err) {
+			return
+		}
+		h.logger.Error("api key creation failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not create api key",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(apiKey))
+}
+
+// List handles GET /api-keys requests.
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := h.service.ListAPIKeys(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			forbidden(w)
+			return
+		}
+		h.logger.Error("list api keys failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch api keys",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(apiKeys))
+}
+
+// Get handles GET /api-keys/{id} requests.
+func (h *APIKeyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid api key id",
+		}))
+		return
+	}
+
+	apiKey, err := h.service.GetAPIKey(r.Context(), id)
+	if err != nil {
+		if h.writeLookupError(w, err) {
+			return
+		}
+		h.logger.Error("get api key failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch api key",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(apiKey))
+}
+
+// Update handles PUT /api-keys/{id} requests.
+func (h *APIKeyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid api key id",
+		}))
+		return
+	}
+
+	var req model.UpdateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid api key update payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("api key update validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	apiKey, err := h.service.UpdateAPIKey(r.Context(), id, req)
+	if err != nil {
+		if h.writeScopeError(w, err) || h.writeLookupError(w, err) {
+			return
+		}
+		h.logger.Error("update api key failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not update api key",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(apiKey))
+}
+
+// Delete handles DELETE /api-keys/{id} requests.
+func (h *APIKeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid api key id",
+		}))
+		return
+	}
+
+	if err := h.service.DeleteAPIKey(r.Context(), id); err != nil {
+		if h.writeLookupError(w, err) {
+			return
+		}
+		h.logger.Error("delete api key failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not delete api key",
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *APIKeyHandler) writeScopeError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrScopeNotGranted):
+		response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+			"message": err.Error(),
+		}))
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	default:
+		return false
+	}
+	return true
+}
+
+func (h *APIKeyHandler) writeLookupError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrAPIKeyNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "api key not found",
+		}))
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	default:
+		return false
+	}
+	return true
+}
+
+func forbidden(w http.ResponseWriter) {
+	response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+		"message": "forbidden",
+	}))
+}
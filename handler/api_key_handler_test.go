@@ -0,0 +1,72 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAPIKeyHandler_Create_ScopeNotGranted(t *testing.T) {
+	serviceMock := new(mocks.APIKeyServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAPIKeyHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	req := model.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"workspaces:write"}}
+	serviceMock.On("CreateAPIKey", mock.Anything, req).
+		Return(model.APIKeyResponse{}, fmt.Errorf("%w: workspaces:write", service.ErrScopeNotGranted))
+
+	rr := httptest.NewRecorder()
+	h.Create(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+		bytes.NewReader([]byte(`{"name":"ci","scopes":["workspaces:write"]}`))))
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAPIKeyHandler_Create_ValidationError(t *testing.T) {
+	serviceMock := new(mocks.APIKeyServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAPIKeyHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	rr := httptest.NewRecorder()
+	h.Create(rr, httptest.NewRequest(http.MethodPost, "/api/v1/api-keys",
+		bytes.NewReader([]byte(`{"name":"ci","scopes":[]}`))))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyHandler_Delete_NotFound(t *testing.T) {
+	serviceMock := new(mocks.APIKeyServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAPIKeyHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	serviceMock.On("DeleteAPIKey", mock.Anything, id).Return(repository.ErrAPIKeyNotFound)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/api-keys/"+id.String(), nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.Delete(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
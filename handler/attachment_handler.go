@@ -0,0 +1,281 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// multipartOverhead is how far a multipart upload may exceed the attachment
+// size limit to leave room for part headers and boundaries.
+const multipartOverhead = 1 << 20
+
+// uploadMemory is how much of an upload is buffered in memory; the rest is
+// spooled to a temporary file.
+const uploadMemory = 1 << 20
+
+// AttachmentHandler exposes HTTP handlers for todo list and item attachments.
+type AttachmentHandler struct {
+	service service.AttachmentService
+	maxSize int64
+	logger  *zap.Logger
+}
+
+// NewAttachmentHandler constructs an AttachmentHandler. Request bodies are
+// cut off once they exceed maxSize by more than the multipart framing needs.
+func NewAttachmentHandler(service service.AttachmentService, maxSize int64, logger *zap.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+		maxSize: maxSize,
+		logger:  logger,
+	}
+}
+
+// Upload handles POST /todolists/{id}/attachments requests.
+func (h *AttachmentHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	h.upload(w, r, todoListID, nil)
+}
+
+// UploadToItem handles POST /todolists/{id}/items/{itemId}/attachments
+// requests.
+func (h *AttachmentHandler) UploadToItem(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+	h.upload(w, r, todoListID, &itemID)
+}
+
+// upload reads the multipart "file" field and passes it to the service.
+func (h *AttachmentHandler) upload(w http.ResponseWriter, r *http.Request, todoListID uuid.UUID, itemID *uuid.UUID) {
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(uploadMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			h.writeError(w, service.ErrAttachmentTooLarge, "could not upload attachment")
+			return
+		}
+		h.logger.Warn("invalid attachment upload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid multipart payload",
+		}))
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+			"file": "a file is required",
+		}))
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.UploadAttachment(r.Context(), todoListID, itemID, model.AttachmentUpload{
+		Filename: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		h.writeError(w, err, "could not upload attachment")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(attachment))
+}
+
+// List handles GET /todolists/{id}/attachments requests.
+func (h *AttachmentHandler) List(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(r.Context(), todoListID, nil)
+	if err != nil {
+		h.writeError(w, err, "could not list attachments")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(attachments))
+}
+
+// ListForItem handles GET /todolists/{id}/items/{itemId}/attachments
+// requests.
+func (h *AttachmentHandler) ListForItem(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(r.Context(), todoListID, &itemID)
+	if err != nil {
+		h.writeError(w, err, "could not list attachments")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(attachments))
+}
+
+// Get handles GET /todolists/{id}/attachments/{attachmentId} requests.
+func (h *AttachmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	todoListID, attachmentID, ok := parseAttachmentParams(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.GetAttachment(r.Context(), todoListID, attachmentID)
+	if err != nil {
+		h.writeError(w, err, "could not get attachment")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(attachment))
+}
+
+// Delete handles DELETE /todolists/{id}/attachments/{attachmentId} requests.
+func (h *AttachmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	todoListID, attachmentID, ok := parseAttachmentParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteAttachment(r.Context(), todoListID, attachmentID); err != nil {
+		h.writeError(w, err, "could not delete attachment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Download handles GET /public/attachments/{id} requests. The URL's expires
+// and signature parameters stand in for authentication.
+func (h *AttachmentHandler) Download(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid attachment id",
+		}))
+		return
+	}
+	expires, err := strconv.ParseInt(r.URL.Query().Get("expires"), 10, 64)
+	if err != nil {
+		h.writeError(w, service.ErrDownloadLinkInvalid, "could not download attachment")
+		return
+	}
+
+	attachment, content, err := h.service.OpenDownload(r.Context(), id, expires, r.URL.Query().Get("signature"))
+	if err != nil {
+		h.writeError(w, err, "could not download attachment")
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", `"`+attachment.Checksum+`"`)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, content); err != nil {
+		h.logger.Warn("attachment download interrupted", zap.String("id", id.String()), zap.Error(err))
+	}
+}
+
+// parseAttachmentParams reads the {id} and {attachmentId} URL parameters,
+// writing a 400 response when either is malformed.
+func parseAttachmentParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	attachmentID, err := parseUUIDParam(r, "attachmentId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid attachment id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return todoListID, attachmentID, true
+}
+
+// writeError maps service errors shared by every attachment endpoint to
+// responses.
+func (h *AttachmentHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo list not found",
+		}))
+	case errors.Is(err, repository.ErrTodoItemNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo item not found",
+		}))
+	case errors.Is(err, repository.ErrAttachmentNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "attachment not found",
+		}))
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		response.Write(w, http.StatusRequestEntityTooLarge, response.Failure(map[string]string{
+			"message": "attachment exceeds the size limit",
+		}))
+	case errors.Is(err, service.ErrAttachmentTypeNotAllowed):
+		response.Write(w, http.StatusUnsupportedMediaType, response.Failure(map[string]string{
+			"message": "attachment type is not allowed",
+		}))
+	case errors.Is(err, service.ErrDownloadLinkInvalid):
+		response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+			"message": "download link is invalid or expired",
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
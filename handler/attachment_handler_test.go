@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// multipartRequest builds an upload request with content in the "file" part.
+func multipartRequest(t *testing.T, filename string, content []byte, params map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := todoItemRequest(http.MethodPost, "/", body.String(), params)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	serviceMock := new(mocks.AttachmentServiceMock)
+	h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	serviceMock.On("UploadAttachment", mock.Anything, listID, (*uuid.UUID)(nil), mock.MatchedBy(func(upload model.AttachmentUpload) bool {
+		content, _ := io.ReadAll(upload.Content)
+		return upload.Filename == "notes.txt" && upload.Size == 5 && string(content) == "hello"
+	})).Return(model.AttachmentResponse{ID: uuid.New(), Filename: "notes.txt"}, nil)
+
+	rr := httptest.NewRecorder()
+	h.Upload(rr, multipartRequest(t, "notes.txt", []byte("hello"), map[string]string{"id": listID.String()}))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.Contains(t, rr.Body.String(), "notes.txt")
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_Upload_BodyTooLarge(t *testing.T) {
+	serviceMock := new(mocks.AttachmentServiceMock)
+	h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	h.Upload(rr, multipartRequest(t, "big.bin", make([]byte, 3<<20), map[string]string{"id": listID}))
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	serviceMock.AssertNotCalled(t, "UploadAttachment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentHandler_Upload_MissingFile(t *testing.T) {
+	serviceMock := new(mocks.AttachmentServiceMock)
+	h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("note", "no file here"))
+	require.NoError(t, writer.Close())
+	listID := uuid.New().String()
+	req := todoItemRequest(http.MethodPost, "/", body.String(), map[string]string{"id": listID})
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	rr := httptest.NewRecorder()
+	h.Upload(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "file")
+}
+
+func TestAttachmentHandler_Upload_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"too large", service.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge},
+		{"type not allowed", service.ErrAttachmentTypeNotAllowed, http.StatusUnsupportedMediaType},
+		{"forbidden", service.ErrForbidden, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.AttachmentServiceMock)
+			h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+			listID, itemID := uuid.New(), uuid.New()
+			serviceMock.On("UploadAttachment", mock.Anything, listID, &itemID, mock.Anything).
+				Return(model.AttachmentResponse{}, tt.err)
+
+			rr := httptest.NewRecorder()
+			h.UploadToItem(rr, multipartRequest(t, "x.exe", []byte("MZ"), map[string]string{
+				"id":     listID.String(),
+				"itemId": itemID.String(),
+			}))
+
+			require.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_Download(t *testing.T) {
+	serviceMock := new(mocks.AttachmentServiceMock)
+	h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	serviceMock.On("OpenDownload", mock.Anything, id, int64(1760000000), "sig").Return(model.Attachment{
+		ID:          id,
+		Filename:    "weekly report.pdf",
+		ContentType: "application/pdf",
+		Size:        8,
+		Checksum:    "abc123",
+	}, io.NopCloser(bytes.NewReader([]byte("%PDF-1.7"))), nil)
+
+	rr := httptest.NewRecorder()
+	h.Download(rr, todoItemRequest(http.MethodGet, "/?expires=1760000000&signature=sig", "", map[string]string{"id": id.String()}))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	require.Equal(t, "8", rr.Header().Get("Content-Length"))
+	require.Equal(t, `attachment; filename="weekly report.pdf"`, rr.Header().Get("Content-Disposition"))
+	require.Equal(t, "nosniff", rr.Header().Get("X-Content-Type-Options"))
+	require.Equal(t, "%PDF-1.7", rr.Body.String())
+}
+
+func TestAttachmentHandler_Download_InvalidLink(t *testing.T) {
+	serviceMock := new(mocks.AttachmentServiceMock)
+	h := handler.NewAttachmentHandler(serviceMock, 1024, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	serviceMock.On("OpenDownload", mock.Anything, id, int64(1), "forged").
+		Return(model.Attachment{}, nil, service.ErrDownloadLinkInvalid)
+
+	rr := httptest.NewRecorder()
+	h.Download(rr, todoItemRequest(http.MethodGet, "/?expires=1&signature=forged", "", map[string]string{"id": id.String()}))
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.Download(rr, todoItemRequest(http.MethodGet, "/?signature=forged", "", map[string]string{"id": id.String()}))
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	serviceMock.AssertNumberOfCalls(t, "OpenDownload", 1)
+}
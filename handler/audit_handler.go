@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+const defaultAuditPageSize = 50
+
+// AuditHandler exposes HTTP handlers for the audit log.
+type AuditHandler struct {
+	service  service.AuditService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewAuditHandler constructs an AuditHandler.
+func NewAuditHandler(service service.AuditService, validate *validator.Validate, logger *zap.Logger) *AuditHandler {
+	return &AuditHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// List handles GET /audit requests. Entries can be filtered by actor_id,
+// action, entity_type, entity_id and an RFC 3339 from/to range, and are
+// paginated with limit and offset.
+func (h *AuditHandler) List(w http.ResponseWriter, r *http.Request) {
+	filter, errs := parseAuditLogFilter(r.URL.Query())
+	if len(errs) > 0 {
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(errs))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), filter); err != nil {
+		h.logger.Warn("audit log filter validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	page, err := h.service.ListAuditLogs(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("list audit logs failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not list audit logs",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(page))
+}
+
+func parseAuditLogFilter(query url.Values) (model.AuditLogFilter, map[string]string) {
+	filter := model.AuditLogFilter{
+		ActorID:    query.Get("actor_id"),
+		Action:     query.Get("action"),
+		EntityType: query.Get("entity_type"),
+		Limit:      defaultAuditPageSize,
+	}
+	errs := make(map[string]string)
+
+	if value := query.Get("entity_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			errs["entity_id"] = "must be a uuid"
+		} else {
+			filter.EntityID = &id
+		}
+	}
+	for name, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := query.Get(name); value != "" {
+			at, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				errs[name] = "must be an RFC 3339 timestamp"
+			} else {
+				*target = &at
+			}
+		}
+	}
+	for name, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := query.Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				errs[name] = "must be an integer"
+			} else {
+				*target = n
+			}
+		}
+	}
+	return filter, errs
+}
@@ -0,0 +1,52 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuditHandler_List_ParsesFilter(t *testing.T) {
+	serviceMock := new(mocks.AuditServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAuditHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	entityID := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	serviceMock.On("ListAuditLogs", mock.Anything, mock.MatchedBy(func(filter model.AuditLogFilter) bool {
+		return filter.Action == model.AuditActionUpdate &&
+			filter.EntityID != nil && *filter.EntityID == entityID &&
+			filter.From != nil && filter.From.Equal(from) && filter.To == nil &&
+			filter.Limit == 50 && filter.Offset == 100
+	})).Return(model.AuditLogPage{Items: []model.AuditLogResponse{}}, nil)
+
+	rr := httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet,
+		"/api/v1/audit?action=update&entity_id="+entityID.String()+"&from=2026-01-01T00:00:00Z&offset=100", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuditHandler_List_InvalidFilter(t *testing.T) {
+	serviceMock := new(mocks.AuditServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	h := handler.NewAuditHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	for _, query := range []string{"entity_id=nope", "from=yesterday", "limit=1000", "action=rename"} {
+		rr := httptest.NewRecorder()
+		h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/audit?"+query, nil))
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, query)
+	}
+	serviceMock.AssertNotCalled(t, "ListAuditLogs", mock.Anything, mock.Anything)
+}
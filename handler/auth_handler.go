@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// AuthHandler exposes HTTP handlers for accounts and sessions.
+type AuthHandler struct {
+	service  service.AuthService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewAuthHandler constructs an AuthHandler.
+func NewAuthHandler(service service.AuthService, validate *validator.Validate, logger *zap.Logger) *AuthHandler {
+	return &AuthHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Register handles POST /auth/register requests.
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var req model.RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid register payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("register validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	user, err := h.service.Register(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserEmailTaken) {
+			response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+				"message": "email already registered",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrUsernameTaken) {
+			response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+				"message": "username already taken",
+			}))
+			return
+		}
+		h.logger.Error("register failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not register user",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(user))
+}
+
+// Login handles POST /auth/login requests.
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req model.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid login payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("login validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	tokens, err := h.service.Login(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			response.Write(w, http.StatusUnauthorized, response.Failure(map[string]string{
+				"message": "invalid email or password",
+			}))
+			return
+		}
+		if errors.Is(err, service.ErrEmailNotVerified) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "email address not verified",
+			}))
+			return
+		}
+		h.logger.Error("login failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not log in",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(tokens))
+}
+
+// Refresh handles POST /auth/refresh requests.
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req model.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid refresh payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("refresh validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	tokens, err := h.service.Refresh(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			response.Write(w, http.StatusUnauthorized, response.Failure(map[string]string{
+				"message": "invalid refresh token",
+			}))
+			return
+		}
+		h.logger.Error("refresh failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not refresh token",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(tokens))
+}
+
+// Logout handles POST /auth/logout requests.
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	var req model.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid logout payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.service.Logout(r.Context(), req); err != nil {
+		h.logger.Error("logout failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not log out",
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyMFA handles POST /auth/mfa/verify requests, the second login step.
+func (h *AuthHandler) VerifyMFA(w http.ResponseWriter, r *http.Request) {
+	var req model.VerifyMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid mfa verify payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("mfa verify validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	tokens, err := h.service.VerifyMFA(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFAChallenge):
+			response.Write(w, http.StatusUnauthorized, response.Failure(map[string]string{
+				"message": "invalid or expired mfa token",
+			}))
+		case errors.Is(err, service.ErrInvalidMFACode):
+			response.Write(w, http.StatusUnauthorized, response.Failure(map[string]string{
+				"message": "invalid mfa code",
+			}))
+		case errors.Is(err, service.ErrMFALocked):
+			response.Write(w, http.StatusTooManyRequests, response.Failure(map[string]string{
+				"message": "too many invalid codes, try again later",
+			}))
+		default:
+			h.logger.Error("mfa verification failed", zap.Error(err))
+			response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+				"message": "could not verify mfa",
+			}))
+		}
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(tokens))
+}
+
+// EnrollMFA handles POST /auth/mfa/enroll requests.
+func (h *AuthHandler) EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.service.EnrollMFA(r.Context())
+	if err != nil {
+		if h.writeMFAError(w, err) {
+			return
+		}
+		h.logger.Error("mfa enrollment failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not start mfa enrollment",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(enrollment))
+}
+
+// ConfirmMFA handles POST /auth/mfa/confirm requests.
+func (h *AuthHandler) ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	var req model.ConfirmMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid mfa confirm payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("mfa confirm validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	codes, err := h.service.ConfirmMFA(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+				"code": "invalid mfa code",
+			}))
+			return
+		}
+		if h.writeMFAError(w, err) {
+			return
+		}
+		h.logger.Error("mfa confirmation failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not confirm mfa",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(codes))
+}
+
+// SetUsername handles PUT /auth/username requests.
+func (h *AuthHandler) SetUsername(w http.ResponseWriter, r *http.Request) {
+	var req model.SetUsernameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid username payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("username validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	user, err := h.service.SetUsername(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUsernameTaken):
+			response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+				"message": "username already taken",
+			}))
+		case errors.Is(err, service.ErrLocalAccountRequired):
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "usernames are only available for registered accounts",
+			}))
+		default:
+			h.logger.Error("set username failed", zap.Error(err))
+			response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+				"message": "could not set username",
+			}))
+		}
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(user))
+}
+
+// SetArchivePolicy handles PUT /auth/archive-policy requests.
+func (h *AuthHandler) SetArchivePolicy(w http.ResponseWriter, r *http.Request) {
+	var req model.SetArchivePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid archive policy payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("archive policy validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	user, err := h.service.SetArchivePolicy(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrLocalAccountRequired) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "archive policies are only available for registered accounts",
+			}))
+			return
+		}
+		h.logger.Error("set archive policy failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not set archive policy",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(user))
+}
+
+func (h *AuthHandler) writeMFAError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, service.ErrLocalAccountRequired):
+		response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+			"message": "mfa is only available for registered accounts",
+		}))
+	case errors.Is(err, service.ErrMFAAlreadyEnabled):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "mfa already enabled",
+		}))
+	case errors.Is(err, service.ErrMFANotEnrolled):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "mfa enrollment not started",
+		}))
+	default:
+		return false
+	}
+	return true
+}
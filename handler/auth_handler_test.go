@@ -0,0 +1,135 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuthHandler_Register_EmailTaken(t *testing.T) {
+	serviceMock := new(mocks.AuthServiceMock)
+	validate := validation.New()
+	h := handler.NewAuthHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	req := model.RegisterRequest{Email: "ada@example.com", Password: "correct horse"}
+	serviceMock.On("Register", mock.Anything, req).Return(model.UserResponse{}, repository.ErrUserEmailTaken)
+
+	rr := httptest.NewRecorder()
+	h.Register(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/register",
+		bytes.NewReader([]byte(`{"email":"ada@example.com","password":"correct horse"}`))))
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuthHandler_Refresh_InvalidToken(t *testing.T) {
+	serviceMock := new(mocks.AuthServiceMock)
+	validate := validation.New()
+	h := handler.NewAuthHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	serviceMock.On("Refresh", mock.Anything, model.RefreshRequest{RefreshToken: "reused"}).
+		Return(model.TokenResponse{}, service.ErrInvalidRefreshToken)
+
+	rr := httptest.NewRecorder()
+	h.Refresh(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/refresh",
+		bytes.NewReader([]byte(`{"refresh_token":"reused"}`))))
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuthHandler_Logout_EmptyBody(t *testing.T) {
+	serviceMock := new(mocks.AuthServiceMock)
+	validate := validation.New()
+	h := handler.NewAuthHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	serviceMock.On("Logout", mock.Anything, model.LogoutRequest{}).Return(nil)
+
+	rr := httptest.NewRecorder()
+	h.Logout(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/logout", http.NoBody))
+
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuthHandler_VerifyMFA_Locked(t *testing.T) {
+	serviceMock := new(mocks.AuthServiceMock)
+	validate := validation.New()
+	h := handler.NewAuthHandler(serviceMock, validate, zaptest.NewLogger(t))
+
+	serviceMock.On("VerifyMFA", mock.Anything, model.VerifyMFARequest{MFAToken: "challenge", Code: "123456"}).
+		Return(model.TokenResponse{}, service.ErrMFALocked)
+
+	rr := httptest.NewRecorder()
+	h.VerifyMFA(rr, httptest.NewRequest(http.MethodPost, "/api/v1/auth/mfa/verify",
+		bytes.NewReader([]byte(`{"mfa_token":"challenge","code":"123456"}`))))
+
+	require.Equal(t, http.StatusTooManyRequests, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestAuthHandler_SetUsername(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  error
+		code int
+	}{
+		{"set", `{"username":"ada"}`, nil, http.StatusOK},
+		{"taken", `{"username":"ada"}`, repository.ErrUsernameTaken, http.StatusConflict},
+		{"invalid", `{"username":"Ada Lovelace"}`, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.AuthServiceMock)
+			h := handler.NewAuthHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+			serviceMock.On("SetUsername", mock.Anything, model.SetUsernameRequest{Username: "ada"}).
+				Return(model.UserResponse{Username: "ada"}, tt.err).Maybe()
+
+			rr := httptest.NewRecorder()
+			h.SetUsername(rr, httptest.NewRequest(http.MethodPut, "/api/v1/auth/username", bytes.NewReader([]byte(tt.body))))
+
+			require.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
+
+func TestAuthHandler_SetArchivePolicy(t *testing.T) {
+	days := 30
+	tests := []struct {
+		name string
+		body string
+		err  error
+		code int
+	}{
+		{"set", `{"after_days":30}`, nil, http.StatusOK},
+		{"local account required", `{"after_days":30}`, service.ErrLocalAccountRequired, http.StatusForbidden},
+		{"too short", `{"after_days":0}`, nil, http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.AuthServiceMock)
+			h := handler.NewAuthHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+			serviceMock.On("SetArchivePolicy", mock.Anything, model.SetArchivePolicyRequest{AfterDays: &days}).
+				Return(model.UserResponse{AutoArchiveAfterDays: &days}, tt.err).Maybe()
+
+			rr := httptest.NewRecorder()
+			h.SetArchivePolicy(rr, httptest.NewRequest(http.MethodPut, "/api/v1/auth/archive-policy", bytes.NewReader([]byte(tt.body))))
+
+			require.Equal(t, tt.code, rr.Code)
+		})
+	}
+}
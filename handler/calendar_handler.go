@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// calendarCacheControl lets calendar clients and proxies reuse a feed for a
+// few minutes before revalidating it with If-None-Match.
+const calendarCacheControl = "private, max-age=300"
+
+// CalendarHandler exposes HTTP handlers for iCalendar feeds.
+type CalendarHandler struct {
+	service service.CalendarService
+	logger  *zap.Logger
+}
+
+// NewCalendarHandler constructs a CalendarHandler.
+func NewCalendarHandler(service service.CalendarService, logger *zap.Logger) *CalendarHandler {
+	return &CalendarHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// CreateFeed handles POST /calendar-feed requests. It issues the caller's
+// feed URL, replacing any previous one.
+func (h *CalendarHandler) CreateFeed(w http.ResponseWriter, r *http.Request) {
+	feed, err := h.service.CreateFeed(r.Context())
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			forbidden(w)
+			return
+		}
+		h.logger.Error("calendar feed creation failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not create calendar feed",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(feed))
+}
+
+// DeleteFeed handles DELETE /calendar-feed requests.
+func (h *CalendarHandler) DeleteFeed(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteFeed(r.Context()); err != nil {
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			forbidden(w)
+		case errors.Is(err, repository.ErrCalendarFeedNotFound):
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "calendar feed not found",
+			}))
+		default:
+			h.logger.Error("calendar feed deletion failed", zap.Error(err))
+			response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+				"message": "could not delete calendar feed",
+			}))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Feed handles unauthenticated GET /calendar/{token}.ics requests. The
+// response carries an ETag of its content, and a matching If-None-Match is
+// answered with 304 Not Modified.
+func (h *CalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	body, err := h.service.RenderFeed(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "calendar feed not found",
+			}))
+			return
+		}
+		h.logger.Error("render calendar feed failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not render calendar feed",
+		}))
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", calendarCacheControl)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		h.logger.Warn("calendar feed write interrupted", zap.Error(err))
+	}
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing
+// weakly as RFC 9110 prescribes for that header.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
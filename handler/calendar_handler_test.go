@@ -0,0 +1,75 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func calendarFeedRequest(rawToken string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/calendar/"+rawToken+".ics", nil)
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("token", rawToken)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestCalendarHandler_Feed_ETag(t *testing.T) {
+	serviceMock := new(mocks.CalendarServiceMock)
+	h := handler.NewCalendarHandler(serviceMock, zaptest.NewLogger(t))
+
+	body := []byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	serviceMock.On("RenderFeed", mock.Anything, "secret").Return(body, nil)
+
+	rr := httptest.NewRecorder()
+	h.Feed(rr, calendarFeedRequest("secret"))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+	require.Equal(t, body, rr.Body.Bytes())
+	etag := rr.Header().Get("ETag")
+	require.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+
+	for _, header := range []string{etag, `"other", W/` + etag, "*"} {
+		req := calendarFeedRequest("secret")
+		req.Header.Set("If-None-Match", header)
+		rr = httptest.NewRecorder()
+		h.Feed(rr, req)
+		require.Equal(t, http.StatusNotModified, rr.Code, header)
+		require.Empty(t, rr.Body.Bytes())
+		require.Equal(t, etag, rr.Header().Get("ETag"))
+	}
+
+	req := calendarFeedRequest("secret")
+	req.Header.Set("If-None-Match", `"stale"`)
+	rr = httptest.NewRecorder()
+	h.Feed(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestCalendarHandler_Feed_NotFound(t *testing.T) {
+	serviceMock := new(mocks.CalendarServiceMock)
+	h := handler.NewCalendarHandler(serviceMock, zaptest.NewLogger(t))
+	serviceMock.On("RenderFeed", mock.Anything, "unknown").Return(nil, repository.ErrCalendarFeedNotFound)
+
+	rr := httptest.NewRecorder()
+	h.Feed(rr, calendarFeedRequest("unknown"))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestCalendarHandler_DeleteFeed_NotFound(t *testing.T) {
+	serviceMock := new(mocks.CalendarServiceMock)
+	h := handler.NewCalendarHandler(serviceMock, zaptest.NewLogger(t))
+	serviceMock.On("DeleteFeed", mock.Anything).Return(repository.ErrCalendarFeedNotFound)
+
+	rr := httptest.NewRecorder()
+	h.DeleteFeed(rr, httptest.NewRequest(http.MethodDelete, "/api/v1/calendar-feed", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
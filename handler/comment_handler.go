@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+const defaultCommentPageSize = 20
+
+// CommentHandler exposes HTTP handlers for comments on todo lists and items.
+type CommentHandler struct {
+	service  service.CommentService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewCommentHandler constructs a CommentHandler.
+func NewCommentHandler(service service.CommentService, validate *validator.Validate, logger *zap.Logger) *CommentHandler {
+	return &CommentHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /todolists/{id}/comments requests.
+func (h *CommentHandler) Create(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	h.create(w, r, todoListID, nil)
+}
+
+// CreateForItem handles POST /todolists/{id}/items/{itemId}/comments
+// requests.
+func (h *CommentHandler) CreateForItem(w http.ResponseWriter, r *http.Request) {
+	todoListID, itemID, ok := parseCommentItemParams(w, r)
+	if !ok {
+		return
+	}
+	h.create(w, r, todoListID, &itemID)
+}
+
+func (h *CommentHandler) create(w http.ResponseWriter, r *http.Request, todoListID uuid.UUID, itemID *uuid.UUID) {
+	var req model.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid comment payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("comment validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	comment, err := h.service.CreateComment(r.Context(), todoListID, itemID, req)
+	if err != nil {
+		h.writeError(w, err, "could not create comment")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(comment))
+}
+
+// List handles GET /todolists/{id}/comments requests. Threads are paginated
+// with limit and offset.
+func (h *CommentHandler) List(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	h.list(w, r, model.CommentFilter{TodoListID: todoListID})
+}
+
+// ListForItem handles GET /todolists/{id}/items/{itemId}/comments requests.
+func (h *CommentHandler) ListForItem(w http.ResponseWriter, r *http.Request) {
+	todoListID, itemID, ok := parseCommentItemParams(w, r)
+	if !ok {
+		return
+	}
+	h.list(w, r, model.CommentFilter{TodoListID: todoListID, TodoItemID: &itemID})
+}
+
+func (h *CommentHandler) list(w http.ResponseWriter, r *http.Request, filter model.CommentFilter) {
+	filter.Limit = defaultCommentPageSize
+	errs := make(map[string]string)
+	for name, target := range map[string]*int{"limit": &filter.Limit, "offset": &filter.Offset} {
+		if value := r.URL.Query().Get(name); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				errs[name] = "must be an integer"
+			} else {
+				*target = n
+			}
+		}
+	}
+	if len(errs) > 0 {
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(errs))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), filter); err != nil {
+		h.logger.Warn("comment filter validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	page, err := h.service.ListComments(r.Context(), filter)
+	if err != nil {
+		h.writeError(w, err, "could not list comments")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(page))
+}
+
+// Update handles PUT /todolists/{id}/comments/{commentId} requests.
+func (h *CommentHandler) Update(w http.ResponseWriter, r *http.Request) {
+	todoListID, commentID, ok := parseCommentParams(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid comment payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("comment validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	comment, err := h.service.UpdateComment(r.Context(), todoListID, commentID, req)
+	if err != nil {
+		h.writeError(w, err, "could not update comment")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(comment))
+}
+
+// Delete handles DELETE /todolists/{id}/comments/{commentId} requests.
+func (h *CommentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	todoListID, commentID, ok := parseCommentParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteComment(r.Context(), todoListID, commentID); err != nil {
+		h.writeError(w, err, "could not delete comment")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseCommentParams reads the {id} and {commentId} URL parameters, writing
+// a 400 response when either is malformed.
+func parseCommentParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	commentID, err := parseUUIDParam(r, "commentId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid comment id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return todoListID, commentID, true
+}
+
+// parseCommentItemParams reads the {id} and {itemId} URL parameters, writing
+// a 400 response when either is malformed.
+func parseCommentItemParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return todoListID, itemID, true
+}
+
+// writeError maps service errors shared by every comment endpoint to
+// responses.
+func (h *CommentHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo list not found",
+		}))
+	case errors.Is(err, repository.ErrTodoItemNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo item not found",
+		}))
+	case errors.Is(err, repository.ErrCommentNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "comment not found",
+		}))
+	case errors.Is(err, service.ErrInvalidCommentParent):
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+			"parent_id": "must be a top-level comment on the same list or item",
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
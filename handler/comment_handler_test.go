@@ -0,0 +1,83 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCommentHandler_Create_RequiresBody(t *testing.T) {
+	serviceMock := new(mocks.CommentServiceMock)
+	h := handler.NewCommentHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	h.Create(rr, todoItemRequest(http.MethodPost, "/", `{"body":""}`, map[string]string{"id": listID}))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "body")
+	serviceMock.AssertNotCalled(t, "CreateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCommentHandler_CreateForItem_InvalidParent(t *testing.T) {
+	serviceMock := new(mocks.CommentServiceMock)
+	h := handler.NewCommentHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID, itemID, parentID := uuid.New(), uuid.New(), uuid.New()
+	serviceMock.On("CreateComment", mock.Anything, listID, &itemID, model.CreateCommentRequest{Body: "+1", ParentID: &parentID}).
+		Return(model.CommentResponse{}, service.ErrInvalidCommentParent)
+
+	rr := httptest.NewRecorder()
+	h.CreateForItem(rr, todoItemRequest(http.MethodPost, "/", `{"body":"+1","parent_id":"`+parentID.String()+`"}`,
+		map[string]string{"id": listID.String(), "itemId": itemID.String()}))
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "parent_id")
+	serviceMock.AssertExpectations(t)
+}
+
+func TestCommentHandler_List_Pagination(t *testing.T) {
+	serviceMock := new(mocks.CommentServiceMock)
+	h := handler.NewCommentHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	serviceMock.On("ListComments", mock.Anything, model.CommentFilter{TodoListID: listID, Limit: 20, Offset: 40}).
+		Return(model.CommentPage{Total: 41, Limit: 20, Offset: 40}, nil)
+
+	rr := httptest.NewRecorder()
+	h.List(rr, todoItemRequest(http.MethodGet, "/?offset=40", "", map[string]string{"id": listID.String()}))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `"total":41`)
+
+	for _, query := range []string{"?limit=0", "?limit=101", "?offset=-1", "?limit=ten"} {
+		rr = httptest.NewRecorder()
+		h.List(rr, todoItemRequest(http.MethodGet, "/"+query, "", map[string]string{"id": listID.String()}))
+		require.Equal(t, http.StatusUnprocessableEntity, rr.Code, query)
+	}
+	serviceMock.AssertNumberOfCalls(t, "ListComments", 1)
+}
+
+func TestCommentHandler_Update_NotAuthor(t *testing.T) {
+	serviceMock := new(mocks.CommentServiceMock)
+	h := handler.NewCommentHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID, commentID := uuid.New(), uuid.New()
+	serviceMock.On("UpdateComment", mock.Anything, listID, commentID, model.UpdateCommentRequest{Body: "mine now"}).
+		Return(model.CommentResponse{}, service.ErrForbidden)
+
+	rr := httptest.NewRecorder()
+	h.Update(rr, todoItemRequest(http.MethodPut, "/", `{"body":"mine now"}`,
+		map[string]string{"id": listID.String(), "commentId": commentID.String()}))
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/graph"
+	"go.uber.org/zap"
+)
+
+// GraphQLHandler serves the GraphQL API. Responses use the GraphQL shape,
+// {"data": ..., "errors": [...]}, rather than the REST envelope.
+type GraphQLHandler struct {
+	schema *graph.Schema
+	logger *zap.Logger
+}
+
+// NewGraphQLHandler constructs a GraphQLHandler.
+func NewGraphQLHandler(schema *graph.Schema, logger *zap.Logger) *GraphQLHandler {
+	return &GraphQLHandler{
+		schema: schema,
+		logger: logger,
+	}
+}
+
+// Serve handles GET and POST /graphql requests. POST bodies carry a JSON
+// request; GET requests pass query, operationName and JSON-encoded variables
+// as URL parameters and cannot run mutations. POST bodies cut short by
+// http.MaxBytesReader are answered with 413. Subscriptions are streamed as
+// server-sent events until the client disconnects or the request times out.
+func (h *GraphQLHandler) Serve(w http.ResponseWriter, r *http.Request) {
+	var req graph.Request
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				h.logger.Warn("invalid graphql variables", zap.Error(err))
+				writeGraphQL(w, http.StatusBadRequest, graphQLFailure("invalid variables"))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeGraphQL(w, http.StatusRequestEntityTooLarge, graphQLFailure("request body too large"))
+			return
+		}
+		h.logger.Warn("invalid graphql payload", zap.Error(err))
+		writeGraphQL(w, http.StatusBadRequest, graphQLFailure("invalid request payload"))
+		return
+	}
+
+	op, errs := h.schema.Prepare(req)
+	if errs != nil {
+		writeGraphQL(w, http.StatusOK, map[string]interface{}{"errors": errs})
+		return
+	}
+	if op.IsMutation() && r.Method == http.MethodGet {
+		w.Header().Set("Allow", http.MethodPost)
+		writeGraphQL(w, http.StatusMethodNotAllowed, graphQLFailure("mutations must be sent with POST"))
+		return
+	}
+	if op.IsSubscription() {
+		h.stream(w, r, op)
+		return
+	}
+
+	writeGraphQL(w, http.StatusOK, h.schema.Execute(r.Context(), op))
+}
+
+// stream writes each subscription result as a "next" event, followed by a
+// "complete" event once the subscription ends.
+func (h *GraphQLHandler) stream(w http.ResponseWriter, r *http.Request, op *graph.Operation) {
+	controller := http.NewResponseController(w)
+	// The server's write timeout would otherwise cut long-lived streams.
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Warn("could not clear graphql stream write deadline", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	_ = controller.Flush()
+
+	for result := range h.schema.Subscribe(r.Context(), op) {
+		data, err := json.Marshal(result)
+		if err != nil {
+			h.logger.Error("graphql subscription result encoding failed", zap.Error(err))
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "event: next\ndata: %s\n\n", data); err != nil {
+			return
+		}
+		_ = controller.Flush()
+	}
+	_, _ = fmt.Fprint(w, "event: complete\ndata:\n\n")
+	_ = controller.Flush()
+}
+
+func graphQLFailure(message string) map[string]interface{} {
+	return map[string]interface{}{
+		"errors": []map[string]string{{"message": message}},
+	}
+}
+
+func writeGraphQL(w http.ResponseWriter, statusCode int, payload interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(payload)
+}
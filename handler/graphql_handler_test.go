@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/graph"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/pubsub"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newGraphQLHandler(t *testing.T, todoLists *mocks.TodoListServiceMock, events *pubsub.Broker[model.TodoListEvent]) *handler.GraphQLHandler {
+	t.Helper()
+
+	logger := zaptest.NewLogger(t)
+	schema, err := graph.NewSchema(todoLists, events, validation.New(), graph.Limits{MaxDepth: 8, MaxComplexity: 200}, logger)
+	require.NoError(t, err)
+	return handler.NewGraphQLHandler(schema, logger)
+}
+
+func graphQLRequest(ctx context.Context, method, target, body string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	ctx = auth.WithClaims(ctx, &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Roles:            []string{auth.RoleMember},
+	})
+	return req.WithContext(ctx)
+}
+
+func TestGraphQLHandler_Query(t *testing.T) {
+	todoLists := new(mocks.TodoListServiceMock)
+	h := newGraphQLHandler(t, todoLists, pubsub.New[model.TodoListEvent](1))
+	todoLists.On("ListTodoLists", mock.Anything, model.TodoListFilter{}).Return([]model.TodoListResponse{{Title: "Groceries"}}, nil)
+	todoLists.On("ListTodoLists", mock.Anything, model.TodoListFilter{DueFilter: model.DueFilter{Status: model.StatusOpen}}).Return([]model.TodoListResponse{{Title: "Chores"}}, nil)
+
+	rr := httptest.NewRecorder()
+	h.Serve(rr, graphQLRequest(context.Background(), http.MethodPost, "/api/v1/graphql", `{"query":"{ todoLists { title } }"}`))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	require.JSONEq(t, `{"data":{"todoLists":[{"title":"Groceries"}]}}`, rr.Body.String())
+
+	query := url.Values{"query": {`query ($status: String) { todoLists(status: $status) { title } }`}, "variables": {`{"status":"open"}`}}
+	rr = httptest.NewRecorder()
+	h.Serve(rr, graphQLRequest(context.Background(), http.MethodGet, "/api/v1/graphql?"+query.Encode(), ""))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"data":{"todoLists":[{"title":"Chores"}]}}`, rr.Body.String())
+}
+
+func TestGraphQLHandler_RejectsInvalidRequests(t *testing.T) {
+	todoLists := new(mocks.TodoListServiceMock)
+	h := newGraphQLHandler(t, todoLists, pubsub.New[model.TodoListEvent](1))
+
+	rr := httptest.NewRecorder()
+	h.Serve(rr, graphQLRequest(context.Background(), http.MethodPost, "/api/v1/graphql", `{"query":`))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.JSONEq(t, `{"errors":[{"message":"invalid request payload"}]}`, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.Serve(rr, graphQLRequest(context.Background(), http.MethodPost, "/api/v1/graphql", `{"query":"{ nope }"}`))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `Cannot query field \"nope\"`)
+
+	query := url.Values{"query": {`mutation { deleteTodoList(id: "` + uuid.NewString() + `") }`}}
+	rr = httptest.NewRecorder()
+	h.Serve(rr, graphQLRequest(context.Background(), http.MethodGet, "/api/v1/graphql?"+query.Encode(), ""))
+	require.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	require.Equal(t, http.MethodPost, rr.Header().Get("Allow"))
+	todoLists.AssertNotCalled(t, "DeleteTodoList", mock.Anything, mock.Anything)
+}
+
+func TestGraphQLHandler_RejectsOversizedBodies(t *testing.T) {
+	todoLists := new(mocks.TodoListServiceMock)
+	h := newGraphQLHandler(t, todoLists, pubsub.New[model.TodoListEvent](1))
+
+	rr := httptest.NewRecorder()
+	req := graphQLRequest(context.Background(), http.MethodPost, "/api/v1/graphql", `{"query":"{ todoLists { title } }"}`)
+	req.Body = http.MaxBytesReader(rr, req.Body, 16)
+	h.Serve(rr, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	require.JSONEq(t, `{"errors":[{"message":"request body too large"}]}`, rr.Body.String())
+	todoLists.AssertNotCalled(t, "ListTodoLists", mock.Anything, mock.Anything)
+}
+
+func TestGraphQLHandler_SubscriptionStreamsEvents(t *testing.T) {
+	events := pubsub.New[model.TodoListEvent](1)
+	h := newGraphQLHandler(t, new(mocks.TodoListServiceMock), events)
+
+	workspaceID := uuid.New()
+	ctx, cancel := context.WithCancel(tenant.WithWorkspaceID(context.Background(), workspaceID))
+	req := graphQLRequest(ctx, http.MethodPost, "/api/v1/graphql", `{"query":"subscription { todoListChanged { type todoList { title } } }"}`)
+
+	rr := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.Serve(rr, req)
+	}()
+
+	// The subscription registers with the broker asynchronously, so keep
+	// publishing until the handler has been running for a while.
+	for i := 0; i < 20; i++ {
+		events.Publish(model.TodoListEvent{Type: model.TodoListEventCreated, WorkspaceID: workspaceID, TodoList: model.TodoListResponse{Title: "Groceries"}})
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	require.Equal(t, "text/event-stream", rr.Header().Get("Content-Type"))
+	body := rr.Body.String()
+	require.Contains(t, body, "event: next\ndata: {\"data\":{\"todoListChanged\":{\"todoList\":{\"title\":\"Groceries\"},\"type\":\"CREATED\"}}}\n\n")
+	require.True(t, strings.HasSuffix(body, "event: complete\ndata:\n\n"))
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// NotificationHandler exposes HTTP handlers for the caller's in-app inbox.
+type NotificationHandler struct {
+	service service.NotificationService
+	logger  *zap.Logger
+}
+
+// NewNotificationHandler constructs a NotificationHandler.
+func NewNotificationHandler(service service.NotificationService, logger *zap.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// List handles GET /notifications requests. ?unread=true limits the result
+// to notifications that have not been read yet.
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	unreadOnly := false
+	if value := r.URL.Query().Get("unread"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+				"unread": "must be a boolean",
+			}))
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	notifications, err := h.service.ListNotifications(r.Context(), unreadOnly)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			forbidden(w)
+			return
+		}
+		h.logger.Error("list notifications failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not list notifications",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(notifications))
+}
+
+// MarkRead handles POST /notifications/{id}/read requests.
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid notification id",
+		}))
+		return
+	}
+
+	if err := h.service.MarkRead(r.Context(), id); err != nil {
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			forbidden(w)
+		case errors.Is(err, repository.ErrNotificationNotFound):
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "notification not found",
+			}))
+		default:
+			h.logger.Error("mark notification read failed", zap.String("id", id.String()), zap.Error(err))
+			response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+				"message": "could not mark notification read",
+			}))
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,45 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNotificationHandler_List_UnreadFilter(t *testing.T) {
+	serviceMock := new(mocks.NotificationServiceMock)
+	h := handler.NewNotificationHandler(serviceMock, zaptest.NewLogger(t))
+	serviceMock.On("ListNotifications", mock.Anything, true).Return([]model.NotificationResponse{{ID: uuid.New(), Title: "Reminder: Pay rent"}}, nil)
+
+	rr := httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/notifications?unread=true", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), "Pay rent")
+
+	rr = httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/notifications?unread=maybe", nil))
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertNumberOfCalls(t, "ListNotifications", 1)
+}
+
+func TestNotificationHandler_MarkRead_NotFound(t *testing.T) {
+	serviceMock := new(mocks.NotificationServiceMock)
+	h := handler.NewNotificationHandler(serviceMock, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	serviceMock.On("MarkRead", mock.Anything, id).Return(repository.ErrNotificationNotFound)
+
+	rr := httptest.NewRecorder()
+	h.MarkRead(rr, todoItemRequest(http.MethodPost, "/", "", map[string]string{"id": id.String()}))
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
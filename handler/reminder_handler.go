@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// ReminderHandler exposes HTTP handlers for reminders on todo items.
+type ReminderHandler struct {
+	service  service.ReminderService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewReminderHandler constructs a ReminderHandler.
+func NewReminderHandler(service service.ReminderService, validate *validator.Validate, logger *zap.Logger) *ReminderHandler {
+	return &ReminderHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /todolists/{id}/items/{itemId}/reminders requests.
+func (h *ReminderHandler) Create(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	var req model.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid reminder create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("reminder create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	reminder, err := h.service.CreateReminder(r.Context(), todoListID, itemID, req)
+	if err != nil {
+		h.writeError(w, err, "could not create reminder")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(reminder))
+}
+
+// List handles GET /todolists/{id}/items/{itemId}/reminders requests.
+func (h *ReminderHandler) List(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	reminders, err := h.service.ListReminders(r.Context(), todoListID, itemID)
+	if err != nil {
+		h.writeError(w, err, "could not list reminders")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(reminders))
+}
+
+// Delete handles DELETE /todolists/{id}/items/{itemId}/reminders/{reminderId}
+// requests.
+func (h *ReminderHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+	reminderID, err := parseUUIDParam(r, "reminderId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid reminder id",
+		}))
+		return
+	}
+
+	if err := h.service.DeleteReminder(r.Context(), todoListID, itemID, reminderID); err != nil {
+		h.writeError(w, err, "could not delete reminder")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps service errors shared by every reminder endpoint to
+// responses.
+func (h *ReminderHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoItemNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo item not found",
+		}))
+	case errors.Is(err, repository.ErrReminderNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "reminder not found",
+		}))
+	case errors.Is(err, service.ErrNoDueDate):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "todo item has no due date",
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
@@ -0,0 +1,67 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestReminderHandler_Create_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"neither time nor offset", `{"channel":"inbox"}`, "remindat"},
+		{"both time and offset", `{"remind_at":"2999-01-01T00:00:00Z","offset_minutes":5,"channel":"inbox"}`, "remindat"},
+		{"unknown channel", `{"offset_minutes":5,"channel":"sms"}`, "channel"},
+		{"webhook without target", `{"offset_minutes":5,"channel":"webhook"}`, "target"},
+		{"plain http target", `{"offset_minutes":5,"channel":"webhook","target":"http://hooks.example.com/todo"}`, "target"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.ReminderServiceMock)
+			h := handler.NewReminderHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+			listID, itemID := uuid.New().String(), uuid.New().String()
+			rr := httptest.NewRecorder()
+			req := todoItemRequest(http.MethodPost, "/", tt.body, map[string]string{"id": listID, "itemId": itemID})
+
+			h.Create(rr, req)
+
+			require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			require.Contains(t, rr.Body.String(), tt.field)
+			serviceMock.AssertNotCalled(t, "CreateReminder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReminderHandler_Create_NoDueDate(t *testing.T) {
+	serviceMock := new(mocks.ReminderServiceMock)
+	h := handler.NewReminderHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID, itemID := uuid.New(), uuid.New()
+	serviceMock.On("CreateReminder", mock.Anything, listID, itemID, mock.MatchedBy(func(req model.CreateReminderRequest) bool {
+		return *req.OffsetMinutes == 15 && req.Channel == model.ReminderChannelEmail
+	})).Return(model.ReminderResponse{}, service.ErrNoDueDate)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/", `{"offset_minutes":15,"channel":"email"}`,
+		map[string]string{"id": listID.String(), "itemId": itemID.String()})
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// ShareLinkHandler exposes HTTP handlers for todo list share links.
+type ShareLinkHandler struct {
+	service  service.ShareLinkService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewShareLinkHandler constructs a ShareLinkHandler.
+func NewShareLinkHandler(service service.ShareLinkService, validate *validator.Validate, logger *zap.Logger) *ShareLinkHandler {
+	return &ShareLinkHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /todolists/{id}/share-links requests.
+func (h *ShareLinkHandler) Create(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	var req model.CreateShareLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid share link create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("share link create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	shareLink, err := h.service.CreateShareLink(r.Context(), todoListID, req)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			forbidden(w)
+			return
+		}
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		h.logger.Error("share link creation failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not create share link",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(shareLink))
+}
+
+// List handles GET /todolists/{id}/share-links requests.
+func (h *ShareLinkHandler) List(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	shareLinks, err := h.service.ListShareLinks(r.Context(), todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		h.logger.Error("list share links failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch share links",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(shareLinks))
+}
+
+// Revoke handles DELETE /todolists/{id}/share-links/{linkId} requests.
+func (h *ShareLinkHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	id, err := parseUUIDParam(r, "linkId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid share link id",
+		}))
+		return
+	}
+
+	if err := h.service.RevokeShareLink(r.Context(), todoListID, id); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			forbidden(w)
+			return
+		}
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "share link not found",
+			}))
+			return
+		}
+		h.logger.Error("revoke share link failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not revoke share link",
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetShared handles unauthenticated GET /public/lists/{token} requests.
+func (h *ShareLinkHandler) GetShared(w http.ResponseWriter, r *http.Request) {
+	rawToken := chi.URLParam(r, "token")
+	if rawToken == "" {
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "shared todo list not found",
+		}))
+		return
+	}
+
+	todoList, err := h.service.GetSharedTodoList(r.Context(), rawToken)
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) ||
+			errors.Is(err, service.ErrShareLinkInactive) ||
+			errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "shared todo list not found",
+			}))
+			return
+		}
+		h.logger.Error("get shared todo list failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch todo list",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(todoList))
+}
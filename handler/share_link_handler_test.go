@@ -0,0 +1,106 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestShareLinkHandler_Create_Success(t *testing.T) {
+	serviceMock := new(mocks.ShareLinkServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	logger := zaptest.NewLogger(t)
+	h := handler.NewShareLinkHandler(serviceMock, validate, logger)
+
+	todoListID := uuid.New()
+	serviceMock.
+		On("CreateShareLink", mock.Anything, todoListID, model.CreateShareLinkRequest{}).
+		Return(model.ShareLinkResponse{
+			ID:         uuid.New(),
+			TodoListID: todoListID,
+			Token:      "token",
+			URL:        "/public/lists/token",
+			CreatedAt:  time.Now(),
+		}, nil)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todolists/"+todoListID.String()+"/share-links", nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", todoListID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestShareLinkHandler_Create_PastExpiry(t *testing.T) {
+	serviceMock := new(mocks.ShareLinkServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	logger := zaptest.NewLogger(t)
+	h := handler.NewShareLinkHandler(serviceMock, validate, logger)
+
+	todoListID := uuid.New()
+	body, err := json.Marshal(model.CreateShareLinkRequest{ExpiresAt: func() *time.Time {
+		past := time.Now().Add(-time.Hour)
+		return &past
+	}()})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todolists/"+todoListID.String()+"/share-links", bytes.NewReader(body))
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", todoListID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertNotCalled(t, "CreateShareLink", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestShareLinkHandler_GetShared_Inactive(t *testing.T) {
+	serviceMock := new(mocks.ShareLinkServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	logger := zaptest.NewLogger(t)
+	h := handler.NewShareLinkHandler(serviceMock, validate, logger)
+
+	serviceMock.
+		On("GetSharedTodoList", mock.Anything, "revoked").
+		Return(model.TodoListResponse{}, service.ErrShareLinkInactive)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/public/lists/revoked", nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("token", "revoked")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.GetShared(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	var resp response.Message
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, "error", resp.Status)
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// TagHandler exposes HTTP handlers for tags and for tagging todo lists.
+type TagHandler struct {
+	service  service.TagService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewTagHandler constructs a TagHandler.
+func NewTagHandler(service service.TagService, validate *validator.Validate, logger *zap.Logger) *TagHandler {
+	return &TagHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /tags requests.
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid tag create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("tag create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	tag, err := h.service.CreateTag(r.Context(), req)
+	if err != nil {
+		h.writeError(w, err, "could not create tag")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(tag))
+}
+
+// List handles GET /tags requests.
+func (h *TagHandler) List(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.service.ListTags(r.Context())
+	if err != nil {
+		h.writeError(w, err, "could not fetch tags")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(tags))
+}
+
+// Update handles PUT /tags/{id} requests.
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid tag id",
+		}))
+		return
+	}
+
+	var req model.UpdateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid tag update payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("tag update validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	tag, err := h.service.UpdateTag(r.Context(), id, req)
+	if err != nil {
+		h.writeError(w, err, "could not update tag")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(tag))
+}
+
+// Delete handles DELETE /tags/{id} requests.
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid tag id",
+		}))
+		return
+	}
+
+	if err := h.service.DeleteTag(r.Context(), id); err != nil {
+		h.writeError(w, err, "could not delete tag")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Attach handles PUT /todolists/{id}/tags/{tagId} requests.
+func (h *TagHandler) Attach(w http.ResponseWriter, r *http.Request) {
+	h.retag(w, r, h.service.AttachTag, "could not attach tag")
+}
+
+// Detach handles DELETE /todolists/{id}/tags/{tagId} requests.
+func (h *TagHandler) Detach(w http.ResponseWriter, r *http.Request) {
+	h.retag(w, r, h.service.DetachTag, "could not detach tag")
+}
+
+func (h *TagHandler) retag(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, todoListID, tagID uuid.UUID) error, message string) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	tagID, err := parseUUIDParam(r, "tagId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid tag id",
+		}))
+		return
+	}
+
+	if err := change(r.Context(), todoListID, tagID); err != nil {
+		h.writeError(w, err, message)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError maps service errors shared by every tag endpoint to responses.
+func (h *TagHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo list not found",
+		}))
+	case errors.Is(err, repository.ErrTagNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "tag not found",
+		}))
+	case errors.Is(err, repository.ErrTagNameTaken):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "tag name already taken",
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
@@ -0,0 +1,76 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestTagHandler_Create_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"missing name", `{"color":"#4caf50"}`, "name"},
+		{"comma in name", `{"name":"home,work"}`, "name"},
+		{"invalid color", `{"name":"home","color":"green"}`, "color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.TagServiceMock)
+			h := handler.NewTagHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+			rr := httptest.NewRecorder()
+			h.Create(rr, todoItemRequest(http.MethodPost, "/", tt.body, nil))
+
+			require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+			require.Contains(t, rr.Body.String(), tt.field)
+			serviceMock.AssertNotCalled(t, "CreateTag", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestTagHandler_Create_NameTaken(t *testing.T) {
+	serviceMock := new(mocks.TagServiceMock)
+	h := handler.NewTagHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	serviceMock.On("CreateTag", mock.Anything, model.CreateTagRequest{Name: "home", Color: "#4caf50"}).
+		Return(model.TagResponse{}, repository.ErrTagNameTaken)
+
+	rr := httptest.NewRecorder()
+	h.Create(rr, todoItemRequest(http.MethodPost, "/", `{"name":"home","color":"#4caf50"}`, nil))
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTagHandler_Attach(t *testing.T) {
+	serviceMock := new(mocks.TagServiceMock)
+	h := handler.NewTagHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID, tagID, missingID := uuid.New(), uuid.New(), uuid.New()
+	serviceMock.On("AttachTag", mock.Anything, listID, tagID).Return(nil)
+	serviceMock.On("AttachTag", mock.Anything, listID, missingID).Return(repository.ErrTagNotFound)
+
+	rr := httptest.NewRecorder()
+	h.Attach(rr, todoItemRequest(http.MethodPut, "/", "", map[string]string{"id": listID.String(), "tagId": tagID.String()}))
+	require.Equal(t, http.StatusNoContent, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.Attach(rr, todoItemRequest(http.MethodPut, "/", "", map[string]string{"id": listID.String(), "tagId": missingID.String()}))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Contains(t, rr.Body.String(), "tag not found")
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// TodoItemHandler exposes HTTP handlers for the items of a todo list.
+type TodoItemHandler struct {
+	service  service.TodoItemService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewTodoItemHandler constructs a TodoItemHandler.
+func NewTodoItemHandler(service service.TodoItemService, validate *validator.Validate, logger *zap.Logger) *TodoItemHandler {
+	return &TodoItemHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /todolists/{id}/items requests.
+func (h *TodoItemHandler) Create(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	var req model.CreateTodoItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid todo item create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo item create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	item, err := h.service.CreateTodoItem(r.Context(), todoListID, req)
+	if err != nil {
+		h.writeError(w, err, "could not create todo item")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(item))
+}
+
+// List handles GET /todolists/{id}/items requests. Items can be filtered by
+// status, priority, overdue and an RFC 3339 due_before/due_after range.
+func (h *TodoItemHandler) List(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	dueFilter, errs := parseDueFilter(r.URL.Query())
+	if len(errs) > 0 {
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(errs))
+		return
+	}
+	filter := model.TodoItemFilter{DueFilter: dueFilter}
+
+	if err := h.validate.StructCtx(r.Context(), filter); err != nil {
+		h.logger.Warn("todo item filter validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	items, err := h.service.ListTodoItems(r.Context(), todoListID, filter)
+	if err != nil {
+		h.writeError(w, err, "could not fetch todo items")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(items))
+}
+
+// Get handles GET /todolists/{id}/items/{itemId} requests.
+func (h *TodoItemHandler) Get(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	item, err := h.service.GetTodoItem(r.Context(), todoListID, itemID)
+	if err != nil {
+		h.writeError(w, err, "could not fetch todo item")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(item))
+}
+
+// Update handles PUT /todolists/{id}/items/{itemId} requests.
+func (h *TodoItemHandler) Update(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	var req model.UpdateTodoItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid todo item update payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo item update validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	item, err := h.service.UpdateTodoItem(r.Context(), todoListID, itemID, req)
+	if err != nil {
+		h.writeError(w, err, "could not update todo item")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(item))
+}
+
+// Delete handles DELETE /todolists/{id}/items/{itemId} requests.
+func (h *TodoItemHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	if err := h.service.DeleteTodoItem(r.Context(), todoListID, itemID); err != nil {
+		h.writeError(w, err, "could not delete todo item")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Materialize handles POST /todolists/{id}/items/{itemId}/occurrences
+// requests, creating upcoming occurrences of a recurring item.
+func (h *TodoItemHandler) Materialize(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	var req model.MaterializeOccurrencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid todo item occurrences payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo item occurrences validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	items, err := h.service.MaterializeOccurrences(r.Context(), todoListID, itemID, req.Count)
+	if err != nil {
+		h.writeError(w, err, "could not materialize todo item occurrences")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(items))
+}
+
+// Move handles POST /todolists/{id}/items/{itemId}/move requests,
+// repositioning the item within its list or moving it to another one.
+func (h *TodoItemHandler) Move(w http.ResponseWriter, r *http.Request) {
+	todoListID, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	itemID, err := parseUUIDParam(r, "itemId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo item id",
+		}))
+		return
+	}
+
+	var req model.MoveTodoItemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid todo item move payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo item move validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	item, err := h.service.MoveTodoItem(r.Context(), todoListID, itemID, req)
+	if err != nil {
+		h.writeError(w, err, "could not move todo item")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(item))
+}
+
+// writeError maps service errors shared by every item endpoint to responses.
+func (h *TodoItemHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo list not found",
+		}))
+	case errors.Is(err, repository.ErrTodoItemNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo item not found",
+		}))
+	case errors.Is(err, service.ErrNotRecurring):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "todo item is not recurring",
+		}))
+	case errors.Is(err, service.ErrSubtaskTooDeep):
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+			"message": "subtasks are nested too deeply",
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
@@ -0,0 +1,188 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func todoItemRequest(method, target, body string, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	routeCtx := chi.NewRouteContext()
+	for key, value := range params {
+		routeCtx.URLParams.Add(key, value)
+	}
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+}
+
+func TestTodoItemHandler_Create_RejectsPastDueDate(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/api/v1/todolists/"+listID+"/items",
+		`{"title":"Milk","due_at":"2001-01-01T00:00:00Z"}`, map[string]string{"id": listID})
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "dueat")
+	serviceMock.AssertNotCalled(t, "CreateTodoItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoItemHandler_List_ParsesFilter(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	serviceMock.On("ListTodoItems", mock.Anything, listID, mock.MatchedBy(func(filter model.TodoItemFilter) bool {
+		return filter.Overdue && filter.Priority == model.PriorityHigh && filter.DueBefore != nil && filter.DueBefore.Day() == 31
+	})).Return([]model.TodoItemResponse{}, nil)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodGet, "/api/v1/todolists/"+listID.String()+"/items?overdue=true&priority=high&due_before=2026-12-31T00:00:00Z",
+		"", map[string]string{"id": listID.String()})
+
+	h.List(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoItemHandler_List_InvalidStatus(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodGet, "/api/v1/todolists/"+listID+"/items?status=blocked&overdue=maybe",
+		"", map[string]string{"id": listID})
+
+	h.List(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "overdue")
+}
+
+func TestTodoItemHandler_Get_NotFound(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	serviceMock.On("GetTodoItem", mock.Anything, listID, itemID).Return(model.TodoItemResponse{}, repository.ErrTodoItemNotFound)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodGet, "/", "", map[string]string{"id": listID.String(), "itemId": itemID.String()})
+
+	h.Get(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoItemHandler_Create_RejectsInvalidRecurrence(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/api/v1/todolists/"+listID+"/items",
+		`{"title":"Bins","recurrence":"FREQ=HOURLY","timezone":"Mars/Olympus"}`, map[string]string{"id": listID})
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "recurrence")
+	require.Contains(t, rr.Body.String(), "timezone")
+	require.Contains(t, rr.Body.String(), "dueat")
+	serviceMock.AssertNotCalled(t, "CreateTodoItem", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoItemHandler_Materialize_NotRecurring(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	serviceMock.On("MaterializeOccurrences", mock.Anything, listID, itemID, 4).Return(nil, service.ErrNotRecurring)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/", `{"count":4}`, map[string]string{"id": listID.String(), "itemId": itemID.String()})
+
+	h.Materialize(rr, req)
+
+	require.Equal(t, http.StatusConflict, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoItemHandler_Move_RejectsBothNeighbours(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New().String()
+	itemID := uuid.New().String()
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/api/v1/todolists/"+listID+"/items/"+itemID+"/move",
+		`{"after_id":"`+uuid.New().String()+`","before_id":"`+uuid.New().String()+`"}`,
+		map[string]string{"id": listID, "itemId": itemID})
+
+	h.Move(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "afterid")
+	serviceMock.AssertNotCalled(t, "MoveTodoItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoItemHandler_Move_AnchorNotFound(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	afterID := uuid.New()
+	serviceMock.On("MoveTodoItem", mock.Anything, listID, itemID, model.MoveTodoItemRequest{AfterID: &afterID}).
+		Return(model.TodoItemResponse{}, repository.ErrTodoItemNotFound)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/api/v1/todolists/"+listID.String()+"/items/"+itemID.String()+"/move",
+		`{"after_id":"`+afterID.String()+`"}`, map[string]string{"id": listID.String(), "itemId": itemID.String()})
+
+	h.Move(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoItemHandler_Create_SubtaskTooDeep(t *testing.T) {
+	serviceMock := new(mocks.TodoItemServiceMock)
+	h := handler.NewTodoItemHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	parentID := uuid.New()
+	serviceMock.On("CreateTodoItem", mock.Anything, listID, model.CreateTodoItemRequest{ParentID: &parentID, Title: "Deep"}).
+		Return(model.TodoItemResponse{}, service.ErrSubtaskTooDeep)
+
+	rr := httptest.NewRecorder()
+	req := todoItemRequest(http.MethodPost, "/", `{"parent_id":"`+parentID.String()+`","title":"Deep"}`, map[string]string{"id": listID.String()})
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
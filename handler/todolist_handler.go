@@ -1,10 +1,15 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	validator "github.com/go-playground/validator/v10"
@@ -51,6 +56,12 @@ func (h *TodoListHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	todoList, err := h.service.CreateTodoList(r.Context(), req)
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
 		h.logger.Error("todo list creation failed", zap.Error(err))
 		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
 			"message": "could not create todo list",
@@ -61,9 +72,49 @@ func (h *TodoListHandler) Create(w http.ResponseWriter, r *http.Request) {
 	response.Write(w, http.StatusCreated, response.Success(todoList))
 }
 
-// List handles GET /todolists requests.
+// List handles GET /todolists requests. Lists can be filtered by status,
+// priority, overdue, an RFC 3339 due_before/due_after range and comma-separated
+// tags, matching any (the default) or all of them. Archived lists are only
+// included with include_archived=true.
 func (h *TodoListHandler) List(w http.ResponseWriter, r *http.Request) {
-	todoLists, err := h.service.ListTodoLists(r.Context())
+	h.list(w, r, false)
+}
+
+// ListTemplates handles GET /todolists/templates requests, listing the
+// workspace's template library with the same filters as List.
+func (h *TodoListHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	h.list(w, r, true)
+}
+
+func (h *TodoListHandler) list(w http.ResponseWriter, r *http.Request, templates bool) {
+	query := r.URL.Query()
+	dueFilter, errs := parseDueFilter(query)
+	filter := model.TodoListFilter{
+		DueFilter: dueFilter,
+		Tags:      parseTagNames(query.Get("tags")),
+		Match:     query.Get("match"),
+		Templates: templates,
+	}
+	if value := query.Get("include_archived"); value != "" {
+		includeArchived, err := strconv.ParseBool(value)
+		if err != nil {
+			errs["include_archived"] = "must be a boolean"
+		} else {
+			filter.IncludeArchived = includeArchived
+		}
+	}
+	if len(errs) > 0 {
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(errs))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), filter); err != nil {
+		h.logger.Warn("todo list filter validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	todoLists, err := h.service.ListTodoLists(r.Context(), filter)
 	if err != nil {
 		h.logger.Error("list todo lists failed", zap.Error(err))
 		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
@@ -130,6 +181,12 @@ func (h *TodoListHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	todoList, err := h.service.UpdateTodoList(r.Context(), id, req)
 	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
 		if errors.Is(err, repository.ErrTodoListNotFound) {
 			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
 				"message": "todo list not found",
@@ -157,6 +214,12 @@ func (h *TodoListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.service.DeleteTodoList(r.Context(), id); err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
 		if errors.Is(err, repository.ErrTodoListNotFound) {
 			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
 				"message": "todo list not found",
@@ -173,11 +236,386 @@ func (h *TodoListHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListRevisions handles GET /todolists/{id}/revisions requests.
+func (h *TodoListHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	revisions, err := h.service.ListRevisions(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		h.logger.Error("list todo list revisions failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch revisions",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(revisions))
+}
+
+// GetRevision handles GET /todolists/{id}/revisions/{n} requests.
+func (h *TodoListHandler) GetRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	number, err := parseRevisionParam(r)
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid revision number",
+		}))
+		return
+	}
+
+	revision, err := h.service.GetRevision(r.Context(), id, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "revision not found",
+			}))
+			return
+		}
+		h.logger.Error("get todo list revision failed", zap.String("id", id.String()), zap.Int("number", number), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch revision",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(revision))
+}
+
+// RestoreRevision handles POST /todolists/{id}/revisions/{n}/restore requests.
+func (h *TodoListHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+	number, err := parseRevisionParam(r)
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid revision number",
+		}))
+		return
+	}
+
+	todoList, err := h.service.RestoreRevision(r.Context(), id, number)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "revision not found",
+			}))
+			return
+		}
+		h.logger.Error("restore todo list revision failed", zap.String("id", id.String()), zap.Int("number", number), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not restore revision",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(todoList))
+}
+
+// Move handles POST /todolists/{id}/move requests, repositioning the list in
+// the sidebar order.
+func (h *TodoListHandler) Move(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	var req model.MoveTodoListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid todo list move payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo list move validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	todoList, err := h.service.MoveTodoList(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "todo list not found",
+			}))
+			return
+		}
+		h.logger.Error("move todo list failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not move todo list",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(todoList))
+}
+
+// Duplicate handles POST /todolists/{id}/duplicate requests. The body is
+// optional.
+func (h *TodoListHandler) Duplicate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	var req model.DuplicateTodoListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid todo list duplicate payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("todo list duplicate validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	todoList, err := h.service.DuplicateTodoList(r.Context(), id, req)
+	if err != nil {
+		h.writeError(w, err, "could not duplicate todo list")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(todoList))
+}
+
+// MarkTemplate handles PUT /todolists/{id}/template requests, adding the list
+// to the workspace's template library.
+func (h *TodoListHandler) MarkTemplate(w http.ResponseWriter, r *http.Request) {
+	h.setTemplate(w, r, true)
+}
+
+// UnmarkTemplate handles DELETE /todolists/{id}/template requests, moving the
+// list back to the workspace's lists.
+func (h *TodoListHandler) UnmarkTemplate(w http.ResponseWriter, r *http.Request) {
+	h.setTemplate(w, r, false)
+}
+
+func (h *TodoListHandler) setTemplate(w http.ResponseWriter, r *http.Request, isTemplate bool) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	todoList, err := h.service.SetTemplate(r.Context(), id, isTemplate)
+	if err != nil {
+		h.writeError(w, err, "could not update todo list template")
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(todoList))
+}
+
+// Instantiate handles POST /todolists/{id}/instantiate requests, creating a
+// list from a template.
+func (h *TodoListHandler) Instantiate(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	var req model.InstantiateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid template instantiate payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("template instantiate validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	todoList, err := h.service.InstantiateTemplate(r.Context(), id, req)
+	if err != nil {
+		h.writeError(w, err, "could not instantiate template")
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(todoList))
+}
+
+// Archive handles POST /todolists/{id}/archive requests.
+func (h *TodoListHandler) Archive(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, h.service.ArchiveTodoList, "could not archive todo list")
+}
+
+// Unarchive handles POST /todolists/{id}/unarchive requests.
+func (h *TodoListHandler) Unarchive(w http.ResponseWriter, r *http.Request) {
+	h.setArchived(w, r, h.service.UnarchiveTodoList, "could not unarchive todo list")
+}
+
+func (h *TodoListHandler) setArchived(w http.ResponseWriter, r *http.Request, set func(context.Context, uuid.UUID) (model.TodoListResponse, error), message string) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid todo list id",
+		}))
+		return
+	}
+
+	todoList, err := set(r.Context(), id)
+	if err != nil {
+		h.writeError(w, err, message)
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(todoList))
+}
+
+// writeError maps the errors of the duplicate, template and archive
+// endpoints to responses.
+func (h *TodoListHandler) writeError(w http.ResponseWriter, err error, message string) {
+	switch {
+	case errors.Is(err, service.ErrForbidden):
+		forbidden(w)
+	case errors.Is(err, repository.ErrTodoListNotFound):
+		response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+			"message": "todo list not found",
+		}))
+	case errors.Is(err, service.ErrNotTemplate):
+		response.Write(w, http.StatusConflict, response.Failure(map[string]string{
+			"message": "todo list is not a template",
+		}))
+	case errors.Is(err, service.ErrMissingTemplateVariables):
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(map[string]string{
+			"variables": err.Error(),
+		}))
+	default:
+		h.logger.Error(message, zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+	}
+}
+
+func parseDueFilter(query url.Values) (model.DueFilter, map[string]string) {
+	filter := model.DueFilter{
+		Status:   query.Get("status"),
+		Priority: query.Get("priority"),
+	}
+	errs := make(map[string]string)
+
+	if value := query.Get("overdue"); value != "" {
+		overdue, err := strconv.ParseBool(value)
+		if err != nil {
+			errs["overdue"] = "must be a boolean"
+		} else {
+			filter.Overdue = overdue
+		}
+	}
+	for name, target := range map[string]**time.Time{"due_before": &filter.DueBefore, "due_after": &filter.DueAfter} {
+		if value := query.Get(name); value != "" {
+			at, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				errs[name] = "must be an RFC 3339 timestamp"
+			} else {
+				*target = &at
+			}
+		}
+	}
+	return filter, errs
+}
+
+// parseTagNames splits a comma-separated tag list, dropping blanks and
+// duplicates.
+func parseTagNames(value string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
 func parseUUIDParam(r *http.Request, param string) (uuid.UUID, error) {
 	id := chi.URLParam(r, param)
 	return uuid.Parse(id)
 }
 
+// parseRevisionParam reads the {n} URL parameter as a positive revision number.
+func parseRevisionParam(r *http.Request) (int, error) {
+	number, err := strconv.Atoi(chi.URLParam(r, "n"))
+	if err != nil {
+		return 0, err
+	}
+	if number < 1 {
+		return 0, errors.New("revision number must be positive")
+	}
+	return number, nil
+}
+
 func validationErrors(err error) map[string]string {
 	errorsMap := make(map[string]string)
 	if validationErrs, ok := err.(validator.ValidationErrors); ok {
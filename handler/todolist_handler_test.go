@@ -4,18 +4,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/go-chi/chi/v5"
-	validator "github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/handler"
 	"github.com/lumoshiveacademy/todolist/model"
 	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/package/validation"
 	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
 	"github.com/lumoshiveacademy/todolist/test/mocks"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -24,7 +26,7 @@ import (
 
 func TestTodoListHandler_Create_Success(t *testing.T) {
 	serviceMock := new(mocks.TodoListServiceMock)
-	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate := validation.New()
 	logger := zaptest.NewLogger(t)
 
 	h := handler.NewTodoListHandler(serviceMock, validate, logger)
@@ -58,7 +60,7 @@ func TestTodoListHandler_Create_Success(t *testing.T) {
 
 func TestTodoListHandler_Create_ValidationError(t *testing.T) {
 	serviceMock := new(mocks.TodoListServiceMock)
-	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate := validation.New()
 	logger := zaptest.NewLogger(t)
 	h := handler.NewTodoListHandler(serviceMock, validate, logger)
 
@@ -73,7 +75,7 @@ func TestTodoListHandler_Create_ValidationError(t *testing.T) {
 
 func TestTodoListHandler_Get_NotFound(t *testing.T) {
 	serviceMock := new(mocks.TodoListServiceMock)
-	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate := validation.New()
 	logger := zaptest.NewLogger(t)
 	h := handler.NewTodoListHandler(serviceMock, validate, logger)
 
@@ -97,7 +99,7 @@ func TestTodoListHandler_Get_NotFound(t *testing.T) {
 
 func TestTodoListHandler_Delete_Success(t *testing.T) {
 	serviceMock := new(mocks.TodoListServiceMock)
-	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate := validation.New()
 	logger := zaptest.NewLogger(t)
 	h := handler.NewTodoListHandler(serviceMock, validate, logger)
 
@@ -116,3 +118,212 @@ func TestTodoListHandler_Delete_Success(t *testing.T) {
 	require.Equal(t, http.StatusNoContent, rr.Code)
 	serviceMock.AssertExpectations(t)
 }
+
+func TestTodoListHandler_Delete_Forbidden(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	id := uuid.New()
+	serviceMock.On("DeleteTodoList", mock.Anything, id).Return(service.ErrForbidden)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/todolists/"+id.String(), nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.Delete(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_GetRevision_InvalidNumber(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	id := uuid.New()
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists/"+id.String()+"/revisions/0", nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id.String())
+	routeCtx.URLParams.Add("n", "0")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.GetRevision(rr, req)
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	serviceMock.AssertNotCalled(t, "GetRevision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoListHandler_RestoreRevision_NotFound(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	id := uuid.New()
+	serviceMock.
+		On("RestoreRevision", mock.Anything, id, 3).
+		Return(model.TodoListResponse{}, repository.ErrRevisionNotFound)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todolists/"+id.String()+"/revisions/3/restore", nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id.String())
+	routeCtx.URLParams.Add("n", "3")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.RestoreRevision(rr, req)
+
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_RestoreRevision_Success(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	id := uuid.New()
+	serviceMock.
+		On("RestoreRevision", mock.Anything, id, 2).
+		Return(model.TodoListResponse{ID: id, Title: "Earlier"}, nil)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todolists/"+id.String()+"/revisions/2/restore", nil)
+
+	routeCtx := chi.NewRouteContext()
+	routeCtx.URLParams.Add("id", id.String())
+	routeCtx.URLParams.Add("n", "2")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, routeCtx))
+
+	h.RestoreRevision(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_List_FiltersByTags(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	serviceMock.
+		On("ListTodoLists", mock.Anything, model.TodoListFilter{Tags: []string{"home", "urgent"}, Match: model.TagMatchAll}).
+		Return([]model.TodoListResponse{}, nil)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists?tags=home,%20urgent,,home&match=all", nil)
+
+	h.List(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_List_InvalidTagMatch(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	h := handler.NewTodoListHandler(serviceMock, validate, logger)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists?tags=home&match=some", nil)
+
+	h.List(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "match")
+	serviceMock.AssertNotCalled(t, "ListTodoLists", mock.Anything, mock.Anything)
+}
+
+func TestTodoListHandler_ListTemplates(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	h := handler.NewTodoListHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	serviceMock.
+		On("ListTodoLists", mock.Anything, model.TodoListFilter{Tags: []string{"hr"}, Templates: true}).
+		Return([]model.TodoListResponse{{Title: "Onboarding", IsTemplate: true}}, nil)
+
+	rr := httptest.NewRecorder()
+	h.ListTemplates(rr, httptest.NewRequest(http.MethodGet, "/api/v1/todolists/templates?tags=hr", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Body.String(), `"is_template":true`)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_Duplicate_BodyIsOptional(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	h := handler.NewTodoListHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	id := uuid.New()
+	serviceMock.
+		On("DuplicateTodoList", mock.Anything, id, model.DuplicateTodoListRequest{}).
+		Return(model.TodoListResponse{ID: uuid.New(), Title: "Groceries (copy)"}, nil)
+
+	rr := httptest.NewRecorder()
+	h.Duplicate(rr, todoItemRequest(http.MethodPost, "/", "", map[string]string{"id": id.String()}))
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestTodoListHandler_Instantiate_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+		body string
+	}{
+		{"not a template", service.ErrNotTemplate, http.StatusConflict, "not a template"},
+		{"missing variables", fmt.Errorf("%w: name", service.ErrMissingTemplateVariables), http.StatusUnprocessableEntity, `"variables":"missing template variables: name"`},
+		{"not found", repository.ErrTodoListNotFound, http.StatusNotFound, "todo list not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceMock := new(mocks.TodoListServiceMock)
+			h := handler.NewTodoListHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+			id := uuid.New()
+			req := model.InstantiateTemplateRequest{Variables: map[string]string{"team": "Platform"}}
+			serviceMock.On("InstantiateTemplate", mock.Anything, id, req).Return(model.TodoListResponse{}, tt.err)
+
+			rr := httptest.NewRecorder()
+			h.Instantiate(rr, todoItemRequest(http.MethodPost, "/", `{"variables":{"team":"Platform"}}`, map[string]string{"id": id.String()}))
+
+			require.Equal(t, tt.code, rr.Code)
+			require.Contains(t, rr.Body.String(), tt.body)
+		})
+	}
+}
+
+func TestTodoListHandler_List_IncludeArchived(t *testing.T) {
+	serviceMock := new(mocks.TodoListServiceMock)
+	h := handler.NewTodoListHandler(serviceMock, validation.New(), zaptest.NewLogger(t))
+
+	serviceMock.
+		On("ListTodoLists", mock.Anything, model.TodoListFilter{IncludeArchived: true}).
+		Return([]model.TodoListResponse{}, nil).Once()
+
+	rr := httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/todolists?include_archived=true", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.List(rr, httptest.NewRequest(http.MethodGet, "/api/v1/todolists?include_archived=maybe", nil))
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.Contains(t, rr.Body.String(), "include_archived")
+	serviceMock.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"go.uber.org/zap"
+)
+
+// WorkspaceHandler exposes HTTP handlers for workspace resources.
+type WorkspaceHandler struct {
+	service  service.WorkspaceService
+	validate *validator.Validate
+	logger   *zap.Logger
+}
+
+// NewWorkspaceHandler constructs a WorkspaceHandler.
+func NewWorkspaceHandler(service service.WorkspaceService, validate *validator.Validate, logger *zap.Logger) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		service:  service,
+		validate: validate,
+		logger:   logger,
+	}
+}
+
+// Create handles POST /workspaces requests.
+func (h *WorkspaceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req model.CreateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid workspace create payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("workspace create validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	workspace, err := h.service.CreateWorkspace(r.Context(), req)
+	if err != nil {
+		if errors.Is(err, service.ErrForbidden) {
+			response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+				"message": "forbidden",
+			}))
+			return
+		}
+		h.logger.Error("workspace creation failed", zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not create workspace",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusCreated, response.Success(workspace))
+}
+
+// Get handles GET /workspaces/{id} requests.
+func (h *WorkspaceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid workspace id",
+		}))
+		return
+	}
+
+	workspace, err := h.service.GetWorkspace(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "workspace not found",
+			}))
+			return
+		}
+		h.logger.Error("get workspace failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not fetch workspace",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(workspace))
+}
+
+// Update handles PUT /workspaces/{id} requests.
+func (h *WorkspaceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid workspace id",
+		}))
+		return
+	}
+
+	var req model.UpdateWorkspaceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Warn("invalid workspace update payload", zap.Error(err))
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid request payload",
+		}))
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		h.logger.Warn("workspace update validation failed", zap.Error(err))
+		response.Write(w, http.StatusUnprocessableEntity, response.Failure(validationErrors(err)))
+		return
+	}
+
+	workspace, err := h.service.UpdateWorkspace(r.Context(), id, req)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "workspace not found",
+			}))
+			return
+		}
+		h.logger.Error("update workspace failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": "could not update workspace",
+		}))
+		return
+	}
+
+	response.Write(w, http.StatusOK, response.Success(workspace))
+}
+
+// AddMember handles PUT /workspaces/{id}/members/{userId} requests.
+func (h *WorkspaceHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	h.changeMember(w, r, h.service.AddMember, "could not add workspace member")
+}
+
+// RemoveMember handles DELETE /workspaces/{id}/members/{userId} requests.
+func (h *WorkspaceHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	h.changeMember(w, r, h.service.RemoveMember, "could not remove workspace member")
+}
+
+func (h *WorkspaceHandler) changeMember(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id uuid.UUID, subject string) error, message string) {
+	id, err := parseUUIDParam(r, "id")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid workspace id",
+		}))
+		return
+	}
+	userID, err := parseUUIDParam(r, "userId")
+	if err != nil {
+		response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+			"message": "invalid user id",
+		}))
+		return
+	}
+
+	if err := change(r.Context(), id, userID.String()); err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+				"message": "workspace not found",
+			}))
+			return
+		}
+		h.logger.Error("change workspace member failed", zap.String("id", id.String()), zap.Error(err))
+		response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+			"message": message,
+		}))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handler_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWorkspaceHandler_Create_Success(t *testing.T) {
+	serviceMock := new(mocks.WorkspaceServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	logger := zaptest.NewLogger(t)
+	h := handler.NewWorkspaceHandler(serviceMock, validate, logger)
+
+	serviceMock.
+		On("CreateWorkspace", mock.Anything, model.CreateWorkspaceRequest{Name: "Platform"}).
+		Return(model.WorkspaceResponse{ID: uuid.New(), Name: "Platform"}, nil)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspaces", bytes.NewReader([]byte(`{"name":"Platform"}`)))
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	serviceMock.AssertExpectations(t)
+}
+
+func TestWorkspaceHandler_Create_ValidationError(t *testing.T) {
+	serviceMock := new(mocks.WorkspaceServiceMock)
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	logger := zaptest.NewLogger(t)
+	h := handler.NewWorkspaceHandler(serviceMock, validate, logger)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/workspaces", bytes.NewReader([]byte(`{"name":""}`)))
+
+	h.Create(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	serviceMock.AssertNotCalled(t, "CreateWorkspace", mock.Anything, mock.Anything)
+}
@@ -2,52 +2,116 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/lumoshiveacademy/todolist/package/auth"
 	"github.com/lumoshiveacademy/todolist/package/response"
 	"go.uber.org/zap"
 )
 
-type contextKey string
+// TokenVerifier validates bearer tokens and returns their claims.
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*auth.Claims, error)
+}
 
-const (
-	// ContextKeyClaims stores JWT claims in request context.
-	ContextKeyClaims contextKey = "jwtClaims"
-)
+// TokenDenylist reports access tokens that were revoked before they expired.
+type TokenDenylist interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// APIKeyAuthenticator resolves personal API keys into claims.
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, rawKey string) (*auth.Claims, error)
+}
 
-// JWTAuthentication validates JWT bearer tokens from the Authorization header.
-func JWTAuthentication(secret, issuer string, logger *zap.Logger) func(http.Handler) http.Handler {
+// ErrUnauthenticated reports missing, malformed, invalid or revoked
+// credentials.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// Authentication accepts either a JWT ("Authorization: Bearer ...") or a
+// personal API key ("Authorization: ApiKey ...") and stores the resulting
+// auth.Claims in the request context. Denylisted JWTs are rejected.
+func Authentication(verifier TokenVerifier, denylist TokenDenylist, apiKeys APIKeyAuthenticator, logger *zap.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader == "" {
-				unauthorized(w)
+			claims, err := Authenticate(r.Context(), r.Header.Get("Authorization"), verifier, denylist, apiKeys, logger)
+			if err != nil {
+				if errors.Is(err, ErrUnauthenticated) {
+					unauthorized(w)
+					return
+				}
+				response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+					"message": "internal server error",
+				}))
 				return
 			}
 
-			tokenString, ok := strings.CutPrefix(authHeader, "Bearer ")
-			if !ok || tokenString == "" {
-				unauthorized(w)
-				return
-			}
+			ctx := auth.WithClaims(r.Context(), claims)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
 
-			token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-				if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
-					return nil, jwt.ErrTokenSignatureInvalid
-				}
-				return []byte(secret), nil
-			}, jwt.WithAudience(issuer), jwt.WithIssuer(issuer))
+// Authenticate resolves the value of an Authorization header into claims, as
+// Authentication does for HTTP requests. Rejected credentials return
+// ErrUnauthenticated; any other error means the check itself failed.
+func Authenticate(ctx context.Context, authHeader string, verifier TokenVerifier, denylist TokenDenylist, apiKeys APIKeyAuthenticator, logger *zap.Logger) (*auth.Claims, error) {
+	if authHeader == "" {
+		return nil, ErrUnauthenticated
+	}
 
-			if err != nil || !token.Valid {
-				logger.Warn("jwt validation failed", zap.Error(err))
+	if rawKey, ok := strings.CutPrefix(authHeader, "ApiKey "); ok {
+		claims, err := apiKeys.Authenticate(ctx, rawKey)
+		if err != nil {
+			logger.Warn("api key validation failed", zap.Error(err))
+			return nil, ErrUnauthenticated
+		}
+		return claims, nil
+	}
+
+	tokenString, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok || tokenString == "" {
+		return nil, ErrUnauthenticated
+	}
+
+	claims, err := verifier.Verify(ctx, tokenString)
+	if err != nil {
+		logger.Warn("jwt validation failed", zap.Error(err))
+		return nil, ErrUnauthenticated
+	}
+
+	if claims.ID != "" {
+		revoked, err := denylist.IsAccessTokenRevoked(ctx, claims.ID)
+		if err != nil {
+			logger.Error("jwt denylist check failed", zap.Error(err))
+			return nil, fmt.Errorf("check access token denylist: %w", err)
+		}
+		if revoked {
+			logger.Warn("revoked jwt rejected", zap.String("jti", claims.ID))
+			return nil, ErrUnauthenticated
+		}
+	}
+	return claims, nil
+}
+
+// RequirePermission rejects requests whose claims do not grant the permission.
+// It must run after Authentication.
+func RequirePermission(permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := auth.ClaimsFromContext(r.Context())
+			if !ok {
 				unauthorized(w)
 				return
 			}
-
-			ctx := context.WithValue(r.Context(), ContextKeyClaims, token.Claims)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			if !claims.HasPermission(permission) {
+				forbidden(w)
+				return
+			}
+			next.ServeHTTP(w, r)
 		})
 	}
 }
@@ -57,3 +121,9 @@ func unauthorized(w http.ResponseWriter) {
 		"message": "unauthorized",
 	}))
 }
+
+func forbidden(w http.ResponseWriter) {
+	response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+		"message": "forbidden",
+	}))
+}
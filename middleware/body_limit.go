@@ -0,0 +1,15 @@
+package middleware
+
+import "net/http"
+
+// LimitBody caps request bodies at maxBytes for handlers that read them
+// without Validation. Reads past the cap fail with *http.MaxBytesError, which
+// handlers answer with 413.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
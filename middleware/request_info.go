@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lumoshiveacademy/todolist/package/requestinfo"
+)
+
+// RequestInfo stores the request ID and client IP in the request context so
+// services can record them. It must run after chi's RequestID and RealIP.
+func RequestInfo(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		ctx := requestinfo.WithInfo(r.Context(), requestinfo.Info{
+			RequestID: middleware.GetReqID(r.Context()),
+			IP:        ip,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,295 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/go-chi/chi/v5"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"go.uber.org/zap"
+)
+
+// uuidPattern accepts any UUID in canonical form, as uuid.Parse does.
+const uuidPattern = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+func init() {
+	// kin-openapi only checks formats that are registered.
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(uuidPattern))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+}
+
+// Validation checks requests against the operation doc describes for the
+// matched route, so handlers receive well-formed input. Malformed path
+// parameters and bodies that are not JSON are rejected with 400; query
+// parameters and bodies that break the schema with 422 and the offending
+// parameters or fields mapped to the reason. Requests to routes doc does not
+// describe pass through.
+//
+// Only JSON bodies are validated; other media types, such as uploads, are
+// left to their handlers. Bodies are read as JSON whatever their
+// Content-Type, like the handlers do, and rejected with 413 when they exceed
+// maxBodySize bytes.
+//
+// With validateResponses, JSON responses are buffered and checked too, and
+// mismatches are logged. This is meant for development.
+//
+// Validation must run after routing, authentication and permission checks,
+// so register it with the route rather than at the top of the router.
+func Validation(doc *openapi3.T, maxBodySize int64, validateResponses bool, logger *zap.Logger) func(http.Handler) http.Handler {
+	routes := make(map[string]*routers.Route)
+	for path, item := range doc.Paths.Map() {
+		for method, operation := range item.Operations() {
+			routes[method+" "+path] = &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			if rctx == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			pattern := rctx.RoutePattern()
+			if len(pattern) > 1 {
+				pattern = strings.TrimSuffix(pattern, "/")
+			}
+			route, ok := routes[r.Method+" "+pattern]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pathParams := make(map[string]string, len(rctx.URLParams.Keys))
+			for i, key := range rctx.URLParams.Keys {
+				if key != "*" {
+					pathParams[key] = rctx.URLParams.Values[i]
+				}
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r.Clone(r.Context()),
+				PathParams: pathParams,
+				Route:      route,
+				Options: &openapi3filter.Options{
+					MultiError:          true,
+					SkipSettingDefaults: true,
+					// Authentication has its own middleware.
+					AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				},
+			}
+			if hasJSONBody(route.Operation) {
+				body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					response.Write(w, http.StatusRequestEntityTooLarge, response.Failure(map[string]string{
+						"message": "request body too large",
+					}))
+					return
+				}
+				if err != nil {
+					logger.Warn("read request body failed", zap.Error(err))
+					response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+						"message": "invalid request payload",
+					}))
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				input.Request.Body = io.NopCloser(bytes.NewReader(body))
+				input.Request.Header.Set("Content-Type", "application/json")
+			} else {
+				input.Request.Body = http.NoBody
+				input.Options.ExcludeRequestBody = true
+			}
+
+			if err := openapi3filter.ValidateRequest(r.Context(), input); err != nil {
+				status, errs := requestErrors(err)
+				logger.Warn("request does not match the openapi document",
+					zap.String("method", r.Method),
+					zap.String("route", route.Path),
+					zap.Error(err),
+				)
+				response.Write(w, status, response.Failure(errs))
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+			recorder := &validationRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			if recorder.body == nil {
+				return
+			}
+
+			err := openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 recorder.status,
+				Header:                 w.Header(),
+				Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+				Options: &openapi3filter.Options{
+					MultiError:            true,
+					IncludeResponseStatus: true,
+				},
+			})
+			if err != nil {
+				logger.Error("response does not match the openapi document",
+					zap.String("method", r.Method),
+					zap.String("route", route.Path),
+					zap.Int("status", recorder.status),
+					zap.Error(err),
+				)
+			}
+			w.WriteHeader(recorder.status)
+			_, _ = w.Write(recorder.body.Bytes())
+		})
+	}
+}
+
+// hasJSONBody reports whether operation takes a JSON request body.
+func hasJSONBody(operation *openapi3.Operation) bool {
+	return operation.RequestBody != nil &&
+		operation.RequestBody.Value != nil &&
+		operation.RequestBody.Value.Content.Get("application/json") != nil
+}
+
+// requestErrors maps a validation error to the response status and the
+// error payload. Each invalid parameter or body field maps to its reason.
+func requestErrors(err error) (int, map[string]string) {
+	status := http.StatusUnprocessableEntity
+	errs := make(map[string]string)
+
+	var requestErrs []*openapi3filter.RequestError
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		for _, e := range multi {
+			var requestErr *openapi3filter.RequestError
+			if errors.As(e, &requestErr) {
+				requestErrs = append(requestErrs, requestErr)
+			}
+		}
+	} else {
+		var requestErr *openapi3filter.RequestError
+		if errors.As(err, &requestErr) {
+			requestErrs = append(requestErrs, requestErr)
+		}
+	}
+
+	for _, requestErr := range requestErrs {
+		schemaErrs := schemaErrors(requestErr.Err)
+		switch {
+		case requestErr.Parameter != nil:
+			parameter := requestErr.Parameter
+			if parameter.In != openapi3.ParameterInQuery {
+				status = http.StatusBadRequest
+			}
+			reason := requestErr.Reason
+			if len(schemaErrs) > 0 {
+				reason = schemaReason(schemaErrs[0])
+			} else if reason == "" && requestErr.Err != nil {
+				reason = requestErr.Err.Error()
+			}
+			errs[parameter.Name] = reason
+		case len(schemaErrs) > 0:
+			for _, schemaErr := range schemaErrs {
+				field := strings.Join(schemaErr.JSONPointer(), ".")
+				if field == "" {
+					status = http.StatusBadRequest
+					errs["message"] = "invalid request payload"
+					continue
+				}
+				if _, ok := errs[field]; !ok {
+					errs[field] = schemaReason(schemaErr)
+				}
+			}
+		default:
+			status = http.StatusBadRequest
+			errs["message"] = "invalid request payload"
+		}
+	}
+	if len(errs) == 0 {
+		status = http.StatusBadRequest
+		errs["message"] = "invalid request"
+	}
+	return status, errs
+}
+
+// schemaErrors flattens the schema violations in err.
+func schemaErrors(err error) []*openapi3.SchemaError {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		var errs []*openapi3.SchemaError
+		for _, e := range multi {
+			errs = append(errs, schemaErrors(e)...)
+		}
+		return errs
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []*openapi3.SchemaError{schemaErr}
+	}
+	return nil
+}
+
+// schemaReason describes a schema violation without the patterns behind
+// formats.
+func schemaReason(err *openapi3.SchemaError) string {
+	switch err.SchemaField {
+	case "required":
+		return "is required"
+	case "format":
+		return fmt.Sprintf("must be a valid %s", err.Schema.Format)
+	}
+	return err.Reason
+}
+
+// validationRecorder buffers JSON responses for validation and passes any
+// other response, such as downloads and event streams, straight through.
+type validationRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        *bytes.Buffer
+}
+
+func (w *validationRecorder) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	if strings.HasPrefix(w.Header().Get("Content-Type"), "application/json") {
+		w.body = new(bytes.Buffer)
+		return
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *validationRecorder) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.body != nil {
+		return w.body.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *validationRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
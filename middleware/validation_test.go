@@ -0,0 +1,133 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/lumoshiveacademy/todolist/middleware"
+	"github.com/lumoshiveacademy/todolist/package/openapi"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type createNoteRequest struct {
+	Text string `json:"text" validate:"required,max=10"`
+}
+
+type noteResponse struct {
+	Text string `json:"text"`
+}
+
+func newValidatedRouter(handler http.HandlerFunc, validateResponses bool) (http.Handler, *observer.ObservedLogs) {
+	generator := openapi.New(openapi.Options{Info: openapi3.Info{Title: "Notes", Version: "1.0.0"}})
+	generator.Add(openapi.Operation{
+		Method:   http.MethodPost,
+		Path:     "/notes",
+		Public:   true,
+		Request:  createNoteRequest{},
+		Status:   http.StatusCreated,
+		Response: noteResponse{},
+	})
+	generator.Add(openapi.Operation{
+		Method:   http.MethodGet,
+		Path:     "/notes/export",
+		Public:   true,
+		Response: openapi.Content{"text/plain": openapi3.NewStringSchema()},
+	})
+
+	core, logs := observer.New(zap.WarnLevel)
+	validate := middleware.Validation(generator.Document(), 64, validateResponses, zap.New(core))
+	r := chi.NewRouter()
+	r.With(validate).Post("/notes", handler)
+	r.With(validate).Get("/notes/export", handler)
+	return r, logs
+}
+
+func TestValidation_RejectsInvalidBodies(t *testing.T) {
+	r, _ := newValidatedRouter(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("handler called with an invalid body")
+	}, false)
+
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"text":"far too long for a note"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	require.JSONEq(t, `{"status":"error","error":{"text":"maximum string length is 10"}}`, rr.Body.String())
+}
+
+func TestValidation_RejectsOversizedBodies(t *testing.T) {
+	r, _ := newValidatedRouter(func(http.ResponseWriter, *http.Request) {
+		t.Fatal("handler called with an oversized body")
+	}, false)
+
+	body := `{"text":"milk","padding":"` + strings.Repeat("x", 64) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	require.JSONEq(t, `{"status":"error","error":{"message":"request body too large"}}`, rr.Body.String())
+}
+
+func TestValidation_PassesBodyToHandler(t *testing.T) {
+	r, _ := newValidatedRouter(func(w http.ResponseWriter, r *http.Request) {
+		var req createNoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		response.Write(w, http.StatusCreated, response.Success(noteResponse{Text: req.Text}))
+	}, true)
+
+	req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"text":"milk"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusCreated, rr.Code)
+	require.JSONEq(t, `{"status":"success","data":{"text":"milk"}}`, rr.Body.String())
+}
+
+func TestValidation_LogsInvalidResponses(t *testing.T) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		response.Write(w, http.StatusCreated, response.Success(map[string]int{"text": 1}))
+	}
+
+	for _, validateResponses := range []bool{false, true} {
+		r, logs := newValidatedRouter(handler, validateResponses)
+
+		req := httptest.NewRequest(http.MethodPost, "/notes", strings.NewReader(`{"text":"milk"}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		require.Equal(t, http.StatusCreated, rr.Code, "responses are sent unchanged")
+		require.JSONEq(t, `{"status":"success","data":{"text":1}}`, rr.Body.String())
+		entries := logs.FilterMessage("response does not match the openapi document").All()
+		if !validateResponses {
+			require.Empty(t, entries)
+			continue
+		}
+		require.Len(t, entries, 1)
+		require.Contains(t, entries[0].ContextMap()["error"], `value must be a string`)
+	}
+}
+
+func TestValidation_StreamsOtherResponses(t *testing.T) {
+	r, logs := newValidatedRouter(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("milk\n"))
+		require.NoError(t, http.NewResponseController(w).Flush())
+	}, true)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/notes/export", nil))
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.True(t, rr.Flushed)
+	require.Equal(t, "milk\n", rr.Body.String())
+	require.Zero(t, logs.Len())
+}
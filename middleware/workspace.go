@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// HeaderWorkspaceID selects the active workspace when the token carries none.
+const HeaderWorkspaceID = "X-Workspace-ID"
+
+// WorkspaceFinder looks up workspaces and their members so requests cannot
+// target unknown tenants or tenants the caller does not belong to.
+type WorkspaceFinder interface {
+	GetWorkspace(ctx context.Context, id uuid.UUID) (model.WorkspaceResponse, error)
+	IsMember(ctx context.Context, id uuid.UUID, subject string) (bool, error)
+}
+
+// Errors reported by ResolveWorkspace.
+var (
+	ErrWorkspaceRequired = errors.New("workspace is required")
+	ErrInvalidWorkspace  = errors.New("invalid workspace id")
+	ErrWorkspaceMismatch = errors.New("workspace header does not match token claim")
+	ErrNotMember         = errors.New("not a member of the workspace")
+	ErrMFARequired       = errors.New("mfa required")
+)
+
+// Workspace resolves the active workspace from the JWT claim or the
+// X-Workspace-ID header and stores it in the request context. When both are
+// present they must match, and the caller must be a member of the workspace.
+// Workspaces that require MFA reject sessions that did not pass it.
+func Workspace(finder WorkspaceFinder, logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			workspaceID, err := ResolveWorkspace(r.Context(), finder, r.Header.Get(HeaderWorkspaceID), logger)
+			if err != nil {
+				switch {
+				case errors.Is(err, ErrWorkspaceRequired), errors.Is(err, ErrInvalidWorkspace):
+					response.Write(w, http.StatusBadRequest, response.Failure(map[string]string{
+						"message": err.Error(),
+					}))
+				case errors.Is(err, ErrWorkspaceMismatch), errors.Is(err, ErrNotMember):
+					forbidden(w)
+				case errors.Is(err, repository.ErrWorkspaceNotFound):
+					response.Write(w, http.StatusNotFound, response.Failure(map[string]string{
+						"message": "workspace not found",
+					}))
+				case errors.Is(err, ErrMFARequired):
+					response.Write(w, http.StatusForbidden, response.Failure(map[string]string{
+						"message": "mfa required",
+					}))
+				default:
+					response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+						"message": "could not resolve workspace",
+					}))
+				}
+				return
+			}
+
+			ctx := tenant.WithWorkspaceID(r.Context(), workspaceID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ResolveWorkspace picks the active workspace for the claims in ctx, as
+// Workspace does for HTTP requests, given the requested workspace ID (the
+// X-Workspace-ID header or its equivalent). It returns one of the errors
+// above, repository.ErrWorkspaceNotFound, or any other error when the lookup
+// failed.
+func ResolveWorkspace(ctx context.Context, finder WorkspaceFinder, requested string, logger *zap.Logger) (uuid.UUID, error) {
+	var claimValue string
+	claims, hasClaims := auth.ClaimsFromContext(ctx)
+	if hasClaims {
+		claimValue = claims.WorkspaceID
+	}
+
+	value := claimValue
+	if value == "" {
+		value = requested
+	}
+	if value == "" {
+		return uuid.Nil, ErrWorkspaceRequired
+	}
+	if claimValue != "" && requested != "" && claimValue != requested {
+		logger.Warn("workspace header does not match token claim",
+			zap.String("claim", claimValue),
+			zap.String("header", requested),
+		)
+		return uuid.Nil, ErrWorkspaceMismatch
+	}
+
+	workspaceID, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, ErrInvalidWorkspace
+	}
+
+	workspace, err := finder.GetWorkspace(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			return uuid.Nil, err
+		}
+		logger.Error("resolve workspace failed", zap.String("workspace_id", workspaceID.String()), zap.Error(err))
+		return uuid.Nil, fmt.Errorf("resolve workspace: %w", err)
+	}
+	if !hasClaims {
+		return uuid.Nil, ErrNotMember
+	}
+	member, err := finder.IsMember(ctx, workspaceID, claims.Subject)
+	if err != nil {
+		logger.Error("resolve workspace membership failed", zap.String("workspace_id", workspaceID.String()), zap.Error(err))
+		return uuid.Nil, fmt.Errorf("resolve workspace: %w", err)
+	}
+	if !member {
+		logger.Warn("workspace access denied",
+			zap.String("workspace_id", workspaceID.String()),
+			zap.String("subject", claims.Subject),
+		)
+		return uuid.Nil, ErrNotMember
+	}
+	if workspace.RequireMFA && !claims.MFAAuthenticated() {
+		return uuid.Nil, ErrMFARequired
+	}
+	return workspaceID, nil
+}
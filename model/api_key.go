@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey is a long-lived credential a user creates for scripts and CI.
+// The key is presented as "tdl_<prefix>.<secret>"; the prefix identifies
+// the record and only the SHA-256 hash of the secret is persisted.
+type APIKey struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID      string    `gorm:"size:255;not null;index"`
+	WorkspaceID string    `gorm:"size:36"`
+	Name        string    `gorm:"size:100;not null"`
+	Prefix      string    `gorm:"size:16;not null;uniqueIndex"`
+	SecretHash  string    `gorm:"size:64;not null"`
+	Scopes      []string  `gorm:"serializer:json;type:text;not null"`
+	MFA         bool      `gorm:"not null;default:false"`
+	ExpiresAt   *time.Time
+	LastUsedAt  *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BeforeCreate ensures the APIKey has a UUID before persisting.
+func (k *APIKey) BeforeCreate(_ *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// Expired reports whether the key can no longer be used at the given time.
+func (k APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+// CreateAPIKeyRequest defines the payload for creating an API key.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name" validate:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1,dive,required"`
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt"`
+}
+
+// UpdateAPIKeyRequest defines the payload for renaming or re-scoping an API key.
+type UpdateAPIKeyRequest struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
+
+// APIKeyResponse describes an API key returned to clients.
+// Key is only populated when the key is created.
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty" doc:"Full key, only returned on creation."`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (k APIKey) ToResponse() APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+		UpdatedAt:  k.UpdatedAt,
+	}
+}
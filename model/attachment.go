@@ -0,0 +1,75 @@
+package model
+
+import (
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Attachment is a file uploaded to a todo list or, when TodoItemID is set, to
+// one of its items. The contents live in blob storage under StorageKey;
+// Checksum is their hex-encoded SHA-256 and ContentType is sniffed from them
+// rather than taken from the client.
+type Attachment struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TodoListID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TodoItemID  *uuid.UUID `gorm:"type:uuid;index"`
+	UploaderID  string     `gorm:"size:255;not null"`
+	Filename    string     `gorm:"size:255;not null"`
+	ContentType string     `gorm:"size:255;not null"`
+	Size        int64      `gorm:"not null"`
+	Checksum    string     `gorm:"size:64;not null"`
+	StorageKey  string     `gorm:"size:512;not null"`
+	CreatedAt   time.Time
+}
+
+// BeforeCreate ensures the Attachment has a UUID before persisting.
+func (a *Attachment) BeforeCreate(_ *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AttachmentUpload is a file received in a multipart upload. Content is read
+// twice, once to checksum it and once to store it.
+type AttachmentUpload struct {
+	Filename string
+	Size     int64
+	Content  io.ReadSeeker
+}
+
+// AttachmentResponse describes an attachment returned to clients.
+// DownloadURL is a signed link that works without credentials until
+// DownloadExpiresAt.
+type AttachmentResponse struct {
+	ID                uuid.UUID  `json:"id"`
+	TodoListID        uuid.UUID  `json:"todo_list_id"`
+	TodoItemID        *uuid.UUID `json:"todo_item_id,omitempty"`
+	UploaderID        string     `json:"uploader_id"`
+	Filename          string     `json:"filename"`
+	ContentType       string     `json:"content_type" doc:"Sniffed from the file's contents."`
+	Size              int64      `json:"size"`
+	Checksum          string     `json:"checksum" doc:"Hex-encoded SHA-256 of the contents."`
+	DownloadURL       string     `json:"download_url,omitempty"`
+	DownloadExpiresAt *time.Time `json:"download_expires_at,omitempty"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (a Attachment) ToResponse() AttachmentResponse {
+	return AttachmentResponse{
+		ID:          a.ID,
+		TodoListID:  a.TodoListID,
+		TodoItemID:  a.TodoItemID,
+		UploaderID:  a.UploaderID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		CreatedAt:   a.CreatedAt,
+	}
+}
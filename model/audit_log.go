@@ -0,0 +1,101 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Audited actions.
+const (
+	AuditActionCreate  = "create"
+	AuditActionUpdate  = "update"
+	AuditActionDelete  = "delete"
+	AuditActionRestore = "restore"
+)
+
+// Audited entity types.
+const (
+	AuditEntityTodoList = "todo_list"
+	AuditEntityTodoItem = "todo_item"
+	AuditEntityTag      = "tag"
+)
+
+// AuditChange holds a field's value before and after a mutation. Before is
+// nil for created entities and After is nil for deleted ones.
+type AuditChange struct {
+	Before interface{} `json:"before" doc:"Value before the change; null for created entities."`
+	After  interface{} `json:"after" doc:"Value after the change; null for deleted entities."`
+}
+
+// AuditLog is an append-only record of a mutation: who changed which entity,
+// the fields that changed and the request that did it.
+type AuditLog struct {
+	ID          uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID              `gorm:"type:uuid;not null;index:idx_audit_logs_workspace_created,priority:1"`
+	ActorID     string                 `gorm:"size:255;not null;index"`
+	Action      string                 `gorm:"size:32;not null"`
+	EntityType  string                 `gorm:"size:64;not null"`
+	EntityID    uuid.UUID              `gorm:"type:uuid;not null;index"`
+	Changes     map[string]AuditChange `gorm:"type:jsonb;serializer:json"`
+	RequestID   string                 `gorm:"size:64"`
+	IP          string                 `gorm:"size:64"`
+	CreatedAt   time.Time              `gorm:"not null;index:idx_audit_logs_workspace_created,priority:2,sort:desc"`
+}
+
+// BeforeCreate ensures the AuditLog has a UUID before persisting.
+func (a *AuditLog) BeforeCreate(_ *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// AuditLogFilter narrows an audit log listing. Zero values do not filter.
+type AuditLogFilter struct {
+	ActorID    string     `query:"actor_id" validate:"max=255"`
+	Action     string     `query:"action" validate:"omitempty,oneof=create update delete restore"`
+	EntityType string     `query:"entity_type" validate:"max=64" doc:"For example todo_list."`
+	EntityID   *uuid.UUID `query:"entity_id"`
+	From       *time.Time `query:"from" doc:"Inclusive lower bound on the entry time."`
+	To         *time.Time `query:"to" doc:"Exclusive upper bound on the entry time."`
+	Limit      int        `query:"limit" validate:"min=1,max=200" doc:"Defaults to 50."`
+	Offset     int        `query:"offset" validate:"min=0"`
+}
+
+// AuditLogResponse describes an audit entry returned to clients.
+type AuditLogResponse struct {
+	ID         uuid.UUID              `json:"id"`
+	ActorID    string                 `json:"actor_id"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   uuid.UUID              `json:"entity_id"`
+	Changes    map[string]AuditChange `json:"changes" doc:"Changed fields keyed by name."`
+	RequestID  string                 `json:"request_id,omitempty"`
+	IP         string                 `json:"ip,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// AuditLogPage is one page of audit entries, newest first.
+type AuditLogPage struct {
+	Items  []AuditLogResponse `json:"items"`
+	Total  int64              `json:"total"`
+	Limit  int                `json:"limit"`
+	Offset int                `json:"offset"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (a AuditLog) ToResponse() AuditLogResponse {
+	return AuditLogResponse{
+		ID:         a.ID,
+		ActorID:    a.ActorID,
+		Action:     a.Action,
+		EntityType: a.EntityType,
+		EntityID:   a.EntityID,
+		Changes:    a.Changes,
+		RequestID:  a.RequestID,
+		IP:         a.IP,
+		CreatedAt:  a.CreatedAt,
+	}
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CalendarFeed grants unauthenticated read access to an iCalendar feed of
+// the due items in a user's todo lists. A user has at most one feed per
+// workspace; only the SHA-256 hash of its token is persisted.
+type CalendarFeed struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_calendar_feeds_user,priority:1"`
+	UserID      string    `gorm:"size:255;not null;uniqueIndex:idx_calendar_feeds_user,priority:2"`
+	TokenHash   string    `gorm:"size:64;not null;uniqueIndex"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BeforeCreate ensures the CalendarFeed has a UUID before persisting.
+func (c *CalendarFeed) BeforeCreate(_ *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// CalendarFeedResponse describes a newly issued calendar feed. The token
+// cannot be retrieved again; issuing a new one replaces it.
+type CalendarFeedResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
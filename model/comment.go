@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment is a Markdown note on a todo list or, when TodoItemID is set, on
+// one of its items. Replies point at a top-level comment through ParentID;
+// threads are one level deep. Deleted comments keep their place in the
+// thread with an empty body so replies stay attached.
+type Comment struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TodoListID  uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TodoItemID  *uuid.UUID `gorm:"type:uuid;index"`
+	ParentID    *uuid.UUID `gorm:"type:uuid;index"`
+	AuthorID    string     `gorm:"size:255;not null"`
+	Body        string     `gorm:"type:text;not null"`
+	EditedAt    *time.Time
+	DeletedAt   *time.Time
+	CreatedAt   time.Time
+}
+
+// BeforeCreate ensures the Comment has a UUID before persisting.
+func (c *Comment) BeforeCreate(_ *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// CreateCommentRequest defines the payload for posting a comment. ParentID
+// makes it a reply to a top-level comment on the same list or item.
+type CreateCommentRequest struct {
+	Body     string     `json:"body" validate:"required,max=10000"`
+	ParentID *uuid.UUID `json:"parent_id" doc:"Makes the comment a reply to a top-level comment on the same list or item."`
+}
+
+// UpdateCommentRequest defines the payload for editing a comment.
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,max=10000"`
+}
+
+// CommentFilter pages through the threads on a list or, when TodoItemID is
+// set, on one of its items.
+type CommentFilter struct {
+	TodoListID uuid.UUID
+	TodoItemID *uuid.UUID
+	Limit      int `query:"limit" validate:"min=1,max=100" doc:"Defaults to 20."`
+	Offset     int `query:"offset" validate:"min=0"`
+}
+
+// CommentResponse describes a comment returned to clients. Body is the
+// Markdown source and BodyHTML its sanitized rendering; both are empty once
+// the comment is deleted.
+type CommentResponse struct {
+	ID         uuid.UUID         `json:"id"`
+	TodoListID uuid.UUID         `json:"todo_list_id"`
+	TodoItemID *uuid.UUID        `json:"todo_item_id,omitempty"`
+	ParentID   *uuid.UUID        `json:"parent_id,omitempty"`
+	AuthorID   string            `json:"author_id"`
+	Body       string            `json:"body" doc:"Markdown source; empty once deleted."`
+	BodyHTML   string            `json:"body_html" doc:"The body rendered to HTML. Raw HTML is escaped and only http, https and mailto links are kept."`
+	Deleted    bool              `json:"deleted,omitempty"`
+	EditedAt   *time.Time        `json:"edited_at,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+}
+
+// CommentPage is one page of threads, oldest first, each with all its
+// replies.
+type CommentPage struct {
+	Items  []CommentResponse `json:"items"`
+	Total  int64             `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
+
+// ToResponse converts the model into a response DTO. The caller renders
+// BodyHTML.
+func (c Comment) ToResponse() CommentResponse {
+	return CommentResponse{
+		ID:         c.ID,
+		TodoListID: c.TodoListID,
+		TodoItemID: c.TodoItemID,
+		ParentID:   c.ParentID,
+		AuthorID:   c.AuthorID,
+		Body:       c.Body,
+		Deleted:    c.DeletedAt != nil,
+		EditedAt:   c.EditedAt,
+		CreatedAt:  c.CreatedAt,
+	}
+}
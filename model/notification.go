@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Notification is an entry in a user's in-app inbox.
+type Notification struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;not null;index:idx_notifications_inbox,priority:1"`
+	UserID      string     `gorm:"size:255;not null;index:idx_notifications_inbox,priority:2"`
+	TodoItemID  *uuid.UUID `gorm:"type:uuid"`
+	Title       string     `gorm:"size:255;not null"`
+	Body        string     `gorm:"type:text"`
+	ReadAt      *time.Time
+	CreatedAt   time.Time `gorm:"index:idx_notifications_inbox,priority:3"`
+}
+
+// BeforeCreate ensures the Notification has a UUID before persisting.
+func (n *Notification) BeforeCreate(_ *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// NotificationResponse describes an inbox entry returned to clients.
+type NotificationResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	TodoItemID *uuid.UUID `json:"todo_item_id,omitempty"`
+	Title      string     `json:"title"`
+	Body       string     `json:"body,omitempty"`
+	ReadAt     *time.Time `json:"read_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (n Notification) ToResponse() NotificationResponse {
+	return NotificationResponse{
+		ID:         n.ID,
+		TodoItemID: n.TodoItemID,
+		Title:      n.Title,
+		Body:       n.Body,
+		ReadAt:     n.ReadAt,
+		CreatedAt:  n.CreatedAt,
+	}
+}
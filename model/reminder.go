@@ -0,0 +1,105 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Reminder delivery channels.
+const (
+	ReminderChannelWebhook = "webhook"
+	ReminderChannelEmail   = "email"
+	ReminderChannelInbox   = "inbox"
+)
+
+// Reminder lifecycle states. Pending reminders are picked up by the
+// scheduler once RemindAt has passed.
+const (
+	ReminderStatusPending   = "pending"
+	ReminderStatusSent      = "sent"
+	ReminderStatusFailed    = "failed"
+	ReminderStatusCancelled = "cancelled"
+)
+
+// Reminder notifies UserID about a todo item at RemindAt. Reminders set
+// relative to the item's due date keep OffsetSeconds so they can be moved
+// when the due date changes; RemindAt is nil while the item has no due date.
+// LeasedUntil hides a reminder from other scheduler replicas while one of
+// them delivers it.
+type Reminder struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	TodoListID    uuid.UUID  `gorm:"type:uuid;not null"`
+	TodoItemID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID        string     `gorm:"size:255;not null"`
+	Channel       string     `gorm:"size:16;not null"`
+	Target        string     `gorm:"size:2048"`
+	RemindAt      *time.Time `gorm:"index:idx_reminders_pending,where:status = 'pending'"`
+	OffsetSeconds *int64
+	Status        string `gorm:"size:16;not null;default:pending"`
+	Attempts      int    `gorm:"not null;default:0"`
+	LastError     string `gorm:"size:1024"`
+	SentAt        *time.Time
+	LeasedUntil   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// BeforeCreate ensures the Reminder has a UUID and status before persisting.
+func (r *Reminder) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	if r.Status == "" {
+		r.Status = ReminderStatusPending
+	}
+	return nil
+}
+
+// CreateReminderRequest defines the payload for creating a reminder. Exactly
+// one of remind_at and offset_minutes (before the item's due date) is set;
+// webhook reminders also need a target URL.
+type CreateReminderRequest struct {
+	RemindAt      *time.Time `json:"remind_at" validate:"required_without=OffsetMinutes,excluded_with=OffsetMinutes,omitempty,future" doc:"Must be in the future."`
+	OffsetMinutes *int       `json:"offset_minutes" validate:"required_without=RemindAt,omitempty,min=0,max=525600" doc:"Minutes before the item's due date."`
+	Channel       string     `json:"channel" validate:"required,oneof=webhook email inbox"`
+	Target        string     `json:"target" validate:"required_if=Channel webhook,omitempty,https_url,max=2048" doc:"Webhook https URL; required for the webhook channel. Addresses that are not on the public internet are refused when the reminder is sent."`
+}
+
+// ReminderResponse describes a reminder returned to clients.
+type ReminderResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	TodoItemID    uuid.UUID  `json:"todo_item_id"`
+	Channel       string     `json:"channel"`
+	Target        string     `json:"target,omitempty"`
+	RemindAt      *time.Time `json:"remind_at,omitempty" doc:"Absent while an offset reminder's item has no due date."`
+	OffsetMinutes *int       `json:"offset_minutes,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error,omitempty"`
+	SentAt        *time.Time `json:"sent_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (r Reminder) ToResponse() ReminderResponse {
+	res := ReminderResponse{
+		ID:         r.ID,
+		TodoItemID: r.TodoItemID,
+		Channel:    r.Channel,
+		Target:     r.Target,
+		RemindAt:   r.RemindAt,
+		Status:     r.Status,
+		Attempts:   r.Attempts,
+		LastError:  r.LastError,
+		SentAt:     r.SentAt,
+		CreatedAt:  r.CreatedAt,
+	}
+	if r.OffsetSeconds != nil {
+		minutes := int(*r.OffsetSeconds / 60)
+		res.OffsetMinutes = &minutes
+	}
+	return res
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShareLink grants unauthenticated read-only access to a todo list.
+// Only the SHA-256 hash of the token is persisted.
+type ShareLink struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `gorm:"type:uuid;not null;index"`
+	TodoListID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash   string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt   *time.Time
+	RevokedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BeforeCreate ensures the ShareLink has a UUID before persisting.
+func (s *ShareLink) BeforeCreate(_ *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// Active reports whether the link can still be used at the given time.
+func (s ShareLink) Active(now time.Time) bool {
+	if s.RevokedAt != nil {
+		return false
+	}
+	return s.ExpiresAt == nil || now.Before(*s.ExpiresAt)
+}
+
+// CreateShareLinkRequest defines the payload for creating a share link.
+type CreateShareLinkRequest struct {
+	ExpiresAt *time.Time `json:"expires_at" validate:"omitempty,gt" doc:"Optional expiry; must be in the future."`
+}
+
+// ShareLinkResponse describes a share link returned to clients.
+// Token and URL are only populated when the link is created.
+type ShareLinkResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	TodoListID uuid.UUID  `json:"todo_list_id"`
+	Token      string     `json:"token,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (s ShareLink) ToResponse() ShareLinkResponse {
+	return ShareLinkResponse{
+		ID:         s.ID,
+		TodoListID: s.TodoListID,
+		ExpiresAt:  s.ExpiresAt,
+		RevokedAt:  s.RevokedAt,
+		CreatedAt:  s.CreatedAt,
+	}
+}
@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultTagColor is used for tags created without a color.
+const DefaultTagColor = "#9e9e9e"
+
+// Tag match modes for TodoListFilter.
+const (
+	TagMatchAny = "any"
+	TagMatchAll = "all"
+)
+
+// Tag is a colored label that can be attached to todo lists. Tag names are
+// unique within a workspace; OwnerID is the user who created the tag.
+type Tag struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_tags_workspace_name,priority:1"`
+	OwnerID     string    `gorm:"size:255;not null"`
+	Name        string    `gorm:"size:64;not null;uniqueIndex:idx_tags_workspace_name,priority:2"`
+	Color       string    `gorm:"size:9;not null"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// BeforeCreate ensures the Tag has a UUID and color before persisting.
+func (t *Tag) BeforeCreate(_ *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.Color == "" {
+		t.Color = DefaultTagColor
+	}
+	return nil
+}
+
+// TodoListTag attaches a Tag to a TodoList. The (tag_id, todo_list_id) index
+// serves tag filters; the primary key serves lookups by list.
+type TodoListTag struct {
+	TodoListID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_todo_list_tags_tag_list,priority:2"`
+	TagID      uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_todo_list_tags_tag_list,priority:1"`
+	CreatedAt  time.Time
+}
+
+// CreateTagRequest defines the payload for creating a tag. Names may not
+// contain commas because they are listed comma-separated in tag filters.
+type CreateTagRequest struct {
+	Name  string `json:"name" validate:"required,max=64,excludesall=0x2C" doc:"Unique within the workspace; may not contain commas."`
+	Color string `json:"color" validate:"omitempty,hexcolor" doc:"Hex color such as #4caf50. Defaults to #9e9e9e."`
+}
+
+// UpdateTagRequest defines the payload for renaming or recoloring a tag. An
+// empty color keeps the current one.
+type UpdateTagRequest struct {
+	Name  string `json:"name" validate:"required,max=64,excludesall=0x2C"`
+	Color string `json:"color" validate:"omitempty,hexcolor" doc:"Hex color. Omit to keep the current color."`
+}
+
+// TagResponse describes a tag returned to clients.
+type TagResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (t Tag) ToResponse() TagResponse {
+	return TagResponse{
+		ID:        t.ID,
+		Name:      t.Name,
+		Color:     t.Color,
+		CreatedAt: t.CreatedAt,
+	}
+}
@@ -0,0 +1,185 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Priorities of todo lists and items.
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Statuses of todo lists and items. Open and in-progress work counts as
+// pending and can become overdue.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusDone       = "done"
+	StatusArchived   = "archived"
+)
+
+// Priorities lists the accepted priority values.
+var Priorities = []string{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// Statuses lists the accepted status values.
+var Statuses = []string{StatusOpen, StatusInProgress, StatusDone, StatusArchived}
+
+// PendingStatuses lists the statuses of work that is not finished yet.
+var PendingStatuses = []string{StatusOpen, StatusInProgress}
+
+// MaxSubtaskDepth is how many levels of subtasks may nest below a top-level
+// todo item.
+const MaxSubtaskDepth = 3
+
+// DueFilter narrows todo list and item listings by status, priority and due
+// date. Overdue selects pending entries whose due date has passed.
+type DueFilter struct {
+	Status    string     `query:"status" validate:"omitempty,todo_status"`
+	Priority  string     `query:"priority" validate:"omitempty,todo_priority"`
+	Overdue   bool       `query:"overdue" validate:"-" doc:"When true, only open or in-progress entries whose due date has passed."`
+	DueBefore *time.Time `query:"due_before" validate:"-" doc:"Only entries due before this time."`
+	DueAfter  *time.Time `query:"due_after" validate:"-" doc:"Only entries due at or after this time."`
+}
+
+// TodoItem is a single task inside a TodoList.
+type TodoItem struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_todo_items_pending_due,priority:1,where:status = 'open' OR status = 'in_progress'"`
+	TodoListID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_todo_items_list_status,priority:1;index:idx_todo_items_list_rank,priority:1"`
+	Title       string     `gorm:"size:255;not null"`
+	Description string     `gorm:"type:text"`
+	Status      string     `gorm:"size:20;not null;default:open;index:idx_todo_items_list_status,priority:2"`
+	Priority    string     `gorm:"size:20;not null;default:medium"`
+	DueAt       *time.Time `gorm:"index:idx_todo_items_pending_due,priority:2;uniqueIndex:idx_todo_items_series_due,priority:2"`
+	CompletedAt *time.Time
+	// Recurrence is an RFC 5545 RRULE. Occurrences of one series share
+	// SeriesID and are expanded from SeriesStartAt in Timezone.
+	Recurrence    string     `gorm:"size:512"`
+	Timezone      string     `gorm:"size:64"`
+	SeriesID      *uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_todo_items_series_due,priority:1,where:series_id IS NOT NULL"`
+	SeriesStartAt *time.Time
+	// ParentID makes the item a subtask of another item in the same list.
+	ParentID *uuid.UUID `gorm:"type:uuid;index"`
+	// Rank orders items within their list; see package rank. Items created
+	// before manual ordering existed have an empty rank until rebalanced.
+	Rank      string `gorm:"type:varchar(255) COLLATE \"C\";not null;default:'';index:idx_todo_items_list_rank,priority:2"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BeforeCreate ensures the TodoItem has a UUID and defaults before persisting.
+func (t *TodoItem) BeforeCreate(_ *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	if t.Status == "" {
+		t.Status = StatusOpen
+	}
+	if t.Priority == "" {
+		t.Priority = PriorityMedium
+	}
+	return nil
+}
+
+// Progress counts how many of a todo item's subtasks, or of a list's items,
+// are done. Archived entries are not counted.
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// TodoItemFilter narrows the items returned for a todo list.
+type TodoItemFilter struct {
+	DueFilter
+}
+
+// CreateTodoItemRequest defines the expected payload for creating a todo item.
+// A recurring item needs a due date, which becomes the start of its series.
+// ParentID creates the item as a subtask of another item in the list.
+type CreateTodoItemRequest struct {
+	ParentID    *uuid.UUID `json:"parent_id" doc:"Makes the item a subtask of this item, which must be in the same list. Subtasks nest at most 3 levels deep."`
+	Title       string     `json:"title" validate:"required,min=1,max=255"`
+	Description string     `json:"description" validate:"max=4096"`
+	Priority    string     `json:"priority" validate:"omitempty,todo_priority"`
+	DueAt       *time.Time `json:"due_at" validate:"required_with=Recurrence,omitempty,future" doc:"Must be in the future."`
+	Recurrence  string     `json:"recurrence" validate:"omitempty,max=512,rrule" doc:"RFC 5545 RRULE, for example FREQ=WEEKLY;BYDAY=MO. Requires due_at."`
+	Timezone    string     `json:"timezone" validate:"omitempty,timezone" doc:"IANA timezone the series is expanded in; defaults to DB_TIMEZONE."`
+}
+
+// UpdateTodoItemRequest defines the payload for updating a todo item. Empty
+// status and priority keep their current values; a missing due_at, recurrence
+// or timezone clears it.
+type UpdateTodoItemRequest struct {
+	Title       string     `json:"title" validate:"required,min=1,max=255"`
+	Description string     `json:"description" validate:"max=4096"`
+	Status      string     `json:"status" validate:"omitempty,todo_status"`
+	Priority    string     `json:"priority" validate:"omitempty,todo_priority"`
+	DueAt       *time.Time `json:"due_at" validate:"required_with=Recurrence"`
+	Recurrence  string     `json:"recurrence" validate:"omitempty,max=512,rrule" doc:"RFC 5545 RRULE, for example FREQ=WEEKLY;BYDAY=MO. Requires due_at."`
+	Timezone    string     `json:"timezone" validate:"omitempty,timezone" doc:"IANA timezone the series is expanded in; defaults to DB_TIMEZONE."`
+}
+
+// MaterializeOccurrencesRequest asks for upcoming occurrences of a recurring
+// item to be created ahead of time.
+type MaterializeOccurrencesRequest struct {
+	Count int `json:"count" validate:"required,min=1,max=52"`
+}
+
+// MoveTodoItemRequest positions an item directly after or before another item
+// of the target list, or at its end when neither is given. TodoListID moves
+// the item, together with its subtasks, to another list; it defaults to the
+// item's current list.
+type MoveTodoItemRequest struct {
+	TodoListID *uuid.UUID `json:"todo_list_id" doc:"Target list; defaults to the item's current list."`
+	AfterID    *uuid.UUID `json:"after_id" validate:"excluded_with=BeforeID"`
+	BeforeID   *uuid.UUID `json:"before_id"`
+}
+
+// TodoItemResponse describes a todo item returned to clients. Progress is set
+// for items with subtasks; Subtasks only when a single item is fetched.
+type TodoItemResponse struct {
+	ID          uuid.UUID          `json:"id"`
+	TodoListID  uuid.UUID          `json:"todo_list_id"`
+	ParentID    *uuid.UUID         `json:"parent_id,omitempty" doc:"Set when the item is a subtask of another item in the same list."`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Status      string             `json:"status"`
+	Priority    string             `json:"priority"`
+	DueAt       *time.Time         `json:"due_at,omitempty"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" doc:"Set when the item's status becomes done."`
+	Recurrence  string             `json:"recurrence,omitempty"`
+	Timezone    string             `json:"timezone,omitempty"`
+	SeriesID    *uuid.UUID         `json:"series_id,omitempty" doc:"Shared by every occurrence of a recurring item."`
+	Rank        string             `json:"rank" doc:"Lexicographic sort key for manual ordering; empty until the item is ranked."`
+	Progress    *Progress          `json:"progress,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	Subtasks    []TodoItemResponse `json:"subtasks,omitempty" doc:"Returned only when a single item is fetched."`
+}
+
+// ToResponse converts the model into a response DTO.
+func (t TodoItem) ToResponse() TodoItemResponse {
+	return TodoItemResponse{
+		ID:          t.ID,
+		TodoListID:  t.TodoListID,
+		ParentID:    t.ParentID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		CompletedAt: t.CompletedAt,
+		Recurrence:  t.Recurrence,
+		Timezone:    t.Timezone,
+		SeriesID:    t.SeriesID,
+		Rank:        t.Rank,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
@@ -7,51 +7,154 @@ import (
 	"gorm.io/gorm"
 )
 
-// TodoList represents a collection of todo items owned by the user.
+// TodoList represents a collection of todo items owned by the user. Lists
+// marked IsTemplate form the workspace's template library instead, and
+// archived lists, with ArchivedAt set, are hidden from default listings.
 type TodoList struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Title       string    `gorm:"size:255;not null"`
-	Description string    `gorm:"type:text"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;not null;index;index:idx_todo_lists_workspace_status,priority:1;index:idx_todo_lists_pending_due,priority:1,where:status = 'open' OR status = 'in_progress';index:idx_todo_lists_workspace_rank,priority:1"`
+	OwnerID     string     `gorm:"size:255;not null;index"`
+	Title       string     `gorm:"size:255;not null"`
+	Description string     `gorm:"type:text"`
+	Status      string     `gorm:"size:20;not null;default:open;index:idx_todo_lists_workspace_status,priority:2"`
+	Priority    string     `gorm:"size:20;not null;default:medium"`
+	DueAt       *time.Time `gorm:"index:idx_todo_lists_pending_due,priority:2"`
+	IsTemplate  bool       `gorm:"not null;default:false"`
+	ArchivedAt  *time.Time
+	// Rank orders lists in the sidebar; see package rank. Lists created
+	// before manual ordering existed have an empty rank until rebalanced.
+	Rank      string `gorm:"type:varchar(255) COLLATE \"C\";not null;default:'';index:idx_todo_lists_workspace_rank,priority:2"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
-// BeforeCreate ensures the TodoList has a UUID before persisting.
+// BeforeCreate ensures the TodoList has a UUID and defaults before persisting.
 func (t *TodoList) BeforeCreate(_ *gorm.DB) error {
 	if t.ID == uuid.Nil {
 		t.ID = uuid.New()
 	}
+	if t.Status == "" {
+		t.Status = StatusOpen
+	}
+	if t.Priority == "" {
+		t.Priority = PriorityMedium
+	}
 	return nil
 }
 
 // CreateTodoListRequest defines the expected payload for creating a todo list.
 type CreateTodoListRequest struct {
-	Title       string `json:"title" validate:"required,min=3,max=255"`
-	Description string `json:"description" validate:"max=1024"`
+	Title       string     `json:"title" validate:"required,min=3,max=255"`
+	Description string     `json:"description" validate:"max=1024"`
+	Priority    string     `json:"priority" validate:"omitempty,todo_priority"`
+	DueAt       *time.Time `json:"due_at" validate:"omitempty,future" doc:"Must be in the future."`
 }
 
-// UpdateTodoListRequest defines the payload for updating a todo list.
+// UpdateTodoListRequest defines the payload for updating a todo list. Empty
+// status and priority keep their current values; a missing due_at clears it.
 type UpdateTodoListRequest struct {
-	Title       string `json:"title" validate:"required,min=3,max=255"`
-	Description string `json:"description" validate:"max=1024"`
+	Title       string     `json:"title" validate:"required,min=3,max=255"`
+	Description string     `json:"description" validate:"max=1024"`
+	Status      string     `json:"status" validate:"omitempty,todo_status"`
+	Priority    string     `json:"priority" validate:"omitempty,todo_priority"`
+	DueAt       *time.Time `json:"due_at"`
+}
+
+// MoveTodoListRequest positions a list directly after or before another list
+// of the workspace, or at the end when neither is given.
+type MoveTodoListRequest struct {
+	AfterID  *uuid.UUID `json:"after_id" validate:"excluded_with=BeforeID"`
+	BeforeID *uuid.UUID `json:"before_id"`
+}
+
+// DuplicateTodoListRequest copies a list. The copy is titled Title, or the
+// original's title followed by " (copy)" when empty.
+type DuplicateTodoListRequest struct {
+	Title string `json:"title" validate:"omitempty,min=3,max=255" doc:"Defaults to the original title followed by \" (copy)\"."`
+}
+
+// InstantiateTemplateRequest creates a list from a template. Variables hold
+// the values of the {{name}} placeholders in the template's titles and
+// descriptions; Title, when given, replaces the template's title.
+type InstantiateTemplateRequest struct {
+	Title     string            `json:"title" validate:"omitempty,min=3,max=255" doc:"Replaces the template's title; placeholders in it are substituted too."`
+	Variables map[string]string `json:"variables" validate:"max=50,dive,keys,min=1,max=64,endkeys,max=255"`
+}
+
+// TodoListFilter narrows GET /todolists results. Lists carrying any (or, with
+// Match "all", every one) of Tags are returned. Templates selects the
+// template library instead of the workspace's lists, and archived lists are
+// left out unless IncludeArchived is set.
+type TodoListFilter struct {
+	DueFilter
+	Tags            []string `query:"tags" validate:"max=20,dive,min=1,max=64" doc:"Comma-separated tag names. Lists carrying any of them are returned, or all of them with match=all."`
+	Match           string   `query:"match" validate:"omitempty,oneof=all any" doc:"Whether lists need any or all of the tags; defaults to any."`
+	Templates       bool     `validate:"-"`
+	IncludeArchived bool     `query:"include_archived" validate:"-" doc:"When true, archived lists are returned alongside active ones."`
 }
 
 // TodoListResponse describes the response returned to clients.
 type TodoListResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uuid.UUID     `json:"id"`
+	OwnerID     string        `json:"owner_id,omitempty" doc:"JWT subject of the user that created the list."`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Status      string        `json:"status"`
+	Priority    string        `json:"priority"`
+	DueAt       *time.Time    `json:"due_at,omitempty"`
+	Tags        []TagResponse `json:"tags,omitempty"`
+	Rank        string        `json:"rank" doc:"Lexicographic sort key for manual ordering; empty until the list is ranked."`
+	Progress    *Progress     `json:"progress,omitempty"`
+	IsTemplate  bool          `json:"is_template" doc:"Whether the list belongs to the workspace's template library."`
+	ArchivedAt  *time.Time    `json:"archived_at,omitempty" doc:"When the list was archived; absent for active lists."`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
 }
 
 // ToResponse converts the model into a response DTO.
 func (t TodoList) ToResponse() TodoListResponse {
 	return TodoListResponse{
 		ID:          t.ID,
+		OwnerID:     t.OwnerID,
 		Title:       t.Title,
 		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		Rank:        t.Rank,
+		IsTemplate:  t.IsTemplate,
+		ArchivedAt:  t.ArchivedAt,
 		CreatedAt:   t.CreatedAt,
 		UpdatedAt:   t.UpdatedAt,
 	}
 }
+
+// ToPublicResponse converts the model into a response DTO that is safe to
+// expose to unauthenticated clients through share links.
+func (t TodoList) ToPublicResponse() TodoListResponse {
+	return TodoListResponse{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Priority:    t.Priority,
+		DueAt:       t.DueAt,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// Kinds of TodoListEvent.
+const (
+	TodoListEventCreated = "created"
+	TodoListEventUpdated = "updated"
+	TodoListEventDeleted = "deleted"
+)
+
+// TodoListEvent announces a committed change to a todo list of a workspace.
+// Deleted events carry the list as it was before deletion.
+type TodoListEvent struct {
+	Type        string
+	WorkspaceID uuid.UUID
+	TodoList    TodoListResponse
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TodoListRevision is an immutable snapshot of a TodoList. Revisions are
+// numbered from 1 per list; restoring an old revision appends a new one.
+type TodoListRevision struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	WorkspaceID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	TodoListID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_todo_list_revisions_list_number,priority:1"`
+	Number       int       `gorm:"not null;uniqueIndex:idx_todo_list_revisions_list_number,priority:2"`
+	Title        string    `gorm:"size:255;not null"`
+	Description  string    `gorm:"type:text"`
+	AuthorID     string    `gorm:"size:255;not null"`
+	RestoredFrom *int
+	CreatedAt    time.Time
+}
+
+// BeforeCreate ensures the TodoListRevision has a UUID before persisting.
+func (r *TodoListRevision) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TodoListRevisionResponse describes a revision returned to clients.
+type TodoListRevisionResponse struct {
+	Number       int       `json:"number"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	AuthorID     string    `json:"author_id" doc:"JWT subject of the user that made the change."`
+	RestoredFrom *int      `json:"restored_from,omitempty" doc:"Revision number this revision was restored from."`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (r TodoListRevision) ToResponse() TodoListRevisionResponse {
+	return TodoListRevisionResponse{
+		Number:       r.Number,
+		Title:        r.Title,
+		Description:  r.Description,
+		AuthorID:     r.AuthorID,
+		RestoredFrom: r.RestoredFrom,
+		CreatedAt:    r.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken is an opaque, single-use credential for obtaining new access
+// tokens. Tokens issued from the same login share a FamilyID so that reuse of
+// a rotated token can revoke the whole chain.
+type RefreshToken struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	FamilyID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash    string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	RevokedAt    *time.Time
+	ReplacedByID *uuid.UUID `gorm:"type:uuid"`
+	MFA          bool       `gorm:"not null;default:false"`
+	CreatedAt    time.Time
+}
+
+// BeforeCreate ensures the RefreshToken has a UUID before persisting.
+func (t *RefreshToken) BeforeCreate(_ *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// RevokedAccessToken denylists an access token by its jti until it expires.
+type RevokedAccessToken struct {
+	JTI       string    `gorm:"primaryKey;size:64"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time
+}
+
+// RefreshRequest defines the payload for rotating a refresh token.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
+// LogoutRequest defines the payload for ending a session.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// TokenResponse describes the token pair returned after login or refresh.
+// When the account has MFA enabled, login instead returns MFARequired and a
+// short-lived MFAToken to exchange at the MFA verification endpoint.
+type TokenResponse struct {
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+	ExpiresIn    int    `json:"expires_in,omitempty" doc:"Access token lifetime in seconds."`
+	MFARequired  bool   `json:"mfa_required,omitempty" doc:"Set instead of the tokens when the account has MFA enabled."`
+	MFAToken     string `json:"mfa_token,omitempty" doc:"Five-minute challenge for /api/v1/auth/mfa/verify."`
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User is an account that can sign in and receive access tokens.
+type User struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Email        string     `gorm:"size:255;not null;uniqueIndex"`
+	Username     *string    `gorm:"size:32;uniqueIndex"`
+	PasswordHash string     `gorm:"size:255;not null"`
+	Role         string     `gorm:"size:32;not null;default:member"`
+	WorkspaceID  *uuid.UUID `gorm:"type:uuid;index"`
+
+	EmailVerifiedAt *time.Time
+	// AutoArchiveAfterDays, when set, archives the user's lists once every
+	// item has been done for that many days.
+	AutoArchiveAfterDays *int
+
+	MFAEnabled        bool   `gorm:"not null;default:false"`
+	MFASecret         string `gorm:"size:64"`
+	MFAPendingSecret  string `gorm:"size:64"`
+	MFALastStep       int64  `gorm:"not null;default:0"`
+	MFAFailedAttempts int    `gorm:"not null;default:0"`
+	MFALockedUntil    *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BeforeCreate ensures the User has a UUID before persisting.
+func (u *User) BeforeCreate(_ *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// RegisterRequest defines the payload for creating an account. Username is
+// optional and can be set later; it is the handle used in @mentions.
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Username string `json:"username" validate:"omitempty,username" doc:"Optional handle used in @mentions; unique across accounts."`
+	Password string `json:"password" validate:"required,min=8,max=72"`
+}
+
+// SetUsernameRequest defines the payload for choosing a username.
+type SetUsernameRequest struct {
+	Username string `json:"username" validate:"required,username" doc:"Handle used in @mentions; unique across accounts."`
+}
+
+// SetArchivePolicyRequest defines the payload for the auto-archive policy. A
+// null after_days turns it off.
+type SetArchivePolicyRequest struct {
+	AfterDays *int `json:"after_days" validate:"omitempty,min=1,max=3650" doc:"Days a finished list must stay unchanged before it is archived; null or absent disables auto-archiving."`
+}
+
+// LoginRequest defines the payload for exchanging credentials for tokens.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// UserResponse describes the account returned to clients.
+type UserResponse struct {
+	ID                   uuid.UUID  `json:"id"`
+	Email                string     `json:"email"`
+	Username             string     `json:"username,omitempty"`
+	Role                 string     `json:"role"`
+	WorkspaceID          *uuid.UUID `json:"workspace_id,omitempty"`
+	EmailVerified        bool       `json:"email_verified"`
+	MFAEnabled           bool       `json:"mfa_enabled"`
+	AutoArchiveAfterDays *int       `json:"auto_archive_after_days,omitempty" doc:"The caller's auto-archive policy; absent when it is off."`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (u User) ToResponse() UserResponse {
+	var username string
+	if u.Username != nil {
+		username = *u.Username
+	}
+	return UserResponse{
+		ID:                   u.ID,
+		Email:                u.Email,
+		Username:             username,
+		Role:                 u.Role,
+		WorkspaceID:          u.WorkspaceID,
+		EmailVerified:        u.EmailVerifiedAt != nil,
+		MFAEnabled:           u.MFAEnabled,
+		AutoArchiveAfterDays: u.AutoArchiveAfterDays,
+		CreatedAt:            u.CreatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Workspace isolates the todo lists of one team from every other team.
+type Workspace struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name       string    `gorm:"size:255;not null"`
+	RequireMFA bool      `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// BeforeCreate ensures the Workspace has a UUID before persisting.
+func (w *Workspace) BeforeCreate(_ *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// WorkspaceMember grants a subject, the user ID carried in access tokens and
+// API keys, access to a workspace.
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Subject     string    `gorm:"size:255;primaryKey;index"`
+	CreatedAt   time.Time
+}
+
+// CreateWorkspaceRequest defines the expected payload for creating a workspace.
+type CreateWorkspaceRequest struct {
+	Name       string `json:"name" validate:"required,min=3,max=255"`
+	RequireMFA bool   `json:"require_mfa"`
+}
+
+// UpdateWorkspaceRequest defines the expected payload for updating a workspace.
+type UpdateWorkspaceRequest struct {
+	Name       string `json:"name" validate:"required,min=3,max=255"`
+	RequireMFA bool   `json:"require_mfa"`
+}
+
+// WorkspaceResponse describes the workspace returned to clients.
+type WorkspaceResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	RequireMFA bool      `json:"require_mfa" doc:"When true, only sessions that passed MFA may access the workspace."`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToResponse converts the model into a response DTO.
+func (w Workspace) ToResponse() WorkspaceResponse {
+	return WorkspaceResponse{
+		ID:         w.ID,
+		Name:       w.Name,
+		RequireMFA: w.RequireMFA,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
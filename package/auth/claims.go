@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type contextKey string
+
+const contextKeyClaims contextKey = "claims"
+
+// Claims is the typed set of JWT claims issued to API clients.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles       []string `json:"roles,omitempty"`
+	Scopes      []string `json:"scopes,omitempty"`
+	WorkspaceID string   `json:"workspace_id,omitempty"`
+	// AMR lists the authentication methods used (RFC 8176), e.g. "pwd", "otp", "mfa".
+	AMR []string `json:"amr,omitempty"`
+}
+
+// HasRole reports whether the claims carry the given role.
+func (c *Claims) HasRole(role string) bool {
+	return slices.Contains(c.Roles, role)
+}
+
+// MFAAuthenticated reports whether the session passed multi-factor authentication.
+func (c *Claims) MFAAuthenticated() bool {
+	return slices.Contains(c.AMR, AMRMultiFactor)
+}
+
+// APIKeyAuthenticated reports whether the claims were resolved from a
+// personal API key.
+func (c *Claims) APIKeyAuthenticated() bool {
+	return slices.Contains(c.AMR, AMRAPIKey)
+}
+
+// IsAdmin reports whether the claims carry the admin role.
+func (c *Claims) IsAdmin() bool {
+	return c.HasRole(RoleAdmin)
+}
+
+// HasPermission reports whether the permission is granted either directly
+// through a scope or indirectly through one of the roles.
+func (c *Claims) HasPermission(permission string) bool {
+	if slices.Contains(c.Scopes, permission) {
+		return true
+	}
+	for _, role := range c.Roles {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithClaims returns a copy of ctx carrying the authenticated claims.
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKeyClaims, claims)
+}
+
+// ClaimsFromContext returns the authenticated claims stored in ctx, if any.
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKeyClaims).(*Claims)
+	return claims, ok && claims != nil
+}
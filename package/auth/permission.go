@@ -0,0 +1,46 @@
+package auth
+
+// Roles understood by the API.
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// Permissions checked by RequirePermission.
+const (
+	PermissionTodoListsRead   = "todolists:read"
+	PermissionTodoListsWrite  = "todolists:write"
+	PermissionWorkspacesRead  = "workspaces:read"
+	PermissionWorkspacesWrite = "workspaces:write"
+	PermissionAuditRead       = "audit:read"
+)
+
+var rolePermissions = map[string][]string{
+	RoleAdmin: {
+		PermissionTodoListsRead,
+		PermissionTodoListsWrite,
+		PermissionWorkspacesRead,
+		PermissionWorkspacesWrite,
+		PermissionAuditRead,
+	},
+	RoleMember: {
+		PermissionTodoListsRead,
+		PermissionTodoListsWrite,
+		PermissionWorkspacesRead,
+	},
+	RoleViewer: {
+		PermissionTodoListsRead,
+		PermissionWorkspacesRead,
+	},
+}
+
+// Authentication method references carried in the amr claim.
+const (
+	AMRPassword    = "pwd"
+	AMROTP         = "otp"
+	AMRMultiFactor = "mfa"
+	// AMRAPIKey marks claims resolved from a personal API key rather than a
+	// token.
+	AMRAPIKey = "apikey"
+)
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/config"
+)
+
+// mfaChallengeTTL bounds how long a password-verified login may wait for its
+// second factor.
+const mfaChallengeTTL = 5 * time.Minute
+
+// ErrInvalidMFAChallenge indicates that an MFA challenge token is malformed or expired.
+var ErrInvalidMFAChallenge = errors.New("invalid mfa challenge")
+
+// Signer issues HS256 access tokens for locally authenticated users.
+type Signer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+// NewSigner constructs a Signer from the JWT configuration.
+func NewSigner(cfg config.JWTConfig) *Signer {
+	return &Signer{
+		secret: []byte(cfg.Secret),
+		issuer: cfg.Issuer,
+		ttl:    time.Duration(cfg.TTL) * time.Second,
+	}
+}
+
+// Issuer returns the issuer placed in signed tokens.
+func (s *Signer) Issuer() string {
+	return s.issuer
+}
+
+// TTL returns the lifetime of issued access tokens.
+func (s *Signer) TTL() time.Duration {
+	return s.ttl
+}
+
+// Sign fills in the registered claims (issuer, audience, jti and lifetime)
+// and returns the signed token.
+func (s *Signer) Sign(claims Claims, now time.Time) (string, error) {
+	claims.Issuer = s.issuer
+	claims.Audience = jwt.ClaimStrings{s.issuer}
+	claims.ID = uuid.NewString()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(s.ttl))
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("sign access token: %w", err)
+	}
+	return signed, nil
+}
+
+// SignMFAChallenge returns a short-lived token proving that subject passed the
+// password step. Its audience differs from access tokens, so the Verifier
+// never accepts it as one.
+func (s *Signer) SignMFAChallenge(subject string, now time.Time) (string, error) {
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    s.issuer,
+		Audience:  jwt.ClaimStrings{s.mfaAudience()},
+		ID:        uuid.NewString(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(mfaChallengeTTL)),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secret)
+	if err != nil {
+		return "", fmt.Errorf("sign mfa challenge: %w", err)
+	}
+	return signed, nil
+}
+
+// VerifyMFAChallenge validates a token from SignMFAChallenge at the given
+// time and returns its subject.
+func (s *Signer) VerifyMFAChallenge(tokenString string, now time.Time) (string, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(*jwt.Token) (interface{}, error) {
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(s.issuer),
+		jwt.WithAudience(s.mfaAudience()),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidMFAChallenge, err)
+	}
+	return claims.Subject, nil
+}
+
+func (s *Signer) mfaAudience() string {
+	return s.issuer + ":mfa"
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lumoshiveacademy/todolist/package/config"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrUntrustedIssuer indicates that the token issuer is not configured as trusted.
+	ErrUntrustedIssuer = errors.New("untrusted token issuer")
+	// ErrUntrustedAudience indicates that the token is not intended for this API.
+	ErrUntrustedAudience = errors.New("untrusted token audience")
+)
+
+// Verifier validates access tokens signed with a shared HMAC secret, static
+// PEM public keys, or keys published through a JWKS endpoint.
+type Verifier struct {
+	secret     []byte
+	publicKeys []crypto.PublicKey
+	jwks       *JWKS
+	algorithms []string
+	issuers    []string
+	audiences  []string
+	leeway     time.Duration
+}
+
+// NewVerifier builds a Verifier from the JWT configuration, loading any PEM
+// public keys from disk.
+func NewVerifier(cfg config.JWTConfig, logger *zap.Logger) (*Verifier, error) {
+	v := &Verifier{
+		secret:     []byte(cfg.Secret),
+		algorithms: cfg.Algorithms,
+		issuers:    cfg.Issuers,
+		audiences:  cfg.Audiences,
+		leeway:     time.Duration(cfg.Leeway) * time.Second,
+	}
+	for _, path := range cfg.PublicKeyFiles {
+		key, err := LoadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		v.publicKeys = append(v.publicKeys, key)
+	}
+	if cfg.JWKSURL != "" {
+		v.jwks = NewJWKS(cfg.JWKSURL, time.Duration(cfg.JWKSRefreshInterval)*time.Second, nil, logger)
+	}
+	return v, nil
+}
+
+// Start keeps the JWKS cache fresh until ctx is cancelled. It returns
+// immediately when no JWKS URL is configured.
+func (v *Verifier) Start(ctx context.Context) {
+	if v.jwks != nil {
+		v.jwks.Start(ctx)
+	}
+}
+
+// Refresh forces the JWKS cache to be reloaded.
+func (v *Verifier) Refresh(ctx context.Context) error {
+	if v.jwks == nil {
+		return nil
+	}
+	return v.jwks.Refresh(ctx)
+}
+
+// Verify parses and validates tokenString and returns its claims.
+func (v *Verifier) Verify(ctx context.Context, tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return v.key(ctx, token)
+	}, jwt.WithValidMethods(v.algorithms), jwt.WithLeeway(v.leeway), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	if !slices.Contains(v.issuers, claims.Issuer) {
+		return nil, ErrUntrustedIssuer
+	}
+	if !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(v.audiences, aud)
+	}) {
+		return nil, ErrUntrustedAudience
+	}
+	return claims, nil
+}
+
+func (v *Verifier) key(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(v.secret) == 0 {
+			return nil, ErrKeyNotFound
+		}
+		return v.secret, nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+	default:
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+
+	if kid, _ := token.Header["kid"].(string); kid != "" && v.jwks != nil {
+		key, err := v.jwks.Key(ctx, kid)
+		if err == nil {
+			return key, nil
+		}
+		if !errors.Is(err, ErrKeyNotFound) {
+			return nil, err
+		}
+	}
+
+	_, wantECDSA := token.Method.(*jwt.SigningMethodECDSA)
+	var keys jwt.VerificationKeySet
+	for _, key := range v.publicKeys {
+		if _, isECDSA := key.(*ecdsa.PublicKey); isECDSA == wantECDSA {
+			keys.Keys = append(keys.Keys, key)
+		}
+	}
+	if len(keys.Keys) == 0 {
+		return nil, ErrKeyNotFound
+	}
+	return keys, nil
+}
+
+// LoadPublicKey reads an RSA or ECDSA public key from a PEM file.
+func LoadPublicKey(path string) (crypto.PublicKey, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read public key %s: %w", path, err)
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(raw); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(raw); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("parse public key %s: unsupported key format", path)
+}
@@ -0,0 +1,276 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/config"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func signClaims(t *testing.T, method jwt.SigningMethod, key interface{}, kid, issuer, audience string, expiresAt time.Time) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		Roles: []string{auth.RoleMember},
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+// jwksServer serves a mutable JSON Web Key Set.
+type jwksServer struct {
+	mu   sync.Mutex
+	keys []map[string]string
+	hits int
+}
+
+func (s *jwksServer) set(keys ...map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits++
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": s.keys})
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "EC",
+		"kid": kid,
+		"use": "sig",
+		"crv": "P-256",
+		"x":   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		"y":   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA",
+		"kid": kid,
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func TestVerifier_HS256(t *testing.T) {
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Secret:     "secret",
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{"todolist"},
+		Audiences:  []string{"todolist"},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodHS256, []byte("secret"), "", "todolist", "todolist", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	require.Equal(t, "user-1", claims.Subject)
+	require.True(t, claims.HasRole(auth.RoleMember))
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodHS256, []byte("other"), "", "todolist", "todolist", time.Now().Add(time.Hour)))
+	require.Error(t, err)
+}
+
+func TestVerifier_RS256FromPEMFile(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "public.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Algorithms:     []string{"RS256"},
+		Issuers:        []string{"https://idp.example.com"},
+		Audiences:      []string{"todolist"},
+		PublicKeyFiles: []string{path},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodRS256, privateKey, "", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	// HS256 is not in the allowed algorithms, even with a matching secret.
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodHS256, []byte(""), "", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.Error(t, err)
+}
+
+func TestVerifier_ES256FromJWKSSelectsKid(t *testing.T) {
+	first, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	second, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	keys := &jwksServer{}
+	keys.set(ecJWK("first", &first.PublicKey), ecJWK("second", &second.PublicKey))
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Algorithms: []string{"ES256"},
+		Issuers:    []string{"https://idp.example.com"},
+		Audiences:  []string{"todolist"},
+		JWKSURL:    server.URL,
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodES256, second, "second", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	// Signed by the second key but claiming to be the first one.
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodES256, second, "first", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.Error(t, err)
+
+	// Keys are cached between verifications.
+	require.Equal(t, 1, keys.hits)
+}
+
+func TestVerifier_JWKSRefreshPicksUpRotatedKey(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	keys := &jwksServer{}
+	keys.set(rsaJWK("old", &oldKey.PublicKey))
+	server := httptest.NewServer(keys)
+	defer server.Close()
+
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Algorithms: []string{"RS256"},
+		Issuers:    []string{"https://idp.example.com"},
+		Audiences:  []string{"todolist"},
+		JWKSURL:    server.URL,
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodRS256, oldKey, "old", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	keys.set(rsaJWK("new", &newKey.PublicKey))
+	require.NoError(t, verifier.Refresh(context.Background()))
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodRS256, newKey, "new", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(),
+		signClaims(t, jwt.SigningMethodRS256, oldKey, "old", "https://idp.example.com", "todolist", time.Now().Add(time.Hour)))
+	require.ErrorIs(t, err, auth.ErrKeyNotFound)
+}
+
+func TestVerifier_IssuersAndAudiences(t *testing.T) {
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Secret:     "secret",
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{"todolist", "https://idp.example.com"},
+		Audiences:  []string{"todolist", "todolist-admin"},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		issuer   string
+		audience string
+		wantErr  error
+	}{
+		{"primary issuer", "todolist", "todolist", nil},
+		{"secondary issuer and audience", "https://idp.example.com", "todolist-admin", nil},
+		{"untrusted issuer", "https://evil.example.com", "todolist", auth.ErrUntrustedIssuer},
+		{"untrusted audience", "todolist", "billing", auth.ErrUntrustedAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := verifier.Verify(context.Background(),
+				signClaims(t, jwt.SigningMethodHS256, []byte("secret"), "", tt.issuer, tt.audience, time.Now().Add(time.Hour)))
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestVerifier_Leeway(t *testing.T) {
+	cfg := config.JWTConfig{
+		Secret:     "secret",
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{"todolist"},
+		Audiences:  []string{"todolist"},
+	}
+	expired := signClaims(t, jwt.SigningMethodHS256, []byte("secret"), "", "todolist", "todolist", time.Now().Add(-10*time.Second))
+
+	strict, err := auth.NewVerifier(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	_, err = strict.Verify(context.Background(), expired)
+	require.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	cfg.Leeway = 30
+	lenient, err := auth.NewVerifier(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	_, err = lenient.Verify(context.Background(), expired)
+	require.NoError(t, err)
+}
+
+func TestVerifier_RequiresExpiry(t *testing.T) {
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Secret:     "secret",
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{"todolist"},
+		Audiences:  []string{"todolist"},
+	}, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  "user-1",
+			Issuer:   "todolist",
+			Audience: jwt.ClaimStrings{"todolist"},
+		},
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+
+	_, err = verifier.Verify(context.Background(), token)
+	require.ErrorIs(t, err, jwt.ErrTokenRequiredClaimMissing)
+}
@@ -0,0 +1,20 @@
+// Package blob stores the contents of file attachments in pluggable backends
+// such as the local filesystem and S3-compatible object stores.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound indicates that no blob is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store saves and retrieves blobs by key. Keys are slash-separated relative
+// paths chosen by the caller. Deleting a missing blob is not an error.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
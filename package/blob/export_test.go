@@ -0,0 +1,4 @@
+package blob
+
+// SignS3 exposes request signing to the external tests.
+var SignS3 = (*S3).sign
@@ -0,0 +1,41 @@
+package blob_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocal_PutGetDelete(t *testing.T) {
+	store := &blob.Local{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	require.NoError(t, store.Put(ctx, "ws/file", strings.NewReader("hello"), 5, "text/plain"))
+
+	r, err := store.Get(ctx, "ws/file")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "hello", string(data))
+
+	require.NoError(t, store.Delete(ctx, "ws/file"))
+	require.NoError(t, store.Delete(ctx, "ws/file"), "deleting twice is not an error")
+	_, err = store.Get(ctx, "ws/file")
+	require.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestLocal_PutRejectsShortBodyAndEscapingKeys(t *testing.T) {
+	store := &blob.Local{Dir: t.TempDir()}
+	ctx := context.Background()
+
+	require.Error(t, store.Put(ctx, "short", strings.NewReader("abc"), 5, ""))
+	_, err := store.Get(ctx, "short")
+	require.ErrorIs(t, err, blob.ErrNotFound, "a failed upload leaves nothing behind")
+
+	require.Error(t, store.Put(ctx, "../outside", strings.NewReader("x"), 1, ""))
+}
@@ -0,0 +1,17 @@
+package clock
+
+import "time"
+
+// Clock reports the current time. Services take a Clock instead of calling
+// time.Now so tests can control time-based behaviour.
+type Clock interface {
+	Now() time.Time
+}
+
+// Real is a Clock backed by the system time.
+type Real struct{}
+
+// Now returns the current system time.
+func (Real) Now() time.Time {
+	return time.Now()
+}
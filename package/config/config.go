@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/joho/godotenv"
@@ -11,15 +12,24 @@ import (
 
 // Config aggregates application configuration loaded from environment variables.
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	App        AppConfig
+	Database   DatabaseConfig
+	JWT        JWTConfig
+	Mail       MailConfig
+	Reminder   ReminderConfig
+	Rank       RankConfig
+	Attachment AttachmentConfig
+	Archive    ArchiveConfig
+	GraphQL    GraphQLConfig
+	GRPC       GRPCConfig
 }
 
 type AppConfig struct {
-	Name  string
-	Port  int
-	Debug bool
+	Name        string
+	Port        int
+	Debug       bool
+	BaseURL     string
+	MaxBodySize int
 }
 
 type DatabaseConfig struct {
@@ -39,9 +49,91 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret string
-	Issuer string
-	TTL    int
+	Secret              string
+	Issuer              string
+	TTL                 int
+	RefreshTTL          int
+	Algorithms          []string
+	Issuers             []string
+	Audiences           []string
+	Leeway              int
+	PublicKeyFiles      []string
+	JWKSURL             string
+	JWKSRefreshInterval int
+}
+
+// MailConfig selects and configures the outgoing mail transport. Driver is
+// "smtp" or "file"; the file driver writes messages to FileDir (or only logs
+// them when FileDir is empty) and is intended for local development.
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	FileDir      string
+}
+
+// ReminderConfig tunes the background reminder scheduler. PollInterval and
+// WebhookTimeout are in seconds. WebhookSecret, when set, signs webhook
+// bodies with HMAC-SHA256.
+type ReminderConfig struct {
+	PollInterval   int
+	BatchSize      int
+	MaxAttempts    int
+	WebhookSecret  string
+	WebhookTimeout int
+}
+
+// RankConfig tunes the background rank rebalancer. RebalanceInterval is in
+// seconds; ranks longer than MaxLength characters are rewritten.
+type RankConfig struct {
+	RebalanceInterval int
+	BatchSize         int
+	MaxLength         int
+}
+
+// AttachmentConfig selects where attachment contents are stored and limits
+// uploads. Driver is "local" or "s3"; the S3 settings apply to any
+// S3-compatible store. MaxSize is in bytes and URLTTL in seconds. URLSecret
+// signs download URLs, which stay valid for URLTTL.
+type AttachmentConfig struct {
+	Driver            string
+	LocalDir          string
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3PathStyle       bool
+	MaxSize           int
+	AllowedTypes      []string
+	URLSecret         string
+	URLTTL            int
+}
+
+// ArchiveConfig tunes the background job that auto-archives finished lists
+// for users who opted in. Interval is in seconds.
+type ArchiveConfig struct {
+	Interval  int
+	BatchSize int
+}
+
+// GraphQLConfig limits the operations accepted by the /graphql endpoint.
+// MaxDepth bounds how deeply selections nest and MaxComplexity the number of
+// fields an operation selects in total, with fields under a list counted once
+// per entry.
+type GraphQLConfig struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// GRPCConfig configures the gRPC server. Reflection lets tools such as
+// grpcurl discover the API without the .proto files.
+type GRPCConfig struct {
+	Port       int
+	Reflection bool
 }
 
 var (
@@ -69,10 +161,52 @@ func Load() (Config, error) {
 			err = fmt.Errorf("load jwt config: %w", e)
 			return
 		}
+		mailConfig, e := loadMailConfig()
+		if e != nil {
+			err = fmt.Errorf("load mail config: %w", e)
+			return
+		}
+		reminderConfig, e := loadReminderConfig()
+		if e != nil {
+			err = fmt.Errorf("load reminder config: %w", e)
+			return
+		}
+		rankConfig, e := loadRankConfig()
+		if e != nil {
+			err = fmt.Errorf("load rank config: %w", e)
+			return
+		}
+		attachmentConfig, e := loadAttachmentConfig(jwtConfig.Secret)
+		if e != nil {
+			err = fmt.Errorf("load attachment config: %w", e)
+			return
+		}
+		archiveConfig, e := loadArchiveConfig()
+		if e != nil {
+			err = fmt.Errorf("load archive config: %w", e)
+			return
+		}
+		graphQLConfig, e := loadGraphQLConfig()
+		if e != nil {
+			err = fmt.Errorf("load graphql config: %w", e)
+			return
+		}
+		grpcConfig, e := loadGRPCConfig()
+		if e != nil {
+			err = fmt.Errorf("load grpc config: %w", e)
+			return
+		}
 		config = Config{
-			App:      appConfig,
-			Database: dbConfig,
-			JWT:      jwtConfig,
+			App:        appConfig,
+			Database:   dbConfig,
+			JWT:        jwtConfig,
+			Mail:       mailConfig,
+			Reminder:   reminderConfig,
+			Rank:       rankConfig,
+			Attachment: attachmentConfig,
+			Archive:    archiveConfig,
+			GraphQL:    graphQLConfig,
+			GRPC:       grpcConfig,
 		}
 	})
 	if err != nil {
@@ -90,10 +224,19 @@ func loadAppConfig() (AppConfig, error) {
 	if err != nil {
 		return AppConfig{}, err
 	}
+	maxBodySize, err := intFromEnv("MAX_BODY_SIZE", 1<<20)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	if maxBodySize <= 0 {
+		return AppConfig{}, fmt.Errorf("MAX_BODY_SIZE must be positive")
+	}
 	return AppConfig{
-		Name:  stringFromEnv("APP_NAME", "todolist"),
-		Port:  port,
-		Debug: debug,
+		Name:        stringFromEnv("APP_NAME", "todolist"),
+		Port:        port,
+		Debug:       debug,
+		BaseURL:     strings.TrimRight(stringFromEnv("APP_BASE_URL", fmt.Sprintf("http://localhost:%d", port)), "/"),
+		MaxBodySize: maxBodySize,
 	}, nil
 }
 
@@ -144,13 +287,216 @@ func loadJWTConfig() (JWTConfig, error) {
 	if err != nil {
 		return JWTConfig{}, err
 	}
+	leeway, err := intFromEnv("JWT_LEEWAY", 0)
+	if err != nil {
+		return JWTConfig{}, err
+	}
+	refreshTTL, err := intFromEnv("JWT_REFRESH_TTL", 2592000)
+	if err != nil {
+		return JWTConfig{}, err
+	}
+	jwksRefresh, err := intFromEnv("JWT_JWKS_REFRESH_INTERVAL", 3600)
+	if err != nil {
+		return JWTConfig{}, err
+	}
+	issuer := stringFromEnv("JWT_ISSUER", "todolist")
 	return JWTConfig{
-		Secret: stringFromEnv("JWT_SECRET", "please-change-me"),
-		Issuer: stringFromEnv("JWT_ISSUER", "todolist"),
-		TTL:    ttl,
+		Secret:              stringFromEnv("JWT_SECRET", "please-change-me"),
+		Issuer:              issuer,
+		TTL:                 ttl,
+		RefreshTTL:          refreshTTL,
+		Algorithms:          stringsFromEnv("JWT_ALGORITHMS", []string{"HS256"}),
+		Issuers:             stringsFromEnv("JWT_ISSUERS", []string{issuer}),
+		Audiences:           stringsFromEnv("JWT_AUDIENCES", []string{issuer}),
+		Leeway:              leeway,
+		PublicKeyFiles:      stringsFromEnv("JWT_PUBLIC_KEY_FILES", nil),
+		JWKSURL:             stringFromEnv("JWT_JWKS_URL", ""),
+		JWKSRefreshInterval: jwksRefresh,
 	}, nil
 }
 
+func loadMailConfig() (MailConfig, error) {
+	port, err := intFromEnv("SMTP_PORT", 587)
+	if err != nil {
+		return MailConfig{}, err
+	}
+	driver := stringFromEnv("MAIL_DRIVER", "file")
+	if driver != "smtp" && driver != "file" {
+		return MailConfig{}, fmt.Errorf("unsupported MAIL_DRIVER %q", driver)
+	}
+	return MailConfig{
+		Driver:       driver,
+		From:         stringFromEnv("MAIL_FROM", "todolist <no-reply@localhost>"),
+		SMTPHost:     stringFromEnv("SMTP_HOST", "localhost"),
+		SMTPPort:     port,
+		SMTPUsername: stringFromEnv("SMTP_USERNAME", ""),
+		SMTPPassword: stringFromEnv("SMTP_PASSWORD", ""),
+		FileDir:      stringFromEnv("MAIL_FILE_DIR", ""),
+	}, nil
+}
+
+func loadReminderConfig() (ReminderConfig, error) {
+	pollInterval, err := intFromEnv("REMINDER_POLL_INTERVAL", 30)
+	if err != nil {
+		return ReminderConfig{}, err
+	}
+	batchSize, err := intFromEnv("REMINDER_BATCH_SIZE", 50)
+	if err != nil {
+		return ReminderConfig{}, err
+	}
+	maxAttempts, err := intFromEnv("REMINDER_MAX_ATTEMPTS", 5)
+	if err != nil {
+		return ReminderConfig{}, err
+	}
+	webhookTimeout, err := intFromEnv("REMINDER_WEBHOOK_TIMEOUT", 10)
+	if err != nil {
+		return ReminderConfig{}, err
+	}
+	if pollInterval <= 0 || batchSize <= 0 || maxAttempts <= 0 || webhookTimeout <= 0 {
+		return ReminderConfig{}, fmt.Errorf("reminder settings must be positive")
+	}
+	return ReminderConfig{
+		PollInterval:   pollInterval,
+		BatchSize:      batchSize,
+		MaxAttempts:    maxAttempts,
+		WebhookSecret:  stringFromEnv("REMINDER_WEBHOOK_SECRET", ""),
+		WebhookTimeout: webhookTimeout,
+	}, nil
+}
+
+func loadRankConfig() (RankConfig, error) {
+	rebalanceInterval, err := intFromEnv("RANK_REBALANCE_INTERVAL", 300)
+	if err != nil {
+		return RankConfig{}, err
+	}
+	batchSize, err := intFromEnv("RANK_BATCH_SIZE", 100)
+	if err != nil {
+		return RankConfig{}, err
+	}
+	maxLength, err := intFromEnv("RANK_MAX_LENGTH", 32)
+	if err != nil {
+		return RankConfig{}, err
+	}
+	if rebalanceInterval <= 0 || batchSize <= 0 || maxLength <= 0 {
+		return RankConfig{}, fmt.Errorf("rank settings must be positive")
+	}
+	return RankConfig{
+		RebalanceInterval: rebalanceInterval,
+		BatchSize:         batchSize,
+		MaxLength:         maxLength,
+	}, nil
+}
+
+func loadArchiveConfig() (ArchiveConfig, error) {
+	interval, err := intFromEnv("ARCHIVE_INTERVAL", 3600)
+	if err != nil {
+		return ArchiveConfig{}, err
+	}
+	batchSize, err := intFromEnv("ARCHIVE_BATCH_SIZE", 100)
+	if err != nil {
+		return ArchiveConfig{}, err
+	}
+	if interval <= 0 || batchSize <= 0 {
+		return ArchiveConfig{}, fmt.Errorf("archive settings must be positive")
+	}
+	return ArchiveConfig{
+		Interval:  interval,
+		BatchSize: batchSize,
+	}, nil
+}
+
+func loadGraphQLConfig() (GraphQLConfig, error) {
+	maxDepth, err := intFromEnv("GRAPHQL_MAX_DEPTH", 8)
+	if err != nil {
+		return GraphQLConfig{}, err
+	}
+	maxComplexity, err := intFromEnv("GRAPHQL_MAX_COMPLEXITY", 1000)
+	if err != nil {
+		return GraphQLConfig{}, err
+	}
+	if maxDepth <= 0 || maxComplexity <= 0 {
+		return GraphQLConfig{}, fmt.Errorf("graphql settings must be positive")
+	}
+	return GraphQLConfig{
+		MaxDepth:      maxDepth,
+		MaxComplexity: maxComplexity,
+	}, nil
+}
+
+func loadGRPCConfig() (GRPCConfig, error) {
+	port, err := intFromEnv("GRPC_PORT", 9090)
+	if err != nil {
+		return GRPCConfig{}, err
+	}
+	reflection, err := boolFromEnv("GRPC_REFLECTION", true)
+	if err != nil {
+		return GRPCConfig{}, err
+	}
+	return GRPCConfig{
+		Port:       port,
+		Reflection: reflection,
+	}, nil
+}
+
+// placeholderSecrets are the sample values shipped in the docs, which must
+// never sign anything.
+var placeholderSecrets = map[string]bool{
+	"please-change-me":           true,
+	"replace-with-secure-secret": true,
+}
+
+func loadAttachmentConfig(jwtSecret string) (AttachmentConfig, error) {
+	maxSize, err := intFromEnv("ATTACHMENT_MAX_SIZE", 10<<20)
+	if err != nil {
+		return AttachmentConfig{}, err
+	}
+	urlTTL, err := intFromEnv("ATTACHMENT_URL_TTL", 900)
+	if err != nil {
+		return AttachmentConfig{}, err
+	}
+	pathStyle, err := boolFromEnv("ATTACHMENT_S3_PATH_STYLE", false)
+	if err != nil {
+		return AttachmentConfig{}, err
+	}
+	if maxSize <= 0 || urlTTL <= 0 {
+		return AttachmentConfig{}, fmt.Errorf("attachment settings must be positive")
+	}
+	cfg := AttachmentConfig{
+		Driver:            stringFromEnv("ATTACHMENT_DRIVER", "local"),
+		LocalDir:          stringFromEnv("ATTACHMENT_LOCAL_DIR", "data/attachments"),
+		S3Endpoint:        stringFromEnv("ATTACHMENT_S3_ENDPOINT", ""),
+		S3Region:          stringFromEnv("ATTACHMENT_S3_REGION", "us-east-1"),
+		S3Bucket:          stringFromEnv("ATTACHMENT_S3_BUCKET", ""),
+		S3AccessKeyID:     stringFromEnv("ATTACHMENT_S3_ACCESS_KEY_ID", ""),
+		S3SecretAccessKey: stringFromEnv("ATTACHMENT_S3_SECRET_ACCESS_KEY", ""),
+		S3PathStyle:       pathStyle,
+		MaxSize:           maxSize,
+		AllowedTypes: stringsFromEnv("ATTACHMENT_ALLOWED_TYPES", []string{
+			"image/png", "image/jpeg", "image/gif", "image/webp", "application/pdf", "text/plain",
+		}),
+		URLSecret: stringFromEnv("ATTACHMENT_URL_SECRET", ""),
+		URLTTL:    urlTTL,
+	}
+	switch {
+	case cfg.URLSecret == "":
+		return AttachmentConfig{}, fmt.Errorf("ATTACHMENT_URL_SECRET is required")
+	case placeholderSecrets[cfg.URLSecret]:
+		return AttachmentConfig{}, fmt.Errorf("ATTACHMENT_URL_SECRET must not be a sample value")
+	case cfg.URLSecret == jwtSecret:
+		return AttachmentConfig{}, fmt.Errorf("ATTACHMENT_URL_SECRET must differ from JWT_SECRET")
+	}
+	switch cfg.Driver {
+	case "local":
+	case "s3":
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" {
+			return AttachmentConfig{}, fmt.Errorf("ATTACHMENT_S3_ENDPOINT and ATTACHMENT_S3_BUCKET are required by the s3 driver")
+		}
+	default:
+		return AttachmentConfig{}, fmt.Errorf("unsupported ATTACHMENT_DRIVER %q", cfg.Driver)
+	}
+	return cfg, nil
+}
+
 func stringFromEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -158,6 +504,22 @@ func stringFromEnv(key, fallback string) string {
 	return fallback
 }
 
+// stringsFromEnv reads a comma-separated list, ignoring blank entries.
+// Unset or blank variables yield the fallback.
+func stringsFromEnv(key string, fallback []string) []string {
+	value, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
 func intFromEnv(key string, fallback int) (int, error) {
 	if value, ok := os.LookupEnv(key); ok {
 		parsed, err := strconv.Atoi(value)
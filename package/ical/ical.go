@@ -0,0 +1,250 @@
+// Package ical writes iCalendar data as specified by RFC 5545: content
+// lines end in CRLF and are folded at 75 octets, TEXT values are escaped,
+// and zoned date-times are backed by VTIMEZONE components.
+package ical
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxLineOctets is the longest content line RFC 5545 allows before folding,
+// excluding the line break.
+const maxLineOctets = 75
+
+const (
+	dateTimeFormat    = "20060102T150405"
+	utcDateTimeFormat = "20060102T150405Z"
+)
+
+// Writer accumulates an iCalendar object one content line at a time.
+type Writer struct {
+	buf bytes.Buffer
+}
+
+// Bytes returns the content written so far.
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// Begin opens a component such as VCALENDAR or VTODO.
+func (w *Writer) Begin(component string) {
+	w.line("BEGIN:" + component)
+}
+
+// End closes a component opened with Begin.
+func (w *Writer) End(component string) {
+	w.line("END:" + component)
+}
+
+// Property writes a property whose value is already in its iCalendar form.
+// Params are "NAME=value" pairs; build them with Param.
+func (w *Writer) Property(name, value string, params ...string) {
+	var line strings.Builder
+	line.WriteString(name)
+	for _, param := range params {
+		line.WriteByte(';')
+		line.WriteString(param)
+	}
+	line.WriteByte(':')
+	line.WriteString(value)
+	w.line(line.String())
+}
+
+// Text writes a TEXT property, escaping its value.
+func (w *Writer) Text(name, value string, params ...string) {
+	w.Property(name, Escape(value), params...)
+}
+
+// UTC writes a DATE-TIME property in UTC form, such as DTSTAMP.
+func (w *Writer) UTC(name string, t time.Time, params ...string) {
+	w.Property(name, t.UTC().Format(utcDateTimeFormat), params...)
+}
+
+// Local writes a DATE-TIME property as wall-clock time in t's location with
+// a TZID parameter, or in UTC form when t is in UTC. Every zone used must
+// also be written with Timezone.
+func (w *Writer) Local(name string, t time.Time) {
+	if t.Location() == time.UTC {
+		w.UTC(name, t)
+		return
+	}
+	w.Property(name, t.Format(dateTimeFormat), Param("TZID", t.Location().String()))
+}
+
+// Timezone writes a VTIMEZONE component for loc that covers [from, to]: the
+// offset in effect at from and every transition up to to.
+func (w *Writer) Timezone(loc *time.Location, from, to time.Time) {
+	w.Begin("VTIMEZONE")
+	w.Property("TZID", loc.String())
+	start := from.In(loc)
+	_, offset := start.Zone()
+	w.observance(start, offset)
+	for t := start; ; {
+		next, ok := nextTransition(t, to)
+		if !ok {
+			break
+		}
+		w.observance(next, offset)
+		_, offset = next.Zone()
+		t = next
+	}
+	w.End("VTIMEZONE")
+}
+
+// observance writes the STANDARD or DAYLIGHT component that starts at t,
+// when the offset changes from the given one. Its DTSTART is t's wall-clock
+// time under the previous offset.
+func (w *Writer) observance(t time.Time, from int) {
+	component := "STANDARD"
+	if t.IsDST() {
+		component = "DAYLIGHT"
+	}
+	name, to := t.Zone()
+	w.Begin(component)
+	w.Property("DTSTART", t.UTC().Add(time.Duration(from)*time.Second).Format(dateTimeFormat))
+	w.Property("TZOFFSETFROM", formatOffset(from))
+	w.Property("TZOFFSETTO", formatOffset(to))
+	if name != "" && !strings.HasPrefix(name, "+") && !strings.HasPrefix(name, "-") {
+		w.Text("TZNAME", name)
+	}
+	w.End(component)
+}
+
+// nextTransition returns the first instant after t, and no later than to,
+// at which t's location changes its UTC offset or abbreviation.
+func nextTransition(t, to time.Time) (time.Time, bool) {
+	name, offset := t.Zone()
+	to = to.In(t.Location())
+	changed := func(u time.Time) bool {
+		n, o := u.Zone()
+		return n != name || o != offset
+	}
+	// Zones change at most a few times a year, so stepping a week at a time
+	// finds the change before narrowing it down to the second.
+	const step = 7 * 24 * time.Hour
+	lo := t
+	for {
+		hi := lo.Add(step)
+		if hi.After(to) {
+			hi = to
+		}
+		if !hi.After(lo) {
+			return time.Time{}, false
+		}
+		if changed(hi) {
+			for hi.Sub(lo) > time.Second {
+				mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+				if changed(mid) {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			return hi, true
+		}
+		lo = hi
+	}
+}
+
+// Param formats a property parameter, quoting values that contain
+// characters RFC 5545 only allows inside quotes.
+func Param(name, value string) string {
+	value = strings.Map(func(r rune) rune {
+		if r == '"' || (r < ' ' && r != '\t') || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	if strings.ContainsAny(value, ";:,") {
+		value = `"` + value + `"`
+	}
+	return name + "=" + value
+}
+
+// Escape escapes a TEXT value: backslashes, semicolons and commas are
+// prefixed with a backslash and line breaks become \n. Other control
+// characters are not allowed in TEXT and are dropped.
+func Escape(value string) string {
+	var b strings.Builder
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	for _, r := range value {
+		switch {
+		case r == '\\' || r == ';' || r == ',':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r':
+			b.WriteString(`\n`)
+		case (r < ' ' && r != '\t') || r == 0x7f:
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Duration formats d as a DURATION value such as -PT15M or P1DT2H.
+func Duration(d time.Duration) string {
+	var b strings.Builder
+	if d < 0 {
+		b.WriteByte('-')
+		d = -d
+	}
+	b.WriteByte('P')
+	seconds := int64(d / time.Second)
+	days := seconds / 86400
+	seconds %= 86400
+	if days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+	}
+	if seconds > 0 || days == 0 {
+		b.WriteByte('T')
+		hours, minutes := seconds/3600, seconds%3600/60
+		seconds %= 60
+		if hours > 0 {
+			fmt.Fprintf(&b, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&b, "%dM", minutes)
+		}
+		if seconds > 0 || (hours == 0 && minutes == 0) {
+			fmt.Fprintf(&b, "%dS", seconds)
+		}
+	}
+	return b.String()
+}
+
+// line writes a content line, folding it so that no physical line exceeds
+// 75 octets. Folds never split a UTF-8 sequence, and each continuation
+// starts with a space.
+func (w *Writer) line(content string) {
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = maxLineOctets - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	offset := fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds%3600/60)
+	if s := seconds % 60; s != 0 {
+		offset += fmt.Sprintf("%02d", s)
+	}
+	return offset
+}
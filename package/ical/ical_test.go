@@ -0,0 +1,99 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lumoshiveacademy/todolist/package/ical"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscape(t *testing.T) {
+	require.Equal(t, `Milk\, eggs\; bread\\butter\nand jam`, ical.Escape("Milk, eggs; bread\\butter\r\nand jam"))
+	require.Equal(t, "tab\tkept", ical.Escape("tab\tkept\x00\x07"))
+}
+
+func TestParam(t *testing.T) {
+	require.Equal(t, "TZID=Europe/Berlin", ical.Param("TZID", "Europe/Berlin"))
+	require.Equal(t, `CN="Doe, Jane"`, ical.Param("CN", `Doe, "Jane"`))
+}
+
+func TestDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		-15 * time.Minute:               "-PT15M",
+		26 * time.Hour:                  "P1DT2H",
+		48 * time.Hour:                  "P2D",
+		-(90*time.Second + 2*time.Hour): "-PT2H1M30S",
+		0:                               "PT0S",
+	}
+	for d, want := range tests {
+		require.Equal(t, want, ical.Duration(d), d.String())
+	}
+}
+
+func TestWriter_FoldsLongLinesWithoutSplittingCharacters(t *testing.T) {
+	var w ical.Writer
+	summary := strings.Repeat("ä", 60)
+	w.Text("SUMMARY", summary)
+
+	out := string(w.Bytes())
+	require.True(t, strings.HasSuffix(out, "\r\n"))
+	lines := strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n")
+	require.Greater(t, len(lines), 1)
+	for i, line := range lines {
+		require.LessOrEqual(t, len(line), 75)
+		require.True(t, utf8.ValidString(line))
+		if i > 0 {
+			require.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(out, "\r\n"), "\r\n ", "")
+	require.Equal(t, "SUMMARY:"+summary, unfolded)
+}
+
+func TestWriter_Local(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	var w ical.Writer
+	w.Local("DTSTART", time.Date(2026, time.October, 23, 9, 0, 0, 0, berlin))
+	w.Local("DUE", time.Date(2026, time.October, 23, 7, 0, 0, 0, time.UTC))
+
+	require.Equal(t, "DTSTART;TZID=Europe/Berlin:20261023T090000\r\nDUE:20261023T070000Z\r\n", string(w.Bytes()))
+}
+
+func TestWriter_Timezone(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	var w ical.Writer
+	w.Timezone(berlin, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC))
+
+	require.Equal(t, strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"BEGIN:STANDARD",
+		"DTSTART:20260101T010000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0100",
+		"TZNAME:CET",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20260329T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"TZNAME:CEST",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20261025T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"TZNAME:CET",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"",
+	}, "\r\n"), string(w.Bytes()))
+}
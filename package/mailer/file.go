@@ -0,0 +1,48 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// File is a development Mailer that writes each message as an .eml file into
+// Dir and logs it. When Dir is empty messages are only logged.
+type File struct {
+	Dir    string
+	From   string
+	Logger *zap.Logger
+}
+
+// Send implements Mailer.
+func (m *File) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now()
+	data, err := format(m.From, msg, now)
+	if err != nil {
+		return err
+	}
+
+	fields := []zap.Field{zap.String("to", msg.To), zap.String("subject", msg.Subject)}
+	if m.Dir == "" {
+		m.Logger.Info("mail not delivered (file mailer)", append(fields, zap.String("body", msg.Body))...)
+		return nil
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return fmt.Errorf("create mail dir: %w", err)
+	}
+	name := filepath.Join(m.Dir, fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405Z"), uuid.NewString()))
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return fmt.Errorf("write mail: %w", err)
+	}
+	m.Logger.Info("mail written", append(fields, zap.String("path", name))...)
+	return nil
+}
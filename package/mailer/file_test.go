@@ -0,0 +1,46 @@
+package mailer_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestFileSendWritesMessage(t *testing.T) {
+	dir := t.TempDir()
+	m := &mailer.File{Dir: dir, From: "todolist <no-reply@example.com>", Logger: zaptest.NewLogger(t)}
+
+	err := m.Send(context.Background(), mailer.Message{
+		To:      "alice@example.com",
+		Subject: "Verify your email",
+		Body:    "line one\nline two",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	content := string(data)
+	assert.Contains(t, content, "To: alice@example.com\r\n")
+	assert.Contains(t, content, "Subject: Verify your email\r\n")
+	assert.True(t, strings.HasSuffix(content, "\r\n\r\nline one\r\nline two"))
+}
+
+func TestFileSendRejectsHeaderInjection(t *testing.T) {
+	m := &mailer.File{From: "no-reply@example.com", Logger: zaptest.NewLogger(t)}
+
+	err := m.Send(context.Background(), mailer.Message{To: "alice@example.com", Subject: "hi\r\nBcc: eve@example.com"})
+	assert.Error(t, err)
+
+	err = m.Send(context.Background(), mailer.Message{To: "alice@example.com\r\nBcc: eve@example.com", Subject: "hi"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,214 @@
+// Package markdown renders the subset of Markdown used in comments to HTML.
+//
+// Supported are paragraphs with hard line breaks, ATX headings, block
+// quotes, flat bulleted and numbered lists, fenced code blocks, and inline
+// code, **strong**, *emphasis*, ~~strikethrough~~ and [links](url). Raw HTML
+// in the source is escaped rather than passed through, and only http, https
+// and mailto links are kept, so the output is safe to embed in a page.
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	bulletPattern      = regexp.MustCompile(`^\s{0,3}[-*+]\s+(.*)$`)
+	orderedPattern     = regexp.MustCompile(`^\s{0,3}\d{1,9}[.)]\s+(.*)$`)
+	quotePattern       = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	fencePattern       = regexp.MustCompile("^\\s{0,3}(```+|~~~+)")
+	allowedLinkSchemes = []string{"http", "https", "mailto"}
+)
+
+// Render converts src to sanitized HTML.
+func Render(src string) string {
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	var b strings.Builder
+	renderBlocks(&b, lines)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fencePattern.MatchString(line):
+			fence := strings.TrimSpace(line)[:3]
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, lines[i])
+			}
+			i++ // closing fence
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(strings.Join(code, "\n")))
+			b.WriteString("</code></pre>\n")
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			level := string(rune('0' + len(m[1])))
+			b.WriteString("<h" + level + ">" + renderInline(m[2]) + "</h" + level + ">\n")
+			i++
+		case quotePattern.MatchString(line):
+			var quoted []string
+			for ; i < len(lines) && quotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, quotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			b.WriteString("<blockquote>\n")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>\n")
+		case bulletPattern.MatchString(line):
+			i = renderList(b, lines, i, bulletPattern, "ul")
+		case orderedPattern.MatchString(line):
+			i = renderList(b, lines, i, orderedPattern, "ol")
+		default:
+			var paragraph []string
+			for ; i < len(lines) && strings.TrimSpace(lines[i]) != "" && !startsBlock(lines[i]); i++ {
+				paragraph = append(paragraph, renderInline(strings.TrimSpace(lines[i])))
+			}
+			b.WriteString("<p>" + strings.Join(paragraph, "<br>\n") + "</p>\n")
+		}
+	}
+}
+
+func renderList(b *strings.Builder, lines []string, i int, pattern *regexp.Regexp, tag string) int {
+	b.WriteString("<" + tag + ">\n")
+	for ; i < len(lines) && pattern.MatchString(lines[i]); i++ {
+		b.WriteString("<li>" + renderInline(pattern.FindStringSubmatch(lines[i])[1]) + "</li>\n")
+	}
+	b.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// startsBlock reports whether line interrupts a paragraph.
+func startsBlock(line string) bool {
+	return fencePattern.MatchString(line) || headingPattern.MatchString(line) || quotePattern.MatchString(line) ||
+		bulletPattern.MatchString(line) || orderedPattern.MatchString(line)
+}
+
+// inlineSpans maps emphasis delimiters to the elements they produce, longest
+// delimiters first.
+var inlineSpans = []struct{ delim, tag string }{
+	{"**", "strong"},
+	{"__", "strong"},
+	{"~~", "del"},
+	{"*", "em"},
+	{"_", "em"},
+}
+
+func renderInline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		rest := s[i:]
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_~[]()#>-+!", rune(rest[1])):
+			b.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+			continue
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[':
+			if text, href, n, ok := parseLink(rest); ok {
+				if safeURL(href) {
+					b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer">` + renderInline(text) + "</a>")
+				} else {
+					b.WriteString(renderInline(text))
+				}
+				i += n
+				continue
+			}
+		}
+		if span, inner, n, ok := parseSpan(s, i); ok {
+			b.WriteString("<" + span + ">" + renderInline(inner) + "</" + span + ">")
+			i += n
+			continue
+		}
+		b.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+	return b.String()
+}
+
+// parseSpan matches an emphasis span at s[i:], returning its element, its
+// contents and its length.
+func parseSpan(s string, i int) (string, string, int, bool) {
+	before, s := s[:i], s[i:]
+	for _, span := range inlineSpans {
+		if !strings.HasPrefix(s, span.delim) {
+			continue
+		}
+		d := len(span.delim)
+		end := strings.Index(s[d:], span.delim)
+		if end <= 0 {
+			continue
+		}
+		inner := s[d : d+end]
+		// Like CommonMark, "* not emphasis *" and "2 * 3 * 4" stay literal.
+		if strings.TrimSpace(inner) != inner {
+			continue
+		}
+		// Underscores inside words, as in snake_case_names, are literal.
+		if span.delim[0] == '_' && (endsWithWordChar(before) || startsWithWordChar(s[2*d+end:])) {
+			continue
+		}
+		return span.tag, inner, 2*d + end, true
+	}
+	return "", "", 0, false
+}
+
+// parseLink matches [text](href) at the start of s.
+func parseLink(s string) (string, string, int, bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if i+1 >= len(s) || s[i+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[i+2:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			return s[1:i], strings.TrimSpace(s[i+2 : i+2+end]), i + 3 + end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func endsWithWordChar(s string) bool {
+	return s != "" && isWordChar(s[len(s)-1])
+}
+
+func startsWithWordChar(s string) bool {
+	return s != "" && isWordChar(s[0])
+}
+
+func isWordChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c >= 0x80
+}
+
+func safeURL(href string) bool {
+	u, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	for _, scheme := range allowedLinkSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/markdown"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"paragraphs", "one\ntwo\n\nthree", "<p>one<br>\ntwo</p>\n<p>three</p>"},
+		{"emphasis", "**bold**, *it*, _it_ and ~~gone~~", "<p><strong>bold</strong>, <em>it</em>, <em>it</em> and <del>gone</del></p>"},
+		{"literal asterisks", "2 * 3 * 4 and snake_case_name", "<p>2 * 3 * 4 and snake_case_name</p>"},
+		{"code span", "run `<b>*x*</b>`", "<p>run <code>&lt;b&gt;*x*&lt;/b&gt;</code></p>"},
+		{"code block", "```go\nif a < b {}\n```", "<pre><code>if a &lt; b {}</code></pre>"},
+		{"heading", "## Plan ##", "<h2>Plan</h2>"},
+		{"lists", "- milk\n- eggs\n\n1. first\n2. second", "<ul>\n<li>milk</li>\n<li>eggs</li>\n</ul>\n<ol>\n<li>first</li>\n<li>second</li>\n</ol>"},
+		{"quote", "> quoted *text*\nreply", "<blockquote>\n<p>quoted <em>text</em></p>\n</blockquote>\n<p>reply</p>"},
+		{"link", "see [the docs](https://example.com/a?b=1&c=2)", `<p>see <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer">the docs</a></p>`},
+		{"escape", `\*not em\*`, "<p>*not em*</p>"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, markdown.Render(tc.src))
+		})
+	}
+}
+
+func TestRender_Sanitizes(t *testing.T) {
+	tests := []struct {
+		name, src, want string
+	}{
+		{"raw html", `<script>alert("x")</script><img src=x onerror=alert(1)>`, "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;&lt;img src=x onerror=alert(1)&gt;</p>"},
+		{"javascript link", "[click](javascript:alert(1))", "<p>click)</p>"},
+		{"data link", "[click](data:text/html;base64,PHNjcmlwdD4=)", "<p>click</p>"},
+		{"attribute breakout", `[x](https://example.com/"onmouseover="alert(1))`, `<p><a href="https://example.com/&#34;onmouseover=&#34;alert(1" rel="nofollow noopener noreferrer">x</a>)</p>`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, markdown.Render(tc.src))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Package mention finds @username mentions in Markdown text.
+package mention
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UsernamePattern matches the usernames ValidUsername accepts.
+const UsernamePattern = `^[a-z0-9_-]{3,32}$`
+
+var (
+	usernamePattern = regexp.MustCompile(UsernamePattern)
+	// mentionPattern needs the character before the @ so that email
+	// addresses such as bob@example.com are not taken for mentions.
+	mentionPattern   = regexp.MustCompile(`(?:^|[^A-Za-z0-9_@.-])@([A-Za-z0-9_-]{3,32})`)
+	codeFencePattern = regexp.MustCompile("(?ms)^\\s{0,3}(```+|~~~+).*?(^\\s{0,3}(```+|~~~+)|\\z)")
+	codeSpanPattern  = regexp.MustCompile("`[^`\n]*`")
+)
+
+// ValidUsername reports whether name can be mentioned: 3 to 32 lowercase
+// letters, digits, underscores or hyphens.
+func ValidUsername(name string) bool {
+	return usernamePattern.MatchString(name)
+}
+
+// Parse returns the distinct usernames mentioned in text, lowercased, in the
+// order they first appear. Mentions inside code are ignored.
+func Parse(text string) []string {
+	text = codeFencePattern.ReplaceAllString(text, "")
+	text = codeSpanPattern.ReplaceAllString(text, "")
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatchIndex(text, -1) {
+		// A longer run of username characters is not a valid mention.
+		if m[3] < len(text) && isUsernameChar(text[m[3]]) {
+			continue
+		}
+		name := strings.ToLower(text[m[2]:m[3]])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func isUsernameChar(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c == '-'
+}
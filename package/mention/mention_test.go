@@ -0,0 +1,41 @@
+package mention_test
+
+import (
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/mention"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"@alice can you look?", []string{"alice"}},
+		{"thanks @Alice and @bob_2, cc @alice", []string{"alice", "bob_2"}},
+		{"(@carol) @dave.", []string{"carol", "dave"}},
+		{"mail bob@example.com", nil},
+		{"@al is too short", nil},
+		{"@" + "abcdefghijklmnopqrstuvwxyz1234567", nil},
+		{"run `@alice` or\n```\n@bob\n```\n@carol", []string{"carol"}},
+		{"no mentions here", nil},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.want, mention.Parse(tc.text), tc.text)
+	}
+}
+
+func TestValidUsername(t *testing.T) {
+	for name, want := range map[string]bool{
+		"alice":   true,
+		"bob_2":   true,
+		"c-d":     true,
+		"al":      false,
+		"Alice":   false,
+		"dot.ted": false,
+		"":        false,
+	} {
+		require.Equal(t, want, mention.ValidUsername(name), name)
+	}
+}
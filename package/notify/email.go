@@ -0,0 +1,39 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+)
+
+// Email sends messages through a mailer.Mailer. BaseURL is used to link to
+// the todo list in the message body.
+type Email struct {
+	Mailer  mailer.Mailer
+	BaseURL string
+}
+
+// Notify implements Notifier.
+func (e *Email) Notify(ctx context.Context, msg Message) error {
+	if msg.Email == "" {
+		return fmt.Errorf("send reminder email: recipient has no email address")
+	}
+	// Titles are user input; keep them from breaking the subject header.
+	title := strings.Join(strings.Fields(msg.Title), " ")
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "Reminder: %s\n", msg.Title)
+	if msg.DueAt != nil {
+		fmt.Fprintf(&body, "Due: %s\n", msg.DueAt.UTC().Format(time.RFC1123))
+	}
+	fmt.Fprintf(&body, "\n%s/api/v1/todolists/%s/items/%s\n", e.BaseURL, msg.TodoListID, msg.TodoItemID)
+
+	return e.Mailer.Send(ctx, mailer.Message{
+		To:      msg.Email,
+		Subject: "Reminder: " + title,
+		Body:    body.String(),
+	})
+}
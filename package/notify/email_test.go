@@ -0,0 +1,46 @@
+package notify_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingMailer struct {
+	sent []mailer.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, msg mailer.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+func TestEmailNotifyLinksToItem(t *testing.T) {
+	m := &recordingMailer{}
+	email := &notify.Email{Mailer: m, BaseURL: "https://todo.example.com"}
+	listID, itemID := uuid.New(), uuid.New()
+	dueAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+
+	err := email.Notify(context.Background(), notify.Message{
+		TodoListID: listID,
+		TodoItemID: itemID,
+		Email:      "alice@example.com",
+		Title:      "Pay\r\nrent",
+		DueAt:      &dueAt,
+	})
+	require.NoError(t, err)
+	require.Len(t, m.sent, 1)
+	require.Equal(t, "alice@example.com", m.sent[0].To)
+	require.Equal(t, "Reminder: Pay rent", m.sent[0].Subject)
+	require.Contains(t, m.sent[0].Body, "https://todo.example.com/api/v1/todolists/"+listID.String()+"/items/"+itemID.String())
+}
+
+func TestEmailNotifyRequiresAddress(t *testing.T) {
+	email := &notify.Email{Mailer: &recordingMailer{}}
+	require.Error(t, email.Notify(context.Background(), notify.Message{Title: "Pay rent"}))
+}
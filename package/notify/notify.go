@@ -0,0 +1,31 @@
+// Package notify delivers todo item reminders over pluggable channels such
+// as webhooks and email.
+package notify
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a reminder about a todo item addressed to one recipient.
+// Channels use the fields that apply to them: Email for email, Target for
+// webhooks and UserID for the in-app inbox.
+type Message struct {
+	ReminderID  uuid.UUID
+	WorkspaceID uuid.UUID
+	TodoListID  uuid.UUID
+	TodoItemID  uuid.UUID
+	UserID      string
+	Email       string
+	Target      string
+	Title       string
+	DueAt       *time.Time
+	RemindAt    time.Time
+}
+
+// Notifier delivers messages over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
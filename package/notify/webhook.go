@@ -0,0 +1,147 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body when the
+// webhook has a secret, so receivers can check the request came from us.
+const SignatureHeader = "X-Todolist-Signature"
+
+// ErrBlockedAddress is returned when a webhook target resolves to an address
+// that is not on the public internet, such as loopback or a private network.
+var ErrBlockedAddress = errors.New("webhook address is not public")
+
+// blockedPrefixes are special-purpose ranges that netip does not classify as
+// private or non-global but that still reach internal hosts.
+var blockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2002::/16"),
+}
+
+// Webhook posts messages as JSON to the reminder's target URL, which must
+// use https. Any response other than 2xx is reported as an error.
+type Webhook struct {
+	// Client sends the requests; use NewWebhookClient outside tests.
+	Client *http.Client
+	Secret string
+}
+
+// NewWebhookClient returns a client that only connects to public addresses.
+// The check runs on the resolved address of every connection, so hostnames
+// that resolve, or later re-resolve, to internal addresses are refused.
+// Redirects are not followed and proxies are not used.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// publicOnly is a net.Dialer Control hook that refuses non-public addresses.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+	}
+	for _, prefix := range blockedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s", ErrBlockedAddress, addr)
+		}
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Event      string     `json:"event"`
+	ReminderID uuid.UUID  `json:"reminder_id"`
+	TodoListID uuid.UUID  `json:"todo_list_id"`
+	TodoItemID uuid.UUID  `json:"todo_item_id"`
+	Title      string     `json:"title"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	RemindAt   time.Time  `json:"remind_at"`
+}
+
+// Notify implements Notifier.
+func (w *Webhook) Notify(ctx context.Context, msg Message) error {
+	target, err := url.Parse(msg.Target)
+	if err != nil || target.Scheme != "https" || target.Host == "" {
+		return errors.New("send webhook: target must be an https url")
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		Event:      "todo_item.reminder",
+		ReminderID: msg.ReminderID,
+		TodoListID: msg.TodoListID,
+		TodoItemID: msg.TodoItemID,
+		Title:      msg.Title,
+		DueAt:      msg.DueAt,
+		RemindAt:   msg.RemindAt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.Secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("send webhook: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package notify_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNotifySignsPayload(t *testing.T) {
+	var body []byte
+	var signature string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(notify.SignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	itemID := uuid.New()
+	webhook := &notify.Webhook{Client: server.Client(), Secret: "s3cret"}
+	err := webhook.Notify(context.Background(), notify.Message{
+		TodoItemID: itemID,
+		Target:     server.URL,
+		Title:      "Pay rent",
+		RemindAt:   time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &payload))
+	require.Equal(t, "todo_item.reminder", payload["event"])
+	require.Equal(t, itemID.String(), payload["todo_item_id"])
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	require.Equal(t, hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestWebhookNotifyRejectsErrorStatus(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	webhook := &notify.Webhook{Client: server.Client()}
+	err := webhook.Notify(context.Background(), notify.Message{Target: server.URL, Title: "Pay rent"})
+	require.ErrorContains(t, err, "502")
+}
+
+func TestWebhookNotifyRequiresHTTPS(t *testing.T) {
+	webhook := &notify.Webhook{Client: http.DefaultClient}
+	err := webhook.Notify(context.Background(), notify.Message{Target: "http://example.com/hook", Title: "Pay rent"})
+	require.ErrorContains(t, err, "https")
+}
+
+func TestWebhookClientRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request reached a loopback address")
+	}))
+	defer server.Close()
+
+	webhook := &notify.Webhook{Client: notify.NewWebhookClient(time.Second)}
+	for _, target := range []string{
+		server.URL,
+		"https://localhost:1/hook",
+		"https://10.0.0.1:1/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]:1/hook",
+	} {
+		err := webhook.Notify(context.Background(), notify.Message{Target: target, Title: "Pay rent"})
+		require.ErrorIs(t, err, notify.ErrBlockedAddress, target)
+	}
+}
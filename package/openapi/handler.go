@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/lumoshiveacademy/todolist/package/response"
+	swaggerFiles "github.com/swaggo/files/v2"
+)
+
+// Handler serves doc as JSON. The document is encoded on the first request,
+// so later changes to doc are not served.
+func Handler(doc *openapi3.T) http.HandlerFunc {
+	encode := sync.OnceValues(func() ([]byte, error) {
+		return json.Marshal(doc)
+	})
+	return func(w http.ResponseWriter, _ *http.Request) {
+		body, err := encode()
+		if err != nil {
+			response.Write(w, http.StatusInternalServerError, response.Failure(map[string]string{
+				"message": "could not encode openapi document",
+			}))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(body)
+	}
+}
+
+// UI serves the embedded Swagger UI, pointed at the document served at
+// specURL. Mount it under a path prefix with http.StripPrefix; the prefix's
+// trailing slash serves the UI itself.
+func UI(specURL string) http.Handler {
+	specJSON, _ := json.Marshal(specURL)
+	initializer := []byte(`window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: ` + string(specJSON) + `,
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    persistAuthorization: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`)
+	files := http.FileServerFS(swaggerFiles.FS)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.TrimPrefix(r.URL.Path, "/") == "swagger-initializer.js" {
+			w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+			_, _ = w.Write(initializer)
+			return
+		}
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,293 @@
+// Package openapi generates the OpenAPI 3 description of the HTTP API from
+// route metadata and the Go types the handlers decode and encode. Schemas
+// follow encoding/json naming and take their constraints from `validate`
+// struct tags, so the document cannot drift from the code that enforces it.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Content describes a body that is not a JSON model, such as a file
+// download, keyed by media type.
+type Content map[string]*openapi3.Schema
+
+// Operation documents one route.
+type Operation struct {
+	Method string
+	// Path uses chi's pattern syntax, e.g. /api/v1/todolists/{id}.
+	Path        string
+	Tag         string
+	Summary     string
+	Description string
+	// Public operations need no credentials.
+	Public bool
+	// Query is a struct whose `query`-tagged fields are the operation's
+	// query parameters.
+	Query any
+	// Parameters lists header and query parameters that Query does not
+	// describe.
+	Parameters []*openapi3.Parameter
+	// Request is the JSON request body as a value of its Go type, or a
+	// Content for other media types.
+	Request         any
+	RequestOptional bool
+	// Status is the success status, 200 when zero.
+	Status int
+	// StatusDescription describes the success response; defaults to the
+	// status text.
+	StatusDescription string
+	// Response is the data of the success envelope as a value of its Go
+	// type, or a Content sent as is. Nil means an empty response.
+	Response any
+	// Headers lists the success response's headers and their descriptions.
+	Headers map[string]string
+	// Errors lists the operation's other statuses and their descriptions,
+	// besides 401, 413 and 500 which are added as needed. Error statuses carry
+	// the failure envelope, and an empty description uses the shared
+	// response for the status.
+	Errors map[int]string
+}
+
+// Options configure New.
+type Options struct {
+	Info    openapi3.Info
+	Servers openapi3.Servers
+	// SecuritySchemes are required by every operation that is not public;
+	// any one of them is enough.
+	SecuritySchemes openapi3.SecuritySchemes
+	// Rules maps custom validation tags to the schema constraint they
+	// enforce.
+	Rules map[string]func(*openapi3.Schema)
+	// PathParameters gives the schema of path parameters by name. Others are
+	// plain strings.
+	PathParameters map[string]*openapi3.Schema
+}
+
+// Generator accumulates operations into an OpenAPI document.
+type Generator struct {
+	doc            *openapi3.T
+	rules          map[string]func(*openapi3.Schema)
+	pathParameters map[string]*openapi3.Schema
+	// components maps the Go types registered as component schemas to
+	// their names.
+	components   map[reflect.Type]string
+	descriptions map[reflect.Type]string
+}
+
+// defaultErrors describes the shared error responses, which are registered
+// as components and referenced by operations.
+var defaultErrors = map[int]struct{ name, description string }{
+	http.StatusBadRequest:            {"BadRequest", "Invalid request payload"},
+	http.StatusUnauthorized:          {"Unauthorized", "Missing or invalid credentials"},
+	http.StatusForbidden:             {"Forbidden", "The caller lacks the required permission or does not own the resource"},
+	http.StatusNotFound:              {"NotFound", "Resource not found"},
+	http.StatusRequestEntityTooLarge: {"PayloadTooLarge", "The request body exceeds the size limit"},
+	http.StatusUnprocessableEntity:   {"ValidationError", "Validation error; `error` maps each invalid field to the rule it broke"},
+	http.StatusInternalServerError:   {"ServerError", "Internal server error"},
+}
+
+var pathParameterPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// New constructs a Generator for an empty document.
+func New(options Options) *Generator {
+	doc := &openapi3.T{
+		OpenAPI: "3.0.3",
+		Info:    &options.Info,
+		Servers: options.Servers,
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas:         openapi3.Schemas{},
+			Responses:       openapi3.ResponseBodies{},
+			SecuritySchemes: options.SecuritySchemes,
+		},
+	}
+	names := make([]string, 0, len(options.SecuritySchemes))
+	for name := range options.SecuritySchemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		doc.Security.With(openapi3.NewSecurityRequirement().Authenticate(name))
+	}
+
+	g := &Generator{
+		doc:            doc,
+		rules:          options.Rules,
+		pathParameters: options.PathParameters,
+		components:     make(map[reflect.Type]string),
+		descriptions:   make(map[reflect.Type]string),
+	}
+	doc.Components.Schemas["Error"] = openapi3.NewSchemaRef("", errorSchema())
+	for _, response := range defaultErrors {
+		doc.Components.Responses[response.name] = &openapi3.ResponseRef{Value: g.errorResponse(response.description)}
+	}
+	return g
+}
+
+// Document returns the generated document.
+func (g *Generator) Document() *openapi3.T {
+	return g.doc
+}
+
+// Describe sets the description of the component schema generated for the
+// type of v.
+func (g *Generator) Describe(v any, description string) {
+	t := reflect.TypeOf(v)
+	g.descriptions[t] = description
+	if name, ok := g.components[t]; ok {
+		g.doc.Components.Schemas[name].Value.Description = description
+	}
+}
+
+// Add documents op. It panics when the route is already documented, which
+// only happens through a programming error.
+func (g *Generator) Add(op Operation) {
+	path := g.doc.Paths.Find(op.Path)
+	if path == nil {
+		path = &openapi3.PathItem{}
+		g.doc.Paths.Set(op.Path, path)
+	}
+	if path.GetOperation(op.Method) != nil {
+		panic(fmt.Sprintf("openapi: %s %s is documented twice", op.Method, op.Path))
+	}
+
+	operation := &openapi3.Operation{
+		Summary:     op.Summary,
+		Description: op.Description,
+		Responses:   openapi3.NewResponsesWithCapacity(len(op.Errors) + 3),
+	}
+	if op.Tag != "" {
+		operation.Tags = []string{op.Tag}
+	}
+	if op.Public {
+		operation.Security = openapi3.NewSecurityRequirements()
+	}
+
+	for _, match := range pathParameterPattern.FindAllStringSubmatch(op.Path, -1) {
+		schema, ok := g.pathParameters[match[1]]
+		if !ok {
+			schema = openapi3.NewStringSchema()
+		}
+		operation.AddParameter(openapi3.NewPathParameter(match[1]).WithSchema(schema))
+	}
+	if op.Query != nil {
+		for _, parameter := range g.queryParameters(reflect.TypeOf(op.Query)) {
+			operation.AddParameter(parameter)
+		}
+	}
+	for _, parameter := range op.Parameters {
+		operation.AddParameter(parameter)
+	}
+
+	if op.Request != nil {
+		operation.RequestBody = &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{
+			Required: !op.RequestOptional,
+			Content:  g.content(op.Request, false),
+		}}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	description := op.StatusDescription
+	if description == "" {
+		description = http.StatusText(status)
+	}
+	success := openapi3.NewResponse().WithDescription(description)
+	if op.Response != nil {
+		success.Content = g.content(op.Response, true)
+	}
+	if len(op.Headers) > 0 {
+		success.Headers = openapi3.Headers{}
+		for name, description := range op.Headers {
+			header := &openapi3.Header{Parameter: openapi3.Parameter{
+				Description: description,
+				Schema:      openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+			}}
+			success.Headers[name] = &openapi3.HeaderRef{Value: header}
+		}
+	}
+	operation.AddResponse(status, success)
+
+	errs := map[int]string{http.StatusInternalServerError: ""}
+	if !op.Public {
+		errs[http.StatusUnauthorized] = ""
+	}
+	if op.Request != nil {
+		errs[http.StatusRequestEntityTooLarge] = ""
+	}
+	for status, description := range op.Errors {
+		errs[status] = description
+	}
+	for status, description := range errs {
+		if status < http.StatusBadRequest {
+			operation.AddResponse(status, openapi3.NewResponse().WithDescription(description))
+			continue
+		}
+		if shared, ok := defaultErrors[status]; ok && description == "" {
+			operation.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{
+				Ref:   "#/components/responses/" + shared.name,
+				Value: g.doc.Components.Responses[shared.name].Value,
+			})
+			continue
+		}
+		if description == "" {
+			description = http.StatusText(status)
+		}
+		operation.AddResponse(status, g.errorResponse(description))
+	}
+
+	path.SetOperation(op.Method, operation)
+}
+
+// content describes body, a Content or a value of a JSON model. Response
+// models are wrapped in the success envelope of package response.
+func (g *Generator) content(body any, response bool) openapi3.Content {
+	if content, ok := body.(Content); ok {
+		result := openapi3.Content{}
+		for mediaType, schema := range content {
+			result[mediaType] = openapi3.NewMediaType().WithSchema(schema)
+		}
+		return result
+	}
+
+	schema := g.schema(reflect.TypeOf(body), response)
+	if response {
+		schema = openapi3.NewSchemaRef("", envelope(schema))
+	}
+	return openapi3.NewContentWithJSONSchemaRef(schema)
+}
+
+// envelope describes a response.Success message carrying data.
+func envelope(data *openapi3.SchemaRef) *openapi3.Schema {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("status", openapi3.NewStringSchema().WithEnum("success")).
+		WithRequired([]string{"status"})
+	schema.Properties["data"] = data
+	return schema
+}
+
+// errorSchema describes a response.Failure message.
+func errorSchema() *openapi3.Schema {
+	return openapi3.NewObjectSchema().
+		WithProperty("status", openapi3.NewStringSchema().WithEnum("error")).
+		WithProperty("error", openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema())).
+		WithProperty("trace_id", openapi3.NewStringSchema()).
+		WithRequired([]string{"status", "error"})
+}
+
+func (g *Generator) errorResponse(description string) *openapi3.Response {
+	schema := openapi3.NewSchemaRef(componentPrefix+"Error", g.doc.Components.Schemas["Error"].Value)
+	return openapi3.NewResponse().
+		WithDescription(description).
+		WithContent(openapi3.NewContentWithJSONSchemaRef(schema))
+}
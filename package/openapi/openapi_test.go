@@ -0,0 +1,179 @@
+package openapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+type paging struct {
+	Limit int `json:"limit" query:"limit" validate:"min=1,max=100" doc:"Page size."`
+}
+
+type widgetFilter struct {
+	paging
+	Colors []string `query:"colors" validate:"max=3,dive,oneof=red blue"`
+	Owner  string
+}
+
+type createWidgetRequest struct {
+	Name   string            `json:"name" validate:"required,max=64,excludesall=0x2C"`
+	Color  string            `json:"color" validate:"omitempty,hexcolor"`
+	Kind   string            `json:"kind" validate:"widget_kind"`
+	Shape  string            `json:"shape" validate:"omitempty,oneof=round square"`
+	Motto  string            `json:"motto" validate:"omitempty,min=3"`
+	Size   *int              `json:"size" validate:"omitempty,min=1,max=10" doc:"In centimetres."`
+	Labels map[string]string `json:"labels" validate:"max=5,dive,keys,min=1,endkeys,max=32"`
+	Secret string            `json:"-"`
+}
+
+type WidgetResponse struct {
+	ID        uuid.UUID        `json:"id"`
+	Name      string           `json:"name"`
+	DueAt     *time.Time       `json:"due_at,omitempty"`
+	Tags      []string         `json:"tags"`
+	Children  []WidgetResponse `json:"children,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func newGenerator() *openapi.Generator {
+	return openapi.New(openapi.Options{
+		Info: openapi3.Info{Title: "Widgets", Version: "1.0.0"},
+		SecuritySchemes: openapi3.SecuritySchemes{
+			"bearerAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+		},
+		Rules: map[string]func(*openapi3.Schema){
+			"widget_kind": func(schema *openapi3.Schema) { schema.Enum = []any{"gear", "sprocket"} },
+		},
+		PathParameters: map[string]*openapi3.Schema{"id": openapi3.NewUUIDSchema()},
+	})
+}
+
+func TestGenerator_Schemas(t *testing.T) {
+	g := newGenerator()
+	g.Describe(WidgetResponse{}, "A widget.")
+	g.Add(openapi.Operation{
+		Method:   http.MethodPost,
+		Path:     "/widgets",
+		Request:  createWidgetRequest{},
+		Status:   http.StatusCreated,
+		Response: WidgetResponse{},
+		Errors:   map[int]string{http.StatusUnprocessableEntity: "", http.StatusConflict: "Name taken"},
+	})
+	doc := g.Document()
+	require.NoError(t, doc.Validate(context.Background()))
+
+	request := doc.Components.Schemas["createWidgetRequest"].Value
+	require.Equal(t, []string{"name"}, request.Required)
+	require.NotContains(t, request.Properties, "Secret")
+
+	name := request.Properties["name"].Value
+	require.EqualValues(t, 1, name.MinLength)
+	require.EqualValues(t, 64, *name.MaxLength)
+	require.Equal(t, "^[^,]*$", name.Pattern)
+	require.Equal(t, "^$|^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$", request.Properties["color"].Value.Pattern, "omitempty allows the empty string")
+	require.Equal(t, []any{"round", "square", ""}, request.Properties["shape"].Value.Enum)
+	require.Zero(t, request.Properties["motto"].Value.MinLength)
+	require.Equal(t, `^$|^[\s\S]{3,}$`, request.Properties["motto"].Value.Pattern)
+	require.Equal(t, []any{"gear", "sprocket"}, request.Properties["kind"].Value.Enum)
+
+	size := request.Properties["size"].Value
+	require.True(t, size.Nullable)
+	require.Equal(t, 1.0, *size.Min)
+	require.Equal(t, 10.0, *size.Max)
+	require.Equal(t, "In centimetres.", size.Description)
+
+	labels := request.Properties["labels"].Value
+	require.EqualValues(t, 5, *labels.MaxProps)
+	require.EqualValues(t, 32, *labels.AdditionalProperties.Schema.Value.MaxLength)
+
+	widget := doc.Components.Schemas["Widget"].Value
+	require.Equal(t, "A widget.", widget.Description)
+	require.Equal(t, []string{"id", "name", "tags", "created_at"}, widget.Required)
+	require.Equal(t, "uuid", widget.Properties["id"].Value.Format)
+	require.Equal(t, "date-time", widget.Properties["due_at"].Value.Format)
+	require.True(t, widget.Properties["tags"].Value.Nullable, "nil slices encode as null")
+	require.Equal(t, "#/components/schemas/Widget", widget.Properties["children"].Value.Items.Ref)
+
+	operation := doc.Paths.Find("/widgets").Post
+	require.True(t, operation.RequestBody.Value.Required)
+	created := operation.Responses.Status(http.StatusCreated).Value.Content.Get("application/json").Schema.Value
+	require.Equal(t, []any{"success"}, created.Properties["status"].Value.Enum)
+	require.Equal(t, "#/components/schemas/Widget", created.Properties["data"].Ref)
+
+	require.Equal(t, "#/components/responses/ValidationError", operation.Responses.Status(http.StatusUnprocessableEntity).Ref)
+	require.Equal(t, "#/components/responses/Unauthorized", operation.Responses.Status(http.StatusUnauthorized).Ref)
+	require.Equal(t, "#/components/responses/PayloadTooLarge", operation.Responses.Status(http.StatusRequestEntityTooLarge).Ref)
+	require.Equal(t, "Name taken", *operation.Responses.Status(http.StatusConflict).Value.Description)
+}
+
+func TestGenerator_Parameters(t *testing.T) {
+	g := newGenerator()
+	g.Add(openapi.Operation{
+		Method:   http.MethodGet,
+		Path:     "/widgets/{id}/parts/{name}",
+		Public:   true,
+		Query:    widgetFilter{},
+		Response: []WidgetResponse{},
+	})
+	doc := g.Document()
+	require.NoError(t, doc.Validate(context.Background()))
+
+	operation := doc.Paths.Find("/widgets/{id}/parts/{name}").Get
+	require.NotNil(t, operation.Security)
+	require.Empty(t, *operation.Security)
+	require.Nil(t, operation.Responses.Status(http.StatusUnauthorized))
+
+	require.Equal(t, "uuid", operation.Parameters.GetByInAndName(openapi3.ParameterInPath, "id").Schema.Value.Format)
+	require.Equal(t, "string", operation.Parameters.GetByInAndName(openapi3.ParameterInPath, "name").Schema.Value.Type.Slice()[0])
+
+	limit := operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "limit")
+	require.Equal(t, "Page size.", limit.Description)
+	require.True(t, limit.AllowEmptyValue)
+	require.Equal(t, 100.0, *limit.Schema.Value.Max)
+
+	colors := operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "colors")
+	require.Equal(t, openapi3.SerializationForm, colors.Style)
+	require.False(t, *colors.Explode)
+	require.EqualValues(t, 3, *colors.Schema.Value.MaxItems)
+	require.Equal(t, []any{"red", "blue"}, colors.Schema.Value.Items.Value.Enum)
+	require.Nil(t, operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "Owner"))
+}
+
+func TestGenerator_AddTwicePanics(t *testing.T) {
+	g := newGenerator()
+	g.Add(openapi.Operation{Method: http.MethodGet, Path: "/widgets"})
+	require.Panics(t, func() {
+		g.Add(openapi.Operation{Method: http.MethodGet, Path: "/widgets"})
+	})
+}
+
+func TestHandlerAndUI(t *testing.T) {
+	g := newGenerator()
+	g.Add(openapi.Operation{Method: http.MethodGet, Path: "/widgets", Response: []WidgetResponse{}})
+
+	rec := httptest.NewRecorder()
+	openapi.Handler(g.Document()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	require.NoError(t, err)
+	require.NotNil(t, doc.Paths.Find("/widgets"))
+
+	ui := http.StripPrefix("/docs", openapi.UI("/openapi.json"))
+	rec = httptest.NewRecorder()
+	ui.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.True(t, strings.Contains(rec.Body.String(), "swagger-initializer.js"))
+
+	rec = httptest.NewRecorder()
+	ui.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	require.Contains(t, rec.Body.String(), `url: "/openapi.json"`)
+}
@@ -0,0 +1,340 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+)
+
+const componentPrefix = "#/components/schemas/"
+
+// Patterns equivalent to the validator's built-in rules of the same name.
+const (
+	hexColorPattern = `^#(?:[0-9a-fA-F]{3}|[0-9a-fA-F]{4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`
+	numericPattern  = `^[-+]?[0-9]+(?:\.[0-9]+)?$`
+)
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// schema describes t. Named structs become component schemas named after
+// their type without a "Response" suffix; the reference carries the
+// component's value so that documents built in code validate without
+// resolving references.
+//
+// In responses every field that is not omitempty is required, because
+// encoding/json always writes it; in requests only fields whose validate tag
+// has "required" are. A struct's role is fixed by its first use.
+func (g *Generator) schema(t reflect.Type, response bool) *openapi3.SchemaRef {
+	switch t {
+	case timeType:
+		return openapi3.NewSchemaRef("", openapi3.NewDateTimeSchema())
+	case uuidType:
+		return openapi3.NewSchemaRef("", openapi3.NewUUIDSchema())
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		ref := g.schema(t.Elem(), response)
+		if ref.Ref == "" {
+			ref.Value.Nullable = true
+		}
+		return ref
+	case reflect.Bool:
+		return openapi3.NewSchemaRef("", openapi3.NewBoolSchema())
+	case reflect.Int64, reflect.Uint64:
+		return openapi3.NewSchemaRef("", openapi3.NewInt64Schema())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())
+	case reflect.Float32, reflect.Float64:
+		return openapi3.NewSchemaRef("", openapi3.NewFloat64Schema())
+	case reflect.String:
+		return openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return openapi3.NewSchemaRef("", openapi3.NewBytesSchema())
+		}
+		schema := openapi3.NewArraySchema()
+		schema.Items = g.schema(t.Elem(), response)
+		return openapi3.NewSchemaRef("", schema)
+	case reflect.Map:
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties.Schema = g.schema(t.Elem(), response)
+		return openapi3.NewSchemaRef("", schema)
+	case reflect.Interface:
+		return openapi3.NewSchemaRef("", &openapi3.Schema{})
+	case reflect.Struct:
+		if t.Name() == "" {
+			schema := openapi3.NewObjectSchema()
+			g.fields(schema, t, response)
+			return openapi3.NewSchemaRef("", schema)
+		}
+		return g.component(t, response)
+	}
+	panic(fmt.Sprintf("openapi: cannot describe %s", t))
+}
+
+func (g *Generator) component(t reflect.Type, response bool) *openapi3.SchemaRef {
+	name, ok := g.components[t]
+	if !ok {
+		name = strings.TrimSuffix(t.Name(), "Response")
+		if _, taken := g.doc.Components.Schemas[name]; taken {
+			panic(fmt.Sprintf("openapi: %s and another type are both named %s", t, name))
+		}
+		schema := openapi3.NewObjectSchema()
+		schema.Description = g.descriptions[t]
+		g.components[t] = name
+		g.doc.Components.Schemas[name] = openapi3.NewSchemaRef("", schema)
+		g.fields(schema, t, response)
+	}
+	return openapi3.NewSchemaRef(componentPrefix+name, g.doc.Components.Schemas[name].Value)
+}
+
+// fields adds the JSON fields of struct t to schema, flattening embedded
+// structs like encoding/json does.
+func (g *Generator) fields(schema *openapi3.Schema, t reflect.Type, response bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitempty := jsonName(field)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(schema, embedded, response)
+				continue
+			}
+		}
+		if !field.IsExported() || name == "-" {
+			continue
+		}
+
+		rules := validateRules(field)
+		ref := g.schema(field.Type, response)
+		if ref.Ref == "" {
+			g.constrain(ref.Value, rules)
+			if hasRule(rules, "omitempty") && field.Type.Kind() == reflect.String {
+				allowEmpty(ref.Value)
+			}
+			ref.Value.Description = field.Tag.Get("doc")
+			if response && !omitempty && (field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Map) {
+				// encoding/json writes nil slices and maps as null.
+				ref.Value.Nullable = true
+			}
+		}
+		schema.Properties[name] = ref
+
+		if (response && !omitempty) || (!response && hasRule(rules, "required")) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// queryParameters describes the `query`-tagged fields of struct t.
+func (g *Generator) queryParameters(t reflect.Type) []*openapi3.Parameter {
+	var parameters []*openapi3.Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			parameters = append(parameters, g.queryParameters(field.Type)...)
+			continue
+		}
+		name := field.Tag.Get("query")
+		if name == "" {
+			continue
+		}
+
+		rules := validateRules(field)
+		schema := g.schema(field.Type, false).Value
+		schema.Nullable = false
+		g.constrain(schema, rules)
+		if hasRule(rules, "omitempty") && field.Type.Kind() == reflect.String {
+			allowEmpty(schema)
+		}
+
+		parameter := openapi3.NewQueryParameter(name).WithSchema(schema)
+		parameter.Description = field.Tag.Get("doc")
+		parameter.Required = hasRule(rules, "required")
+		// Handlers read an empty value like a missing one.
+		parameter.AllowEmptyValue = !parameter.Required
+		if field.Type.Kind() == reflect.Slice {
+			explode := false
+			parameter.Style = openapi3.SerializationForm
+			parameter.Explode = &explode
+		}
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+// constrain applies the validator rules to schema. Rules with no OpenAPI
+// equivalent, such as cross-field ones, are left to the validator.
+func (g *Generator) constrain(schema *openapi3.Schema, rules []string) {
+	for i, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "dive":
+			g.dive(schema, rules[i+1:])
+			return
+		case "required":
+			if schema.Type.Is(openapi3.TypeString) && schema.MinLength == 0 {
+				schema.MinLength = 1
+			}
+		case "min", "max", "len":
+			bound(schema, name, param)
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				schema.Enum = append(schema.Enum, value)
+			}
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "uuid":
+			schema.Format = "uuid"
+		case "hexcolor":
+			schema.Pattern = hexColorPattern
+		case "numeric":
+			schema.Pattern = numericPattern
+		case "excludesall":
+			var class strings.Builder
+			for _, r := range param {
+				if strings.ContainsRune(`\]^-[`, r) {
+					class.WriteByte('\\')
+				}
+				class.WriteRune(r)
+			}
+			schema.Pattern = "^[^" + class.String() + "]*$"
+		default:
+			if apply, ok := g.rules[name]; ok {
+				apply(schema)
+			}
+		}
+	}
+}
+
+// dive applies the rules following "dive" to the items of an array or the
+// values of a map. Rules between "keys" and "endkeys" apply to map keys,
+// which JSON Schema cannot constrain here.
+func (g *Generator) dive(schema *openapi3.Schema, rules []string) {
+	if slices.Contains(rules, "keys") {
+		if end := slices.Index(rules, "endkeys"); end >= 0 {
+			rules = rules[end+1:]
+		}
+	}
+	var elem *openapi3.SchemaRef
+	switch {
+	case schema.Items != nil:
+		elem = schema.Items
+	case schema.AdditionalProperties.Schema != nil:
+		elem = schema.AdditionalProperties.Schema
+	}
+	if elem != nil && elem.Ref == "" {
+		g.constrain(elem.Value, rules)
+	}
+}
+
+// bound applies a min, max or len rule, which the validator reads as a
+// length, count or value depending on the field's type.
+func bound(schema *openapi3.Schema, name, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := uint64(value)
+	lower, upper := name != "max", name != "min"
+
+	switch {
+	case schema.Type.Is(openapi3.TypeString):
+		if lower {
+			schema.MinLength = count
+		}
+		if upper {
+			schema.MaxLength = &count
+		}
+	case schema.Type.Is(openapi3.TypeArray):
+		if lower {
+			schema.MinItems = count
+		}
+		if upper {
+			schema.MaxItems = &count
+		}
+	case schema.Type.Is(openapi3.TypeObject):
+		if lower {
+			schema.MinProps = count
+		}
+		if upper {
+			schema.MaxProps = &count
+		}
+	case schema.Type.Is(openapi3.TypeInteger), schema.Type.Is(openapi3.TypeNumber):
+		if lower {
+			schema.Min = &value
+		}
+		if upper {
+			schema.Max = &value
+		}
+	}
+}
+
+// allowEmpty lets a string schema accept the empty string, which omitempty
+// exempts from the field's other rules. JSON Schema cannot exempt a value
+// from minLength, so a lone minLength becomes a pattern.
+func allowEmpty(schema *openapi3.Schema) {
+	if len(schema.Enum) > 0 {
+		schema.Enum = append(schema.Enum, "")
+	}
+	if schema.MinLength > 0 && schema.Pattern == "" {
+		schema.Pattern = fmt.Sprintf(`^[\s\S]{%d,}$`, schema.MinLength)
+		schema.MinLength = 0
+	}
+	if schema.Pattern != "" {
+		schema.Pattern = "^$|" + schema.Pattern
+	}
+}
+
+// jsonName returns the name encoding/json uses for field and whether it is
+// omitted when empty.
+func jsonName(field reflect.StructField) (string, bool) {
+	name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, strings.Contains(","+options+",", ",omitempty,")
+}
+
+// validateRules splits field's validate tag. The validator writes commas
+// inside parameters as 0x2C.
+func validateRules(field reflect.StructField) []string {
+	tag := field.Tag.Get("validate")
+	if tag == "" || tag == "-" {
+		return nil
+	}
+	rules := strings.Split(tag, ",")
+	for i, rule := range rules {
+		rules[i] = strings.ReplaceAll(rule, "0x2C", ",")
+	}
+	return rules
+}
+
+// hasRule reports whether rules apply name to the field itself rather than
+// to its elements.
+func hasRule(rules []string, name string) bool {
+	for _, rule := range rules {
+		if rule == "dive" {
+			return false
+		}
+		if rule == name {
+			return true
+		}
+	}
+	return false
+}
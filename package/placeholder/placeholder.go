@@ -0,0 +1,36 @@
+// Package placeholder substitutes {{name}} variables in template text.
+package placeholder
+
+import "regexp"
+
+// pattern matches a variable such as {{name}} or {{ first_name }}. Names
+// start with a letter and continue with letters, digits or underscores.
+var pattern = regexp.MustCompile(`\{\{\s*([A-Za-z][A-Za-z0-9_]{0,63})\s*\}\}`)
+
+// Names returns the distinct variable names used in texts, in the order they
+// first appear.
+func Names(texts ...string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, m := range pattern.FindAllStringSubmatch(text, -1) {
+			if !seen[m[1]] {
+				seen[m[1]] = true
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// Expand replaces every variable in text with its value from vars. Variables
+// without a value are left as they are; check Names first to reject them.
+func Expand(text string, vars map[string]string) string {
+	return pattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := pattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
@@ -0,0 +1,39 @@
+package placeholder_test
+
+import (
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/placeholder"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	tests := []struct {
+		texts []string
+		want  []string
+	}{
+		{[]string{"Onboard {{name}}", "Order a laptop for {{ name }} in {{team}}"}, []string{"name", "team"}},
+		{[]string{"{{first_name}} {{last_name}}"}, []string{"first_name", "last_name"}},
+		{[]string{"{{}} {{1st}} {name} {{ two words }}"}, nil},
+		{[]string{"no variables"}, nil},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.want, placeholder.Names(tc.texts...), tc.texts)
+	}
+}
+
+func TestExpand(t *testing.T) {
+	vars := map[string]string{"name": "Ada", "team": "{{name}}"}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Onboard {{name}}", "Onboard Ada"},
+		{"{{ name }} joins {{team}}", "Ada joins {{name}}"},
+		{"Ask {{manager}}", "Ask {{manager}}"},
+		{"plain", "plain"},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.want, placeholder.Expand(tc.text, vars), tc.text)
+	}
+}
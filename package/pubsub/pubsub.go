@@ -0,0 +1,57 @@
+// Package pubsub fans events out to in-process subscribers. It backs GraphQL
+// subscriptions, so events only reach subscribers connected to the same
+// process.
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Broker delivers every published event to all current subscribers.
+// Publishing never blocks: a subscriber whose buffer is full misses the
+// event rather than stalling the publisher.
+type Broker[T any] struct {
+	mu          sync.Mutex
+	buffer      int
+	subscribers map[chan T]struct{}
+}
+
+// New constructs a Broker that buffers up to buffer undelivered events per
+// subscriber.
+func New[T any](buffer int) *Broker[T] {
+	return &Broker[T]{
+		buffer:      buffer,
+		subscribers: make(map[chan T]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the events published from now on.
+// The subscription ends, and the channel is closed, once ctx is done.
+func (b *Broker[T]) Subscribe(ctx context.Context) <-chan T {
+	ch := make(chan T, b.buffer)
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+	return ch
+}
+
+// Publish delivers event to every subscriber with room for it.
+func (b *Broker[T]) Publish(event T) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package pubsub_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/package/pubsub"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBrokerDeliversToEverySubscriber(t *testing.T) {
+	broker := pubsub.New[string](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second := broker.Subscribe(ctx), broker.Subscribe(ctx)
+	broker.Publish("created")
+
+	require.Equal(t, "created", <-first)
+	require.Equal(t, "created", <-second)
+}
+
+func TestBrokerDropsEventsForFullSubscribers(t *testing.T) {
+	broker := pubsub.New[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := broker.Subscribe(ctx)
+	broker.Publish(1)
+	broker.Publish(2)
+
+	require.Equal(t, 1, <-events)
+	select {
+	case event := <-events:
+		t.Fatalf("unexpected event %d", event)
+	default:
+	}
+}
+
+func TestBrokerClosesChannelWhenContextEnds(t *testing.T) {
+	broker := pubsub.New[int](1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events := broker.Subscribe(ctx)
+	cancel()
+
+	select {
+	case _, ok := <-events:
+		require.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed")
+	}
+	broker.Publish(1)
+}
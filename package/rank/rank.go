@@ -0,0 +1,121 @@
+// Package rank generates lexicographic ranks for manually ordered records.
+// A rank is a string of base-36 digits (0-9, a-z) read as a fraction, so a
+// new rank always fits between two others and moving a record rewrites only
+// that record. Repeatedly inserting into the same gap lengthens ranks; Spread
+// produces short, evenly spaced ranks to rebalance a collection.
+//
+// Ranks compare byte-wise, so columns holding them must use a binary
+// collation such as PostgreSQL's "C".
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrInvalidRange indicates that no rank lies between the given bounds,
+// either because they are out of order or because one of them is malformed.
+var ErrInvalidRange = errors.New("rank: invalid range")
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// Between returns a rank that sorts after prev and before next. An empty prev
+// means the start of the collection and an empty next its end, so
+// Between("", "") yields the first rank of an empty collection.
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) || (prev != "" && next != "" && prev >= next) {
+		return "", ErrInvalidRange
+	}
+	return midpoint(prev, next), nil
+}
+
+// Spread returns n ascending ranks of equal length with at least one free
+// digit between neighbours, so that the collection can grow in any gap
+// before ranks get longer.
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	width, space := 1, base
+	for space/(n+1) < base {
+		width++
+		space *= base
+	}
+	step := space / (n + 1)
+
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		value := step * (i + 1)
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[value%base]
+			value /= base
+		}
+		ranks[i] = strings.TrimRight(string(buf), "0")
+	}
+	return ranks
+}
+
+// valid reports whether r is empty or a well-formed rank: base-36 digits
+// without a trailing zero, which would make it equal to a shorter rank.
+func valid(r string) bool {
+	if r == "" {
+		return true
+	}
+	if r[len(r)-1] == '0' {
+		return false
+	}
+	for i := 0; i < len(r); i++ {
+		if strings.IndexByte(digits, r[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// midpoint returns a rank strictly between a and b, where b == "" stands for
+// the end of the range. It keeps the common prefix and splits the first
+// differing digit, descending a level when the digits are adjacent.
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(suffix(a, n), b[n:])
+		}
+	}
+
+	low := 0
+	if a != "" {
+		low = strings.IndexByte(digits, a[0])
+	}
+	high := base
+	if b != "" {
+		high = strings.IndexByte(digits, b[0])
+	}
+	if high-low > 1 {
+		return string(digits[(low+high+1)/2])
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(digits[low]) + midpoint(suffix(a, 1), "")
+}
+
+func digitAt(r string, i int) byte {
+	if i < len(r) {
+		return r[i]
+	}
+	return '0'
+}
+
+func suffix(r string, i int) string {
+	if i < len(r) {
+		return r[i:]
+	}
+	return ""
+}
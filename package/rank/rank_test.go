@@ -0,0 +1,73 @@
+package rank_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/lumoshiveacademy/todolist/package/rank"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		prev, next, want string
+	}{
+		{"", "", "i"},
+		{"i", "", "r"},
+		{"", "i", "9"},
+		{"z", "", "zi"},
+		{"", "1", "0i"},
+		{"a", "b", "ai"},
+		{"a1", "a2", "a1i"},
+		{"a", "a1", "a0i"},
+		{"", "0i", "09"},
+	}
+	for _, tc := range tests {
+		got, err := rank.Between(tc.prev, tc.next)
+		require.NoError(t, err, "%q..%q", tc.prev, tc.next)
+		require.Equal(t, tc.want, got, "%q..%q", tc.prev, tc.next)
+	}
+}
+
+func TestBetween_RejectsInvalidRanges(t *testing.T) {
+	for _, tc := range [][2]string{{"b", "a"}, {"a", "a"}, {"a0", ""}, {"A", ""}, {"", "-"}} {
+		_, err := rank.Between(tc[0], tc[1])
+		require.ErrorIs(t, err, rank.ErrInvalidRange, "%q..%q", tc[0], tc[1])
+	}
+}
+
+func TestBetween_RepeatedInsertsStayOrdered(t *testing.T) {
+	ranks := []string{"i"}
+	prev := ""
+	for i := 0; i < 200; i++ {
+		r, err := rank.Between(prev, ranks[0])
+		require.NoError(t, err)
+		ranks = append([]string{r}, ranks...)
+	}
+	last := ranks[len(ranks)-1]
+	for i := 0; i < 200; i++ {
+		r, err := rank.Between(ranks[len(ranks)-2], last)
+		require.NoError(t, err)
+		ranks = append(ranks[:len(ranks)-1], r, last)
+	}
+	require.True(t, sort.StringsAreSorted(ranks))
+	for i := 1; i < len(ranks); i++ {
+		require.NotEqual(t, ranks[i-1], ranks[i])
+	}
+}
+
+func TestSpread(t *testing.T) {
+	require.Equal(t, []string{"9", "i", "r"}, rank.Spread(3))
+	require.Nil(t, rank.Spread(0))
+
+	ranks := rank.Spread(1000)
+	require.Len(t, ranks, 1000)
+	require.True(t, sort.StringsAreSorted(ranks))
+	for i, r := range ranks {
+		require.LessOrEqual(t, len(r), 3)
+		if i > 0 {
+			_, err := rank.Between(ranks[i-1], r)
+			require.NoError(t, err)
+		}
+	}
+}
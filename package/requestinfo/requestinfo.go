@@ -0,0 +1,27 @@
+// Package requestinfo carries request metadata such as the request ID and
+// client IP from the HTTP layer to services.
+package requestinfo
+
+import "context"
+
+type contextKey string
+
+const contextKeyInfo contextKey = "requestInfo"
+
+// Info describes the HTTP request being served.
+type Info struct {
+	RequestID string
+	IP        string
+}
+
+// WithInfo returns a copy of ctx carrying info.
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKeyInfo, info)
+}
+
+// FromContext returns the request metadata stored in ctx. Background work
+// outside a request yields the zero Info.
+func FromContext(ctx context.Context) Info {
+	info, _ := ctx.Value(contextKeyInfo).(Info)
+	return info
+}
@@ -0,0 +1,609 @@
+// Package rrule parses and expands the subset of RFC 5545 recurrence rules
+// used by recurring todo items: FREQ (DAILY, WEEKLY, MONTHLY, YEARLY),
+// INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRule indicates that a rule could not be parsed.
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+// Frequency is the FREQ rule part.
+type Frequency int
+
+// Supported frequencies.
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+// WeekdayNum is a BYDAY entry such as MO, 1MO or -1FR. N is 0 when every
+// matching weekday of the period counts.
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// Rule is a parsed recurrence rule.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+
+	// floatingUntil marks an UNTIL without a UTC designator. Its wall-clock
+	// time is read in the location of the series start.
+	floatingUntil bool
+}
+
+// Parse parses an RRULE value, with or without the "RRULE:" prefix.
+func Parse(value string) (Rule, error) {
+	rule := Rule{Interval: 1, WeekStart: time.Monday}
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	seen := make(map[string]bool)
+	hasFreq := false
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !ok || name == "" || val == "" {
+			return Rule{}, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, name)
+		}
+		seen[name] = true
+		val = strings.ToUpper(strings.TrimSpace(val))
+
+		var err error
+		switch name {
+		case "FREQ":
+			hasFreq = true
+			rule.Freq, err = parseFrequency(val)
+		case "INTERVAL":
+			rule.Interval, err = parsePositive(val)
+		case "COUNT":
+			rule.Count, err = parsePositive(val)
+		case "UNTIL":
+			rule.Until, rule.floatingUntil, err = parseUntil(val)
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseIntList(val, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(val, 1, 12)
+			for _, month := range months {
+				rule.ByMonth = append(rule.ByMonth, time.Month(month))
+			}
+		case "WKST":
+			rule.WeekStart, err = parseWeekday(val)
+		default:
+			return Rule{}, fmt.Errorf("%w: unsupported part %s", ErrInvalidRule, name)
+		}
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %s: %v", ErrInvalidRule, name, err)
+		}
+	}
+
+	if !hasFreq {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+	if seen["COUNT"] && seen["UNTIL"] {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRule)
+	}
+	for _, day := range rule.ByDay {
+		if day.N == 0 {
+			continue
+		}
+		if rule.Freq != Monthly && rule.Freq != Yearly {
+			return Rule{}, fmt.Errorf("%w: BYDAY ordinals need FREQ=MONTHLY or FREQ=YEARLY", ErrInvalidRule)
+		}
+		if rule.Freq == Monthly && (day.N > 5 || day.N < -5) {
+			return Rule{}, fmt.Errorf("%w: BYDAY ordinal %d out of range", ErrInvalidRule, day.N)
+		}
+	}
+	if rule.Freq == Weekly && len(rule.ByMonthDay) > 0 {
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY is not allowed with FREQ=WEEKLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+// String formats the rule in its canonical RRULE form, without prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		if r.floatingUntil {
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+		} else {
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = weekdayNames[day.Weekday]
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			months[i] = int(month)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// In resolves a floating UNTIL to the instant it denotes in loc, the
+// location of the series start. RFC 5545 requires a UTC UNTIL whenever
+// DTSTART carries a time zone, as it does when the rule is exported.
+func (r Rule) In(loc *time.Location) Rule {
+	if r.floatingUntil {
+		r.Until = wallClock(r.Until.Year(), r.Until.Month(), r.Until.Day(),
+			r.Until.Hour(), r.Until.Minute(), r.Until.Second(), loc)
+		r.floatingUntil = false
+	}
+	return r
+}
+
+// Next returns the first occurrence strictly after t of the series that
+// starts at dtstart, or false when the series has ended.
+func (r Rule) Next(dtstart, t time.Time) (time.Time, bool) {
+	it := r.Iterator(dtstart)
+	for {
+		occurrence, ok := it.Next()
+		if !ok {
+			return time.Time{}, false
+		}
+		if occurrence.After(t) {
+			return occurrence, true
+		}
+	}
+}
+
+// maxEmptyDays bounds the search for rules that can never match again, such
+// as BYMONTH=2;BYMONTHDAY=30. The Gregorian calendar repeats every 400 years,
+// so a rule that finds nothing in that time never will, while rare dates such
+// as Mondays that fall on February 29 are still found.
+const maxEmptyDays = 146097
+
+// maxEmptyPeriods returns how many periods in a row may pass without an
+// occurrence before the series is considered over: as many as cover
+// maxEmptyDays.
+func (r Rule) maxEmptyPeriods() int {
+	days := maxEmptyDays
+	switch r.Freq {
+	case Weekly:
+		days /= 7
+	case Monthly:
+		days = 400 * 12
+	case Yearly:
+		days = 400
+	}
+	return max(days/r.Interval, 1)
+}
+
+// Iterator yields the occurrences of a series in chronological order.
+type Iterator struct {
+	rule    Rule
+	start   time.Time
+	until   time.Time
+	period  int
+	empty   int
+	emitted int
+	pending []time.Time
+	done    bool
+}
+
+// Iterator returns an iterator over the series that starts at dtstart.
+// Occurrences keep dtstart's wall-clock time in dtstart's location, so a
+// 09:00 item stays at 09:00 across daylight saving transitions. As in RFC
+// 5545, dtstart itself is always the first occurrence and counts towards
+// COUNT.
+func (r Rule) Iterator(dtstart time.Time) *Iterator {
+	it := &Iterator{rule: r, start: dtstart, pending: []time.Time{dtstart}}
+	if !r.Until.IsZero() {
+		it.until = r.Until
+		if r.floatingUntil {
+			it.until = wallClock(r.Until.Year(), r.Until.Month(), r.Until.Day(),
+				r.Until.Hour(), r.Until.Minute(), r.Until.Second(), dtstart.Location())
+		}
+	}
+	return it
+}
+
+// Next returns the next occurrence, or false when the series has ended.
+func (it *Iterator) Next() (time.Time, bool) {
+	for !it.done {
+		if len(it.pending) == 0 {
+			it.pending = it.rule.expand(it.start, it.period)
+			it.period++
+			if len(it.pending) == 0 {
+				it.empty++
+				if it.empty > it.rule.maxEmptyPeriods() {
+					it.done = true
+				}
+			} else {
+				it.empty = 0
+			}
+			continue
+		}
+
+		occurrence := it.pending[0]
+		it.pending = it.pending[1:]
+		if it.emitted > 0 && !occurrence.After(it.start) {
+			continue
+		}
+		if (!it.until.IsZero() && occurrence.After(it.until)) || (it.rule.Count > 0 && it.emitted >= it.rule.Count) {
+			it.done = true
+			break
+		}
+		it.emitted++
+		return occurrence, true
+	}
+	return time.Time{}, false
+}
+
+// date is a calendar day, free of any time zone.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func newDate(year int, month time.Month, day int) date {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return date{t.Year(), t.Month(), t.Day()}
+}
+
+func (d date) weekday() time.Weekday {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// expand returns the candidate occurrences of the n-th period of the series.
+func (r Rule) expand(start time.Time, n int) []time.Time {
+	year, month, day := start.Date()
+	step := n * r.Interval
+
+	var days []date
+	switch r.Freq {
+	case Daily:
+		candidate := newDate(year, month, day+step)
+		if r.matchesMonth(candidate) && r.matchesMonthDay(candidate) && r.matchesWeekday(candidate) {
+			days = append(days, candidate)
+		}
+	case Weekly:
+		offset := (int(start.Weekday()) - int(r.WeekStart) + 7) % 7
+		first := newDate(year, month, day-offset+7*step)
+		for i := 0; i < 7; i++ {
+			candidate := newDate(first.year, first.month, first.day+i)
+			weekday := candidate.weekday()
+			if len(r.ByDay) == 0 && weekday != start.Weekday() {
+				continue
+			}
+			if r.matchesMonth(candidate) && r.matchesWeekday(candidate) {
+				days = append(days, candidate)
+			}
+		}
+	case Monthly:
+		first := newDate(year, month+time.Month(step), 1)
+		if r.matchesMonth(first) {
+			days = r.monthDays(first.year, first.month, day)
+		}
+	case Yearly:
+		days = r.yearDays(year+step, month, day)
+	}
+
+	hour, minute, second := start.Clock()
+	occurrences := make([]time.Time, len(days))
+	for i, d := range days {
+		occurrences[i] = wallClock(d.year, d.month, d.day, hour, minute, second, start.Location())
+	}
+	return occurrences
+}
+
+func (r Rule) yearDays(year int, month time.Month, day int) []date {
+	if len(r.ByMonth) > 0 {
+		var days []date
+		months := append([]time.Month(nil), r.ByMonth...)
+		sort.Slice(months, func(i, j int) bool { return months[i] < months[j] })
+		for _, m := range months {
+			days = append(days, r.monthDays(year, m, day)...)
+		}
+		return days
+	}
+
+	hasOrdinal := false
+	for _, byDay := range r.ByDay {
+		hasOrdinal = hasOrdinal || byDay.N != 0
+	}
+	if hasOrdinal && len(r.ByMonthDay) == 0 {
+		// Ordinals such as 20MO count through the whole year.
+		first := newDate(year, time.January, 1)
+		last := newDate(year, time.December, 31)
+		return weekdaysBetween(r.ByDay, first, last)
+	}
+	if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+		var days []date
+		for m := time.January; m <= time.December; m++ {
+			days = append(days, r.monthDays(year, m, day)...)
+		}
+		return days
+	}
+	if day > daysIn(year, month) {
+		return nil
+	}
+	return []date{{year, month, day}}
+}
+
+// monthDays returns the days of the month selected by BYMONTHDAY and BYDAY,
+// or the series' own day of month when neither is set. Days that do not
+// exist in the month, such as the 31st of April, are skipped.
+func (r Rule) monthDays(year int, month time.Month, day int) []date {
+	length := daysIn(year, month)
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if day > length {
+			return nil
+		}
+		return []date{{year, month, day}}
+	}
+
+	var byMonthDay map[int]bool
+	if len(r.ByMonthDay) > 0 {
+		byMonthDay = make(map[int]bool)
+		for _, monthDay := range r.ByMonthDay {
+			if monthDay < 0 {
+				monthDay = length + monthDay + 1
+			}
+			if monthDay >= 1 && monthDay <= length {
+				byMonthDay[monthDay] = true
+			}
+		}
+	}
+	var byDay map[int]bool
+	if len(r.ByDay) > 0 {
+		byDay = make(map[int]bool)
+		for _, d := range weekdaysBetween(r.ByDay, date{year, month, 1}, date{year, month, length}) {
+			byDay[d.day] = true
+		}
+	}
+
+	var days []date
+	for d := 1; d <= length; d++ {
+		if (byMonthDay == nil || byMonthDay[d]) && (byDay == nil || byDay[d]) {
+			days = append(days, date{year, month, d})
+		}
+	}
+	return days
+}
+
+// weekdaysBetween expands BYDAY entries within the inclusive range
+// [first, last], which lies within one year, and returns them sorted.
+func weekdaysBetween(byDay []WeekdayNum, first, last date) []date {
+	firstTime := time.Date(first.year, first.month, first.day, 0, 0, 0, 0, time.UTC)
+	lastTime := time.Date(last.year, last.month, last.day, 0, 0, 0, 0, time.UTC)
+	total := int(lastTime.Sub(firstTime).Hours()/24) + 1
+
+	selected := make(map[int]bool)
+	for _, entry := range byDay {
+		var matches []int
+		for i := 0; i < total; i++ {
+			if firstTime.AddDate(0, 0, i).Weekday() == entry.Weekday {
+				matches = append(matches, i)
+			}
+		}
+		switch {
+		case entry.N == 0:
+			for _, i := range matches {
+				selected[i] = true
+			}
+		case entry.N > 0 && entry.N <= len(matches):
+			selected[matches[entry.N-1]] = true
+		case entry.N < 0 && -entry.N <= len(matches):
+			selected[matches[len(matches)+entry.N]] = true
+		}
+	}
+
+	offsets := make([]int, 0, len(selected))
+	for i := range selected {
+		offsets = append(offsets, i)
+	}
+	sort.Ints(offsets)
+	days := make([]date, len(offsets))
+	for i, offset := range offsets {
+		days[i] = newDate(first.year, first.month, first.day+offset)
+	}
+	return days
+}
+
+func (r Rule) matchesMonth(d date) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, month := range r.ByMonth {
+		if month == d.month {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesMonthDay(d date) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := daysIn(d.year, d.month)
+	for _, monthDay := range r.ByMonthDay {
+		if monthDay == d.day || length+monthDay+1 == d.day {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesWeekday(d date) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	weekday := d.weekday()
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// wallClock returns the instant the given wall-clock time occurs in loc.
+// Times skipped by a daylight saving gap are read with the offset in effect
+// before the gap, and repeated times resolve to their first occurrence, as
+// RFC 5545 prescribes.
+func wallClock(year int, month time.Month, day, hour, minute, second int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, minute, second, 0, loc)
+	if t.Hour() != hour || t.Minute() != minute {
+		_, offset := t.Add(-3 * time.Hour).Zone()
+		return time.Date(year, month, day, hour, minute, second, 0, time.UTC).
+			Add(-time.Duration(offset) * time.Second).
+			In(loc)
+	}
+	if earlier := t.Add(-time.Hour); earlier.Hour() == hour && earlier.Minute() == minute && earlier.Day() == day {
+		return earlier
+	}
+	return t
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	for frequency, name := range frequencyNames {
+		if name == value {
+			return frequency, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported frequency %q", value)
+}
+
+func parsePositive(value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return n, nil
+}
+
+func parseUntil(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse("20060102T150405", value); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse("20060102", value); err == nil {
+		// A date-only UNTIL includes the whole day.
+		return t.Add(24*time.Hour - time.Second), true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%q is not a date or date-time", value)
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for weekday, name := range weekdayNames {
+		if name == value {
+			return weekday, nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a weekday", value)
+}
+
+func parseByDay(value string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, entry := range strings.Split(value, ",") {
+		if len(entry) < 2 {
+			return nil, fmt.Errorf("%q is not a weekday", entry)
+		}
+		weekday, err := parseWeekday(entry[len(entry)-2:])
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		if prefix := entry[:len(entry)-2]; prefix != "" {
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n > 53 || n < -53 {
+				return nil, fmt.Errorf("%q has an invalid ordinal", entry)
+			}
+		}
+		days = append(days, WeekdayNum{N: n, Weekday: weekday})
+	}
+	return days, nil
+}
+
+func parseIntList(value string, min, max int) ([]int, error) {
+	var values []int
+	for _, entry := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(entry)
+		if err != nil || n < min || n > max || n == 0 {
+			return nil, fmt.Errorf("%q is out of range", entry)
+		}
+		values = append(values, n)
+	}
+	return values, nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
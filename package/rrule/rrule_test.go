@@ -0,0 +1,299 @@
+package rrule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/package/rrule"
+	"github.com/stretchr/testify/require"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func take(t *testing.T, value string, dtstart time.Time, n int) []time.Time {
+	t.Helper()
+	rule, err := rrule.Parse(value)
+	require.NoError(t, err)
+
+	var occurrences []time.Time
+	it := rule.Iterator(dtstart)
+	for len(occurrences) < n {
+		occurrence, ok := it.Next()
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences
+}
+
+func formatAll(occurrences []time.Time) []string {
+	formatted := make([]string, len(occurrences))
+	for i, occurrence := range occurrences {
+		formatted[i] = occurrence.Format("2006-01-02 15:04 MST")
+	}
+	return formatted
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	for _, value := range []string{
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE,FR;WKST=SU",
+		"FREQ=MONTHLY;COUNT=6;BYDAY=-1FR",
+		"FREQ=YEARLY;UNTIL=20301231T230000Z;BYMONTHDAY=29;BYMONTH=2",
+	} {
+		rule, err := rrule.Parse("RRULE:" + value)
+		require.NoError(t, err, value)
+		require.Equal(t, value, rule.String())
+	}
+}
+
+func TestRule_InResolvesFloatingUntil(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+
+	rule, err := rrule.Parse("FREQ=DAILY;UNTIL=20261026T090000")
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=DAILY;UNTIL=20261026T080000Z", rule.In(berlin).String())
+	require.Equal(t, "FREQ=DAILY;UNTIL=20261026T090000", rule.String())
+
+	rule, err = rrule.Parse("FREQ=DAILY;UNTIL=20261026T090000Z")
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=DAILY;UNTIL=20261026T090000Z", rule.In(berlin).String())
+}
+
+func TestParse_Rejects(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=3;UNTIL=20270101T000000Z",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=6MO",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=DAILY;BYHOUR=9",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		_, err := rrule.Parse(value)
+		require.ErrorIs(t, err, rrule.ErrInvalidRule, value)
+	}
+}
+
+func TestIterator_WeeklyKeepsWallClockAcrossFallBack(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	dtstart := time.Date(2026, time.October, 19, 9, 0, 0, 0, newYork)
+
+	occurrences := take(t, "FREQ=WEEKLY;BYDAY=MO", dtstart, 4)
+
+	require.Equal(t, []string{
+		"2026-10-19 09:00 EDT",
+		"2026-10-26 09:00 EDT",
+		"2026-11-02 09:00 EST",
+		"2026-11-09 09:00 EST",
+	}, formatAll(occurrences))
+	require.Equal(t, 7*24*time.Hour+time.Hour, occurrences[2].Sub(occurrences[1]))
+}
+
+func TestIterator_DailySkippedTimeUsesOffsetBeforeGap(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	dtstart := time.Date(2026, time.March, 7, 2, 30, 0, 0, newYork)
+
+	occurrences := take(t, "FREQ=DAILY", dtstart, 3)
+
+	// 02:30 does not exist on 8 March 2026; RFC 5545 reads it with the
+	// pre-transition offset, which lands on 03:30 daylight time.
+	require.Equal(t, []string{
+		"2026-03-07 02:30 EST",
+		"2026-03-08 03:30 EDT",
+		"2026-03-09 02:30 EDT",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_DailyRepeatedTimeUsesFirstOccurrence(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	dtstart := time.Date(2026, time.October, 31, 1, 30, 0, 0, newYork)
+
+	occurrences := take(t, "FREQ=DAILY;COUNT=3", dtstart, 10)
+
+	require.Equal(t, []string{
+		"2026-10-31 01:30 EDT",
+		"2026-11-01 01:30 EDT",
+		"2026-11-02 01:30 EST",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_MonthlyLastFridayAcrossSpringForwardInEurope(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	dtstart := time.Date(2026, time.February, 27, 18, 0, 0, 0, berlin)
+
+	occurrences := take(t, "FREQ=MONTHLY;BYDAY=-1FR", dtstart, 3)
+
+	require.Equal(t, []string{
+		"2026-02-27 18:00 CET",
+		"2026-03-27 18:00 CET",
+		"2026-04-24 18:00 CEST",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_MonthlySkipsMissingDays(t *testing.T) {
+	dtstart := time.Date(2026, time.January, 31, 8, 0, 0, 0, time.UTC)
+
+	occurrences := take(t, "FREQ=MONTHLY;COUNT=4", dtstart, 10)
+
+	require.Equal(t, []string{
+		"2026-01-31 08:00 UTC",
+		"2026-03-31 08:00 UTC",
+		"2026-05-31 08:00 UTC",
+		"2026-07-31 08:00 UTC",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_MonthlyLastDayOfMonth(t *testing.T) {
+	dtstart := time.Date(2026, time.January, 31, 8, 0, 0, 0, time.UTC)
+
+	occurrences := take(t, "FREQ=MONTHLY;BYMONTHDAY=-1", dtstart, 3)
+
+	require.Equal(t, []string{
+		"2026-01-31 08:00 UTC",
+		"2026-02-28 08:00 UTC",
+		"2026-03-31 08:00 UTC",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_BiweeklyRespectsWeekStart(t *testing.T) {
+	dtstart := time.Date(2026, time.October, 18, 10, 0, 0, 0, time.UTC) // a Sunday
+
+	monday := take(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU", dtstart, 4)
+	sunday := take(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU", dtstart, 4)
+
+	require.Equal(t, []string{
+		"2026-10-18 10:00 UTC",
+		"2026-10-27 10:00 UTC",
+		"2026-11-01 10:00 UTC",
+		"2026-11-10 10:00 UTC",
+	}, formatAll(monday))
+	require.Equal(t, []string{
+		"2026-10-18 10:00 UTC",
+		"2026-10-20 10:00 UTC",
+		"2026-11-01 10:00 UTC",
+		"2026-11-03 10:00 UTC",
+	}, formatAll(sunday))
+}
+
+func TestIterator_WeekStartExampleFromRFC5545(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	dtstart := time.Date(1997, time.August, 5, 9, 0, 0, 0, newYork)
+
+	monday := take(t, "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO", dtstart, 10)
+	sunday := take(t, "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU", dtstart, 10)
+
+	require.Equal(t, []string{"1997-08-05 09:00 EDT", "1997-08-10 09:00 EDT", "1997-08-19 09:00 EDT", "1997-08-24 09:00 EDT"}, formatAll(monday))
+	require.Equal(t, []string{"1997-08-05 09:00 EDT", "1997-08-17 09:00 EDT", "1997-08-19 09:00 EDT", "1997-08-31 09:00 EDT"}, formatAll(sunday))
+}
+
+func TestIterator_YearlyLeapDay(t *testing.T) {
+	dtstart := time.Date(2028, time.February, 29, 12, 0, 0, 0, time.UTC)
+
+	occurrences := take(t, "FREQ=YEARLY", dtstart, 3)
+
+	require.Equal(t, []string{
+		"2028-02-29 12:00 UTC",
+		"2032-02-29 12:00 UTC",
+		"2036-02-29 12:00 UTC",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_YearlyNthWeekdayOfMonth(t *testing.T) {
+	newYork := mustLocation(t, "America/New_York")
+	dtstart := time.Date(2026, time.November, 26, 15, 0, 0, 0, newYork)
+
+	occurrences := take(t, "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", dtstart, 3)
+
+	require.Equal(t, []string{
+		"2026-11-26 15:00 EST",
+		"2027-11-25 15:00 EST",
+		"2028-11-23 15:00 EST",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_UntilIsInclusiveAndFloatingUntilUsesLocation(t *testing.T) {
+	berlin := mustLocation(t, "Europe/Berlin")
+	dtstart := time.Date(2026, time.October, 23, 9, 0, 0, 0, berlin)
+
+	occurrences := take(t, "FREQ=DAILY;UNTIL=20261026T090000", dtstart, 10)
+
+	require.Equal(t, []string{
+		"2026-10-23 09:00 CEST",
+		"2026-10-24 09:00 CEST",
+		"2026-10-25 09:00 CET",
+		"2026-10-26 09:00 CET",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_DtstartCountsAsFirstOccurrence(t *testing.T) {
+	dtstart := time.Date(2026, time.October, 21, 9, 0, 0, 0, time.UTC) // a Wednesday
+
+	occurrences := take(t, "FREQ=WEEKLY;BYDAY=MO;COUNT=3", dtstart, 10)
+
+	require.Equal(t, []string{
+		"2026-10-21 09:00 UTC",
+		"2026-10-26 09:00 UTC",
+		"2026-11-02 09:00 UTC",
+	}, formatAll(occurrences))
+}
+
+func TestIterator_ImpossibleRuleEnds(t *testing.T) {
+	dtstart := time.Date(2026, time.January, 30, 9, 0, 0, 0, time.UTC)
+
+	occurrences := take(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", dtstart, 5)
+
+	require.Len(t, occurrences, 1)
+}
+
+func TestIterator_DailyImpossibleRuleEnds(t *testing.T) {
+	dtstart := time.Date(2026, time.January, 30, 9, 0, 0, 0, time.UTC)
+
+	occurrences := take(t, "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=30", dtstart, 5)
+
+	require.Len(t, occurrences, 1)
+}
+
+func TestIterator_RareDatesAreFoundYearsLater(t *testing.T) {
+	dtstart := time.Date(2025, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+	leapDays := take(t, "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29", dtstart, 3)
+	require.Equal(t, []string{
+		"2025-03-01 09:00 UTC",
+		"2028-02-29 09:00 UTC",
+		"2032-02-29 09:00 UTC",
+	}, formatAll(leapDays))
+
+	// February 29 falls on a Monday in 2044, then not again until 2072.
+	leapMondays := take(t, "FREQ=DAILY;BYDAY=MO;BYMONTH=2;BYMONTHDAY=29", dtstart, 3)
+	require.Equal(t, []string{
+		"2025-03-01 09:00 UTC",
+		"2044-02-29 09:00 UTC",
+		"2072-02-29 09:00 UTC",
+	}, formatAll(leapMondays))
+}
+
+func TestRule_Next(t *testing.T) {
+	rule, err := rrule.Parse("FREQ=DAILY;INTERVAL=3")
+	require.NoError(t, err)
+	dtstart := time.Date(2026, time.October, 1, 7, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(dtstart, time.Date(2026, time.October, 8, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	require.Equal(t, time.Date(2026, time.October, 10, 7, 0, 0, 0, time.UTC), next)
+
+	ended, err := rrule.Parse("FREQ=DAILY;COUNT=2")
+	require.NoError(t, err)
+	_, ok = ended.Next(dtstart, dtstart.Add(24*time.Hour))
+	require.False(t, ok)
+}
@@ -0,0 +1,29 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+type contextKey string
+
+const contextKeyWorkspaceID contextKey = "workspaceID"
+
+// ErrWorkspaceRequired indicates that an operation was attempted without an active workspace.
+var ErrWorkspaceRequired = errors.New("workspace is required")
+
+// WithWorkspaceID returns a copy of ctx carrying the active workspace.
+func WithWorkspaceID(ctx context.Context, id uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKeyWorkspaceID, id)
+}
+
+// WorkspaceIDFromContext returns the active workspace stored in ctx, if any.
+func WorkspaceIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	id, ok := ctx.Value(contextKeyWorkspaceID).(uuid.UUID)
+	if !ok || id == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return id, true
+}
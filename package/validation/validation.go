@@ -0,0 +1,64 @@
+// Package validation builds the request validator shared by all handlers and
+// registers the project's custom rules.
+package validation
+
+import (
+	"net/url"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/mention"
+	"github.com/lumoshiveacademy/todolist/package/rrule"
+)
+
+// New returns a validator with the custom rules registered:
+//
+//   - todo_priority: one of model.Priorities
+//   - todo_status: one of model.Statuses
+//   - future: a time.Time strictly after the current time
+//   - rrule: an RFC 5545 recurrence rule understood by package rrule
+//   - username: a handle that package mention can find in @mentions
+//   - https_url: an absolute URL with the https scheme
+func New() *validator.Validate {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	// Registration only fails for empty tags or nil functions.
+	_ = validate.RegisterValidation("todo_priority", oneOf(model.Priorities))
+	_ = validate.RegisterValidation("todo_status", oneOf(model.Statuses))
+	_ = validate.RegisterValidation("future", future)
+	_ = validate.RegisterValidation("rrule", recurrence)
+	_ = validate.RegisterValidation("username", username)
+	_ = validate.RegisterValidation("https_url", httpsURL)
+	return validate
+}
+
+func oneOf(values []string) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		for _, allowed := range values {
+			if value == allowed {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func future(fl validator.FieldLevel) bool {
+	at, ok := fl.Field().Interface().(time.Time)
+	return ok && at.After(time.Now())
+}
+
+func recurrence(fl validator.FieldLevel) bool {
+	_, err := rrule.Parse(fl.Field().String())
+	return err == nil
+}
+
+func username(fl validator.FieldLevel) bool {
+	return mention.ValidUsername(fl.Field().String())
+}
+
+func httpsURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
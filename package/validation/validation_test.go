@@ -0,0 +1,38 @@
+package validation_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_Enums(t *testing.T) {
+	validate := validation.New()
+
+	require.NoError(t, validate.Struct(model.UpdateTodoItemRequest{Title: "Milk", Status: model.StatusInProgress, Priority: model.PriorityUrgent}))
+	require.NoError(t, validate.Struct(model.UpdateTodoItemRequest{Title: "Milk"}))
+	require.Error(t, validate.Struct(model.UpdateTodoItemRequest{Title: "Milk", Status: "blocked"}))
+	require.Error(t, validate.Struct(model.UpdateTodoItemRequest{Title: "Milk", Priority: "critical"}))
+}
+
+func TestNew_FutureDueDate(t *testing.T) {
+	validate := validation.New()
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+
+	require.Error(t, validate.Struct(model.CreateTodoItemRequest{Title: "Milk", DueAt: &past}))
+	require.NoError(t, validate.Struct(model.CreateTodoItemRequest{Title: "Milk", DueAt: &future}))
+	require.NoError(t, validate.Struct(model.CreateTodoItemRequest{Title: "Milk"}))
+}
+
+func TestNew_Recurrence(t *testing.T) {
+	validate := validation.New()
+	future := time.Now().Add(time.Hour)
+
+	require.NoError(t, validate.Struct(model.CreateTodoItemRequest{Title: "Bins", DueAt: &future, Recurrence: "FREQ=WEEKLY;BYDAY=MO", Timezone: "Europe/Berlin"}))
+	require.Error(t, validate.Struct(model.CreateTodoItemRequest{Title: "Bins", DueAt: &future, Recurrence: "FREQ=SECONDLY"}))
+	require.Error(t, validate.Struct(model.CreateTodoItemRequest{Title: "Bins", Recurrence: "FREQ=DAILY"}), "a series needs a due date")
+}
@@ -0,0 +1,712 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: todolist/v1/todolist.proto
+
+package todolistv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TodoListService_CreateTodoList_FullMethodName      = "/todolist.v1.TodoListService/CreateTodoList"
+	TodoListService_ListTodoLists_FullMethodName       = "/todolist.v1.TodoListService/ListTodoLists"
+	TodoListService_ListTemplates_FullMethodName       = "/todolist.v1.TodoListService/ListTemplates"
+	TodoListService_GetTodoList_FullMethodName         = "/todolist.v1.TodoListService/GetTodoList"
+	TodoListService_UpdateTodoList_FullMethodName      = "/todolist.v1.TodoListService/UpdateTodoList"
+	TodoListService_DeleteTodoList_FullMethodName      = "/todolist.v1.TodoListService/DeleteTodoList"
+	TodoListService_MoveTodoList_FullMethodName        = "/todolist.v1.TodoListService/MoveTodoList"
+	TodoListService_DuplicateTodoList_FullMethodName   = "/todolist.v1.TodoListService/DuplicateTodoList"
+	TodoListService_MarkTemplate_FullMethodName        = "/todolist.v1.TodoListService/MarkTemplate"
+	TodoListService_UnmarkTemplate_FullMethodName      = "/todolist.v1.TodoListService/UnmarkTemplate"
+	TodoListService_InstantiateTemplate_FullMethodName = "/todolist.v1.TodoListService/InstantiateTemplate"
+	TodoListService_ArchiveTodoList_FullMethodName     = "/todolist.v1.TodoListService/ArchiveTodoList"
+	TodoListService_UnarchiveTodoList_FullMethodName   = "/todolist.v1.TodoListService/UnarchiveTodoList"
+	TodoListService_ListRevisions_FullMethodName       = "/todolist.v1.TodoListService/ListRevisions"
+	TodoListService_GetRevision_FullMethodName         = "/todolist.v1.TodoListService/GetRevision"
+	TodoListService_RestoreRevision_FullMethodName     = "/todolist.v1.TodoListService/RestoreRevision"
+)
+
+// TodoListServiceClient is the client API for TodoListService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// TodoListService manages the todo lists of the active workspace. Callers
+// authenticate with the same bearer tokens and API keys as the REST API, sent
+// as "authorization" metadata, and select the workspace with
+// "x-workspace-id" unless the token carries one.
+type TodoListServiceClient interface {
+	CreateTodoList(ctx context.Context, in *CreateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	ListTodoLists(ctx context.Context, in *ListTodoListsRequest, opts ...grpc.CallOption) (*ListTodoListsResponse, error)
+	// ListTemplates lists the workspace's template library.
+	ListTemplates(ctx context.Context, in *ListTodoListsRequest, opts ...grpc.CallOption) (*ListTodoListsResponse, error)
+	GetTodoList(ctx context.Context, in *GetTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	UpdateTodoList(ctx context.Context, in *UpdateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	DeleteTodoList(ctx context.Context, in *DeleteTodoListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MoveTodoList(ctx context.Context, in *MoveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	DuplicateTodoList(ctx context.Context, in *DuplicateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	// MarkTemplate adds a list to the template library.
+	MarkTemplate(ctx context.Context, in *MarkTemplateRequest, opts ...grpc.CallOption) (*TodoList, error)
+	// UnmarkTemplate takes a list out of the template library.
+	UnmarkTemplate(ctx context.Context, in *UnmarkTemplateRequest, opts ...grpc.CallOption) (*TodoList, error)
+	InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest, opts ...grpc.CallOption) (*TodoList, error)
+	ArchiveTodoList(ctx context.Context, in *ArchiveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	UnarchiveTodoList(ctx context.Context, in *UnarchiveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error)
+	// ListRevisions lists the revisions of a list, newest first.
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*TodoListRevision, error)
+	// RestoreRevision copies a revision back onto the list.
+	RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*TodoList, error)
+}
+
+type todoListServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTodoListServiceClient(cc grpc.ClientConnInterface) TodoListServiceClient {
+	return &todoListServiceClient{cc}
+}
+
+func (c *todoListServiceClient) CreateTodoList(ctx context.Context, in *CreateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_CreateTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) ListTodoLists(ctx context.Context, in *ListTodoListsRequest, opts ...grpc.CallOption) (*ListTodoListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTodoListsResponse)
+	err := c.cc.Invoke(ctx, TodoListService_ListTodoLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) ListTemplates(ctx context.Context, in *ListTodoListsRequest, opts ...grpc.CallOption) (*ListTodoListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTodoListsResponse)
+	err := c.cc.Invoke(ctx, TodoListService_ListTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) GetTodoList(ctx context.Context, in *GetTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_GetTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) UpdateTodoList(ctx context.Context, in *UpdateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_UpdateTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) DeleteTodoList(ctx context.Context, in *DeleteTodoListRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TodoListService_DeleteTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) MoveTodoList(ctx context.Context, in *MoveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_MoveTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) DuplicateTodoList(ctx context.Context, in *DuplicateTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_DuplicateTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) MarkTemplate(ctx context.Context, in *MarkTemplateRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_MarkTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) UnmarkTemplate(ctx context.Context, in *UnmarkTemplateRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_UnmarkTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_InstantiateTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) ArchiveTodoList(ctx context.Context, in *ArchiveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_ArchiveTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) UnarchiveTodoList(ctx context.Context, in *UnarchiveTodoListRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_UnarchiveTodoList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, TodoListService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) GetRevision(ctx context.Context, in *GetRevisionRequest, opts ...grpc.CallOption) (*TodoListRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoListRevision)
+	err := c.cc.Invoke(ctx, TodoListService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *todoListServiceClient) RestoreRevision(ctx context.Context, in *RestoreRevisionRequest, opts ...grpc.CallOption) (*TodoList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TodoList)
+	err := c.cc.Invoke(ctx, TodoListService_RestoreRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TodoListServiceServer is the server API for TodoListService service.
+// All implementations must embed UnimplementedTodoListServiceServer
+// for forward compatibility.
+//
+// TodoListService manages the todo lists of the active workspace. Callers
+// authenticate with the same bearer tokens and API keys as the REST API, sent
+// as "authorization" metadata, and select the workspace with
+// "x-workspace-id" unless the token carries one.
+type TodoListServiceServer interface {
+	CreateTodoList(context.Context, *CreateTodoListRequest) (*TodoList, error)
+	ListTodoLists(context.Context, *ListTodoListsRequest) (*ListTodoListsResponse, error)
+	// ListTemplates lists the workspace's template library.
+	ListTemplates(context.Context, *ListTodoListsRequest) (*ListTodoListsResponse, error)
+	GetTodoList(context.Context, *GetTodoListRequest) (*TodoList, error)
+	UpdateTodoList(context.Context, *UpdateTodoListRequest) (*TodoList, error)
+	DeleteTodoList(context.Context, *DeleteTodoListRequest) (*emptypb.Empty, error)
+	MoveTodoList(context.Context, *MoveTodoListRequest) (*TodoList, error)
+	DuplicateTodoList(context.Context, *DuplicateTodoListRequest) (*TodoList, error)
+	// MarkTemplate adds a list to the template library.
+	MarkTemplate(context.Context, *MarkTemplateRequest) (*TodoList, error)
+	// UnmarkTemplate takes a list out of the template library.
+	UnmarkTemplate(context.Context, *UnmarkTemplateRequest) (*TodoList, error)
+	InstantiateTemplate(context.Context, *InstantiateTemplateRequest) (*TodoList, error)
+	ArchiveTodoList(context.Context, *ArchiveTodoListRequest) (*TodoList, error)
+	UnarchiveTodoList(context.Context, *UnarchiveTodoListRequest) (*TodoList, error)
+	// ListRevisions lists the revisions of a list, newest first.
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *GetRevisionRequest) (*TodoListRevision, error)
+	// RestoreRevision copies a revision back onto the list.
+	RestoreRevision(context.Context, *RestoreRevisionRequest) (*TodoList, error)
+	mustEmbedUnimplementedTodoListServiceServer()
+}
+
+// UnimplementedTodoListServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTodoListServiceServer struct{}
+
+func (UnimplementedTodoListServiceServer) CreateTodoList(context.Context, *CreateTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) ListTodoLists(context.Context, *ListTodoListsRequest) (*ListTodoListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTodoLists not implemented")
+}
+func (UnimplementedTodoListServiceServer) ListTemplates(context.Context, *ListTodoListsRequest) (*ListTodoListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTemplates not implemented")
+}
+func (UnimplementedTodoListServiceServer) GetTodoList(context.Context, *GetTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) UpdateTodoList(context.Context, *UpdateTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) DeleteTodoList(context.Context, *DeleteTodoListRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) MoveTodoList(context.Context, *MoveTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) DuplicateTodoList(context.Context, *DuplicateTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DuplicateTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) MarkTemplate(context.Context, *MarkTemplateRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkTemplate not implemented")
+}
+func (UnimplementedTodoListServiceServer) UnmarkTemplate(context.Context, *UnmarkTemplateRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmarkTemplate not implemented")
+}
+func (UnimplementedTodoListServiceServer) InstantiateTemplate(context.Context, *InstantiateTemplateRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InstantiateTemplate not implemented")
+}
+func (UnimplementedTodoListServiceServer) ArchiveTodoList(context.Context, *ArchiveTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) UnarchiveTodoList(context.Context, *UnarchiveTodoListRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveTodoList not implemented")
+}
+func (UnimplementedTodoListServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedTodoListServiceServer) GetRevision(context.Context, *GetRevisionRequest) (*TodoListRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedTodoListServiceServer) RestoreRevision(context.Context, *RestoreRevisionRequest) (*TodoList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreRevision not implemented")
+}
+func (UnimplementedTodoListServiceServer) mustEmbedUnimplementedTodoListServiceServer() {}
+func (UnimplementedTodoListServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeTodoListServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TodoListServiceServer will
+// result in compilation errors.
+type UnsafeTodoListServiceServer interface {
+	mustEmbedUnimplementedTodoListServiceServer()
+}
+
+func RegisterTodoListServiceServer(s grpc.ServiceRegistrar, srv TodoListServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTodoListServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TodoListService_ServiceDesc, srv)
+}
+
+func _TodoListService_CreateTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).CreateTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_CreateTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).CreateTodoList(ctx, req.(*CreateTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_ListTodoLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodoListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).ListTodoLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_ListTodoLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).ListTodoLists(ctx, req.(*ListTodoListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_ListTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTodoListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).ListTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_ListTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).ListTemplates(ctx, req.(*ListTodoListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_GetTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).GetTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_GetTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).GetTodoList(ctx, req.(*GetTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_UpdateTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).UpdateTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_UpdateTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).UpdateTodoList(ctx, req.(*UpdateTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_DeleteTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).DeleteTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_DeleteTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).DeleteTodoList(ctx, req.(*DeleteTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_MoveTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).MoveTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_MoveTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).MoveTodoList(ctx, req.(*MoveTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_DuplicateTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuplicateTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).DuplicateTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_DuplicateTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).DuplicateTodoList(ctx, req.(*DuplicateTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_MarkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).MarkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_MarkTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).MarkTemplate(ctx, req.(*MarkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_UnmarkTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmarkTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).UnmarkTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_UnmarkTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).UnmarkTemplate(ctx, req.(*UnmarkTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_InstantiateTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InstantiateTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).InstantiateTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_InstantiateTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).InstantiateTemplate(ctx, req.(*InstantiateTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_ArchiveTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).ArchiveTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_ArchiveTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).ArchiveTodoList(ctx, req.(*ArchiveTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_UnarchiveTodoList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveTodoListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).UnarchiveTodoList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_UnarchiveTodoList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).UnarchiveTodoList(ctx, req.(*UnarchiveTodoListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).GetRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_GetRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).GetRevision(ctx, req.(*GetRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TodoListService_RestoreRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRevisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TodoListServiceServer).RestoreRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TodoListService_RestoreRevision_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TodoListServiceServer).RestoreRevision(ctx, req.(*RestoreRevisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TodoListService_ServiceDesc is the grpc.ServiceDesc for TodoListService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TodoListService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "todolist.v1.TodoListService",
+	HandlerType: (*TodoListServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTodoList",
+			Handler:    _TodoListService_CreateTodoList_Handler,
+		},
+		{
+			MethodName: "ListTodoLists",
+			Handler:    _TodoListService_ListTodoLists_Handler,
+		},
+		{
+			MethodName: "ListTemplates",
+			Handler:    _TodoListService_ListTemplates_Handler,
+		},
+		{
+			MethodName: "GetTodoList",
+			Handler:    _TodoListService_GetTodoList_Handler,
+		},
+		{
+			MethodName: "UpdateTodoList",
+			Handler:    _TodoListService_UpdateTodoList_Handler,
+		},
+		{
+			MethodName: "DeleteTodoList",
+			Handler:    _TodoListService_DeleteTodoList_Handler,
+		},
+		{
+			MethodName: "MoveTodoList",
+			Handler:    _TodoListService_MoveTodoList_Handler,
+		},
+		{
+			MethodName: "DuplicateTodoList",
+			Handler:    _TodoListService_DuplicateTodoList_Handler,
+		},
+		{
+			MethodName: "MarkTemplate",
+			Handler:    _TodoListService_MarkTemplate_Handler,
+		},
+		{
+			MethodName: "UnmarkTemplate",
+			Handler:    _TodoListService_UnmarkTemplate_Handler,
+		},
+		{
+			MethodName: "InstantiateTemplate",
+			Handler:    _TodoListService_InstantiateTemplate_Handler,
+		},
+		{
+			MethodName: "ArchiveTodoList",
+			Handler:    _TodoListService_ArchiveTodoList_Handler,
+		},
+		{
+			MethodName: "UnarchiveTodoList",
+			Handler:    _TodoListService_UnarchiveTodoList_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _TodoListService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _TodoListService_GetRevision_Handler,
+		},
+		{
+			MethodName: "RestoreRevision",
+			Handler:    _TodoListService_RestoreRevision_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "todolist/v1/todolist.proto",
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+)
+
+// ErrAPIKeyNotFound indicates that the API key record does not exist.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKeyRepository defines database operations for API keys. Management
+// operations are scoped to the owning user; FindByPrefix serves
+// authentication, where the owner is not yet known.
+type APIKeyRepository interface {
+	Create(ctx context.Context, apiKey *model.APIKey) error
+	FindByID(ctx context.Context, userID string, id uuid.UUID) (*model.APIKey, error)
+	FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error)
+	FindByUserID(ctx context.Context, userID string) ([]model.APIKey, error)
+	Update(ctx context.Context, apiKey *model.APIKey) error
+	Delete(ctx context.Context, userID string, id uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository constructs an APIKeyRepository backed by GORM.
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey *model.APIKey) error {
+	if err := r.db.WithContext(ctx).Create(apiKey).Error; err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, userID string, id uuid.UUID) (*model.APIKey, error) {
+	var apiKey model.APIKey
+	if err := r.db.WithContext(ctx).First(&apiKey, "id = ? AND user_id = ?", id, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("find api key: %w", err)
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	var apiKey model.APIKey
+	if err := r.db.WithContext(ctx).First(&apiKey, "prefix = ?", prefix).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("find api key by prefix: %w", err)
+	}
+	return &apiKey, nil
+}
+
+func (r *apiKeyRepository) FindByUserID(ctx context.Context, userID string) ([]model.APIKey, error) {
+	var apiKeys []model.APIKey
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&apiKeys).Error; err != nil {
+		return nil, fmt.Errorf("find api keys: %w", err)
+	}
+	return apiKeys, nil
+}
+
+func (r *apiKeyRepository) Update(ctx context.Context, apiKey *model.APIKey) error {
+	result := r.db.WithContext(ctx).
+		Model(apiKey).
+		Where("user_id = ?", apiKey.UserID).
+		Select("name", "scopes", "updated_at").
+		Updates(apiKey)
+	if result.Error != nil {
+		return fmt.Errorf("update api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) Delete(ctx context.Context, userID string, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&model.APIKey{}, "id = ? AND user_id = ?", id, userID)
+	if result.Error != nil {
+		return fmt.Errorf("delete api key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&model.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error; err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepository_FindByPrefix_Success(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAPIKeyRepository(gormDB)
+
+	id := uuid.New()
+	mock.ExpectQuery(`^SELECT \* FROM "api_keys" WHERE prefix = \$1.*`).
+		WithArgs("abc123", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "prefix", "secret_hash", "scopes"}).
+			AddRow(id, "user-1", "abc123", "hash", `["todolists:read"]`))
+	mock.ExpectClose()
+
+	apiKey, err := repo.FindByPrefix(context.Background(), "abc123")
+	require.NoError(t, err)
+	require.Equal(t, id, apiKey.ID)
+	require.Equal(t, []string{"todolists:read"}, apiKey.Scopes)
+}
+
+func TestAPIKeyRepository_Delete_OtherUsersKey(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAPIKeyRepository(gormDB)
+
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "api_keys" WHERE id = \$1 AND user_id = \$2`).
+		WithArgs(id, "user-2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Delete(context.Background(), "user-2", id)
+	require.ErrorIs(t, err, repository.ErrAPIKeyNotFound)
+}
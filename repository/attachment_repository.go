@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// ErrAttachmentNotFound indicates that the attachment does not exist on the
+// todo list.
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// AttachmentRepository defines database operations for attachments. All
+// operations except FindForDownload are scoped to the workspace carried by
+// the context; signed downloads are unauthenticated and have none.
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *model.Attachment) error
+	FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Attachment, error)
+	FindForDownload(ctx context.Context, id uuid.UUID) (*model.Attachment, error)
+	FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.Attachment, error)
+	FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Attachment, error)
+	Delete(ctx context.Context, todoListID, id uuid.UUID) error
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+	DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error
+	MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository constructs an AttachmentRepository backed by GORM.
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func (r *attachmentRepository) Create(ctx context.Context, attachment *model.Attachment) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create attachment: %w", tenant.ErrWorkspaceRequired)
+	}
+	attachment.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(attachment).Error; err != nil {
+		return fmt.Errorf("create attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *attachmentRepository) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Attachment, error) {
+	var attachment model.Attachment
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&attachment, "id = ? AND todo_list_id = ?", id, todoListID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("find attachment: %w", err)
+	}
+	return &attachment, nil
+}
+
+// FindForDownload looks an attachment up in any workspace, provided its list
+// and item still exist. Callers must have verified a download signature for
+// id first.
+func (r *attachmentRepository) FindForDownload(ctx context.Context, id uuid.UUID) (*model.Attachment, error) {
+	var attachment model.Attachment
+	if err := conn(ctx, r.db).
+		Where("EXISTS (SELECT 1 FROM todo_lists WHERE todo_lists.id = attachments.todo_list_id)").
+		Where("attachments.todo_item_id IS NULL OR EXISTS (SELECT 1 FROM todo_items WHERE todo_items.id = attachments.todo_item_id)").
+		First(&attachment, "attachments.id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("find attachment for download: %w", err)
+	}
+	return &attachment, nil
+}
+
+// FindByTodoListID returns the attachments of the list and of all its items,
+// oldest first.
+func (r *attachmentRepository) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("find attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, fmt.Errorf("find attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+func (r *attachmentRepository) Delete(ctx context.Context, todoListID, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Attachment{}, "id = ? AND todo_list_id = ?", id, todoListID)
+	if result.Error != nil {
+		return fmt.Errorf("delete attachment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// DeleteByTodoListID removes the attachments of the list and of all its
+// items. Their contents are left to the caller.
+func (r *attachmentRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Attachment{}, "todo_list_id = ?", todoListID).Error; err != nil {
+		return fmt.Errorf("delete attachments: %w", err)
+	}
+	return nil
+}
+
+// DeleteByTodoItemID removes the attachments of the item. Their contents are
+// left to the caller.
+func (r *attachmentRepository) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Attachment{}, "todo_item_id = ?", todoItemID).Error; err != nil {
+		return fmt.Errorf("delete attachments: %w", err)
+	}
+	return nil
+}
+
+// MoveToTodoList follows an item to another list.
+func (r *attachmentRepository) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Model(&model.Attachment{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Update("todo_list_id", todoListID).Error; err != nil {
+		return fmt.Errorf("move attachments: %w", err)
+	}
+	return nil
+}
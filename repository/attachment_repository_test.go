@@ -0,0 +1,72 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentRepository_DeleteByTodoItemID(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAttachmentRepository(gormDB)
+
+	workspaceID, itemID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "attachments" WHERE todo_item_id = \$1 AND "attachments"\."workspace_id" = \$2$`).
+		WithArgs(itemID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.DeleteByTodoItemID(workspaceContext(workspaceID), itemID))
+}
+
+func TestAttachmentRepository_FindForDownload_IgnoresWorkspace(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAttachmentRepository(gormDB)
+
+	id, workspaceID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`^SELECT \* FROM "attachments" WHERE EXISTS \(SELECT 1 FROM todo_lists WHERE todo_lists\.id = attachments\.todo_list_id\) `+
+		`AND \(attachments\.todo_item_id IS NULL OR EXISTS \(SELECT 1 FROM todo_items WHERE todo_items\.id = attachments\.todo_item_id\)\) `+
+		`AND attachments\.id = \$1 ORDER BY "attachments"\."id" LIMIT \$2$`).
+		WithArgs(id, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "storage_key"}).
+			AddRow(id, workspaceID, workspaceID.String()+"/"+id.String()))
+	mock.ExpectClose()
+
+	attachment, err := repo.FindForDownload(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, workspaceID, attachment.WorkspaceID)
+}
+
+func TestAttachmentRepository_Delete_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAttachmentRepository(gormDB)
+
+	workspaceID, listID, id := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "attachments" WHERE \(id = \$1 AND todo_list_id = \$2\) AND "attachments"\."workspace_id" = \$3$`).
+		WithArgs(id, listID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Delete(workspaceContext(workspaceID), listID, id)
+	require.ErrorIs(t, err, repository.ErrAttachmentNotFound)
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository defines database operations for the audit log. Entries
+// are never updated or deleted; the table also rejects such statements.
+type AuditLogRepository interface {
+	Create(ctx context.Context, auditLog *model.AuditLog) error
+	List(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditLog, int64, error)
+}
+
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository constructs an AuditLogRepository backed by GORM.
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, auditLog *model.AuditLog) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create audit log: %w", tenant.ErrWorkspaceRequired)
+	}
+	auditLog.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(auditLog).Error; err != nil {
+		return fmt.Errorf("create audit log: %w", err)
+	}
+	return nil
+}
+
+// List returns one page of entries in the active workspace, newest first,
+// and the number of entries matching the filter.
+func (r *auditLogRepository) List(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditLog, int64, error) {
+	query := func() *gorm.DB {
+		return conn(ctx, r.db).
+			Model(&model.AuditLog{}).
+			Scopes(workspaceScope(ctx), auditLogFilterScope(filter))
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count audit logs: %w", err)
+	}
+
+	var auditLogs []model.AuditLog
+	if err := query().
+		Order("created_at DESC").
+		Order("id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&auditLogs).Error; err != nil {
+		return nil, 0, fmt.Errorf("list audit logs: %w", err)
+	}
+	return auditLogs, total, nil
+}
+
+func auditLogFilterScope(filter model.AuditLogFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.ActorID != "" {
+			db = db.Where("actor_id = ?", filter.ActorID)
+		}
+		if filter.Action != "" {
+			db = db.Where("action = ?", filter.Action)
+		}
+		if filter.EntityType != "" {
+			db = db.Where("entity_type = ?", filter.EntityType)
+		}
+		if filter.EntityID != nil {
+			db = db.Where("entity_id = ?", *filter.EntityID)
+		}
+		if filter.From != nil {
+			db = db.Where("created_at >= ?", *filter.From)
+		}
+		if filter.To != nil {
+			db = db.Where("created_at < ?", *filter.To)
+		}
+		return db
+	}
+}
@@ -0,0 +1,62 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditLogRepository_List_FiltersWithinWorkspace(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAuditLogRepository(gormDB)
+
+	workspaceID := uuid.New()
+	entityID := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "audit_logs" WHERE "audit_logs"\."workspace_id" = \$1 AND action = \$2 AND entity_id = \$3 AND created_at >= \$4`).
+		WithArgs(workspaceID, model.AuditActionUpdate, entityID, from).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`^SELECT \* FROM "audit_logs" WHERE .* ORDER BY created_at DESC,id DESC LIMIT \$5 OFFSET \$6`).
+		WithArgs(workspaceID, model.AuditActionUpdate, entityID, from, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "actor_id", "action", "entity_type", "entity_id", "changes"}).
+			AddRow(uuid.New(), workspaceID, "user-1", model.AuditActionUpdate, model.AuditEntityTodoList, entityID,
+				[]byte(`{"title":{"before":"Groceries","after":"Shopping"}}`)))
+	mock.ExpectClose()
+
+	auditLogs, total, err := repo.List(workspaceContext(workspaceID), model.AuditLogFilter{
+		Action:   model.AuditActionUpdate,
+		EntityID: &entityID,
+		From:     &from,
+		Limit:    2,
+		Offset:   2,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 3, total)
+	require.Len(t, auditLogs, 1)
+	require.Equal(t, "Shopping", auditLogs[0].Changes["title"].After)
+}
+
+func TestAuditLogRepository_Create_RequiresWorkspace(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewAuditLogRepository(gormDB)
+	mock.ExpectClose()
+
+	err := repo.Create(context.Background(), &model.AuditLog{ActorID: "user-1"})
+	require.ErrorIs(t, err, tenant.ErrWorkspaceRequired)
+}
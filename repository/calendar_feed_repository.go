@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrCalendarFeedNotFound indicates that the calendar feed does not exist.
+var ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+// CalendarFeedRepository defines database operations for calendar feeds.
+// All operations except FindByTokenHash are scoped to the workspace carried
+// by the context; token lookups serve unauthenticated requests that have
+// none.
+type CalendarFeedRepository interface {
+	Save(ctx context.Context, feed *model.CalendarFeed) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*model.CalendarFeed, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedRepository constructs a CalendarFeedRepository backed by GORM.
+func NewCalendarFeedRepository(db *gorm.DB) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+// Save creates the user's feed, or replaces the token of the feed they
+// already have so that the old URL stops working.
+func (r *calendarFeedRepository) Save(ctx context.Context, feed *model.CalendarFeed) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("save calendar feed: %w", tenant.ErrWorkspaceRequired)
+	}
+	feed.WorkspaceID = workspaceID
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "workspace_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at", "updated_at"}),
+		}).
+		Create(feed).Error; err != nil {
+		return fmt.Errorf("save calendar feed: %w", err)
+	}
+	return nil
+}
+
+func (r *calendarFeedRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.CalendarFeed, error) {
+	var feed model.CalendarFeed
+	if err := r.db.WithContext(ctx).First(&feed, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCalendarFeedNotFound
+		}
+		return nil, fmt.Errorf("find calendar feed by token: %w", err)
+	}
+	return &feed, nil
+}
+
+func (r *calendarFeedRepository) Delete(ctx context.Context, userID string) error {
+	result := r.db.WithContext(ctx).
+		Scopes(workspaceScope(ctx)).
+		Where("user_id = ?", userID).
+		Delete(&model.CalendarFeed{})
+	if result.Error != nil {
+		return fmt.Errorf("delete calendar feed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCalendarFeedNotFound
+	}
+	return nil
+}
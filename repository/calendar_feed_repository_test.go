@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedRepository_Save_ReplacesToken(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewCalendarFeedRepository(gormDB)
+
+	workspaceID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO "calendar_feeds" .* ON CONFLICT \("workspace_id","user_id"\) DO UPDATE SET "token_hash"="excluded"\."token_hash","created_at"="excluded"\."created_at","updated_at"="excluded"\."updated_at"$`).
+		WithArgs(sqlmock.AnyArg(), workspaceID, "user-1", "hash", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	feed := &model.CalendarFeed{UserID: "user-1", TokenHash: "hash"}
+	require.NoError(t, repo.Save(workspaceContext(workspaceID), feed))
+	require.Equal(t, workspaceID, feed.WorkspaceID)
+}
+
+func TestCalendarFeedRepository_Delete_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewCalendarFeedRepository(gormDB)
+
+	workspaceID := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "calendar_feeds" WHERE user_id = \$1 AND "calendar_feeds"\."workspace_id" = \$2$`).
+		WithArgs("user-1", workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Delete(workspaceContext(workspaceID), "user-1")
+	require.ErrorIs(t, err, repository.ErrCalendarFeedNotFound)
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// ErrCommentNotFound indicates that the comment does not exist on the todo
+// list.
+var ErrCommentNotFound = errors.New("comment not found")
+
+// CommentRepository defines database operations for comments. Every
+// operation is scoped to the workspace carried by the context.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *model.Comment) error
+	FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Comment, error)
+	FindThreads(ctx context.Context, filter model.CommentFilter) ([]model.Comment, int64, error)
+	FindReplies(ctx context.Context, parentIDs []uuid.UUID) ([]model.Comment, error)
+	Update(ctx context.Context, comment *model.Comment) error
+	MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+	DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error
+}
+
+type commentRepository struct {
+	db *gorm.DB
+}
+
+// NewCommentRepository constructs a CommentRepository backed by GORM.
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *model.Comment) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create comment: %w", tenant.ErrWorkspaceRequired)
+	}
+	comment.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(comment).Error; err != nil {
+		return fmt.Errorf("create comment: %w", err)
+	}
+	return nil
+}
+
+func (r *commentRepository) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Comment, error) {
+	var comment model.Comment
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&comment, "id = ? AND todo_list_id = ?", id, todoListID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("find comment: %w", err)
+	}
+	return &comment, nil
+}
+
+// FindThreads returns one page of top-level comments on the list, or on one
+// of its items, oldest first, and the number of threads in total.
+func (r *commentRepository) FindThreads(ctx context.Context, filter model.CommentFilter) ([]model.Comment, int64, error) {
+	query := func() *gorm.DB {
+		db := conn(ctx, r.db).
+			Model(&model.Comment{}).
+			Scopes(workspaceScope(ctx)).
+			Where("todo_list_id = ? AND parent_id IS NULL", filter.TodoListID)
+		if filter.TodoItemID != nil {
+			return db.Where("todo_item_id = ?", *filter.TodoItemID)
+		}
+		return db.Where("todo_item_id IS NULL")
+	}
+
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count comments: %w", err)
+	}
+
+	var comments []model.Comment
+	if err := query().
+		Order("created_at ASC").
+		Order("id ASC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		Find(&comments).Error; err != nil {
+		return nil, 0, fmt.Errorf("find comments: %w", err)
+	}
+	return comments, total, nil
+}
+
+// FindReplies returns the replies to any of the parents, oldest first.
+func (r *commentRepository) FindReplies(ctx context.Context, parentIDs []uuid.UUID) ([]model.Comment, error) {
+	var replies []model.Comment
+	if len(parentIDs) == 0 {
+		return replies, nil
+	}
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("parent_id IN ?", parentIDs).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&replies).Error; err != nil {
+		return nil, fmt.Errorf("find replies: %w", err)
+	}
+	return replies, nil
+}
+
+// Update saves a comment's body and its edit and deletion times.
+func (r *commentRepository) Update(ctx context.Context, comment *model.Comment) error {
+	result := conn(ctx, r.db).
+		Model(comment).
+		Scopes(workspaceScope(ctx)).
+		Select("body", "edited_at", "deleted_at").
+		Updates(comment)
+	if result.Error != nil {
+		return fmt.Errorf("update comment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCommentNotFound
+	}
+	return nil
+}
+
+// MoveToTodoList follows an item to another list.
+func (r *commentRepository) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Model(&model.Comment{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Update("todo_list_id", todoListID).Error; err != nil {
+		return fmt.Errorf("move comments: %w", err)
+	}
+	return nil
+}
+
+// DeleteByTodoListID removes the comments on the list and on all its items.
+func (r *commentRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Comment{}, "todo_list_id = ?", todoListID).Error; err != nil {
+		return fmt.Errorf("delete comments: %w", err)
+	}
+	return nil
+}
+
+// DeleteByTodoItemID removes the comments on the item.
+func (r *commentRepository) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Comment{}, "todo_item_id = ?", todoItemID).Error; err != nil {
+		return fmt.Errorf("delete comments: %w", err)
+	}
+	return nil
+}
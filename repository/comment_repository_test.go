@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentRepository_FindThreads_ListLevel(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewCommentRepository(gormDB)
+
+	workspaceID, listID := uuid.New(), uuid.New()
+	mock.ExpectQuery(`^SELECT count\(\*\) FROM "comments" WHERE \(todo_list_id = \$1 AND parent_id IS NULL\) AND todo_item_id IS NULL AND "comments"\."workspace_id" = \$2$`).
+		WithArgs(listID, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(`^SELECT \* FROM "comments" WHERE \(todo_list_id = \$1 AND parent_id IS NULL\) AND todo_item_id IS NULL AND "comments"\."workspace_id" = \$2 ORDER BY created_at ASC,id ASC LIMIT \$3 OFFSET \$4$`).
+		WithArgs(listID, workspaceID, 2, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "todo_list_id", "body"}).AddRow(uuid.New(), listID, "third"))
+	mock.ExpectClose()
+
+	comments, total, err := repo.FindThreads(workspaceContext(workspaceID), model.CommentFilter{TodoListID: listID, Limit: 2, Offset: 2})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), total)
+	require.Len(t, comments, 1)
+	require.Equal(t, "third", comments[0].Body)
+}
+
+func TestCommentRepository_FindReplies_NoParents(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewCommentRepository(gormDB)
+	mock.ExpectClose()
+
+	replies, err := repo.FindReplies(workspaceContext(uuid.New()), nil)
+	require.NoError(t, err)
+	require.Empty(t, replies)
+}
+
+func TestCommentRepository_DeleteByTodoListID(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewCommentRepository(gormDB)
+
+	workspaceID, listID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "comments" WHERE todo_list_id = \$1 AND "comments"\."workspace_id" = \$2$`).
+		WithArgs(listID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.DeleteByTodoListID(workspaceContext(workspaceID), listID))
+}
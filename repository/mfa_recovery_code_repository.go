@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+)
+
+// ErrRecoveryCodeNotFound indicates that no unused recovery code matches.
+var ErrRecoveryCodeNotFound = errors.New("recovery code not found")
+
+// MFARecoveryCodeRepository defines database operations for MFA recovery codes.
+type MFARecoveryCodeRepository interface {
+	Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	Use(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error
+}
+
+type mfaRecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARecoveryCodeRepository constructs an MFARecoveryCodeRepository backed by GORM.
+func NewMFARecoveryCodeRepository(db *gorm.DB) MFARecoveryCodeRepository {
+	return &mfaRecoveryCodeRepository{db: db}
+}
+
+// Replace discards the user's existing codes and stores a new set.
+func (r *mfaRecoveryCodeRepository) Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	codes := make([]model.MFARecoveryCode, 0, len(codeHashes))
+	for _, codeHash := range codeHashes {
+		codes = append(codes, model.MFARecoveryCode{UserID: userID, CodeHash: codeHash})
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&model.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return fmt.Errorf("replace recovery codes: %w", err)
+	}
+	return nil
+}
+
+// Use marks a matching unused code as used. Concurrent attempts with the same
+// code cannot both succeed.
+func (r *mfaRecoveryCodeRepository) Use(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", at)
+	if result.Error != nil {
+		return fmt.Errorf("use recovery code: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// ErrNotificationNotFound indicates that the notification is not in the
+// user's inbox.
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// NotificationRepository defines database operations for in-app
+// notifications. Every operation is scoped to the workspace carried by the
+// context.
+type NotificationRepository interface {
+	Create(ctx context.Context, notification *model.Notification) error
+	FindByUserID(ctx context.Context, userID string, unreadOnly bool) ([]model.Notification, error)
+	MarkRead(ctx context.Context, userID string, id uuid.UUID, at time.Time) error
+}
+
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository constructs a NotificationRepository backed by GORM.
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *model.Notification) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create notification: %w", tenant.ErrWorkspaceRequired)
+	}
+	notification.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(notification).Error; err != nil {
+		return fmt.Errorf("create notification: %w", err)
+	}
+	return nil
+}
+
+// FindByUserID returns the user's notifications, newest first.
+func (r *notificationRepository) FindByUserID(ctx context.Context, userID string, unreadOnly bool) ([]model.Notification, error) {
+	query := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	var notifications []model.Notification
+	if err := query.Order("created_at DESC").Find(&notifications).Error; err != nil {
+		return nil, fmt.Errorf("find notifications: %w", err)
+	}
+	return notifications, nil
+}
+
+// MarkRead records when the user read the notification. Reading it again
+// keeps the original time.
+func (r *notificationRepository) MarkRead(ctx context.Context, userID string, id uuid.UUID, at time.Time) error {
+	result := conn(ctx, r.db).
+		Model(&model.Notification{}).
+		Scopes(workspaceScope(ctx)).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", at))
+	if result.Error != nil {
+		return fmt.Errorf("mark notification read: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationRepository_MarkRead_NotFoundForOtherUser(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewNotificationRepository(gormDB)
+
+	workspaceID := uuid.New()
+	id := uuid.New()
+	at := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "notifications" SET "read_at"=COALESCE\(read_at, \$1\) WHERE \(id = \$2 AND user_id = \$3\) AND "notifications"\."workspace_id" = \$4`).
+		WithArgs(at, id, "user-2", workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.MarkRead(workspaceContext(workspaceID), "user-2", id, at)
+	require.ErrorIs(t, err, repository.ErrNotificationNotFound)
+}
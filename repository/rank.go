@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// unbalancedRank matches rows whose rank is missing or has grown longer than
+// the bound given as its argument.
+const unbalancedRank = "rank = '' OR length(rank) > ?"
+
+// lastRank returns the highest rank among the rows of query other than
+// excludeID, or "" when there are none.
+func lastRank(query *gorm.DB, excludeID uuid.UUID) (string, error) {
+	var ranks []string
+	if err := query.
+		Where("id <> ?", excludeID).
+		Order("rank DESC").
+		Limit(1).
+		Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// adjacentRank returns the nearest rank at or after rank (or at or before it
+// when after is false) among the rows of query other than excludeIDs, or ""
+// at the end of the collection. A result equal to rank means that two rows
+// share it.
+func adjacentRank(query *gorm.DB, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	condition, order := "rank <= ?", "rank DESC"
+	if after {
+		condition, order = "rank >= ?", "rank ASC"
+	}
+	var ranks []string
+	if err := query.
+		Where(condition, rank).
+		Where("id NOT IN ?", excludeIDs).
+		Order(order).
+		Limit(1).
+		Pluck("rank", &ranks).Error; err != nil {
+		return "", err
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
@@ -0,0 +1,55 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshTokenRepository_MarkUsed_Success(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewRefreshTokenRepository(gormDB)
+
+	id := uuid.New()
+	replacedByID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "refresh_tokens" SET "replaced_by_id"=\$1,"revoked_at"=\$2 WHERE id = \$3 AND revoked_at IS NULL`).
+		WithArgs(&replacedByID, now, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.MarkUsed(context.Background(), id, &replacedByID, now))
+}
+
+func TestRefreshTokenRepository_MarkUsed_AlreadyUsed(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewRefreshTokenRepository(gormDB)
+
+	id := uuid.New()
+	replacedByID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "refresh_tokens" SET .* WHERE id = \$3 AND revoked_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.MarkUsed(context.Background(), id, &replacedByID, time.Now())
+	require.ErrorIs(t, err, repository.ErrRefreshTokenUsed)
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReminderNotFound indicates that the reminder does not exist on the item.
+var ErrReminderNotFound = errors.New("reminder not found")
+
+// ReminderRepository defines database operations for reminders. Every
+// operation except ClaimDue is scoped to the workspace carried by the
+// context.
+type ReminderRepository interface {
+	Create(ctx context.Context, reminder *model.Reminder) error
+	FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Reminder, error)
+	FindForUser(ctx context.Context, userID string, todoItemIDs []uuid.UUID) ([]model.Reminder, error)
+	Update(ctx context.Context, reminder *model.Reminder) error
+	Delete(ctx context.Context, todoItemID, id uuid.UUID) error
+	DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error
+	Reschedule(ctx context.Context, todoItemID uuid.UUID, dueAt *time.Time) error
+	MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error
+	ClaimDue(ctx context.Context, now time.Time, limit int) ([]model.Reminder, error)
+	Lease(ctx context.Context, ids []uuid.UUID, until time.Time) error
+}
+
+type reminderRepository struct {
+	db *gorm.DB
+}
+
+// NewReminderRepository constructs a ReminderRepository backed by GORM.
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+func (r *reminderRepository) Create(ctx context.Context, reminder *model.Reminder) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create reminder: %w", tenant.ErrWorkspaceRequired)
+	}
+	reminder.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(reminder).Error; err != nil {
+		return fmt.Errorf("create reminder: %w", err)
+	}
+	return nil
+}
+
+func (r *reminderRepository) FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Reminder, error) {
+	var reminders []model.Reminder
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Order("remind_at ASC NULLS LAST").
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("find reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// FindForUser returns the reminders userID has set on any of the items,
+// leaving out cancelled ones.
+func (r *reminderRepository) FindForUser(ctx context.Context, userID string, todoItemIDs []uuid.UUID) ([]model.Reminder, error) {
+	if len(todoItemIDs) == 0 {
+		return nil, nil
+	}
+	var reminders []model.Reminder
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("user_id = ? AND todo_item_id IN ? AND status <> ?", userID, todoItemIDs, model.ReminderStatusCancelled).
+		Order("created_at, id").
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("find user reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// Update records the outcome of delivering a pending reminder and releases
+// its lease: its status, attempts, last error and sent time, and RemindAt
+// when it stays pending for a retry. Other fields are left alone so that
+// changes made while it was being delivered survive. Reminders that are no
+// longer pending are not found.
+func (r *reminderRepository) Update(ctx context.Context, reminder *model.Reminder) error {
+	columns := []string{"status", "attempts", "last_error", "sent_at", "leased_until"}
+	if reminder.Status == model.ReminderStatusPending {
+		columns = append(columns, "remind_at")
+	}
+	reminder.LeasedUntil = nil
+	result := conn(ctx, r.db).
+		Model(reminder).
+		Scopes(workspaceScope(ctx)).
+		Where("status = ?", model.ReminderStatusPending).
+		Select(columns).
+		Updates(reminder)
+	if result.Error != nil {
+		return fmt.Errorf("update reminder: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+func (r *reminderRepository) Delete(ctx context.Context, todoItemID, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Reminder{}, "id = ? AND todo_item_id = ?", id, todoItemID)
+	if result.Error != nil {
+		return fmt.Errorf("delete reminder: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrReminderNotFound
+	}
+	return nil
+}
+
+func (r *reminderRepository) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Delete(&model.Reminder{}).Error; err != nil {
+		return fmt.Errorf("delete reminders: %w", err)
+	}
+	return nil
+}
+
+// Reschedule moves the item's pending offset reminders to their offset
+// before dueAt. A nil dueAt parks them until the item gets a due date again.
+func (r *reminderRepository) Reschedule(ctx context.Context, todoItemID uuid.UUID, dueAt *time.Time) error {
+	if err := conn(ctx, r.db).
+		Model(&model.Reminder{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ? AND status = ? AND offset_seconds IS NOT NULL", todoItemID, model.ReminderStatusPending).
+		Update("remind_at", gorm.Expr("CAST(? AS timestamptz) - offset_seconds * INTERVAL '1 second'", dueAt)).Error; err != nil {
+		return fmt.Errorf("reschedule reminders: %w", err)
+	}
+	return nil
+}
+
+// MoveToTodoList follows the item to another list.
+func (r *reminderRepository) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Model(&model.Reminder{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_item_id = ?", todoItemID).
+		Update("todo_list_id", todoListID).Error; err != nil {
+		return fmt.Errorf("move reminders: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue locks up to limit pending reminders that are due at now and not
+// leased, across all workspaces, for the reminder scheduler. It must run inside a
+// transaction: rows stay locked until it ends, and SKIP LOCKED lets other
+// replicas claim different rows instead of waiting for or repeating them.
+func (r *reminderRepository) ClaimDue(ctx context.Context, now time.Time, limit int) ([]model.Reminder, error) {
+	var reminders []model.Reminder
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("status = ? AND remind_at <= ?", model.ReminderStatusPending, now).
+		Where("leased_until IS NULL OR leased_until <= ?", now).
+		Order("remind_at ASC").
+		Limit(limit).
+		Find(&reminders).Error; err != nil {
+		return nil, fmt.Errorf("claim due reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// Lease hides the given reminders from ClaimDue until until, so that no
+// replica claims them again while they are being delivered. Their RemindAt
+// is kept, and reminders left leased by a replica that stopped are claimed
+// again once the lease runs out.
+func (r *reminderRepository) Lease(ctx context.Context, ids []uuid.UUID, until time.Time) error {
+	if err := conn(ctx, r.db).
+		Model(&model.Reminder{}).
+		Where("id IN ?", ids).
+		Update("leased_until", until).Error; err != nil {
+		return fmt.Errorf("lease reminders: %w", err)
+	}
+	return nil
+}
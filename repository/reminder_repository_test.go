@@ -0,0 +1,98 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReminderRepository_ClaimDue_SkipsLockedRows(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewReminderRepository(gormDB)
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`^SELECT \* FROM "reminders" WHERE \(status = \$1 AND remind_at <= \$2\) AND \(leased_until IS NULL OR leased_until <= \$3\) ORDER BY remind_at ASC LIMIT \$4 FOR UPDATE SKIP LOCKED$`).
+		WithArgs(model.ReminderStatusPending, now, now, 25).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "channel", "status"}).
+			AddRow(uuid.New(), uuid.New(), model.ReminderChannelInbox, model.ReminderStatusPending))
+	mock.ExpectClose()
+
+	reminders, err := repo.ClaimDue(context.Background(), now, 25)
+	require.NoError(t, err)
+	require.Len(t, reminders, 1)
+}
+
+func TestReminderRepository_Reschedule_MovesOffsetReminders(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewReminderRepository(gormDB)
+
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	dueAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "reminders" SET "remind_at"=CAST\(\$1 AS timestamptz\) - offset_seconds \* INTERVAL '1 second',"updated_at"=\$2 WHERE \(todo_item_id = \$3 AND status = \$4 AND offset_seconds IS NOT NULL\) AND "reminders"\."workspace_id" = \$5`).
+		WithArgs(&dueAt, sqlmock.AnyArg(), itemID, model.ReminderStatusPending, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.Reschedule(workspaceContext(workspaceID), itemID, &dueAt))
+}
+
+func TestReminderRepository_Update_OnlyRecordsDelivery(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewReminderRepository(gormDB)
+
+	workspaceID := uuid.New()
+	sentAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	leasedUntil := sentAt.Add(15 * time.Minute)
+	reminder := &model.Reminder{ID: uuid.New(), Status: model.ReminderStatusSent, SentAt: &sentAt, RemindAt: &sentAt, LeasedUntil: &leasedUntil}
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "reminders" SET "status"=\$1,"attempts"=\$2,"last_error"=\$3,"sent_at"=\$4,"leased_until"=\$5,"updated_at"=\$6 `+
+		`WHERE status = \$7 AND "reminders"\."workspace_id" = \$8 AND "id" = \$9$`).
+		WithArgs(model.ReminderStatusSent, 0, "", &sentAt, nil, sqlmock.AnyArg(), model.ReminderStatusPending, workspaceID, reminder.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Update(workspaceContext(workspaceID), reminder)
+	require.ErrorIs(t, err, repository.ErrReminderNotFound, "reminders that stopped being pending are left alone")
+}
+
+func TestReminderRepository_Lease_KeepsRemindAt(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewReminderRepository(gormDB)
+
+	id := uuid.New()
+	until := time.Date(2026, 11, 1, 9, 15, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "reminders" SET "leased_until"=\$1,"updated_at"=\$2 WHERE id IN \(\$3\)$`).
+		WithArgs(until, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.Lease(context.Background(), []uuid.UUID{id}, until))
+}
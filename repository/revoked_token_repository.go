@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RevokedTokenRepository defines database operations for the access token denylist.
+type RevokedTokenRepository interface {
+	Create(ctx context.Context, revoked *model.RevokedAccessToken) error
+	Exists(ctx context.Context, jti string) (bool, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewRevokedTokenRepository constructs a RevokedTokenRepository backed by GORM.
+func NewRevokedTokenRepository(db *gorm.DB) RevokedTokenRepository {
+	return &revokedTokenRepository{db: db}
+}
+
+func (r *revokedTokenRepository) Create(ctx context.Context, revoked *model.RevokedAccessToken) error {
+	if err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(revoked).Error; err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+	return nil
+}
+
+func (r *revokedTokenRepository) Exists(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).
+		Model(&model.RevokedAccessToken{}).
+		Where("jti = ?", jti).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check revoked access token: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *revokedTokenRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at < ?", before).
+		Delete(&model.RevokedAccessToken{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("delete expired revoked access tokens: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
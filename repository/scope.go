@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// workspaceScope restricts a query to the workspace carried by ctx. Queries
+// issued without an active workspace fail instead of reading across tenants.
+func workspaceScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+		if !ok {
+			_ = db.AddError(tenant.ErrWorkspaceRequired)
+			return db
+		}
+		return db.Where(clause.Eq{
+			Column: clause.Column{Table: clause.CurrentTable, Name: "workspace_id"},
+			Value:  workspaceID,
+		})
+	}
+}
+
+// dueFilterScope applies a DueFilter to a todo list or item query. Overdue
+// entries are compared against the database clock so that every replica
+// agrees on what is late.
+func dueFilterScope(filter model.DueFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.Priority != "" {
+			db = db.Where("priority = ?", filter.Priority)
+		}
+		if filter.Overdue {
+			db = db.Where("due_at < CURRENT_TIMESTAMP AND status IN ?", model.PendingStatuses)
+		}
+		if filter.DueBefore != nil {
+			db = db.Where("due_at < ?", *filter.DueBefore)
+		}
+		if filter.DueAfter != nil {
+			db = db.Where("due_at >= ?", *filter.DueAfter)
+		}
+		return db
+	}
+}
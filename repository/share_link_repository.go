@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// ErrShareLinkNotFound indicates that the share link record does not exist.
+var ErrShareLinkNotFound = errors.New("share link not found")
+
+// ShareLinkRepository defines database operations for share links.
+// All operations except FindByTokenHash are scoped to the workspace carried by
+// the context; token lookups serve unauthenticated requests that have none.
+type ShareLinkRepository interface {
+	Create(ctx context.Context, shareLink *model.ShareLink) error
+	FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.ShareLink, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (*model.ShareLink, error)
+	FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLink, error)
+	Update(ctx context.Context, shareLink *model.ShareLink) error
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+}
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewShareLinkRepository constructs a ShareLinkRepository backed by GORM.
+func NewShareLinkRepository(db *gorm.DB) ShareLinkRepository {
+	return &shareLinkRepository{db: db}
+}
+
+func (r *shareLinkRepository) Create(ctx context.Context, shareLink *model.ShareLink) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create share link: %w", tenant.ErrWorkspaceRequired)
+	}
+	shareLink.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(shareLink).Error; err != nil {
+		return fmt.Errorf("create share link: %w", err)
+	}
+	return nil
+}
+
+func (r *shareLinkRepository) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.ShareLink, error) {
+	var shareLink model.ShareLink
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&shareLink, "id = ? AND todo_list_id = ?", id, todoListID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("find share link: %w", err)
+	}
+	return &shareLink, nil
+}
+
+func (r *shareLinkRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*model.ShareLink, error) {
+	var shareLink model.ShareLink
+	if err := conn(ctx, r.db).First(&shareLink, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrShareLinkNotFound
+		}
+		return nil, fmt.Errorf("find share link by token: %w", err)
+	}
+	return &shareLink, nil
+}
+
+func (r *shareLinkRepository) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLink, error) {
+	var shareLinks []model.ShareLink
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Order("created_at DESC").
+		Find(&shareLinks).Error; err != nil {
+		return nil, fmt.Errorf("find share links: %w", err)
+	}
+	return shareLinks, nil
+}
+
+func (r *shareLinkRepository) Update(ctx context.Context, shareLink *model.ShareLink) error {
+	result := conn(ctx, r.db).
+		Model(shareLink).
+		Scopes(workspaceScope(ctx)).
+		Select("*").
+		Omit("id", "workspace_id", "todo_list_id", "token_hash", "created_at").
+		Updates(shareLink)
+	if result.Error != nil {
+		return fmt.Errorf("update share link: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrShareLinkNotFound
+	}
+	return nil
+}
+
+// DeleteByTodoListID removes the list's share links.
+func (r *shareLinkRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.ShareLink{}, "todo_list_id = ?", todoListID).Error; err != nil {
+		return fmt.Errorf("delete share links: %w", err)
+	}
+	return nil
+}
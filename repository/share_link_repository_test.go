@@ -0,0 +1,28 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLinkRepository_FindByTokenHash_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewShareLinkRepository(gormDB)
+
+	mock.ExpectQuery(`^SELECT \* FROM "share_links" WHERE token_hash = \$1.*`).
+		WithArgs("hash", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "todo_list_id", "token_hash"}))
+	mock.ExpectClose()
+
+	shareLink, err := repo.FindByTokenHash(context.Background(), "hash")
+	require.ErrorIs(t, err, repository.ErrShareLinkNotFound)
+	require.Nil(t, shareLink)
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrTagNotFound indicates that the tag does not exist in the workspace.
+	ErrTagNotFound = errors.New("tag not found")
+	// ErrTagNameTaken indicates that the workspace already has a tag with the name.
+	ErrTagNameTaken = errors.New("tag name already taken")
+)
+
+// TagRepository defines database operations for tags and their attachment
+// to todo lists. Every operation is scoped to the workspace carried by the
+// context.
+type TagRepository interface {
+	Create(ctx context.Context, tag *model.Tag) error
+	FindAll(ctx context.Context) ([]model.Tag, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error)
+	FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID][]model.Tag, error)
+	Update(ctx context.Context, tag *model.Tag) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	Attach(ctx context.Context, todoListID, tagID uuid.UUID) error
+	Detach(ctx context.Context, todoListID, tagID uuid.UUID) error
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+}
+
+// listTag is a tag together with a list it is attached to.
+type listTag struct {
+	model.Tag
+	TodoListID uuid.UUID
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository constructs a TagRepository backed by GORM.
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) Create(ctx context.Context, tag *model.Tag) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create tag: %w", tenant.ErrWorkspaceRequired)
+	}
+	tag.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(tag).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return ErrTagNameTaken
+		}
+		return fmt.Errorf("create tag: %w", err)
+	}
+	return nil
+}
+
+// FindAll returns the workspace's tags ordered by name.
+func (r *tagRepository) FindAll(ctx context.Context) ([]model.Tag, error) {
+	var tags []model.Tag
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Order("name ASC").
+		Find(&tags).Error; err != nil {
+		return nil, fmt.Errorf("find tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	var tag model.Tag
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&tag, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTagNotFound
+		}
+		return nil, fmt.Errorf("find tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// FindByTodoListIDs loads the tags of several lists with one query, keyed by
+// list ID. Lists without tags have no entry.
+func (r *tagRepository) FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID][]model.Tag, error) {
+	tagsByList := make(map[uuid.UUID][]model.Tag)
+	if len(todoListIDs) == 0 {
+		return tagsByList, nil
+	}
+	var rows []listTag
+	if err := conn(ctx, r.db).
+		Table("tags").
+		Scopes(workspaceScope(ctx)).
+		Select("tags.*, todo_list_tags.todo_list_id").
+		Joins("JOIN todo_list_tags ON todo_list_tags.tag_id = tags.id").
+		Where("todo_list_tags.todo_list_id IN ?", todoListIDs).
+		Order("tags.name ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("find tags by todo lists: %w", err)
+	}
+	for _, row := range rows {
+		tagsByList[row.TodoListID] = append(tagsByList[row.TodoListID], row.Tag)
+	}
+	return tagsByList, nil
+}
+
+func (r *tagRepository) Update(ctx context.Context, tag *model.Tag) error {
+	result := conn(ctx, r.db).
+		Model(tag).
+		Scopes(workspaceScope(ctx)).
+		Select("name", "color", "updated_at").
+		Updates(tag)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrTagNameTaken
+		}
+		return fmt.Errorf("update tag: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// Delete removes the tag and detaches it from every list. Callers wrap it in
+// a transaction to keep both steps atomic.
+func (r *tagRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	db := conn(ctx, r.db)
+	result := db.
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.Tag{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("delete tag: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTagNotFound
+	}
+	if err := db.Where("tag_id = ?", id).Delete(&model.TodoListTag{}).Error; err != nil {
+		return fmt.Errorf("detach deleted tag: %w", err)
+	}
+	return nil
+}
+
+// Attach tags the list. Attaching a tag twice is a no-op. Callers check that
+// both belong to the current workspace.
+func (r *tagRepository) Attach(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.TodoListTag{TodoListID: todoListID, TagID: tagID}).Error; err != nil {
+		return fmt.Errorf("attach tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) Detach(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Where("todo_list_id = ? AND tag_id = ?", todoListID, tagID).
+		Delete(&model.TodoListTag{}).Error; err != nil {
+		return fmt.Errorf("detach tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Where("todo_list_id = ?", todoListID).
+		Delete(&model.TodoListTag{}).Error; err != nil {
+		return fmt.Errorf("delete todo list tags: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagRepository_FindByTodoListIDs_GroupsByList(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTagRepository(gormDB)
+
+	workspaceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	home := uuid.New()
+	mock.ExpectQuery(`^SELECT tags\.\*, todo_list_tags\.todo_list_id FROM "tags" JOIN todo_list_tags ON todo_list_tags\.tag_id = tags\.id WHERE todo_list_tags\.todo_list_id IN \(\$1,\$2\) AND "tags"\."workspace_id" = \$3 ORDER BY tags\.name ASC$`).
+		WithArgs(first, second, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "name", "color", "todo_list_id"}).
+			AddRow(home, workspaceID, "home", "#4caf50", first).
+			AddRow(home, workspaceID, "home", "#4caf50", second).
+			AddRow(uuid.New(), workspaceID, "urgent", "#f44336", first))
+	mock.ExpectClose()
+
+	tags, err := repo.FindByTodoListIDs(workspaceContext(workspaceID), []uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Len(t, tags[first], 2)
+	require.Equal(t, "urgent", tags[first][1].Name)
+	require.Equal(t, []model.Tag{{ID: home, WorkspaceID: workspaceID, Name: "home", Color: "#4caf50"}}, tags[second])
+}
+
+func TestTagRepository_Attach_IgnoresExistingAttachment(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTagRepository(gormDB)
+
+	listID, tagID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^INSERT INTO "todo_list_tags" \("todo_list_id","tag_id","created_at"\) VALUES \(\$1,\$2,\$3\) ON CONFLICT DO NOTHING$`).
+		WithArgs(listID, tagID, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.Attach(workspaceContext(uuid.New()), listID, tagID))
+}
+
+func TestTagRepository_Delete_DetachesFromLists(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTagRepository(gormDB)
+
+	workspaceID, tagID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "tags" WHERE id = \$1 AND "tags"\."workspace_id" = \$2$`).
+		WithArgs(tagID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "todo_list_tags" WHERE tag_id = \$1$`).
+		WithArgs(tagID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	require.NoError(t, repo.Delete(workspaceContext(workspaceID), tagID))
+}
+
+func TestTagRepository_Delete_OtherWorkspaceNotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTagRepository(gormDB)
+
+	workspaceID, tagID := uuid.New(), uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^DELETE FROM "tags" WHERE id = \$1 AND "tags"\."workspace_id" = \$2$`).
+		WithArgs(tagID, workspaceID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Delete(workspaceContext(workspaceID), tagID)
+	require.ErrorIs(t, err, repository.ErrTagNotFound)
+}
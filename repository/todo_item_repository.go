@@ -0,0 +1,441 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrTodoItemNotFound indicates that the todo item does not exist in the list.
+var ErrTodoItemNotFound = errors.New("todo item not found")
+
+// TodoItemRepository defines database operations for todo items. Every
+// operation is scoped to the workspace carried by the context.
+type TodoItemRepository interface {
+	Create(ctx context.Context, item *model.TodoItem) error
+	CreateAll(ctx context.Context, items []model.TodoItem) error
+	FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.TodoItem, error)
+	FindByTodoListID(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItem, error)
+	Update(ctx context.Context, item *model.TodoItem) error
+	Delete(ctx context.Context, todoListID, id uuid.UUID) error
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+	FindDueByOwner(ctx context.Context, ownerID string, since time.Time, limit int) ([]model.TodoItem, error)
+	FindLatestInSeries(ctx context.Context, seriesID uuid.UUID) (*model.TodoItem, error)
+	ExistsInSeries(ctx context.Context, seriesID uuid.UUID, dueAt time.Time) (bool, error)
+	LastRank(ctx context.Context, todoListID, excludeID uuid.UUID) (string, error)
+	AdjacentRank(ctx context.Context, todoListID uuid.UUID, rank string, excludeIDs []uuid.UUID, after bool) (string, error)
+	Move(ctx context.Context, item *model.TodoItem) error
+	FindForRebalance(ctx context.Context, todoListID uuid.UUID) ([]model.TodoItem, error)
+	SetRank(ctx context.Context, id uuid.UUID, rank string) error
+	FindUnbalanced(ctx context.Context, maxLength, limit int) ([]model.TodoItem, error)
+	FindSubtree(ctx context.Context, todoListID, id uuid.UUID) ([]model.TodoItem, error)
+	FindAncestors(ctx context.Context, id uuid.UUID) ([]model.TodoItem, error)
+	MoveToTodoList(ctx context.Context, ids []uuid.UUID, todoListID uuid.UUID) error
+	CountSubtasks(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error)
+	CountByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error)
+}
+
+// subtreeQuery selects an item and every subtask below it, parents before
+// their subtasks and siblings in rank order.
+const subtreeQuery = `WITH RECURSIVE subtree AS (
+	SELECT todo_items.*, 0 AS depth FROM todo_items
+	WHERE id = @id AND todo_list_id = @todoListID AND workspace_id = @workspaceID
+	UNION ALL
+	SELECT child.*, subtree.depth + 1 FROM todo_items child
+	JOIN subtree ON child.parent_id = subtree.id
+	WHERE child.workspace_id = @workspaceID
+)
+SELECT * FROM subtree ORDER BY depth, rank, created_at`
+
+// ancestorsQuery selects the parent of an item, its parent and so on up to
+// the top-level item, nearest first.
+const ancestorsQuery = `WITH RECURSIVE ancestors AS (
+	SELECT parent.*, 1 AS distance FROM todo_items parent
+	JOIN todo_items item ON item.parent_id = parent.id
+	WHERE item.id = @id AND item.workspace_id = @workspaceID AND parent.workspace_id = @workspaceID
+	UNION ALL
+	SELECT parent.*, ancestors.distance + 1 FROM todo_items parent
+	JOIN ancestors ON ancestors.parent_id = parent.id
+	WHERE parent.workspace_id = @workspaceID
+)
+SELECT * FROM ancestors ORDER BY distance`
+
+// subtaskProgressQuery counts the subtasks at any depth below each of a set
+// of items.
+const subtaskProgressQuery = `WITH RECURSIVE subtasks AS (
+	SELECT parent_id AS root_id, id, status FROM todo_items
+	WHERE parent_id IN @parentIDs AND workspace_id = @workspaceID
+	UNION ALL
+	SELECT subtasks.root_id, child.id, child.status FROM todo_items child
+	JOIN subtasks ON child.parent_id = subtasks.id
+	WHERE child.workspace_id = @workspaceID
+)
+SELECT root_id AS id, COUNT(*) FILTER (WHERE status = @done) AS done, COUNT(*) FILTER (WHERE status <> @archived) AS total
+FROM subtasks GROUP BY root_id`
+
+// progressRow is the Progress of the item or list identified by ID.
+type progressRow struct {
+	ID    uuid.UUID
+	Done  int
+	Total int
+}
+
+type todoItemRepository struct {
+	db *gorm.DB
+}
+
+// NewTodoItemRepository constructs a TodoItemRepository backed by GORM.
+func NewTodoItemRepository(db *gorm.DB) TodoItemRepository {
+	return &todoItemRepository{db: db}
+}
+
+func (r *todoItemRepository) Create(ctx context.Context, item *model.TodoItem) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create todo item: %w", tenant.ErrWorkspaceRequired)
+	}
+	item.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(item).Error; err != nil {
+		return fmt.Errorf("create todo item: %w", err)
+	}
+	return nil
+}
+
+// CreateAll inserts items with a single statement. Items keep the IDs they
+// carry, so subtasks can reference parents created alongside them.
+func (r *todoItemRepository) CreateAll(ctx context.Context, items []model.TodoItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create todo items: %w", tenant.ErrWorkspaceRequired)
+	}
+	for i := range items {
+		items[i].WorkspaceID = workspaceID
+	}
+	if err := conn(ctx, r.db).Create(&items).Error; err != nil {
+		return fmt.Errorf("create todo items: %w", err)
+	}
+	return nil
+}
+
+func (r *todoItemRepository) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.TodoItem, error) {
+	var item model.TodoItem
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&item, "id = ? AND todo_list_id = ?", id, todoListID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTodoItemNotFound
+		}
+		return nil, fmt.Errorf("find todo item: %w", err)
+	}
+	return &item, nil
+}
+
+// FindByTodoListID returns the list's items matching filter in rank order.
+// Unranked items come first, soonest due first and those without a due date
+// last.
+func (r *todoItemRepository) FindByTodoListID(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItem, error) {
+	var items []model.TodoItem
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx), dueFilterScope(filter.DueFilter)).
+		Where("todo_list_id = ?", todoListID).
+		Order("rank ASC").
+		Order("due_at ASC NULLS LAST").
+		Order("created_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("find todo items: %w", err)
+	}
+	return items, nil
+}
+
+func (r *todoItemRepository) Update(ctx context.Context, item *model.TodoItem) error {
+	result := conn(ctx, r.db).
+		Model(item).
+		Scopes(workspaceScope(ctx)).
+		Select("*").
+		Omit("id", "workspace_id", "todo_list_id", "parent_id", "rank", "created_at").
+		Updates(item)
+	if result.Error != nil {
+		return fmt.Errorf("update todo item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoItemNotFound
+	}
+	return nil
+}
+
+func (r *todoItemRepository) Delete(ctx context.Context, todoListID, id uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.TodoItem{}, "id = ? AND todo_list_id = ?", id, todoListID)
+	if result.Error != nil {
+		return fmt.Errorf("delete todo item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoItemNotFound
+	}
+	return nil
+}
+
+func (r *todoItemRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Delete(&model.TodoItem{}).Error; err != nil {
+		return fmt.Errorf("delete todo items: %w", err)
+	}
+	return nil
+}
+
+// FindDueByOwner returns up to limit items with a due date in the active,
+// non-template lists ownerID owns, earliest first. Archived items are left
+// out, as are finished items due before since.
+func (r *todoItemRepository) FindDueByOwner(ctx context.Context, ownerID string, since time.Time, limit int) ([]model.TodoItem, error) {
+	var items []model.TodoItem
+	if err := conn(ctx, r.db).
+		Select("todo_items.*").
+		Scopes(workspaceScope(ctx)).
+		Joins("JOIN todo_lists ON todo_lists.id = todo_items.todo_list_id").
+		Where("todo_lists.owner_id = ? AND todo_lists.archived_at IS NULL AND NOT todo_lists.is_template", ownerID).
+		Where("todo_items.due_at IS NOT NULL AND todo_items.status <> ?", model.StatusArchived).
+		Where("todo_items.status IN ? OR todo_items.due_at >= ?", model.PendingStatuses, since).
+		Order("todo_items.due_at, todo_items.id").
+		Limit(limit).
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("find due todo items: %w", err)
+	}
+	return items, nil
+}
+
+// FindLatestInSeries returns the occurrence of a recurring series that is due
+// last.
+func (r *todoItemRepository) FindLatestInSeries(ctx context.Context, seriesID uuid.UUID) (*model.TodoItem, error) {
+	var item model.TodoItem
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("series_id = ?", seriesID).
+		Order("due_at DESC").
+		First(&item).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTodoItemNotFound
+		}
+		return nil, fmt.Errorf("find latest todo item in series: %w", err)
+	}
+	return &item, nil
+}
+
+// ExistsInSeries reports whether the series already has an occurrence due at
+// dueAt.
+func (r *todoItemRepository) ExistsInSeries(ctx context.Context, seriesID uuid.UUID, dueAt time.Time) (bool, error) {
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Scopes(workspaceScope(ctx)).
+		Where("series_id = ? AND due_at = ?", seriesID, dueAt).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check todo item series: %w", err)
+	}
+	return count > 0, nil
+}
+
+// LastRank returns the highest rank in the list, ignoring excludeID, or ""
+// when the list has no other items.
+func (r *todoItemRepository) LastRank(ctx context.Context, todoListID, excludeID uuid.UUID) (string, error) {
+	rank, err := lastRank(r.listItems(ctx, todoListID), excludeID)
+	if err != nil {
+		return "", fmt.Errorf("find last todo item rank: %w", err)
+	}
+	return rank, nil
+}
+
+// AdjacentRank returns the rank of the list's item next to rank, ignoring
+// excludeIDs; see adjacentRank.
+func (r *todoItemRepository) AdjacentRank(ctx context.Context, todoListID uuid.UUID, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	neighbour, err := adjacentRank(r.listItems(ctx, todoListID), rank, excludeIDs, after)
+	if err != nil {
+		return "", fmt.Errorf("find adjacent todo item rank: %w", err)
+	}
+	return neighbour, nil
+}
+
+// Move stores the item's list, parent and rank.
+func (r *todoItemRepository) Move(ctx context.Context, item *model.TodoItem) error {
+	result := conn(ctx, r.db).
+		Model(item).
+		Scopes(workspaceScope(ctx)).
+		Select("todo_list_id", "parent_id", "rank", "updated_at").
+		Updates(item)
+	if result.Error != nil {
+		return fmt.Errorf("move todo item: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoItemNotFound
+	}
+	return nil
+}
+
+// FindForRebalance locks the list's items until the transaction ends and
+// returns them in display order, so that concurrent moves wait for the new
+// ranks instead of being overwritten by them.
+func (r *todoItemRepository) FindForRebalance(ctx context.Context, todoListID uuid.UUID) ([]model.TodoItem, error) {
+	var items []model.TodoItem
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("todo_list_id = ?", todoListID).
+		Order("rank ASC").
+		Order("due_at ASC NULLS LAST").
+		Order("created_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, fmt.Errorf("find todo items for rebalance: %w", err)
+	}
+	return items, nil
+}
+
+// SetRank stores a rebalanced rank without touching updated_at.
+func (r *todoItemRepository) SetRank(ctx context.Context, id uuid.UUID, rank string) error {
+	if err := conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Scopes(workspaceScope(ctx)).
+		Where("id = ?", id).
+		UpdateColumn("rank", rank).Error; err != nil {
+		return fmt.Errorf("set todo item rank: %w", err)
+	}
+	return nil
+}
+
+// FindUnbalanced returns up to limit lists, across all workspaces, holding
+// items that are unranked or ranked longer than maxLength, for the rank
+// rebalancer. Each result carries only WorkspaceID and TodoListID.
+func (r *todoItemRepository) FindUnbalanced(ctx context.Context, maxLength, limit int) ([]model.TodoItem, error) {
+	var lists []model.TodoItem
+	if err := conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Distinct("workspace_id", "todo_list_id").
+		Where(unbalancedRank, maxLength).
+		Limit(limit).
+		Find(&lists).Error; err != nil {
+		return nil, fmt.Errorf("find unbalanced todo lists: %w", err)
+	}
+	return lists, nil
+}
+
+// FindSubtree returns the item followed by all of its subtasks in one round
+// trip; parents come before their subtasks. It returns ErrTodoItemNotFound
+// when the item is not in the list.
+func (r *todoItemRepository) FindSubtree(ctx context.Context, todoListID, id uuid.UUID) ([]model.TodoItem, error) {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("find todo item subtree: %w", tenant.ErrWorkspaceRequired)
+	}
+	var items []model.TodoItem
+	if err := conn(ctx, r.db).
+		Raw(subtreeQuery, map[string]interface{}{"id": id, "todoListID": todoListID, "workspaceID": workspaceID}).
+		Scan(&items).Error; err != nil {
+		return nil, fmt.Errorf("find todo item subtree: %w", err)
+	}
+	if len(items) == 0 {
+		return nil, ErrTodoItemNotFound
+	}
+	return items, nil
+}
+
+// FindAncestors returns the items the item is nested under, nearest first,
+// or none for a top-level item.
+func (r *todoItemRepository) FindAncestors(ctx context.Context, id uuid.UUID) ([]model.TodoItem, error) {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("find todo item ancestors: %w", tenant.ErrWorkspaceRequired)
+	}
+	var items []model.TodoItem
+	if err := conn(ctx, r.db).
+		Raw(ancestorsQuery, map[string]interface{}{"id": id, "workspaceID": workspaceID}).
+		Scan(&items).Error; err != nil {
+		return nil, fmt.Errorf("find todo item ancestors: %w", err)
+	}
+	return items, nil
+}
+
+// MoveToTodoList moves the items to another list, keeping their parents and
+// ranks.
+func (r *todoItemRepository) MoveToTodoList(ctx context.Context, ids []uuid.UUID, todoListID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Scopes(workspaceScope(ctx)).
+		Where("id IN ?", ids).
+		Update("todo_list_id", todoListID).Error; err != nil {
+		return fmt.Errorf("move todo items: %w", err)
+	}
+	return nil
+}
+
+// CountSubtasks rolls up the subtasks at any depth below each of parentIDs
+// with one query, keyed by parent ID. Items without subtasks have no entry.
+func (r *todoItemRepository) CountSubtasks(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error) {
+	if len(parentIDs) == 0 {
+		return map[uuid.UUID]model.Progress{}, nil
+	}
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("count subtasks: %w", tenant.ErrWorkspaceRequired)
+	}
+	var rows []progressRow
+	if err := conn(ctx, r.db).
+		Raw(subtaskProgressQuery, map[string]interface{}{
+			"parentIDs":   parentIDs,
+			"workspaceID": workspaceID,
+			"done":        model.StatusDone,
+			"archived":    model.StatusArchived,
+		}).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("count subtasks: %w", err)
+	}
+	return progressByID(rows), nil
+}
+
+// CountByTodoListIDs rolls up the items of several lists with one query,
+// keyed by list ID. Lists without items have no entry.
+func (r *todoItemRepository) CountByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error) {
+	if len(todoListIDs) == 0 {
+		return map[uuid.UUID]model.Progress{}, nil
+	}
+	var rows []progressRow
+	if err := conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Scopes(workspaceScope(ctx)).
+		Select("todo_list_id AS id, COUNT(*) FILTER (WHERE status = ?) AS done, COUNT(*) FILTER (WHERE status <> ?) AS total",
+			model.StatusDone, model.StatusArchived).
+		Where("todo_list_id IN ?", todoListIDs).
+		Group("todo_list_id").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("count todo items by list: %w", err)
+	}
+	return progressByID(rows), nil
+}
+
+func progressByID(rows []progressRow) map[uuid.UUID]model.Progress {
+	progress := make(map[uuid.UUID]model.Progress, len(rows))
+	for _, row := range rows {
+		progress[row.ID] = model.Progress{Done: row.Done, Total: row.Total}
+	}
+	return progress
+}
+
+func (r *todoItemRepository) listItems(ctx context.Context, todoListID uuid.UUID) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&model.TodoItem{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID)
+}
@@ -0,0 +1,184 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoItemRepository_FindByTodoListID_FiltersOverdue(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_items" WHERE todo_list_id = \$1 AND "todo_items"\."workspace_id" = \$2 AND status = \$3 AND \(due_at < CURRENT_TIMESTAMP AND status IN \(\$4,\$5\)\) ORDER BY rank ASC,due_at ASC NULLS LAST,created_at ASC`).
+		WithArgs(todoListID, workspaceID, model.StatusOpen, model.StatusOpen, model.StatusInProgress).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "todo_list_id", "title", "status"}).
+			AddRow(uuid.New(), todoListID, "Milk", model.StatusOpen))
+	mock.ExpectClose()
+
+	items, err := repo.FindByTodoListID(workspaceContext(workspaceID), todoListID, model.TodoItemFilter{
+		DueFilter: model.DueFilter{Status: model.StatusOpen, Overdue: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func TestTodoItemRepository_Create_RequiresWorkspace(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+	mock.ExpectClose()
+
+	err := repo.Create(context.Background(), &model.TodoItem{Title: "Milk"})
+	require.ErrorIs(t, err, tenant.ErrWorkspaceRequired)
+}
+
+func TestTodoItemRepository_FindLatestInSeries(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	seriesID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_items" WHERE series_id = \$1 AND "todo_items"\."workspace_id" = \$2 ORDER BY due_at DESC,"todo_items"\."id" LIMIT \$3`).
+		WithArgs(seriesID, workspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "series_id", "title"}).
+			AddRow(uuid.New(), seriesID, "Standup"))
+	mock.ExpectClose()
+
+	item, err := repo.FindLatestInSeries(workspaceContext(workspaceID), seriesID)
+	require.NoError(t, err)
+	require.Equal(t, seriesID, *item.SeriesID)
+}
+
+func TestTodoItemRepository_FindDueByOwner(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	since := time.Date(2026, time.September, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`^SELECT todo_items\.\* FROM "todo_items" JOIN todo_lists ON todo_lists\.id = todo_items\.todo_list_id `+
+		`WHERE \(todo_lists\.owner_id = \$1 AND todo_lists\.archived_at IS NULL AND NOT todo_lists\.is_template\) `+
+		`AND \(todo_items\.due_at IS NOT NULL AND todo_items\.status <> \$2\) AND \(todo_items\.status IN \(\$3,\$4\) OR todo_items\.due_at >= \$5\) `+
+		`AND "todo_items"\."workspace_id" = \$6 ORDER BY todo_items\.due_at, todo_items\.id LIMIT \$7$`).
+		WithArgs("user-1", model.StatusArchived, model.StatusOpen, model.StatusInProgress, since, workspaceID, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "due_at"}).AddRow(uuid.New(), "Pay rent", since.AddDate(0, 1, 0)))
+	mock.ExpectClose()
+
+	items, err := repo.FindDueByOwner(workspaceContext(workspaceID), "user-1", since, 1000)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+}
+
+func TestTodoItemRepository_AdjacentRank(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+	anchorID := uuid.New()
+	movedID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT "rank" FROM "todo_items" WHERE todo_list_id = \$1 AND rank >= \$2 AND id NOT IN \(\$3,\$4\) AND "todo_items"\."workspace_id" = \$5 ORDER BY rank ASC LIMIT \$6`).
+		WithArgs(todoListID, "i", anchorID, movedID, workspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"rank"}).AddRow("r"))
+	mock.ExpectClose()
+
+	next, err := repo.AdjacentRank(workspaceContext(workspaceID), todoListID, "i", []uuid.UUID{anchorID, movedID}, true)
+	require.NoError(t, err)
+	require.Equal(t, "r", next)
+}
+
+func TestTodoItemRepository_FindUnbalanced_SpansWorkspaces(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT DISTINCT "workspace_id","todo_list_id" FROM "todo_items" WHERE rank = '' OR length\(rank\) > \$1 LIMIT \$2$`).
+		WithArgs(32, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"workspace_id", "todo_list_id"}).AddRow(workspaceID, todoListID))
+	mock.ExpectClose()
+
+	lists, err := repo.FindUnbalanced(context.Background(), 32, 100)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	require.Equal(t, todoListID, lists[0].TodoListID)
+}
+
+func TestTodoItemRepository_FindSubtree_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+	id := uuid.New()
+
+	mock.ExpectQuery(`^WITH RECURSIVE subtree AS \(.+\) SELECT .+ FROM subtree ORDER BY depth, rank, created_at$`).
+		WithArgs(id, todoListID, workspaceID, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectClose()
+
+	_, err := repo.FindSubtree(workspaceContext(workspaceID), todoListID, id)
+	require.ErrorIs(t, err, repository.ErrTodoItemNotFound)
+}
+
+func TestTodoItemRepository_CountByTodoListIDs(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoItemRepository(gormDB)
+
+	workspaceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(`^SELECT todo_list_id AS id, COUNT\(\*\) FILTER \(WHERE status = \$1\) AS done, COUNT\(\*\) FILTER \(WHERE status <> \$2\) AS total FROM "todo_items" WHERE todo_list_id IN \(\$3,\$4\) AND "todo_items"\."workspace_id" = \$5 GROUP BY "todo_list_id"$`).
+		WithArgs(model.StatusDone, model.StatusArchived, first, second, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "done", "total"}).AddRow(first, 2, 5))
+	mock.ExpectClose()
+
+	progress, err := repo.CountByTodoListIDs(workspaceContext(workspaceID), []uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]model.Progress{first: {Done: 2, Total: 5}}, progress)
+}
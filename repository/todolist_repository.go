@@ -4,24 +4,58 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ErrTodoListNotFound indicates that the todo list record does not exist.
 var ErrTodoListNotFound = errors.New("todo list not found")
 
 // TodoListRepository defines database operations for todo lists.
+// Every operation is scoped to the workspace carried by the context.
 type TodoListRepository interface {
 	Create(ctx context.Context, todoList *model.TodoList) error
 	FindByID(ctx context.Context, id uuid.UUID) (*model.TodoList, error)
-	FindAll(ctx context.Context) ([]model.TodoList, error)
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]model.TodoList, error)
+	FindAll(ctx context.Context, filter model.TodoListFilter) ([]model.TodoList, error)
 	Update(ctx context.Context, todoList *model.TodoList) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	FirstRank(ctx context.Context) (string, error)
+	LastRank(ctx context.Context, excludeID uuid.UUID) (string, error)
+	AdjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error)
+	UpdateRank(ctx context.Context, todoList *model.TodoList) error
+	UpdateTemplate(ctx context.Context, todoList *model.TodoList) error
+	UpdateArchived(ctx context.Context, todoList *model.TodoList) error
+	FindAutoArchivable(ctx context.Context, now time.Time, limit int) ([]model.TodoList, error)
+	FindForRebalance(ctx context.Context) ([]model.TodoList, error)
+	SetRank(ctx context.Context, id uuid.UUID, rank string) error
+	FindUnbalancedWorkspaces(ctx context.Context, maxLength, limit int) ([]uuid.UUID, error)
 }
 
+// autoArchivableQuery selects the unarchived lists, other than templates, of
+// users with an auto-archive policy that have items, none of them pending,
+// and in which neither the list nor any item has changed for the policy's
+// number of days.
+const autoArchivableQuery = `SELECT todo_lists.* FROM todo_lists
+JOIN users ON users.id::text = todo_lists.owner_id
+WHERE users.auto_archive_after_days IS NOT NULL
+	AND todo_lists.archived_at IS NULL
+	AND NOT todo_lists.is_template
+	AND todo_lists.updated_at <= @now - make_interval(days => users.auto_archive_after_days)
+	AND EXISTS (SELECT 1 FROM todo_items WHERE todo_items.todo_list_id = todo_lists.id)
+	AND NOT EXISTS (
+		SELECT 1 FROM todo_items
+		WHERE todo_items.todo_list_id = todo_lists.id
+			AND (todo_items.status IN @pending OR todo_items.updated_at > @now - make_interval(days => users.auto_archive_after_days))
+	)
+ORDER BY todo_lists.updated_at
+LIMIT @limit`
+
 type todoListRepository struct {
 	db *gorm.DB
 }
@@ -32,7 +66,12 @@ func NewTodoListRepository(db *gorm.DB) TodoListRepository {
 }
 
 func (r *todoListRepository) Create(ctx context.Context, todoList *model.TodoList) error {
-	if err := r.db.WithContext(ctx).Create(todoList).Error; err != nil {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create todo list: %w", tenant.ErrWorkspaceRequired)
+	}
+	todoList.WorkspaceID = workspaceID
+	if err := conn(ctx, r.db).Create(todoList).Error; err != nil {
 		return fmt.Errorf("create todo list: %w", err)
 	}
 	return nil
@@ -40,7 +79,9 @@ func (r *todoListRepository) Create(ctx context.Context, todoList *model.TodoLis
 
 func (r *todoListRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.TodoList, error) {
 	var todoList model.TodoList
-	if err := r.db.WithContext(ctx).First(&todoList, "id = ?", id).Error; err != nil {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&todoList, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrTodoListNotFound
 		}
@@ -49,9 +90,36 @@ func (r *todoListRepository) FindByID(ctx context.Context, id uuid.UUID) (*model
 	return &todoList, nil
 }
 
-func (r *todoListRepository) FindAll(ctx context.Context) ([]model.TodoList, error) {
+// FindByIDs returns the lists with the given IDs in no particular order,
+// skipping IDs that do not exist.
+func (r *todoListRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]model.TodoList, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var todoLists []model.TodoList
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("id IN ?", ids).
+		Find(&todoLists).Error; err != nil {
+		return nil, fmt.Errorf("find todo lists by ids: %w", err)
+	}
+	return todoLists, nil
+}
+
+// FindAll returns the workspace's lists, or its templates when
+// filter.Templates is set, matching filter in rank order. Archived lists are
+// left out unless filter.IncludeArchived is set. Unranked lists come first,
+// newest first.
+func (r *todoListRepository) FindAll(ctx context.Context, filter model.TodoListFilter) ([]model.TodoList, error) {
 	var todoLists []model.TodoList
-	if err := r.db.WithContext(ctx).
+	query := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx), dueFilterScope(filter.DueFilter), tagFilterScope(ctx, filter)).
+		Where("is_template = ?", filter.Templates)
+	if !filter.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+	if err := query.
+		Order("rank ASC").
 		Order("created_at DESC").
 		Find(&todoLists).Error; err != nil {
 		return nil, fmt.Errorf("find all todo lists: %w", err)
@@ -60,14 +128,27 @@ func (r *todoListRepository) FindAll(ctx context.Context) ([]model.TodoList, err
 }
 
 func (r *todoListRepository) Update(ctx context.Context, todoList *model.TodoList) error {
-	if err := r.db.WithContext(ctx).Save(todoList).Error; err != nil {
-		return fmt.Errorf("update todo list: %w", err)
+	// Updates is used instead of Save so that a row belonging to another
+	// workspace is reported as missing rather than upserted.
+	result := conn(ctx, r.db).
+		Model(todoList).
+		Scopes(workspaceScope(ctx)).
+		Select("*").
+		Omit("id", "workspace_id", "owner_id", "rank", "is_template", "archived_at", "created_at").
+		Updates(todoList)
+	if result.Error != nil {
+		return fmt.Errorf("update todo list: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoListNotFound
 	}
 	return nil
 }
 
 func (r *todoListRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&model.TodoList{}, "id = ?", id)
+	result := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Delete(&model.TodoList{}, "id = ?", id)
 	if result.Error != nil {
 		return fmt.Errorf("delete todo list: %w", result.Error)
 	}
@@ -76,3 +157,186 @@ func (r *todoListRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+// FirstRank returns the lowest non-empty rank in the workspace, or "" when no
+// list has been ranked yet.
+func (r *todoListRepository) FirstRank(ctx context.Context) (string, error) {
+	var ranks []string
+	if err := r.lists(ctx).
+		Where("rank <> ''").
+		Order("rank ASC").
+		Limit(1).
+		Pluck("rank", &ranks).Error; err != nil {
+		return "", fmt.Errorf("find first todo list rank: %w", err)
+	}
+	if len(ranks) == 0 {
+		return "", nil
+	}
+	return ranks[0], nil
+}
+
+// LastRank returns the highest rank in the workspace, ignoring excludeID, or
+// "" when the workspace has no other lists.
+func (r *todoListRepository) LastRank(ctx context.Context, excludeID uuid.UUID) (string, error) {
+	rank, err := lastRank(r.lists(ctx), excludeID)
+	if err != nil {
+		return "", fmt.Errorf("find last todo list rank: %w", err)
+	}
+	return rank, nil
+}
+
+// AdjacentRank returns the rank of the list next to rank, ignoring
+// excludeIDs; see adjacentRank.
+func (r *todoListRepository) AdjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	neighbour, err := adjacentRank(r.lists(ctx), rank, excludeIDs, after)
+	if err != nil {
+		return "", fmt.Errorf("find adjacent todo list rank: %w", err)
+	}
+	return neighbour, nil
+}
+
+// UpdateRank stores the list's rank.
+func (r *todoListRepository) UpdateRank(ctx context.Context, todoList *model.TodoList) error {
+	result := conn(ctx, r.db).
+		Model(todoList).
+		Scopes(workspaceScope(ctx)).
+		Select("rank", "updated_at").
+		Updates(todoList)
+	if result.Error != nil {
+		return fmt.Errorf("update todo list rank: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoListNotFound
+	}
+	return nil
+}
+
+// UpdateTemplate stores whether the list is a template.
+func (r *todoListRepository) UpdateTemplate(ctx context.Context, todoList *model.TodoList) error {
+	result := conn(ctx, r.db).
+		Model(todoList).
+		Scopes(workspaceScope(ctx)).
+		Select("is_template", "updated_at").
+		Updates(todoList)
+	if result.Error != nil {
+		return fmt.Errorf("update todo list template: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoListNotFound
+	}
+	return nil
+}
+
+// UpdateArchived stores when the list was archived, or that it is not.
+func (r *todoListRepository) UpdateArchived(ctx context.Context, todoList *model.TodoList) error {
+	result := conn(ctx, r.db).
+		Model(todoList).
+		Scopes(workspaceScope(ctx)).
+		Select("archived_at", "updated_at").
+		Updates(todoList)
+	if result.Error != nil {
+		return fmt.Errorf("update todo list archived: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrTodoListNotFound
+	}
+	return nil
+}
+
+// FindAutoArchivable returns up to limit lists, across all tenants, that the
+// auto-archive policies of their owners say should be archived at now.
+func (r *todoListRepository) FindAutoArchivable(ctx context.Context, now time.Time, limit int) ([]model.TodoList, error) {
+	var todoLists []model.TodoList
+	if err := conn(ctx, r.db).
+		Raw(autoArchivableQuery, map[string]interface{}{
+			"now":     now,
+			"pending": model.PendingStatuses,
+			"limit":   limit,
+		}).
+		Scan(&todoLists).Error; err != nil {
+		return nil, fmt.Errorf("find auto-archivable todo lists: %w", err)
+	}
+	return todoLists, nil
+}
+
+// FindForRebalance locks the workspace's lists until the transaction ends and
+// returns them in display order, so that concurrent moves wait for the new
+// ranks instead of being overwritten by them.
+func (r *todoListRepository) FindForRebalance(ctx context.Context) ([]model.TodoList, error) {
+	var todoLists []model.TodoList
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("rank ASC").
+		Order("created_at DESC").
+		Find(&todoLists).Error; err != nil {
+		return nil, fmt.Errorf("find todo lists for rebalance: %w", err)
+	}
+	return todoLists, nil
+}
+
+// SetRank stores a rebalanced rank without touching updated_at.
+func (r *todoListRepository) SetRank(ctx context.Context, id uuid.UUID, rank string) error {
+	if err := r.lists(ctx).
+		Where("id = ?", id).
+		UpdateColumn("rank", rank).Error; err != nil {
+		return fmt.Errorf("set todo list rank: %w", err)
+	}
+	return nil
+}
+
+// FindUnbalancedWorkspaces returns up to limit workspaces, across all
+// tenants, holding lists that are unranked or ranked longer than maxLength,
+// for the rank rebalancer.
+func (r *todoListRepository) FindUnbalancedWorkspaces(ctx context.Context, maxLength, limit int) ([]uuid.UUID, error) {
+	var workspaceIDs []uuid.UUID
+	if err := conn(ctx, r.db).
+		Model(&model.TodoList{}).
+		Distinct().
+		Where(unbalancedRank, maxLength).
+		Limit(limit).
+		Pluck("workspace_id", &workspaceIDs).Error; err != nil {
+		return nil, fmt.Errorf("find unbalanced workspaces: %w", err)
+	}
+	return workspaceIDs, nil
+}
+
+func (r *todoListRepository) lists(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Model(&model.TodoList{}).
+		Scopes(workspaceScope(ctx))
+}
+
+// tagFilterScope keeps the lists carrying any of filter.Tags, or all of them
+// when filter.Match is "all". Tag names resolve through the unique
+// (workspace_id, name) index and lists through the (tag_id, todo_list_id)
+// index, so the subquery never scans the join table.
+func tagFilterScope(ctx context.Context, filter model.TodoListFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(filter.Tags) == 0 {
+			return db
+		}
+		// workspaceScope has already failed the query when no workspace is set.
+		workspaceID, _ := tenant.WorkspaceIDFromContext(ctx)
+		tagged := db.Session(&gorm.Session{NewDB: true}).
+			Table("todo_list_tags").
+			Select("todo_list_tags.todo_list_id").
+			Joins("JOIN tags ON tags.id = todo_list_tags.tag_id").
+			Where("tags.workspace_id = ? AND tags.name IN ?", workspaceID, filter.Tags)
+		if filter.Match == model.TagMatchAll {
+			// Names are unique per workspace, so each distinct name matches one row per list.
+			tagged = tagged.
+				Group("todo_list_tags.todo_list_id").
+				Having("COUNT(*) = ?", distinctCount(filter.Tags))
+		}
+		return db.Where("id IN (?)", tagged)
+	}
+}
+
+func distinctCount(values []string) int {
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		seen[value] = struct{}{}
+	}
+	return len(seen)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
 	"github.com/lumoshiveacademy/todolist/repository"
 	"github.com/stretchr/testify/require"
 	"gorm.io/driver/postgres"
@@ -32,6 +34,10 @@ func setupRepository(t *testing.T) (*gorm.DB, sqlmock.Sqlmock, repository.TodoLi
 	return gormDB, mock, repo, cleanup
 }
 
+func workspaceContext(workspaceID uuid.UUID) context.Context {
+	return tenant.WithWorkspaceID(context.Background(), workspaceID)
+}
+
 func TestTodoListRepository_Create_Succeeds(t *testing.T) {
 	_, mock, repo, cleanup := setupRepository(t)
 	defer func() {
@@ -39,21 +45,36 @@ func TestTodoListRepository_Create_Succeeds(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	}()
 
+	workspaceID := uuid.New()
 	todoList := &model.TodoList{
 		ID:          uuid.New(),
+		OwnerID:     "user-1",
 		Title:       "Groceries",
 		Description: "Weekly grocery items",
 	}
 
 	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "todo_lists"`)).
-		WithArgs(todoList.ID, todoList.Title, todoList.Description, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(todoList.ID, workspaceID, todoList.OwnerID, todoList.Title, todoList.Description, model.StatusOpen, model.PriorityMedium, nil, false, nil, "", sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 	mock.ExpectClose()
 
-	err := repo.Create(context.Background(), todoList)
+	err := repo.Create(workspaceContext(workspaceID), todoList)
 	require.NoError(t, err)
+	require.Equal(t, workspaceID, todoList.WorkspaceID)
+}
+
+func TestTodoListRepository_Create_RequiresWorkspace(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	mock.ExpectClose()
+
+	err := repo.Create(context.Background(), &model.TodoList{Title: "Groceries"})
+	require.ErrorIs(t, err, tenant.ErrWorkspaceRequired)
 }
 
 func TestTodoListRepository_FindByID_NotFound(t *testing.T) {
@@ -63,17 +84,129 @@ func TestTodoListRepository_FindByID_NotFound(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	}()
 
+	workspaceID := uuid.New()
 	id := uuid.New()
-	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE id = \$1.*`).
-		WithArgs(id, 1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "created_at", "updated_at"}))
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE id = \$1 AND "todo_lists"."workspace_id" = \$2.*`).
+		WithArgs(id, workspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "owner_id", "title", "description", "created_at", "updated_at"}))
 	mock.ExpectClose()
 
-	todoList, err := repo.FindByID(context.Background(), id)
+	todoList, err := repo.FindByID(workspaceContext(workspaceID), id)
 	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
 	require.Nil(t, todoList)
 }
 
+func TestTodoListRepository_FindByID_OtherWorkspaceIsInvisible(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	ownerWorkspaceID := uuid.New()
+	otherWorkspaceID := uuid.New()
+	id := uuid.New()
+
+	// The row exists for ownerWorkspaceID, but the query is always bound to the
+	// caller's workspace, so the database returns nothing for otherWorkspaceID.
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE id = \$1 AND "todo_lists"."workspace_id" = \$2.*`).
+		WithArgs(id, otherWorkspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "owner_id", "title", "description", "created_at", "updated_at"}))
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE id = \$1 AND "todo_lists"."workspace_id" = \$2.*`).
+		WithArgs(id, ownerWorkspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "owner_id", "title", "description", "created_at", "updated_at"}).
+			AddRow(id, ownerWorkspaceID, "user-1", "Groceries", "", nil, nil))
+	mock.ExpectClose()
+
+	_, err := repo.FindByID(workspaceContext(otherWorkspaceID), id)
+	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
+
+	todoList, err := repo.FindByID(workspaceContext(ownerWorkspaceID), id)
+	require.NoError(t, err)
+	require.Equal(t, ownerWorkspaceID, todoList.WorkspaceID)
+}
+
+func TestTodoListRepository_FindByIDs(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	workspaceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE id IN \(\$1,\$2\) AND "todo_lists"."workspace_id" = \$3$`).
+		WithArgs(first, second, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "owner_id", "title"}).
+			AddRow(second, workspaceID, "user-1", "Chores"))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindByIDs(workspaceContext(workspaceID), []uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Len(t, todoLists, 1)
+	require.Equal(t, second, todoLists[0].ID)
+}
+
+func TestTodoListRepository_FindAll_RequiresWorkspace(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAll(context.Background(), model.TodoListFilter{})
+	require.ErrorIs(t, err, tenant.ErrWorkspaceRequired)
+	require.Nil(t, todoLists)
+}
+
+func TestTodoListRepository_FindAll_AppliesDueFilter(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	workspaceID := uuid.New()
+	dueBefore := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE is_template = \$1 AND archived_at IS NULL AND "todo_lists"\."workspace_id" = \$2 AND priority = \$3 AND \(due_at < CURRENT_TIMESTAMP AND status IN \(\$4,\$5\)\) AND due_at < \$6 ORDER BY rank ASC,created_at DESC`).
+		WithArgs(false, workspaceID, model.PriorityHigh, model.StatusOpen, model.StatusInProgress, dueBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "title", "status", "priority", "due_at"}).
+			AddRow(uuid.New(), workspaceID, "Taxes", model.StatusOpen, model.PriorityHigh, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAll(workspaceContext(workspaceID), model.TodoListFilter{DueFilter: model.DueFilter{
+		Priority:  model.PriorityHigh,
+		Overdue:   true,
+		DueBefore: &dueBefore,
+	}})
+	require.NoError(t, err)
+	require.Len(t, todoLists, 1)
+	require.Equal(t, model.PriorityHigh, todoLists[0].Priority)
+}
+
+func TestTodoListRepository_Update_OtherWorkspaceNotFound(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	workspaceID := uuid.New()
+	todoList := &model.TodoList{ID: uuid.New(), WorkspaceID: uuid.New(), Title: "Hijacked"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "todo_lists" SET "title"=\$1,"description"=\$2,"status"=\$3,"priority"=\$4,"due_at"=\$5,"updated_at"=\$6 WHERE "todo_lists"."workspace_id" = \$7 AND "id" = \$8`).
+		WithArgs("Hijacked", "", "", "", nil, sqlmock.AnyArg(), workspaceID, todoList.ID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.Update(workspaceContext(workspaceID), todoList)
+	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
+}
+
 func TestTodoListRepository_Delete_NotFound(t *testing.T) {
 	_, mock, repo, cleanup := setupRepository(t)
 	defer func() {
@@ -81,14 +214,97 @@ func TestTodoListRepository_Delete_NotFound(t *testing.T) {
 		require.NoError(t, mock.ExpectationsWereMet())
 	}()
 
+	workspaceID := uuid.New()
 	id := uuid.New()
 	mock.ExpectBegin()
-	mock.ExpectExec(`^DELETE FROM "todo_lists" WHERE id = \$1`).
-		WithArgs(id).
+	mock.ExpectExec(`^DELETE FROM "todo_lists" WHERE id = \$1 AND "todo_lists"."workspace_id" = \$2`).
+		WithArgs(id, workspaceID).
 		WillReturnResult(sqlmock.NewResult(0, 0))
 	mock.ExpectCommit()
 	mock.ExpectClose()
 
-	err := repo.Delete(context.Background(), id)
+	err := repo.Delete(workspaceContext(workspaceID), id)
 	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
 }
+
+func TestTodoListRepository_FindAll_MatchesAnyTag(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	workspaceID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE is_template = \$1 AND archived_at IS NULL AND "todo_lists"\."workspace_id" = \$2 AND id IN \(SELECT todo_list_tags\.todo_list_id FROM "todo_list_tags" JOIN tags ON tags\.id = todo_list_tags\.tag_id WHERE tags\.workspace_id = \$3 AND tags\.name IN \(\$4,\$5\)\) ORDER BY rank ASC,created_at DESC$`).
+		WithArgs(false, workspaceID, workspaceID, "home", "urgent").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "title"}).
+			AddRow(uuid.New(), workspaceID, "Chores"))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAll(workspaceContext(workspaceID), model.TodoListFilter{Tags: []string{"home", "urgent"}})
+	require.NoError(t, err)
+	require.Len(t, todoLists, 1)
+}
+
+func TestTodoListRepository_FindAll_MatchesAllTags(t *testing.T) {
+	_, mock, repo, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+
+	workspaceID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE is_template = \$1 AND archived_at IS NULL AND "todo_lists"\."workspace_id" = \$2 AND status = \$3 AND id IN \(SELECT todo_list_tags\.todo_list_id FROM "todo_list_tags" JOIN tags ON tags\.id = todo_list_tags\.tag_id WHERE tags\.workspace_id = \$4 AND tags\.name IN \(\$5,\$6,\$7\) GROUP BY "todo_list_tags"\."todo_list_id" HAVING COUNT\(\*\) = \$8\) ORDER BY rank ASC,created_at DESC$`).
+		WithArgs(false, workspaceID, model.StatusOpen, workspaceID, "home", "urgent", "home", 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id", "title"}))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAll(workspaceContext(workspaceID), model.TodoListFilter{
+		DueFilter: model.DueFilter{Status: model.StatusOpen},
+		Tags:      []string{"home", "urgent", "home"},
+		Match:     model.TagMatchAll,
+	})
+	require.NoError(t, err)
+	require.Empty(t, todoLists)
+}
+
+func TestTodoListRepository_FindAll_IncludeArchived(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoListRepository(gormDB)
+
+	workspaceID := uuid.New()
+	mock.ExpectQuery(`^SELECT \* FROM "todo_lists" WHERE is_template = \$1 AND "todo_lists"\."workspace_id" = \$2 ORDER BY rank ASC,created_at DESC$`).
+		WithArgs(false, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "archived_at"}).AddRow(uuid.New(), "Move house", time.Now()))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAll(workspaceContext(workspaceID), model.TodoListFilter{IncludeArchived: true})
+	require.NoError(t, err)
+	require.Len(t, todoLists, 1)
+	require.NotNil(t, todoLists[0].ArchivedAt)
+}
+
+func TestTodoListRepository_FindAutoArchivable(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoListRepository(gormDB)
+
+	now := time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`^SELECT todo_lists\.\* FROM todo_lists\s+JOIN users ON users\.id::text = todo_lists\.owner_id\s+WHERE users\.auto_archive_after_days IS NOT NULL .* LIMIT \$5$`).
+		WithArgs(now, model.StatusOpen, model.StatusInProgress, now, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "workspace_id"}).AddRow(uuid.New(), uuid.New()))
+	mock.ExpectClose()
+
+	todoLists, err := repo.FindAutoArchivable(context.Background(), now, 50)
+	require.NoError(t, err)
+	require.Len(t, todoLists, 1)
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"gorm.io/gorm"
+)
+
+// ErrRevisionNotFound indicates that the todo list revision does not exist.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// TodoListRevisionRepository defines database operations for todo list
+// revisions. Every operation is scoped to the workspace carried by the context.
+type TodoListRevisionRepository interface {
+	Create(ctx context.Context, revision *model.TodoListRevision) error
+	LatestNumber(ctx context.Context, todoListID uuid.UUID) (int, error)
+	FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.TodoListRevision, error)
+	FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) ([]model.TodoListRevision, error)
+	FindByNumber(ctx context.Context, todoListID uuid.UUID, number int) (*model.TodoListRevision, error)
+	DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error
+}
+
+type todoListRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewTodoListRevisionRepository constructs a TodoListRevisionRepository backed by GORM.
+func NewTodoListRevisionRepository(db *gorm.DB) TodoListRevisionRepository {
+	return &todoListRevisionRepository{db: db}
+}
+
+// Create appends revision as the list's next number. Callers serialize
+// concurrent writers by updating the todo list row in the same transaction
+// first; the unique (todo_list_id, number) index rejects any that slip through.
+func (r *todoListRevisionRepository) Create(ctx context.Context, revision *model.TodoListRevision) error {
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return fmt.Errorf("create revision: %w", tenant.ErrWorkspaceRequired)
+	}
+	latest, err := r.LatestNumber(ctx, revision.TodoListID)
+	if err != nil {
+		return fmt.Errorf("create revision: %w", err)
+	}
+	revision.WorkspaceID = workspaceID
+	revision.Number = latest + 1
+	if err := conn(ctx, r.db).Create(revision).Error; err != nil {
+		return fmt.Errorf("create revision: %w", err)
+	}
+	return nil
+}
+
+// LatestNumber returns the highest revision number of the list, or 0 when it
+// has none.
+func (r *todoListRevisionRepository) LatestNumber(ctx context.Context, todoListID uuid.UUID) (int, error) {
+	var latest int
+	if err := conn(ctx, r.db).
+		Model(&model.TodoListRevision{}).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&latest).Error; err != nil {
+		return 0, fmt.Errorf("find latest revision: %w", err)
+	}
+	return latest, nil
+}
+
+// FindByTodoListID returns the list's revisions, newest first.
+func (r *todoListRevisionRepository) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.TodoListRevision, error) {
+	var revisions []model.TodoListRevision
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Order("number DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("find revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// FindByTodoListIDs returns the revisions of all the given lists, grouped by
+// list and newest first within each.
+func (r *todoListRevisionRepository) FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) ([]model.TodoListRevision, error) {
+	if len(todoListIDs) == 0 {
+		return nil, nil
+	}
+	var revisions []model.TodoListRevision
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id IN ?", todoListIDs).
+		Order("todo_list_id, number DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, fmt.Errorf("find revisions by todo lists: %w", err)
+	}
+	return revisions, nil
+}
+
+func (r *todoListRevisionRepository) FindByNumber(ctx context.Context, todoListID uuid.UUID, number int) (*model.TodoListRevision, error) {
+	var revision model.TodoListRevision
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		First(&revision, "todo_list_id = ? AND number = ?", todoListID, number).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("find revision: %w", err)
+	}
+	return &revision, nil
+}
+
+func (r *todoListRevisionRepository) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Scopes(workspaceScope(ctx)).
+		Where("todo_list_id = ?", todoListID).
+		Delete(&model.TodoListRevision{}).Error; err != nil {
+		return fmt.Errorf("delete revisions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoListRevisionRepository_Create_AssignsNextNumber(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoListRevisionRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT COALESCE\(MAX\(number\), 0\) FROM "todo_list_revisions" WHERE todo_list_id = \$1 AND "todo_list_revisions"\."workspace_id" = \$2`).
+		WithArgs(todoListID, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(4))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "todo_list_revisions"`)).
+		WithArgs(sqlmock.AnyArg(), workspaceID, todoListID, 5, "Groceries", "", "user-1", nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	revision := &model.TodoListRevision{TodoListID: todoListID, Title: "Groceries", AuthorID: "user-1"}
+	err := repo.Create(workspaceContext(workspaceID), revision)
+	require.NoError(t, err)
+	require.Equal(t, 5, revision.Number)
+	require.Equal(t, workspaceID, revision.WorkspaceID)
+}
+
+func TestTodoListRevisionRepository_FindByNumber_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoListRevisionRepository(gormDB)
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+
+	mock.ExpectQuery(`^SELECT \* FROM "todo_list_revisions" WHERE \(todo_list_id = \$1 AND number = \$2\) AND "todo_list_revisions"\."workspace_id" = \$3`).
+		WithArgs(todoListID, 7, workspaceID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectClose()
+
+	revision, err := repo.FindByNumber(workspaceContext(workspaceID), todoListID, 7)
+	require.ErrorIs(t, err, repository.ErrRevisionNotFound)
+	require.Nil(t, revision)
+}
+
+func TestTodoListRevisionRepository_FindByTodoListIDs(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewTodoListRevisionRepository(gormDB)
+
+	workspaceID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	mock.ExpectQuery(`^SELECT \* FROM "todo_list_revisions" WHERE todo_list_id IN \(\$1,\$2\) AND "todo_list_revisions"\."workspace_id" = \$3 ORDER BY todo_list_id, number DESC$`).
+		WithArgs(first, second, workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"todo_list_id", "number", "title"}).
+			AddRow(first, 2, "Groceries").
+			AddRow(first, 1, "Shopping"))
+	mock.ExpectClose()
+
+	revisions, err := repo.FindByTodoListIDs(workspaceContext(workspaceID), []uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, 2, revisions[0].Number)
+}
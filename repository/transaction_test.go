@@ -0,0 +1,61 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactor_RepositoriesJoinTransaction(t *testing.T) {
+	gormDB, mock, todoLists, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	auditLogs := repository.NewAuditLogRepository(gormDB)
+	transactor := repository.NewTransactor(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "todo_lists"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_logs"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := transactor.WithinTransaction(workspaceContext(uuid.New()), func(ctx context.Context) error {
+		if err := todoLists.Create(ctx, &model.TodoList{OwnerID: "user-1", Title: "Groceries"}); err != nil {
+			return err
+		}
+		return auditLogs.Create(ctx, &model.AuditLog{ActorID: "user-1", Action: model.AuditActionCreate})
+	})
+	require.NoError(t, err)
+}
+
+func TestTransactor_RollsBackOnError(t *testing.T) {
+	gormDB, mock, todoLists, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	transactor := repository.NewTransactor(gormDB)
+	failure := errors.New("audit failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "todo_lists"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectRollback()
+	mock.ExpectClose()
+
+	err := transactor.WithinTransaction(workspaceContext(uuid.New()), func(ctx context.Context) error {
+		if err := todoLists.Create(ctx, &model.TodoList{OwnerID: "user-1", Title: "Groceries"}); err != nil {
+			return err
+		}
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserNotFound indicates that the user record does not exist.
+	ErrUserNotFound = errors.New("user not found")
+	// ErrUserEmailTaken indicates that another account already uses the email.
+	ErrUserEmailTaken = errors.New("email already registered")
+	// ErrUsernameTaken indicates that another account already uses the username.
+	ErrUsernameTaken = errors.New("username already taken")
+	// ErrMFACodeReused indicates that a TOTP code at or before the last accepted step was presented.
+	ErrMFACodeReused = errors.New("mfa code already used")
+)
+
+// UserRepository defines database operations for user accounts.
+type UserRepository interface {
+	Create(ctx context.Context, user *model.User) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	FindByUsernames(ctx context.Context, workspaceID uuid.UUID, usernames []string) ([]model.User, error)
+	Update(ctx context.Context, user *model.User) error
+	AdvanceMFAStep(ctx context.Context, id uuid.UUID, step int64) error
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository constructs a UserRepository backed by GORM.
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) Create(ctx context.Context, user *model.User) error {
+	if err := conn(ctx, r.db).Create(user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return r.duplicateError(ctx, user)
+		}
+		return fmt.Errorf("create user: %w", err)
+	}
+	return nil
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("find user by email: %w", err)
+	}
+	return &user, nil
+}
+
+// FindByUsernames returns the members of the workspace holding any of the
+// usernames. Unknown usernames and users outside the workspace are skipped.
+func (r *userRepository) FindByUsernames(ctx context.Context, workspaceID uuid.UUID, usernames []string) ([]model.User, error) {
+	var users []model.User
+	if len(usernames) == 0 {
+		return users, nil
+	}
+	members := r.db.Model(&model.WorkspaceMember{}).Select("subject").Where("workspace_id = ?", workspaceID)
+	if err := conn(ctx, r.db).
+		Where("username IN ?", usernames).
+		Where("CAST(id AS TEXT) IN (?)", members).
+		Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("find users by username: %w", err)
+	}
+	return users, nil
+}
+
+func (r *userRepository) Update(ctx context.Context, user *model.User) error {
+	result := r.db.WithContext(ctx).
+		Model(user).
+		Select("*").
+		Omit("id", "email", "created_at", "mfa_last_step").
+		Updates(user)
+	if result.Error != nil {
+		// The email cannot change here, so only the username can collide.
+		if errors.Is(result.Error, gorm.ErrDuplicatedKey) {
+			return ErrUsernameTaken
+		}
+		return fmt.Errorf("update user: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// AdvanceMFAStep records step as the last accepted TOTP step, failing when a
+// concurrent request already accepted the same or a later step.
+func (r *userRepository) AdvanceMFAStep(ctx context.Context, id uuid.UUID, step int64) error {
+	result := r.db.WithContext(ctx).
+		Model(&model.User{}).
+		Where("id = ? AND mfa_last_step < ?", id, step).
+		Update("mfa_last_step", step)
+	if result.Error != nil {
+		return fmt.Errorf("advance mfa step: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrMFACodeReused
+	}
+	return nil
+}
+
+// duplicateError tells which unique column a new user collided on. The
+// driver error does not say, so the email is checked and the username is
+// blamed otherwise.
+func (r *userRepository) duplicateError(ctx context.Context, user *model.User) error {
+	if user.Username == nil {
+		return ErrUserEmailTaken
+	}
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.User{}).Where("email = ?", user.Email).Count(&count).Error; err != nil {
+		return fmt.Errorf("create user: %w", err)
+	}
+	if count > 0 {
+		return ErrUserEmailTaken
+	}
+	return ErrUsernameTaken
+}
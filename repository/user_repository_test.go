@@ -0,0 +1,31 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_AdvanceMFAStep_Reused(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewUserRepository(gormDB)
+
+	id := uuid.New()
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "users" SET "mfa_last_step"=\$1,"updated_at"=\$2 WHERE id = \$3 AND mfa_last_step < \$4`).
+		WithArgs(int64(42), sqlmock.AnyArg(), id, int64(42)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err := repo.AdvanceMFAStep(context.Background(), id, 42)
+	require.ErrorIs(t, err, repository.ErrMFACodeReused)
+}
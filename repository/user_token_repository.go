@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+)
+
+// ErrUserTokenNotFound indicates that no unused, unexpired token matches.
+var ErrUserTokenNotFound = errors.New("user token not found")
+
+// UserTokenRepository defines database operations for password reset and
+// email verification tokens.
+type UserTokenRepository interface {
+	Create(ctx context.Context, userToken *model.UserToken) error
+	Consume(ctx context.Context, purpose, tokenHash string, at time.Time) (*model.UserToken, error)
+	InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string, at time.Time) error
+}
+
+type userTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository constructs a UserTokenRepository backed by GORM.
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{db: db}
+}
+
+func (r *userTokenRepository) Create(ctx context.Context, userToken *model.UserToken) error {
+	if err := r.db.WithContext(ctx).Create(userToken).Error; err != nil {
+		return fmt.Errorf("create user token: %w", err)
+	}
+	return nil
+}
+
+// Consume marks a matching token as used and returns it. Tokens that are
+// expired, already used or issued for another purpose are not found, and
+// concurrent attempts with the same token cannot both succeed.
+func (r *userTokenRepository) Consume(ctx context.Context, purpose, tokenHash string, at time.Time) (*model.UserToken, error) {
+	var userToken model.UserToken
+	if err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, at).
+		First(&userToken).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserTokenNotFound
+		}
+		return nil, fmt.Errorf("find user token: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).
+		Model(&model.UserToken{}).
+		Where("id = ? AND used_at IS NULL", userToken.ID).
+		Update("used_at", at)
+	if result.Error != nil {
+		return nil, fmt.Errorf("consume user token: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrUserTokenNotFound
+	}
+	userToken.UsedAt = &at
+	return &userToken, nil
+}
+
+// InvalidateForUser marks every outstanding token of the purpose as used, so
+// only the most recently issued one remains valid.
+func (r *userTokenRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string, at time.Time) error {
+	if err := r.db.WithContext(ctx).
+		Model(&model.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", at).Error; err != nil {
+		return fmt.Errorf("invalidate user tokens: %w", err)
+	}
+	return nil
+}
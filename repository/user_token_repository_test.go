@@ -0,0 +1,81 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserTokenRepository_Consume_Success(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewUserTokenRepository(gormDB)
+
+	id := uuid.New()
+	userID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery(`^SELECT \* FROM "user_tokens" WHERE token_hash = \$1 AND purpose = \$2 AND used_at IS NULL AND expires_at > \$3`).
+		WithArgs("hash", model.UserTokenPasswordReset, now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "expires_at"}).
+			AddRow(id, userID, model.UserTokenPasswordReset, "hash", now.Add(time.Hour)))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "user_tokens" SET "used_at"=\$1 WHERE id = \$2 AND used_at IS NULL`).
+		WithArgs(now, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	userToken, err := repo.Consume(context.Background(), model.UserTokenPasswordReset, "hash", now)
+	require.NoError(t, err)
+	require.Equal(t, userID, userToken.UserID)
+	require.NotNil(t, userToken.UsedAt)
+}
+
+func TestUserTokenRepository_Consume_ConcurrentUse(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewUserTokenRepository(gormDB)
+
+	now := time.Now()
+
+	mock.ExpectQuery(`^SELECT \* FROM "user_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "expires_at"}).
+			AddRow(uuid.New(), uuid.New(), model.UserTokenEmailVerification, "hash", now.Add(time.Hour)))
+	mock.ExpectBegin()
+	mock.ExpectExec(`^UPDATE "user_tokens" SET "used_at"=\$1 WHERE id = \$2 AND used_at IS NULL`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	_, err := repo.Consume(context.Background(), model.UserTokenEmailVerification, "hash", now)
+	require.ErrorIs(t, err, repository.ErrUserTokenNotFound)
+}
+
+func TestUserTokenRepository_Consume_NotFound(t *testing.T) {
+	gormDB, mock, _, cleanup := setupRepository(t)
+	defer func() {
+		cleanup()
+		require.NoError(t, mock.ExpectationsWereMet())
+	}()
+	repo := repository.NewUserTokenRepository(gormDB)
+
+	mock.ExpectQuery(`^SELECT \* FROM "user_tokens"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectClose()
+
+	_, err := repo.Consume(context.Background(), model.UserTokenPasswordReset, "hash", time.Now())
+	require.ErrorIs(t, err, repository.ErrUserTokenNotFound)
+}
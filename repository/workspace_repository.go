@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrWorkspaceNotFound indicates that the workspace record does not exist.
+var ErrWorkspaceNotFound = errors.New("workspace not found")
+
+// WorkspaceRepository defines database operations for workspaces.
+type WorkspaceRepository interface {
+	Create(ctx context.Context, workspace *model.Workspace) error
+	FindByID(ctx context.Context, id uuid.UUID) (*model.Workspace, error)
+	Update(ctx context.Context, workspace *model.Workspace) error
+	AddMember(ctx context.Context, workspaceID uuid.UUID, subject string) error
+	RemoveMember(ctx context.Context, workspaceID uuid.UUID, subject string) error
+	IsMember(ctx context.Context, workspaceID uuid.UUID, subject string) (bool, error)
+}
+
+type workspaceRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkspaceRepository constructs a WorkspaceRepository backed by GORM.
+func NewWorkspaceRepository(db *gorm.DB) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+func (r *workspaceRepository) Create(ctx context.Context, workspace *model.Workspace) error {
+	if err := conn(ctx, r.db).Create(workspace).Error; err != nil {
+		return fmt.Errorf("create workspace: %w", err)
+	}
+	return nil
+}
+
+func (r *workspaceRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.Workspace, error) {
+	var workspace model.Workspace
+	if err := conn(ctx, r.db).First(&workspace, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, fmt.Errorf("find workspace: %w", err)
+	}
+	return &workspace, nil
+}
+
+func (r *workspaceRepository) Update(ctx context.Context, workspace *model.Workspace) error {
+	result := conn(ctx, r.db).
+		Model(workspace).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(workspace)
+	if result.Error != nil {
+		return fmt.Errorf("update workspace: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrWorkspaceNotFound
+	}
+	return nil
+}
+
+// AddMember grants subject access to the workspace. Adding a member twice is
+// a no-op.
+func (r *workspaceRepository) AddMember(ctx context.Context, workspaceID uuid.UUID, subject string) error {
+	if err := conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&model.WorkspaceMember{WorkspaceID: workspaceID, Subject: subject}).Error; err != nil {
+		return fmt.Errorf("add workspace member: %w", err)
+	}
+	return nil
+}
+
+func (r *workspaceRepository) RemoveMember(ctx context.Context, workspaceID uuid.UUID, subject string) error {
+	if err := conn(ctx, r.db).
+		Where("workspace_id = ? AND subject = ?", workspaceID, subject).
+		Delete(&model.WorkspaceMember{}).Error; err != nil {
+		return fmt.Errorf("remove workspace member: %w", err)
+	}
+	return nil
+}
+
+func (r *workspaceRepository) IsMember(ctx context.Context, workspaceID uuid.UUID, subject string) (bool, error) {
+	var count int64
+	if err := conn(ctx, r.db).
+		Model(&model.WorkspaceMember{}).
+		Where("workspace_id = ? AND subject = ?", workspaceID, subject).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("check workspace member: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,1271 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/lumoshiveacademy/todolist/graph"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/mention"
+	"github.com/lumoshiveacademy/todolist/package/openapi"
+)
+
+// OpenAPI describes the routes registered by New. Only the schemas are
+// generated from the model types; the operations table below is maintained
+// by hand and is not derived from the routes, so edit it alongside New. The
+// router tests fail when a route is missing from it or an operation has no
+// route.
+func OpenAPI() *openapi3.T {
+	generator := openapi.New(openapi.Options{
+		Info: openapi3.Info{
+			Title:       "Todo List API",
+			Version:     "1.0.0",
+			Description: "RESTful API for managing todo lists. Successful responses wrap their payload in `{\"status\": \"success\", \"data\": ...}` and failures in `{\"status\": \"error\", \"error\": {...}}`.",
+		},
+		Servers: openapi3.Servers{{URL: "http://localhost:8080", Description: "Local development server"}},
+		SecuritySchemes: openapi3.SecuritySchemes{
+			"bearerAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+			"apiKeyAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().
+				WithType("apiKey").
+				WithIn("header").
+				WithName("Authorization").
+				WithDescription("Personal API key sent as `Authorization: ApiKey tdl_<prefix>.<secret>`.")},
+		},
+		Rules: map[string]func(*openapi3.Schema){
+			"todo_status":   enum(model.Statuses),
+			"todo_priority": enum(model.Priorities),
+			"username": func(schema *openapi3.Schema) {
+				schema.Pattern = mention.UsernamePattern
+			},
+			"https_url": func(schema *openapi3.Schema) {
+				schema.Format = "uri"
+				schema.Pattern = "^https://"
+			},
+		},
+		PathParameters: map[string]*openapi3.Schema{
+			"id":           openapi3.NewUUIDSchema(),
+			"itemId":       openapi3.NewUUIDSchema(),
+			"reminderId":   openapi3.NewUUIDSchema(),
+			"attachmentId": openapi3.NewUUIDSchema(),
+			"commentId":    openapi3.NewUUIDSchema(),
+			"tagId":        openapi3.NewUUIDSchema(),
+			"linkId":       openapi3.NewUUIDSchema(),
+			"userId":       openapi3.NewUUIDSchema(),
+			"n":            openapi3.NewIntegerSchema().WithMin(1),
+		},
+	})
+
+	generator.Describe(model.Progress{}, "Done and total counts of an item's subtasks at any depth, or of a list's items. Archived entries are not counted.")
+	generator.Describe(model.UpdateTodoListRequest{}, "Omitted status and priority keep their current values; an omitted due_at clears it.")
+	generator.Describe(model.UpdateTodoItemRequest{}, "Omitted status and priority keep their current values; an omitted due_at, recurrence or timezone clears it.")
+	generator.Describe(model.MoveTodoListRequest{}, "Places the list directly after after_id or before before_id, or last when neither is given.")
+	generator.Describe(model.MoveTodoItemRequest{}, "Places the item directly after after_id or before before_id, or last when neither is given. Subtasks follow the item to another list, where it becomes a top-level item.")
+	generator.Describe(model.CreateReminderRequest{}, "Set exactly one of remind_at and offset_minutes.")
+
+	for _, op := range operations {
+		generator.Add(op)
+	}
+	return generator.Document()
+}
+
+func enum(values []string) func(*openapi3.Schema) {
+	return func(schema *openapi3.Schema) {
+		for _, value := range values {
+			schema.Enum = append(schema.Enum, value)
+		}
+	}
+}
+
+// inWorkspace documents a route of the workspace group, which reads the
+// X-Workspace-ID header and refuses callers who are not its members.
+func inWorkspace(op openapi.Operation) openapi.Operation {
+	op.Parameters = append([]*openapi3.Parameter{workspaceHeader}, op.Parameters...)
+	errs := map[int]string{
+		http.StatusBadRequest: "",
+		http.StatusForbidden:  "",
+		http.StatusNotFound:   "",
+	}
+	for status, description := range op.Errors {
+		errs[status] = description
+	}
+	op.Errors = errs
+	return op
+}
+
+var workspaceHeader = &openapi3.Parameter{
+	Name:        "X-Workspace-ID",
+	In:          openapi3.ParameterInHeader,
+	Description: "Active workspace. Required unless the JWT carries a `workspace_id` claim, in which case both must match. The caller must be a member of the workspace.",
+	Schema:      openapi3.NewSchemaRef("", openapi3.NewUUIDSchema()),
+}
+
+var unreadParameter = &openapi3.Parameter{
+	Name:        "unread",
+	In:          openapi3.ParameterInQuery,
+	Description: "Only return notifications that have not been read.",
+	Schema:      openapi3.NewSchemaRef("", openapi3.NewBoolSchema()),
+}
+
+var downloadParameters = []*openapi3.Parameter{
+	{
+		Name:        "expires",
+		In:          openapi3.ParameterInQuery,
+		Required:    true,
+		Description: "Unix time after which the link stops working.",
+		Schema:      openapi3.NewSchemaRef("", openapi3.NewInt64Schema()),
+	},
+	{
+		Name:     "signature",
+		In:       openapi3.ParameterInQuery,
+		Required: true,
+		Schema:   openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+	},
+}
+
+var graphQLParameters = []*openapi3.Parameter{
+	{
+		Name:     "query",
+		In:       openapi3.ParameterInQuery,
+		Required: true,
+		Schema:   openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+	},
+	{
+		Name:   "operationName",
+		In:     openapi3.ParameterInQuery,
+		Schema: openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+	},
+	{
+		Name:        "variables",
+		In:          openapi3.ParameterInQuery,
+		Description: "JSON-encoded variables.",
+		Schema:      openapi3.NewSchemaRef("", openapi3.NewStringSchema()),
+	},
+}
+
+var graphQLRequest = openapi.Content{
+	"application/json": openapi3.NewObjectSchema().
+		WithProperty("query", openapi3.NewStringSchema()).
+		WithProperty("operationName", openapi3.NewStringSchema()).
+		WithProperty("variables", openapi3.NewObjectSchema().WithAnyAdditionalProperties()).
+		WithRequired([]string{"query"}),
+}
+
+// graphQLResult describes GraphQL responses, which follow the GraphQL
+// specification rather than the response envelope.
+var graphQLResult = openapi.Content{
+	"application/json": openapi3.NewObjectSchema().
+		WithProperty("data", openapi3.NewObjectSchema().WithAnyAdditionalProperties().WithNullable()).
+		WithProperty("errors", openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema().
+			WithProperty("message", openapi3.NewStringSchema()).
+			WithProperty("path", openapi3.NewArraySchema().WithItems(&openapi3.Schema{})).
+			WithProperty("extensions", openapi3.NewObjectSchema().
+				WithProperty("code", openapi3.NewStringSchema().WithEnum(graph.CodeBadUserInput, graph.CodeForbidden, graph.CodeNotFound, graph.CodeConflict, graph.CodeQueryTooComplex, graph.CodeInternal)).
+				WithProperty("fields", openapi3.NewObjectSchema().WithAdditionalProperties(openapi3.NewStringSchema()))))),
+	"text/event-stream": openapi3.NewStringSchema(),
+}
+
+var attachmentUpload = openapi.Content{
+	"multipart/form-data": openapi3.NewObjectSchema().
+		WithProperty("file", openapi3.NewStringSchema().WithFormat("binary")).
+		WithRequired([]string{"file"}),
+}
+
+var operations = []openapi.Operation{
+	{
+		Method:            http.MethodGet,
+		Path:              "/health",
+		Tag:               "Health",
+		Summary:           "Health check",
+		Public:            true,
+		StatusDescription: "Service is healthy",
+		Response:          openapi.Content{"application/json": openapi3.NewObjectSchema().WithProperty("status", openapi3.NewStringSchema().WithEnum("ok"))},
+	},
+	{
+		Method:            http.MethodGet,
+		Path:              "/openapi.json",
+		Tag:               "Documentation",
+		Summary:           "OpenAPI document",
+		Description:       "This document. The Swagger UI at `/docs/` renders it.",
+		Public:            true,
+		StatusDescription: "OpenAPI 3 document generated from the routes and their models",
+		Response:          openapi.Content{"application/json": openapi3.NewObjectSchema()},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/register",
+		Tag:         "Auth",
+		Summary:     "Register a user",
+		Description: "Creates an unverified account and emails a verification link. Login is refused until the address is verified.",
+		Public:      true,
+		Request:     model.RegisterRequest{},
+		Status:      http.StatusCreated,
+		Response:    model.UserResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusConflict:            "Email already registered or username already taken",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/login",
+		Tag:         "Auth",
+		Summary:     "Log in with email and password",
+		Description: "Accounts with MFA enabled receive `mfa_required` and an `mfa_token` instead of tokens; finish with `/api/v1/auth/mfa/verify`.",
+		Public:      true,
+		Request:     model.LoginRequest{},
+		Response:    model.TokenResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnauthorized:        "",
+			http.StatusForbidden:           "Email address not verified",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/refresh",
+		Tag:         "Auth",
+		Summary:     "Rotate a refresh token",
+		Description: "Returns a new token pair and invalidates the presented refresh token. Presenting an already rotated refresh token revokes every token issued from the same login.",
+		Public:      true,
+		Request:     model.RefreshRequest{},
+		Response:    model.TokenResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnauthorized:        "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/auth/logout",
+		Tag:               "Auth",
+		Summary:           "Log out",
+		Description:       "Revokes the current access token and, when given, the refresh token's session.",
+		Request:           model.LogoutRequest{},
+		RequestOptional:   true,
+		Status:            http.StatusNoContent,
+		StatusDescription: "Logged out",
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/forgot-password",
+		Tag:         "Auth",
+		Summary:     "Request a password reset email",
+		Description: "Always answers 202 so that registered addresses cannot be discovered. The emailed token is single-use and expires after one hour.",
+		Public:      true,
+		Request:     model.ForgotPasswordRequest{},
+		Status:      http.StatusAccepted,
+		Response:    map[string]string{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/auth/reset-password",
+		Tag:               "Auth",
+		Summary:           "Set a new password with a reset token",
+		Description:       "Revokes every refresh token of the account and marks its email address as verified.",
+		Public:            true,
+		Request:           model.ResetPasswordRequest{},
+		Status:            http.StatusNoContent,
+		StatusDescription: "Password changed",
+		Errors: map[int]string{
+			http.StatusBadRequest:          "Invalid payload, or the token is invalid, expired or already used",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/auth/verify-email",
+		Tag:               "Auth",
+		Summary:           "Verify an email address",
+		Description:       "Consumes the single-use token from the verification email. Tokens expire after 24 hours.",
+		Public:            true,
+		Request:           model.VerifyEmailRequest{},
+		Status:            http.StatusNoContent,
+		StatusDescription: "Email verified",
+		Errors: map[int]string{
+			http.StatusBadRequest:          "Invalid payload, or the token is invalid, expired or already used",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/verify-email/resend",
+		Tag:         "Auth",
+		Summary:     "Send another verification email",
+		Description: "Always answers 202. Earlier verification links stop working.",
+		Public:      true,
+		Request:     model.ResendVerificationRequest{},
+		Status:      http.StatusAccepted,
+		Response:    map[string]string{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/auth/mfa/verify",
+		Tag:         "Auth",
+		Summary:     "Complete an MFA login",
+		Description: "Exchanges the `mfa_token` from login plus a TOTP or recovery code for a token pair. Five wrong codes lock the second factor for 15 minutes.",
+		Public:      true,
+		Request:     model.VerifyMFARequest{},
+		Response:    model.TokenResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnauthorized:        "",
+			http.StatusUnprocessableEntity: "",
+			http.StatusTooManyRequests:     "Too many invalid codes",
+		},
+	},
+	{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/auth/username",
+		Tag:               "Auth",
+		Summary:           "Set username",
+		Description:       "Sets or changes the caller's username, the handle others use to @mention them in comments.",
+		Request:           model.SetUsernameRequest{},
+		StatusDescription: "Updated account",
+		Response:          model.UserResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusConflict:            "Username already taken",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/auth/archive-policy",
+		Tag:               "Auth",
+		Summary:           "Set auto-archive policy",
+		Description:       "Archives the caller's lists automatically once all their items are done and nothing has changed for `after_days` days. A null `after_days` turns the policy off.",
+		Request:           model.SetArchivePolicyRequest{},
+		StatusDescription: "Updated account",
+		Response:          model.UserResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/auth/mfa/enroll",
+		Tag:               "Auth",
+		Summary:           "Start TOTP enrollment",
+		StatusDescription: "Pending secret to add to an authenticator app",
+		Response:          model.MFAEnrollmentResponse{},
+		Errors: map[int]string{
+			http.StatusForbidden: "",
+			http.StatusConflict:  "MFA already enabled",
+		},
+	},
+	{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/auth/mfa/confirm",
+		Tag:               "Auth",
+		Summary:           "Confirm TOTP enrollment",
+		Description:       "Enables MFA and returns recovery codes, shown only once.",
+		Request:           model.ConfirmMFARequest{},
+		StatusDescription: "MFA enabled",
+		Response:          model.MFARecoveryCodesResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusConflict:            "MFA already enabled or enrollment not started",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/api-keys",
+		Tag:         "API keys",
+		Summary:     "Create API key",
+		Description: "Returns the full key once. Every requested scope must already be granted to the caller.",
+		Request:     model.CreateAPIKeyRequest{},
+		Status:      http.StatusCreated,
+		Response:    model.APIKeyResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/v1/api-keys",
+		Tag:      "API keys",
+		Summary:  "List the caller's API keys",
+		Response: []model.APIKeyResponse{},
+	},
+	{
+		Method:   http.MethodGet,
+		Path:     "/api/v1/api-keys/{id}",
+		Tag:      "API keys",
+		Summary:  "Get API key",
+		Response: model.APIKeyResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	},
+	{
+		Method:   http.MethodPut,
+		Path:     "/api/v1/api-keys/{id}",
+		Tag:      "API keys",
+		Summary:  "Rename or re-scope API key",
+		Request:  model.UpdateAPIKeyRequest{},
+		Response: model.APIKeyResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/api-keys/{id}",
+		Tag:               "API keys",
+		Summary:           "Delete API key",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted",
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	},
+	{
+		Method:   http.MethodPost,
+		Path:     "/api/v1/workspaces",
+		Tag:      "Workspaces",
+		Summary:  "Create workspace",
+		Request:  model.CreateWorkspaceRequest{},
+		Status:   http.StatusCreated,
+		Response: model.WorkspaceResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+			http.StatusForbidden:           "",
+		},
+	},
+	{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/workspaces/{id}",
+		Tag:               "Workspaces",
+		Summary:           "Get workspace",
+		StatusDescription: "Workspace detail",
+		Response:          model.WorkspaceResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound:  "",
+			http.StatusForbidden: "",
+		},
+	},
+	{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/workspaces/{id}",
+		Tag:               "Workspaces",
+		Summary:           "Update workspace",
+		Request:           model.UpdateWorkspaceRequest{},
+		StatusDescription: "Updated",
+		Response:          model.WorkspaceResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusForbidden:           "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	},
+	{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/workspaces/{id}/members/{userId}",
+		Tag:               "Workspaces",
+		Summary:           "Add workspace member",
+		Description:       "Lets the user select the workspace with X-Workspace-ID. Adding a member twice has no effect.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Member added",
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusForbidden:  "",
+			http.StatusNotFound:   "",
+		},
+	},
+	{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/workspaces/{id}/members/{userId}",
+		Tag:               "Workspaces",
+		Summary:           "Remove workspace member",
+		Description:       "The user loses access to the workspace, including through tokens and API keys that carry it.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Member removed",
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusForbidden:  "",
+			http.StatusNotFound:   "",
+		},
+	},
+	inWorkspace(openapi.Operation{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/calendar-feed",
+		Tag:         "Calendar",
+		Summary:     "Issue the caller's calendar feed",
+		Description: "Returns a secret iCalendar feed URL for the due items in the caller's lists in this workspace. Issuing a feed again replaces the token, so the previous URL stops working.",
+		Status:      http.StatusCreated,
+		Response:    model.CalendarFeedResponse{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/calendar-feed",
+		Tag:               "Calendar",
+		Summary:           "Revoke the caller's calendar feed",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Revoked",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/notifications",
+		Tag:               "Notifications",
+		Summary:           "List the caller's in-app notifications",
+		StatusDescription: "Notifications, newest first",
+		Response:          []model.NotificationResponse{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+		},
+		Parameters: []*openapi3.Parameter{unreadParameter},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/notifications/{id}/read",
+		Tag:               "Notifications",
+		Summary:           "Mark a notification as read",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Marked as read",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        "/api/v1/audit",
+		Tag:         "Audit",
+		Summary:     "List audit log entries",
+		Description: "Returns the workspace's audit entries, newest first. Requires `audit:read`, which only admins have.",
+		Response:    model.AuditLogPage{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+		},
+		Query: model.AuditLogFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:      http.MethodGet,
+		Path:        "/api/v1/graphql",
+		Tag:         "GraphQL",
+		Summary:     "Run a GraphQL query or subscription",
+		Description: "Takes the request as URL parameters. Mutations must use POST. Subscriptions respond with a `text/event-stream` of `next` events followed by a `complete` event. Requires `todolists:read`.",
+		Response:    graphQLResult,
+		Errors: map[int]string{
+			http.StatusBadRequest:       "",
+			http.StatusMethodNotAllowed: "The operation is a mutation",
+		},
+		Parameters: graphQLParameters,
+	}),
+	inWorkspace(openapi.Operation{
+		Method:      http.MethodPost,
+		Path:        "/api/v1/graphql",
+		Tag:         "GraphQL",
+		Summary:     "Run a GraphQL operation",
+		Description: "Runs a query, mutation or subscription. Mutations also require `todolists:write`; without it they fail with a `FORBIDDEN` error. Operations deeper or larger than the configured limits fail with `QUERY_TOO_COMPLEX` before they run.",
+		Request:     graphQLRequest,
+		Response:    graphQLResult,
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:   http.MethodPost,
+		Path:     "/api/v1/tags",
+		Tag:      "Tags",
+		Summary:  "Create tag",
+		Request:  model.CreateTagRequest{},
+		Status:   http.StatusCreated,
+		Response: model.TagResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusConflict:            "A tag with this name already exists",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/tags",
+		Tag:               "Tags",
+		Summary:           "List the workspace's tags",
+		StatusDescription: "Tags ordered by name",
+		Response:          []model.TagResponse{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/tags/{id}",
+		Tag:               "Tags",
+		Summary:           "Rename or recolor tag",
+		Description:       "Only the tag's creator or an admin may change a tag.",
+		Request:           model.UpdateTagRequest{},
+		StatusDescription: "Updated",
+		Response:          model.TagResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusConflict:            "A tag with this name already exists",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/tags/{id}",
+		Tag:               "Tags",
+		Summary:           "Delete tag",
+		Description:       "Deleting a tag detaches it from every list. Only the tag's creator or an admin may delete it.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:   http.MethodPost,
+		Path:     "/api/v1/todolists",
+		Tag:      "Todo lists",
+		Summary:  "Create todo list",
+		Request:  model.CreateTodoListRequest{},
+		Status:   http.StatusCreated,
+		Response: model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists",
+		Tag:               "Todo lists",
+		Summary:           "List todo lists",
+		StatusDescription: "List of todo lists",
+		Response:          []model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+		},
+		Query: model.TodoListFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/templates",
+		Tag:               "Todo lists",
+		Summary:           "List the workspace's templates",
+		Description:       "Templates are shared with every member of the workspace and hidden from `GET /api/v1/todolists`. Accepts the same filters.",
+		StatusDescription: "List of templates",
+		Response:          []model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+		},
+		Query: model.TodoListFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}",
+		Tag:               "Todo lists",
+		Summary:           "Get todo list",
+		StatusDescription: "Todo list detail",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/todolists/{id}",
+		Tag:               "Todo lists",
+		Summary:           "Update todo list",
+		Request:           model.UpdateTodoListRequest{},
+		StatusDescription: "Updated todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}",
+		Tag:               "Todo lists",
+		Summary:           "Delete todo list",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/move",
+		Tag:               "Todo lists",
+		Summary:           "Reposition a todo list in the sidebar order",
+		Description:       "Only the moved list's rank changes unless its new neighbours leave no room, in which case the workspace's lists are rebalanced first.",
+		Request:           model.MoveTodoListRequest{},
+		StatusDescription: "Moved todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/duplicate",
+		Tag:               "Todo lists",
+		Summary:           "Duplicate a todo list",
+		Description:       "Copies the list with its items, subtasks, tags and item order into a new list owned by the caller, in one transaction. Comments, attachments and reminders are not copied.",
+		Request:           model.DuplicateTodoListRequest{},
+		RequestOptional:   true,
+		Status:            http.StatusCreated,
+		StatusDescription: "The copy",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/todolists/{id}/template",
+		Tag:               "Todo lists",
+		Summary:           "Mark a todo list as a template",
+		Description:       "Moves the list into the workspace's template library. Only the owner or an admin can change it.",
+		StatusDescription: "The template",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/template",
+		Tag:               "Todo lists",
+		Summary:           "Stop using a todo list as a template",
+		Description:       "Moves the list back to the workspace's lists.",
+		StatusDescription: "The todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/instantiate",
+		Tag:               "Todo lists",
+		Summary:           "Create a todo list from a template",
+		Description:       "Copies the template like duplicate, replacing `{{name}}` placeholders in the list and item titles and descriptions with the given variables. The new list and its items start open.",
+		Request:           model.InstantiateTemplateRequest{},
+		RequestOptional:   true,
+		Status:            http.StatusCreated,
+		StatusDescription: "The new todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusConflict:            "The list is not a template",
+			http.StatusUnprocessableEntity: "Validation error, or placeholders without a value (listed under `variables`)",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/archive",
+		Tag:               "Todo lists",
+		Summary:           "Archive a todo list",
+		Description:       "Hides the list from default listings. Archiving an archived list keeps its original `archived_at`. Only the owner or an admin may archive a list.",
+		StatusDescription: "The archived list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/unarchive",
+		Tag:               "Todo lists",
+		Summary:           "Unarchive a todo list",
+		Description:       "Returns the list to default listings. Only the owner or an admin may unarchive a list.",
+		StatusDescription: "The restored list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:   http.MethodPost,
+		Path:     "/api/v1/todolists/{id}/items",
+		Tag:      "Todo items",
+		Summary:  "Create todo item",
+		Request:  model.CreateTodoItemRequest{},
+		Status:   http.StatusCreated,
+		Response: model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/items",
+		Tag:               "Todo items",
+		Summary:           "List todo items",
+		Description:       "Items are ordered by due date, soonest first; items without a due date come last.",
+		StatusDescription: "Items of the todo list",
+		Response:          []model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+		Query: model.TodoItemFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}",
+		Tag:               "Todo items",
+		Summary:           "Get todo item",
+		Description:       "Returns the item with its subtasks nested under `subtasks`.",
+		StatusDescription: "Todo item detail",
+		Response:          model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}",
+		Tag:               "Todo items",
+		Summary:           "Update todo item",
+		Request:           model.UpdateTodoItemRequest{},
+		StatusDescription: "Updated todo item",
+		Response:          model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}",
+		Tag:               "Todo items",
+		Summary:           "Delete todo item",
+		Description:       "Deletes the item together with its subtasks.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/occurrences",
+		Tag:               "Todo items",
+		Summary:           "Materialize upcoming occurrences of a recurring todo item",
+		Request:           model.MaterializeOccurrencesRequest{},
+		Status:            http.StatusCreated,
+		StatusDescription: "Occurrences created after the latest one in the series",
+		Response:          []model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusConflict:            "Todo item is not recurring",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/move",
+		Tag:               "Todo items",
+		Summary:           "Reposition a todo item within its list or move it to another list",
+		Description:       "The move is atomic. Only the moved item's rank changes unless its new neighbours leave no room, in which case the target list is rebalanced first. Moving across lists needs write access to both.",
+		Request:           model.MoveTodoItemRequest{},
+		StatusDescription: "Moved todo item",
+		Response:          model.TodoItemResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/reminders",
+		Tag:               "Reminders",
+		Summary:           "Create reminder",
+		Request:           model.CreateReminderRequest{},
+		Status:            http.StatusCreated,
+		StatusDescription: "Created reminder",
+		Response:          model.ReminderResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusNotFound:            "",
+			http.StatusConflict:            "offset_minutes was given but the item has no due date",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/reminders",
+		Tag:               "Reminders",
+		Summary:           "List the caller's reminders on a todo item",
+		StatusDescription: "Reminders, soonest first",
+		Response:          []model.ReminderResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/reminders/{reminderId}",
+		Tag:               "Reminders",
+		Summary:           "Delete reminder",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/attachments",
+		Tag:               "Attachments",
+		Summary:           "Upload attachment to todo item",
+		Description:       "Only the list's owner or an admin may upload.",
+		Request:           attachmentUpload,
+		Status:            http.StatusCreated,
+		StatusDescription: "Uploaded attachment",
+		Response:          model.AttachmentResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:            "",
+			http.StatusRequestEntityTooLarge: "The file exceeds ATTACHMENT_MAX_SIZE",
+			http.StatusUnsupportedMediaType:  "The file's sniffed type is not in ATTACHMENT_ALLOWED_TYPES",
+			http.StatusUnprocessableEntity:   "",
+			http.StatusNotFound:              "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/attachments",
+		Tag:               "Attachments",
+		Summary:           "List todo item attachments",
+		Description:       "Returns the item's attachments with fresh download URLs.",
+		StatusDescription: "Attachments, oldest first",
+		Response:          []model.AttachmentResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/comments",
+		Tag:               "Comments",
+		Summary:           "Comment on todo item",
+		Description:       "Any member with todolists:write may comment. Users @mentioned in the body get an inbox notification.",
+		Request:           model.CreateCommentRequest{},
+		Status:            http.StatusCreated,
+		StatusDescription: "Created comment",
+		Response:          model.CommentResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+			http.StatusNotFound:            "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/items/{itemId}/comments",
+		Tag:               "Comments",
+		Summary:           "List todo item comments",
+		StatusDescription: "One page of threads, oldest first, each with all its replies",
+		Response:          model.CommentPage{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+			http.StatusNotFound:            "",
+		},
+		Query: model.CommentFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/todolists/{id}/tags/{tagId}",
+		Tag:               "Tags",
+		Summary:           "Attach tag to todo list",
+		Description:       "Only the list's owner or an admin may tag a list. Attaching a tag twice has no effect.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Tag attached",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/tags/{tagId}",
+		Tag:               "Tags",
+		Summary:           "Detach tag from todo list",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Tag detached",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/attachments",
+		Tag:               "Attachments",
+		Summary:           "Upload attachment to todo list",
+		Description:       "Only the list's owner or an admin may upload.",
+		Request:           attachmentUpload,
+		Status:            http.StatusCreated,
+		StatusDescription: "Uploaded attachment",
+		Response:          model.AttachmentResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:            "",
+			http.StatusRequestEntityTooLarge: "The file exceeds ATTACHMENT_MAX_SIZE",
+			http.StatusUnsupportedMediaType:  "The file's sniffed type is not in ATTACHMENT_ALLOWED_TYPES",
+			http.StatusUnprocessableEntity:   "",
+			http.StatusNotFound:              "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/attachments",
+		Tag:               "Attachments",
+		Summary:           "List todo list attachments",
+		Description:       "Returns the attachments of the list and of all its items with fresh download URLs.",
+		StatusDescription: "Attachments, oldest first",
+		Response:          []model.AttachmentResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/attachments/{attachmentId}",
+		Tag:               "Attachments",
+		Summary:           "Get attachment",
+		StatusDescription: "Attachment with a fresh download URL",
+		Response:          model.AttachmentResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/attachments/{attachmentId}",
+		Tag:               "Attachments",
+		Summary:           "Delete attachment",
+		Description:       "Only the list's owner or an admin may delete attachments.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/comments",
+		Tag:               "Comments",
+		Summary:           "Comment on todo list",
+		Description:       "Any member with todolists:write may comment. Users @mentioned in the body get an inbox notification.",
+		Request:           model.CreateCommentRequest{},
+		Status:            http.StatusCreated,
+		StatusDescription: "Created comment",
+		Response:          model.CommentResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+			http.StatusNotFound:            "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/comments",
+		Tag:               "Comments",
+		Summary:           "List todo list comments",
+		StatusDescription: "One page of threads, oldest first, each with all its replies",
+		Response:          model.CommentPage{},
+		Errors: map[int]string{
+			http.StatusUnprocessableEntity: "",
+			http.StatusNotFound:            "",
+		},
+		Query: model.CommentFilter{},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPut,
+		Path:              "/api/v1/todolists/{id}/comments/{commentId}",
+		Tag:               "Comments",
+		Summary:           "Edit comment",
+		Description:       "Only the comment's author may edit it. Users mentioned for the first time are notified.",
+		Request:           model.UpdateCommentRequest{},
+		StatusDescription: "Updated comment",
+		Response:          model.CommentResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest:          "",
+			http.StatusUnprocessableEntity: "",
+			http.StatusNotFound:            "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/comments/{commentId}",
+		Tag:               "Comments",
+		Summary:           "Delete comment",
+		Description:       "The author or an admin may delete a comment. It stays in its thread, blanked and marked deleted, so replies keep their place.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Deleted successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/revisions",
+		Tag:               "Revisions",
+		Summary:           "List todo list revisions",
+		StatusDescription: "Revisions of the todo list, newest first",
+		Response:          []model.TodoListRevisionResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/revisions/{n}",
+		Tag:               "Revisions",
+		Summary:           "Get todo list revision",
+		StatusDescription: "Revision detail",
+		Response:          model.TodoListRevisionResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/revisions/{n}/restore",
+		Tag:               "Revisions",
+		Summary:           "Restore todo list revision",
+		Description:       "Copies the revision's fields onto the todo list and records the result as a new revision.",
+		StatusDescription: "Restored todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusBadRequest: "",
+			http.StatusNotFound:   "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodPost,
+		Path:              "/api/v1/todolists/{id}/share-links",
+		Tag:               "Share links",
+		Summary:           "Create public share link",
+		Description:       "Only the list's owner or an admin may share it.",
+		Request:           model.CreateShareLinkRequest{},
+		RequestOptional:   true,
+		Status:            http.StatusCreated,
+		StatusDescription: "Created share link; the token is only returned once",
+		Response:          model.ShareLinkResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound:            "",
+			http.StatusUnprocessableEntity: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodGet,
+		Path:              "/api/v1/todolists/{id}/share-links",
+		Tag:               "Share links",
+		Summary:           "List share links",
+		StatusDescription: "Share links for the todo list",
+		Response:          []model.ShareLinkResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	inWorkspace(openapi.Operation{
+		Method:            http.MethodDelete,
+		Path:              "/api/v1/todolists/{id}/share-links/{linkId}",
+		Tag:               "Share links",
+		Summary:           "Revoke share link",
+		Description:       "Only the list's owner or an admin may revoke its links.",
+		Status:            http.StatusNoContent,
+		StatusDescription: "Revoked successfully",
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	}),
+	{
+		Method:            http.MethodGet,
+		Path:              "/public/lists/{token}",
+		Tag:               "Public",
+		Summary:           "Get shared todo list",
+		Public:            true,
+		StatusDescription: "Read-only todo list",
+		Response:          model.TodoListResponse{},
+		Errors: map[int]string{
+			http.StatusNotFound: "",
+		},
+	},
+	{
+		Method:            http.MethodGet,
+		Path:              "/public/attachments/{id}",
+		Tag:               "Public",
+		Summary:           "Download attachment",
+		Description:       "Serves the file behind a signed download_url. The link itself is the credential.",
+		Public:            true,
+		StatusDescription: "File contents",
+		Response:          openapi.Content{"application/octet-stream": openapi3.NewStringSchema().WithFormat("binary")},
+		Headers: map[string]string{
+			"Content-Disposition": "Offers the file for download under its uploaded name.",
+			"ETag":                "The attachment's SHA-256 checksum.",
+		},
+		Errors: map[int]string{
+			http.StatusForbidden: "The signature is invalid or the link has expired",
+			http.StatusNotFound:  "",
+		},
+		Parameters: downloadParameters,
+	},
+	{
+		Method:            http.MethodGet,
+		Path:              "/calendar/{token}.ics",
+		Tag:               "Public",
+		Summary:           "Calendar feed",
+		Description:       "Serves the feed as iCalendar (RFC 5545). One-off items with a due date are VTODOs; each recurring series is a single VEVENT with its RRULE. The feed owner's reminders are VALARMs. The token in the URL is the credential.",
+		Public:            true,
+		StatusDescription: "The feed",
+		Response:          openapi.Content{"text/calendar": openapi3.NewStringSchema()},
+		Headers: map[string]string{
+			"ETag":          "Changes whenever the feed's content does.",
+			"Cache-Control": "Lets calendar clients cache the feed briefly.",
+		},
+		Errors: map[int]string{
+			http.StatusNotModified: "The feed still matches If-None-Match",
+			http.StatusNotFound:    "",
+		},
+		Parameters: []*openapi3.Parameter{openapi3.NewHeaderParameter("If-None-Match").WithSchema(openapi3.NewStringSchema())},
+	},
+}
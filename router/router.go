@@ -8,15 +8,57 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/lumoshiveacademy/todolist/handler"
 	appMiddleware "github.com/lumoshiveacademy/todolist/middleware"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/openapi"
 	"go.uber.org/zap"
 )
 
+// Options configure New.
+type Options struct {
+	// ValidateResponses checks JSON responses against the OpenAPI document
+	// and logs mismatches. It buffers responses, so enable it only in
+	// development.
+	ValidateResponses bool
+	// MaxBodySize caps JSON and GraphQL request bodies in bytes. Zero means
+	// DefaultMaxBodySize.
+	MaxBodySize int64
+}
+
+// DefaultMaxBodySize is the JSON request body cap used when Options does not
+// set one.
+const DefaultMaxBodySize = 1 << 20
+
 // New initializes the HTTP router with middleware and route registrations.
-func New(todoListHandler *handler.TodoListHandler, logger *zap.Logger, jwtSecret, jwtIssuer string) *chi.Mux {
+// Routes validate their input against the OpenAPI document after their
+// authentication and permission checks.
+func New(
+	todoListHandler *handler.TodoListHandler,
+	todoItemHandler *handler.TodoItemHandler,
+	shareLinkHandler *handler.ShareLinkHandler,
+	workspaceHandler *handler.WorkspaceHandler,
+	authHandler *handler.AuthHandler,
+	accountHandler *handler.AccountHandler,
+	apiKeyHandler *handler.APIKeyHandler,
+	auditHandler *handler.AuditHandler,
+	reminderHandler *handler.ReminderHandler,
+	notificationHandler *handler.NotificationHandler,
+	tagHandler *handler.TagHandler,
+	attachmentHandler *handler.AttachmentHandler,
+	commentHandler *handler.CommentHandler,
+	calendarHandler *handler.CalendarHandler,
+	graphQLHandler *handler.GraphQLHandler,
+	workspaceFinder appMiddleware.WorkspaceFinder,
+	tokenVerifier appMiddleware.TokenVerifier,
+	tokenDenylist appMiddleware.TokenDenylist,
+	apiKeyAuthenticator appMiddleware.APIKeyAuthenticator,
+	options Options,
+	logger *zap.Logger,
+) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
+	r.Use(appMiddleware.RequestInfo)
 	r.Use(middleware.Timeout(60 * time.Second))
 	r.Use(appMiddleware.Recovery(logger))
 	r.Use(appMiddleware.Logger(logger))
@@ -27,15 +69,153 @@ func New(todoListHandler *handler.TodoListHandler, logger *zap.Logger, jwtSecret
 		_, _ = w.Write([]byte(`{"status":"ok"}`))
 	})
 
+	doc := OpenAPI()
+	maxBodySize := options.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	validate := appMiddleware.Validation(doc, maxBodySize, options.ValidateResponses, logger)
+	limitBody := appMiddleware.LimitBody(maxBodySize)
+
+	r.Get("/openapi.json", openapi.Handler(doc))
+	r.Handle("/docs/*", http.StripPrefix("/docs", openapi.UI("/openapi.json")))
+
+	r.With(validate).Get("/public/lists/{token}", shareLinkHandler.GetShared)
+	r.With(validate).Get("/public/attachments/{id}", attachmentHandler.Download)
+	r.With(validate).Get("/calendar/{token}.ics", calendarHandler.Feed)
+
+	readTodoLists := appMiddleware.RequirePermission(auth.PermissionTodoListsRead)
+	writeTodoLists := appMiddleware.RequirePermission(auth.PermissionTodoListsWrite)
+	readWorkspaces := appMiddleware.RequirePermission(auth.PermissionWorkspacesRead)
+	writeWorkspaces := appMiddleware.RequirePermission(auth.PermissionWorkspacesWrite)
+	readAudit := appMiddleware.RequirePermission(auth.PermissionAuditRead)
+	authenticate := appMiddleware.Authentication(tokenVerifier, tokenDenylist, apiKeyAuthenticator, logger)
+
 	r.Route("/api/v1", func(api chi.Router) {
-		// api.Use(appMiddleware.JWTAuthentication(jwtSecret, jwtIssuer, logger))
-		api.Route("/todolists", func(r chi.Router) {
-			r.Post("/", todoListHandler.Create)
-			r.Get("/", todoListHandler.List)
-			r.Route("/{id}", func(r chi.Router) {
-				r.Get("/", todoListHandler.Get)
-				r.Put("/", todoListHandler.Update)
-				r.Delete("/", todoListHandler.Delete)
+		api.Route("/auth", func(r chi.Router) {
+			r.With(validate).Post("/register", authHandler.Register)
+			r.With(validate).Post("/login", authHandler.Login)
+			r.With(validate).Post("/refresh", authHandler.Refresh)
+			r.With(authenticate, validate).Post("/logout", authHandler.Logout)
+			r.With(validate).Post("/forgot-password", accountHandler.ForgotPassword)
+			r.With(validate).Post("/reset-password", accountHandler.ResetPassword)
+			r.With(validate).Post("/verify-email", accountHandler.VerifyEmail)
+			r.With(validate).Post("/verify-email/resend", accountHandler.ResendVerification)
+			r.With(authenticate, validate).Put("/username", authHandler.SetUsername)
+			r.With(authenticate, validate).Put("/archive-policy", authHandler.SetArchivePolicy)
+			r.Route("/mfa", func(r chi.Router) {
+				r.With(validate).Post("/verify", authHandler.VerifyMFA)
+				r.With(authenticate, validate).Post("/enroll", authHandler.EnrollMFA)
+				r.With(authenticate, validate).Post("/confirm", authHandler.ConfirmMFA)
+			})
+		})
+
+		api.Group(func(api chi.Router) {
+			api.Use(authenticate)
+			api.Route("/api-keys", func(r chi.Router) {
+				r.Use(validate)
+				r.Post("/", apiKeyHandler.Create)
+				r.Get("/", apiKeyHandler.List)
+				r.Get("/{id}", apiKeyHandler.Get)
+				r.Put("/{id}", apiKeyHandler.Update)
+				r.Delete("/{id}", apiKeyHandler.Delete)
+			})
+
+			api.Route("/workspaces", func(r chi.Router) {
+				r.With(writeWorkspaces, validate).Post("/", workspaceHandler.Create)
+				r.With(readWorkspaces, validate).Get("/{id}", workspaceHandler.Get)
+				r.With(writeWorkspaces, validate).Put("/{id}", workspaceHandler.Update)
+				r.With(writeWorkspaces, validate).Put("/{id}/members/{userId}", workspaceHandler.AddMember)
+				r.With(writeWorkspaces, validate).Delete("/{id}/members/{userId}", workspaceHandler.RemoveMember)
+			})
+
+			api.Group(func(api chi.Router) {
+				api.Use(appMiddleware.Workspace(workspaceFinder, logger))
+				api.With(readAudit, validate).Get("/audit", auditHandler.List)
+				// GraphQL validates operations itself and reports errors in
+				// its own format, so its bodies are only capped.
+				api.With(readTodoLists).Get("/graphql", graphQLHandler.Serve)
+				api.With(readTodoLists, limitBody).Post("/graphql", graphQLHandler.Serve)
+				api.Route("/calendar-feed", func(r chi.Router) {
+					r.With(readTodoLists, validate).Post("/", calendarHandler.CreateFeed)
+					r.With(readTodoLists, validate).Delete("/", calendarHandler.DeleteFeed)
+				})
+				api.Route("/notifications", func(r chi.Router) {
+					r.Use(validate)
+					r.Get("/", notificationHandler.List)
+					r.Post("/{id}/read", notificationHandler.MarkRead)
+				})
+				api.Route("/tags", func(r chi.Router) {
+					r.With(writeTodoLists, validate).Post("/", tagHandler.Create)
+					r.With(readTodoLists, validate).Get("/", tagHandler.List)
+					r.With(writeTodoLists, validate).Put("/{id}", tagHandler.Update)
+					r.With(writeTodoLists, validate).Delete("/{id}", tagHandler.Delete)
+				})
+				api.Route("/todolists", func(r chi.Router) {
+					r.With(writeTodoLists, validate).Post("/", todoListHandler.Create)
+					r.With(readTodoLists, validate).Get("/", todoListHandler.List)
+					r.With(readTodoLists, validate).Get("/templates", todoListHandler.ListTemplates)
+					r.Route("/{id}", func(r chi.Router) {
+						r.With(readTodoLists, validate).Get("/", todoListHandler.Get)
+						r.With(writeTodoLists, validate).Put("/", todoListHandler.Update)
+						r.With(writeTodoLists, validate).Delete("/", todoListHandler.Delete)
+						r.With(writeTodoLists, validate).Post("/move", todoListHandler.Move)
+						r.With(writeTodoLists, validate).Post("/duplicate", todoListHandler.Duplicate)
+						r.With(writeTodoLists, validate).Put("/template", todoListHandler.MarkTemplate)
+						r.With(writeTodoLists, validate).Delete("/template", todoListHandler.UnmarkTemplate)
+						r.With(writeTodoLists, validate).Post("/instantiate", todoListHandler.Instantiate)
+						r.With(writeTodoLists, validate).Post("/archive", todoListHandler.Archive)
+						r.With(writeTodoLists, validate).Post("/unarchive", todoListHandler.Unarchive)
+						r.Route("/items", func(r chi.Router) {
+							r.With(writeTodoLists, validate).Post("/", todoItemHandler.Create)
+							r.With(readTodoLists, validate).Get("/", todoItemHandler.List)
+							r.With(readTodoLists, validate).Get("/{itemId}", todoItemHandler.Get)
+							r.With(writeTodoLists, validate).Put("/{itemId}", todoItemHandler.Update)
+							r.With(writeTodoLists, validate).Delete("/{itemId}", todoItemHandler.Delete)
+							r.With(writeTodoLists, validate).Post("/{itemId}/occurrences", todoItemHandler.Materialize)
+							r.With(writeTodoLists, validate).Post("/{itemId}/move", todoItemHandler.Move)
+							r.Route("/{itemId}/reminders", func(r chi.Router) {
+								r.With(writeTodoLists, validate).Post("/", reminderHandler.Create)
+								r.With(readTodoLists, validate).Get("/", reminderHandler.List)
+								r.With(writeTodoLists, validate).Delete("/{reminderId}", reminderHandler.Delete)
+							})
+							r.Route("/{itemId}/attachments", func(r chi.Router) {
+								r.With(writeTodoLists, validate).Post("/", attachmentHandler.UploadToItem)
+								r.With(readTodoLists, validate).Get("/", attachmentHandler.ListForItem)
+							})
+							r.Route("/{itemId}/comments", func(r chi.Router) {
+								r.With(writeTodoLists, validate).Post("/", commentHandler.CreateForItem)
+								r.With(readTodoLists, validate).Get("/", commentHandler.ListForItem)
+							})
+						})
+						r.Route("/attachments", func(r chi.Router) {
+							r.With(writeTodoLists, validate).Post("/", attachmentHandler.Upload)
+							r.With(readTodoLists, validate).Get("/", attachmentHandler.List)
+							r.With(readTodoLists, validate).Get("/{attachmentId}", attachmentHandler.Get)
+							r.With(writeTodoLists, validate).Delete("/{attachmentId}", attachmentHandler.Delete)
+						})
+						r.Route("/comments", func(r chi.Router) {
+							r.With(writeTodoLists, validate).Post("/", commentHandler.Create)
+							r.With(readTodoLists, validate).Get("/", commentHandler.List)
+							r.With(writeTodoLists, validate).Put("/{commentId}", commentHandler.Update)
+							r.With(writeTodoLists, validate).Delete("/{commentId}", commentHandler.Delete)
+						})
+						r.Route("/tags", func(r chi.Router) {
+							r.With(writeTodoLists, validate).Put("/{tagId}", tagHandler.Attach)
+							r.With(writeTodoLists, validate).Delete("/{tagId}", tagHandler.Detach)
+						})
+						r.Route("/revisions", func(r chi.Router) {
+							r.With(readTodoLists, validate).Get("/", todoListHandler.ListRevisions)
+							r.With(readTodoLists, validate).Get("/{n}", todoListHandler.GetRevision)
+							r.With(writeTodoLists, validate).Post("/{n}/restore", todoListHandler.RestoreRevision)
+						})
+						r.Route("/share-links", func(r chi.Router) {
+							r.With(writeTodoLists, validate).Post("/", shareLinkHandler.Create)
+							r.With(readTodoLists, validate).Get("/", shareLinkHandler.List)
+							r.With(writeTodoLists, validate).Delete("/{linkId}", shareLinkHandler.Revoke)
+						})
+					})
+				})
 			})
 		})
 	})
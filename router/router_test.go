@@ -0,0 +1,652 @@
+package router_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/graph"
+	"github.com/lumoshiveacademy/todolist/handler"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/config"
+	"github.com/lumoshiveacademy/todolist/package/pubsub"
+	"github.com/lumoshiveacademy/todolist/package/validation"
+	"github.com/lumoshiveacademy/todolist/router"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	testSecret = "test-secret"
+	testIssuer = "todolist"
+)
+
+// testServices overrides the permissive default mocks used by newTestRouter.
+type testServices struct {
+	auth       *mocks.AuthServiceMock
+	apiKeys    *mocks.APIKeyServiceMock
+	workspaces *mocks.WorkspaceServiceMock
+}
+
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	return newTestRouterWith(t, testServices{})
+}
+
+func newTestRouterWith(t *testing.T, services testServices) http.Handler {
+	t.Helper()
+
+	authService := services.auth
+	if authService == nil {
+		authService = new(mocks.AuthServiceMock)
+		authService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything).Return(false, nil).Maybe()
+	}
+	apiKeyService := services.apiKeys
+	if apiKeyService == nil {
+		apiKeyService = new(mocks.APIKeyServiceMock)
+	}
+
+	auditService := new(mocks.AuditServiceMock)
+	auditService.On("ListAuditLogs", mock.Anything, mock.Anything).Return(model.AuditLogPage{}, nil).Maybe()
+
+	accountService := new(mocks.AccountServiceMock)
+	accountService.On("ForgotPassword", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	validate := validation.New()
+	logger := zaptest.NewLogger(t)
+	verifier, err := auth.NewVerifier(config.JWTConfig{
+		Secret:     testSecret,
+		Algorithms: []string{"HS256"},
+		Issuers:    []string{testIssuer},
+		Audiences:  []string{testIssuer},
+	}, logger)
+	require.NoError(t, err)
+
+	todoListService := new(mocks.TodoListServiceMock)
+	todoListService.On("CreateTodoList", mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("ListTodoLists", mock.Anything, mock.Anything).Return([]model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("GetTodoList", mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("UpdateTodoList", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("DeleteTodoList", mock.Anything, mock.Anything).Return(nil).Maybe()
+	todoListService.On("ListRevisions", mock.Anything, mock.Anything).Return([]model.TodoListRevisionResponse{}, nil).Maybe()
+	todoListService.On("GetRevision", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListRevisionResponse{}, nil).Maybe()
+	todoListService.On("RestoreRevision", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("MoveTodoList", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("DuplicateTodoList", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("SetTemplate", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("InstantiateTemplate", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("ArchiveTodoList", mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+	todoListService.On("UnarchiveTodoList", mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+
+	todoItemService := new(mocks.TodoItemServiceMock)
+	todoItemService.On("CreateTodoItem", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoItemResponse{}, nil).Maybe()
+	todoItemService.On("ListTodoItems", mock.Anything, mock.Anything, mock.Anything).Return([]model.TodoItemResponse{}, nil).Maybe()
+	todoItemService.On("GetTodoItem", mock.Anything, mock.Anything, mock.Anything).Return(model.TodoItemResponse{}, nil).Maybe()
+	todoItemService.On("UpdateTodoItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.TodoItemResponse{}, nil).Maybe()
+	todoItemService.On("DeleteTodoItem", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	todoItemService.On("MaterializeOccurrences", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]model.TodoItemResponse{}, nil).Maybe()
+	todoItemService.On("MoveTodoItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.TodoItemResponse{}, nil).Maybe()
+
+	reminderService := new(mocks.ReminderServiceMock)
+	reminderService.On("CreateReminder", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.ReminderResponse{}, nil).Maybe()
+	reminderService.On("ListReminders", mock.Anything, mock.Anything, mock.Anything).Return([]model.ReminderResponse{}, nil).Maybe()
+	reminderService.On("DeleteReminder", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	notificationService := new(mocks.NotificationServiceMock)
+	notificationService.On("ListNotifications", mock.Anything, mock.Anything).Return([]model.NotificationResponse{}, nil).Maybe()
+	notificationService.On("MarkRead", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	tagService := new(mocks.TagServiceMock)
+	tagService.On("CreateTag", mock.Anything, mock.Anything).Return(model.TagResponse{}, nil).Maybe()
+	tagService.On("ListTags", mock.Anything).Return([]model.TagResponse{}, nil).Maybe()
+	tagService.On("UpdateTag", mock.Anything, mock.Anything, mock.Anything).Return(model.TagResponse{}, nil).Maybe()
+	tagService.On("DeleteTag", mock.Anything, mock.Anything).Return(nil).Maybe()
+	tagService.On("AttachTag", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	tagService.On("DetachTag", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	attachmentService := new(mocks.AttachmentServiceMock)
+	attachmentService.On("UploadAttachment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.AttachmentResponse{}, nil).Maybe()
+	attachmentService.On("ListAttachments", mock.Anything, mock.Anything, mock.Anything).Return([]model.AttachmentResponse{}, nil).Maybe()
+	attachmentService.On("GetAttachment", mock.Anything, mock.Anything, mock.Anything).Return(model.AttachmentResponse{}, nil).Maybe()
+	attachmentService.On("DeleteAttachment", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	commentService := new(mocks.CommentServiceMock)
+	commentService.On("CreateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.CommentResponse{}, nil).Maybe()
+	commentService.On("ListComments", mock.Anything, mock.Anything).Return(model.CommentPage{}, nil).Maybe()
+	commentService.On("UpdateComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(model.CommentResponse{}, nil).Maybe()
+	commentService.On("DeleteComment", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	shareLinkService := new(mocks.ShareLinkServiceMock)
+	shareLinkService.On("CreateShareLink", mock.Anything, mock.Anything, mock.Anything).Return(model.ShareLinkResponse{}, nil).Maybe()
+	shareLinkService.On("ListShareLinks", mock.Anything, mock.Anything).Return([]model.ShareLinkResponse{}, nil).Maybe()
+	shareLinkService.On("RevokeShareLink", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	shareLinkService.On("GetSharedTodoList", mock.Anything, mock.Anything).Return(model.TodoListResponse{}, nil).Maybe()
+
+	calendarService := new(mocks.CalendarServiceMock)
+	calendarService.On("CreateFeed", mock.Anything).Return(model.CalendarFeedResponse{}, nil).Maybe()
+	calendarService.On("DeleteFeed", mock.Anything).Return(nil).Maybe()
+	calendarService.On("RenderFeed", mock.Anything, "feed-token").Return([]byte("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), nil).Maybe()
+
+	graphSchema, err := graph.NewSchema(todoListService, pubsub.New[model.TodoListEvent](1), validate, graph.Limits{MaxDepth: 8, MaxComplexity: 200}, logger)
+	require.NoError(t, err)
+
+	workspaceService := services.workspaces
+	if workspaceService == nil {
+		workspaceService = new(mocks.WorkspaceServiceMock)
+		workspaceService.On("CreateWorkspace", mock.Anything, mock.Anything).Return(model.WorkspaceResponse{}, nil).Maybe()
+		workspaceService.On("GetWorkspace", mock.Anything, mock.Anything).Return(model.WorkspaceResponse{}, nil).Maybe()
+		workspaceService.On("IsMember", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Maybe()
+		workspaceService.On("UpdateWorkspace", mock.Anything, mock.Anything, mock.Anything).Return(model.WorkspaceResponse{}, nil).Maybe()
+		workspaceService.On("AddMember", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		workspaceService.On("RemoveMember", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	}
+
+	return router.New(
+		handler.NewTodoListHandler(todoListService, validate, logger),
+		handler.NewTodoItemHandler(todoItemService, validate, logger),
+		handler.NewShareLinkHandler(shareLinkService, validate, logger),
+		handler.NewWorkspaceHandler(workspaceService, validate, logger),
+		handler.NewAuthHandler(authService, validate, logger),
+		handler.NewAccountHandler(accountService, validate, logger),
+		handler.NewAPIKeyHandler(apiKeyService, validate, logger),
+		handler.NewAuditHandler(auditService, validate, logger),
+		handler.NewReminderHandler(reminderService, validate, logger),
+		handler.NewNotificationHandler(notificationService, logger),
+		handler.NewTagHandler(tagService, validate, logger),
+		handler.NewAttachmentHandler(attachmentService, 1<<20, logger),
+		handler.NewCommentHandler(commentService, validate, logger),
+		handler.NewCalendarHandler(calendarService, logger),
+		handler.NewGraphQLHandler(graphSchema, logger),
+		workspaceService,
+		verifier,
+		authService,
+		apiKeyService,
+		router.Options{ValidateResponses: true},
+		logger,
+	)
+}
+
+func signToken(t *testing.T, roles, scopes []string) string {
+	t.Helper()
+	return signClaims(t, auth.Claims{Roles: roles, Scopes: scopes})
+}
+
+func signClaims(t *testing.T, claims auth.Claims) string {
+	t.Helper()
+
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Subject:   "user-1",
+		Issuer:    testIssuer,
+		Audience:  jwt.ClaimStrings{testIssuer},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
+
+func TestRouter_RoutePermissions(t *testing.T) {
+	listID := uuid.New().String()
+	linkID := uuid.New().String()
+	itemID := uuid.New().String()
+	tagID := uuid.New().String()
+	attachmentID := uuid.New().String()
+	commentID := uuid.New().String()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		permission string
+	}{
+		{"create workspace", http.MethodPost, "/api/v1/workspaces", `{"name":"Platform"}`, auth.PermissionWorkspacesWrite},
+		{"get workspace", http.MethodGet, "/api/v1/workspaces/" + uuid.New().String(), "", auth.PermissionWorkspacesRead},
+		{"add workspace member", http.MethodPut, "/api/v1/workspaces/" + uuid.New().String() + "/members/" + uuid.New().String(), "", auth.PermissionWorkspacesWrite},
+		{"create todo list", http.MethodPost, "/api/v1/todolists", `{"title":"Groceries"}`, auth.PermissionTodoListsWrite},
+		{"list todo lists", http.MethodGet, "/api/v1/todolists", "", auth.PermissionTodoListsRead},
+		{"get todo list", http.MethodGet, "/api/v1/todolists/" + listID, "", auth.PermissionTodoListsRead},
+		{"update todo list", http.MethodPut, "/api/v1/todolists/" + listID, `{"title":"Groceries"}`, auth.PermissionTodoListsWrite},
+		{"delete todo list", http.MethodDelete, "/api/v1/todolists/" + listID, "", auth.PermissionTodoListsWrite},
+		{"move todo list", http.MethodPost, "/api/v1/todolists/" + listID + "/move", `{}`, auth.PermissionTodoListsWrite},
+		{"duplicate todo list", http.MethodPost, "/api/v1/todolists/" + listID + "/duplicate", "", auth.PermissionTodoListsWrite},
+		{"list templates", http.MethodGet, "/api/v1/todolists/templates", "", auth.PermissionTodoListsRead},
+		{"mark template", http.MethodPut, "/api/v1/todolists/" + listID + "/template", "", auth.PermissionTodoListsWrite},
+		{"unmark template", http.MethodDelete, "/api/v1/todolists/" + listID + "/template", "", auth.PermissionTodoListsWrite},
+		{"instantiate template", http.MethodPost, "/api/v1/todolists/" + listID + "/instantiate", `{"variables":{"name":"Ada"}}`, auth.PermissionTodoListsWrite},
+		{"archive todo list", http.MethodPost, "/api/v1/todolists/" + listID + "/archive", "", auth.PermissionTodoListsWrite},
+		{"unarchive todo list", http.MethodPost, "/api/v1/todolists/" + listID + "/unarchive", "", auth.PermissionTodoListsWrite},
+		{"create todo item", http.MethodPost, "/api/v1/todolists/" + listID + "/items", `{"title":"Milk"}`, auth.PermissionTodoListsWrite},
+		{"list todo items", http.MethodGet, "/api/v1/todolists/" + listID + "/items?overdue=true", "", auth.PermissionTodoListsRead},
+		{"get todo item", http.MethodGet, "/api/v1/todolists/" + listID + "/items/" + itemID, "", auth.PermissionTodoListsRead},
+		{"update todo item", http.MethodPut, "/api/v1/todolists/" + listID + "/items/" + itemID, `{"title":"Milk","status":"done"}`, auth.PermissionTodoListsWrite},
+		{"delete todo item", http.MethodDelete, "/api/v1/todolists/" + listID + "/items/" + itemID, "", auth.PermissionTodoListsWrite},
+		{"materialize todo item occurrences", http.MethodPost, "/api/v1/todolists/" + listID + "/items/" + itemID + "/occurrences", `{"count":4}`, auth.PermissionTodoListsWrite},
+		{"move todo item", http.MethodPost, "/api/v1/todolists/" + listID + "/items/" + itemID + "/move", `{"todo_list_id":"` + uuid.New().String() + `"}`, auth.PermissionTodoListsWrite},
+		{"create reminder", http.MethodPost, "/api/v1/todolists/" + listID + "/items/" + itemID + "/reminders", `{"offset_minutes":30,"channel":"inbox"}`, auth.PermissionTodoListsWrite},
+		{"list reminders", http.MethodGet, "/api/v1/todolists/" + listID + "/items/" + itemID + "/reminders", "", auth.PermissionTodoListsRead},
+		{"delete reminder", http.MethodDelete, "/api/v1/todolists/" + listID + "/items/" + itemID + "/reminders/" + uuid.New().String(), "", auth.PermissionTodoListsWrite},
+		{"list revisions", http.MethodGet, "/api/v1/todolists/" + listID + "/revisions", "", auth.PermissionTodoListsRead},
+		{"get revision", http.MethodGet, "/api/v1/todolists/" + listID + "/revisions/1", "", auth.PermissionTodoListsRead},
+		{"restore revision", http.MethodPost, "/api/v1/todolists/" + listID + "/revisions/1/restore", "", auth.PermissionTodoListsWrite},
+		{"create share link", http.MethodPost, "/api/v1/todolists/" + listID + "/share-links", "", auth.PermissionTodoListsWrite},
+		{"list share links", http.MethodGet, "/api/v1/todolists/" + listID + "/share-links", "", auth.PermissionTodoListsRead},
+		{"revoke share link", http.MethodDelete, "/api/v1/todolists/" + listID + "/share-links/" + linkID, "", auth.PermissionTodoListsWrite},
+		{"create calendar feed", http.MethodPost, "/api/v1/calendar-feed", "", auth.PermissionTodoListsRead},
+		{"delete calendar feed", http.MethodDelete, "/api/v1/calendar-feed", "", auth.PermissionTodoListsRead},
+		{"list todo lists by tag", http.MethodGet, "/api/v1/todolists?tags=home,urgent&match=all", "", auth.PermissionTodoListsRead},
+		{"attach tag", http.MethodPut, "/api/v1/todolists/" + listID + "/tags/" + tagID, "", auth.PermissionTodoListsWrite},
+		{"detach tag", http.MethodDelete, "/api/v1/todolists/" + listID + "/tags/" + tagID, "", auth.PermissionTodoListsWrite},
+		{"create tag", http.MethodPost, "/api/v1/tags", `{"name":"home","color":"#4caf50"}`, auth.PermissionTodoListsWrite},
+		{"list tags", http.MethodGet, "/api/v1/tags", "", auth.PermissionTodoListsRead},
+		{"update tag", http.MethodPut, "/api/v1/tags/" + tagID, `{"name":"house"}`, auth.PermissionTodoListsWrite},
+		{"delete tag", http.MethodDelete, "/api/v1/tags/" + tagID, "", auth.PermissionTodoListsWrite},
+		{"upload attachment", http.MethodPost, "/api/v1/todolists/" + listID + "/attachments", "", auth.PermissionTodoListsWrite},
+		{"list attachments", http.MethodGet, "/api/v1/todolists/" + listID + "/attachments", "", auth.PermissionTodoListsRead},
+		{"get attachment", http.MethodGet, "/api/v1/todolists/" + listID + "/attachments/" + attachmentID, "", auth.PermissionTodoListsRead},
+		{"delete attachment", http.MethodDelete, "/api/v1/todolists/" + listID + "/attachments/" + attachmentID, "", auth.PermissionTodoListsWrite},
+		{"upload item attachment", http.MethodPost, "/api/v1/todolists/" + listID + "/items/" + itemID + "/attachments", "", auth.PermissionTodoListsWrite},
+		{"list item attachments", http.MethodGet, "/api/v1/todolists/" + listID + "/items/" + itemID + "/attachments", "", auth.PermissionTodoListsRead},
+		{"create comment", http.MethodPost, "/api/v1/todolists/" + listID + "/comments", `{"body":"Looks good"}`, auth.PermissionTodoListsWrite},
+		{"list comments", http.MethodGet, "/api/v1/todolists/" + listID + "/comments", "", auth.PermissionTodoListsRead},
+		{"update comment", http.MethodPut, "/api/v1/todolists/" + listID + "/comments/" + commentID, `{"body":"Edited"}`, auth.PermissionTodoListsWrite},
+		{"delete comment", http.MethodDelete, "/api/v1/todolists/" + listID + "/comments/" + commentID, "", auth.PermissionTodoListsWrite},
+		{"create item comment", http.MethodPost, "/api/v1/todolists/" + listID + "/items/" + itemID + "/comments", `{"body":"Done?"}`, auth.PermissionTodoListsWrite},
+		{"list item comments", http.MethodGet, "/api/v1/todolists/" + listID + "/items/" + itemID + "/comments", "", auth.PermissionTodoListsRead},
+		{"graphql query", http.MethodPost, "/api/v1/graphql", `{"query":"{ todoLists { id } }"}`, auth.PermissionTodoListsRead},
+		{"graphql query over GET", http.MethodGet, "/api/v1/graphql?query=%7B%20todoLists%20%7B%20id%20%7D%20%7D", "", auth.PermissionTodoListsRead},
+		{"list audit logs", http.MethodGet, "/api/v1/audit", "", auth.PermissionAuditRead},
+	}
+
+	r := newTestRouter(t)
+	workspaceID := uuid.New().String()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			do := func(token string) int {
+				req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+				req.Header.Set("X-Workspace-ID", workspaceID)
+				if token != "" {
+					req.Header.Set("Authorization", "Bearer "+token)
+				}
+				rr := httptest.NewRecorder()
+				r.ServeHTTP(rr, req)
+				return rr.Code
+			}
+
+			require.Equal(t, http.StatusUnauthorized, do(""))
+			require.Equal(t, http.StatusForbidden, do(signToken(t, nil, []string{"unrelated:scope"})))
+
+			granted := do(signToken(t, nil, []string{tt.permission}))
+			require.NotEqual(t, http.StatusUnauthorized, granted)
+			require.NotEqual(t, http.StatusForbidden, granted)
+
+			admin := do(signToken(t, []string{auth.RoleAdmin}, nil))
+			require.NotEqual(t, http.StatusForbidden, admin)
+		})
+	}
+}
+
+func TestRouter_ValidatesRequestsAgainstOpenAPI(t *testing.T) {
+	r := newTestRouter(t)
+	listID := uuid.New().String()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		roles  []string
+		status int
+		errors map[string]string
+	}{
+		{
+			name:   "credentials are checked first",
+			method: http.MethodGet,
+			path:   "/api/v1/todolists/not-a-uuid",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "permissions are checked first",
+			method: http.MethodPost,
+			path:   "/api/v1/todolists",
+			body:   `{}`,
+			roles:  []string{auth.RoleViewer},
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "path parameter",
+			method: http.MethodGet,
+			path:   "/api/v1/todolists/not-a-uuid",
+			roles:  []string{auth.RoleMember},
+			status: http.StatusBadRequest,
+			errors: map[string]string{"id": "must be a valid uuid"},
+		},
+		{
+			name:   "query parameters",
+			method: http.MethodGet,
+			path:   "/api/v1/todolists?priority=whenever&due_before=tomorrow",
+			roles:  []string{auth.RoleMember},
+			status: http.StatusUnprocessableEntity,
+			errors: map[string]string{
+				"priority":   `value is not one of the allowed values ["low","medium","high","urgent",""]`,
+				"due_before": "must be a valid date-time",
+			},
+		},
+		{
+			name:   "body",
+			method: http.MethodPost,
+			path:   "/api/v1/todolists",
+			body:   `{"description":"Weekly shop","priority":"whenever"}`,
+			roles:  []string{auth.RoleMember},
+			status: http.StatusUnprocessableEntity,
+			errors: map[string]string{
+				"title":    "is required",
+				"priority": `value is not one of the allowed values ["low","medium","high","urgent",""]`,
+			},
+		},
+		{
+			name:   "malformed body",
+			method: http.MethodPut,
+			path:   "/api/v1/todolists/" + listID,
+			body:   `{"title":`,
+			roles:  []string{auth.RoleMember},
+			status: http.StatusBadRequest,
+			errors: map[string]string{"message": "invalid request payload"},
+		},
+		{
+			name:   "empty optional values",
+			method: http.MethodGet,
+			path:   "/api/v1/todolists?status=&priority=",
+			roles:  []string{auth.RoleMember},
+			status: http.StatusOK,
+		},
+		{
+			name:   "valid body",
+			method: http.MethodPost,
+			path:   "/api/v1/todolists",
+			body:   `{"title":"Groceries","priority":"high"}`,
+			roles:  []string{auth.RoleMember},
+			status: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("X-Workspace-ID", uuid.New().String())
+			if tt.roles != nil {
+				req.Header.Set("Authorization", "Bearer "+signToken(t, tt.roles, nil))
+			}
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+			require.Equal(t, tt.status, rr.Code, rr.Body.String())
+
+			if tt.errors != nil {
+				var body struct {
+					Error map[string]string `json:"error"`
+				}
+				require.NoError(t, json.NewDecoder(rr.Body).Decode(&body))
+				require.Equal(t, tt.errors, body.Error)
+			}
+		})
+	}
+}
+
+func TestRouter_ViewerCannotWrite(t *testing.T) {
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/todolists", strings.NewReader(`{"title":"Groceries"}`))
+	req.Header.Set("X-Workspace-ID", uuid.New().String())
+	req.Header.Set("Authorization", "Bearer "+signToken(t, []string{auth.RoleViewer}, nil))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusForbidden, rr.Code)
+}
+
+func TestRouter_AuditLogAdminOnly(t *testing.T) {
+	r := newTestRouter(t)
+
+	for role, want := range map[string]int{auth.RoleMember: http.StatusForbidden, auth.RoleAdmin: http.StatusOK} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/audit", nil)
+		req.Header.Set("X-Workspace-ID", uuid.New().String())
+		req.Header.Set("Authorization", "Bearer "+signToken(t, []string{role}, nil))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, want, rr.Code, role)
+	}
+}
+
+func TestRouter_NotificationsNeedOnlyAuthentication(t *testing.T) {
+	r := newTestRouter(t)
+
+	for token, want := range map[string]int{
+		"": http.StatusUnauthorized,
+		signToken(t, nil, []string{"unrelated:scope"}): http.StatusOK,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/notifications", nil)
+		req.Header.Set("X-Workspace-ID", uuid.New().String())
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		require.Equal(t, want, rr.Code)
+	}
+}
+
+func TestRouter_PublicShareLinkSkipsAuthentication(t *testing.T) {
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/lists/token", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestRouter_CalendarFeedSkipsAuthentication(t *testing.T) {
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/calendar/feed-token.ics", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+}
+
+func TestRouter_RevokedAccessTokenRejected(t *testing.T) {
+	authService := new(mocks.AuthServiceMock)
+	authService.On("IsAccessTokenRevoked", mock.Anything, mock.Anything).Return(true, nil)
+	r := newTestRouterWith(t, testServices{auth: authService})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists", nil)
+	req.Header.Set("X-Workspace-ID", uuid.New().String())
+	req.Header.Set("Authorization", "Bearer "+signToken(t, []string{auth.RoleAdmin}, nil))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+	authService.AssertExpectations(t)
+}
+
+func TestRouter_AuthEndpointsSkipAuthentication(t *testing.T) {
+	authService := new(mocks.AuthServiceMock)
+	authService.On("Login", mock.Anything, mock.Anything).Return(model.TokenResponse{AccessToken: "token"}, nil)
+	r := newTestRouterWith(t, testServices{auth: authService})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		strings.NewReader(`{"email":"ada@example.com","password":"correct horse"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	authService.AssertExpectations(t)
+}
+
+func TestRouter_ForgotPasswordSkipsAuthentication(t *testing.T) {
+	r := newTestRouter(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/forgot-password",
+		strings.NewReader(`{"email":"ada@example.com"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusAccepted, rr.Code)
+}
+
+func TestRouter_APIKeyAuthentication(t *testing.T) {
+	apiKeyService := new(mocks.APIKeyServiceMock)
+	apiKeyService.On("Authenticate", mock.Anything, "tdl_abc123.secret").Return(&auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"},
+		Scopes:           []string{auth.PermissionTodoListsRead},
+	}, nil)
+	apiKeyService.On("Authenticate", mock.Anything, "tdl_abc123.wrong").Return(nil, service.ErrInvalidAPIKey)
+	r := newTestRouterWith(t, testServices{apiKeys: apiKeyService})
+
+	do := func(method, key string) int {
+		req := httptest.NewRequest(method, "/api/v1/todolists", strings.NewReader(`{"title":"Groceries"}`))
+		req.Header.Set("X-Workspace-ID", uuid.New().String())
+		req.Header.Set("Authorization", "ApiKey "+key)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusOK, do(http.MethodGet, "tdl_abc123.secret"))
+	require.Equal(t, http.StatusForbidden, do(http.MethodPost, "tdl_abc123.secret"))
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "tdl_abc123.wrong"))
+	apiKeyService.AssertExpectations(t)
+}
+
+func TestRouter_WorkspaceRequiresMembership(t *testing.T) {
+	joined, other := uuid.New(), uuid.New()
+	workspaceService := new(mocks.WorkspaceServiceMock)
+	workspaceService.On("GetWorkspace", mock.Anything, mock.Anything).Return(model.WorkspaceResponse{}, nil)
+	workspaceService.On("IsMember", mock.Anything, joined, "user-1").Return(true, nil)
+	workspaceService.On("IsMember", mock.Anything, other, "user-1").Return(false, nil)
+	r := newTestRouterWith(t, testServices{workspaces: workspaceService})
+	token := signClaims(t, auth.Claims{Roles: []string{auth.RoleMember}})
+
+	do := func(workspaceID uuid.UUID) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists", nil)
+		req.Header.Set("X-Workspace-ID", workspaceID.String())
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	require.Equal(t, http.StatusOK, do(joined))
+	require.Equal(t, http.StatusForbidden, do(other))
+}
+
+func TestRouter_WorkspaceRequiringMFA(t *testing.T) {
+	workspaceService := new(mocks.WorkspaceServiceMock)
+	workspaceService.On("GetWorkspace", mock.Anything, mock.Anything).Return(model.WorkspaceResponse{RequireMFA: true}, nil)
+	workspaceService.On("IsMember", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	r := newTestRouterWith(t, testServices{workspaces: workspaceService})
+
+	do := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/todolists", nil)
+		req.Header.Set("X-Workspace-ID", uuid.New().String())
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	passwordOnly := signClaims(t, auth.Claims{Roles: []string{auth.RoleMember}, AMR: []string{auth.AMRPassword}})
+	require.Equal(t, http.StatusForbidden, do(passwordOnly))
+
+	withMFA := signClaims(t, auth.Claims{
+		Roles: []string{auth.RoleMember},
+		AMR:   []string{auth.AMRPassword, auth.AMROTP, auth.AMRMultiFactor},
+	})
+	require.Equal(t, http.StatusOK, do(withMFA))
+}
+
+// normalizeRoute drops trailing slashes and parameter names, which differ
+// between chi patterns and OpenAPI paths.
+func normalizeRoute(route string) string {
+	segments := strings.Split(strings.TrimRight(route, "/"), "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func TestRouter_OpenAPICoversRoutes(t *testing.T) {
+	doc := router.OpenAPI()
+	require.NoError(t, doc.Validate(context.Background()))
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			documented[method+" "+normalizeRoute(path)] = true
+		}
+	}
+
+	routed := make(map[string]bool)
+	err := chi.Walk(newTestRouter(t).(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if route == "/docs/*" {
+			// Swagger UI's static files.
+			return nil
+		}
+		key := method + " " + normalizeRoute(route)
+		routed[key] = true
+		assert.True(t, documented[key], "%s %s is missing from router.OpenAPI", method, route)
+		return nil
+	})
+	require.NoError(t, err)
+
+	for key := range documented {
+		assert.True(t, routed[key], "router.OpenAPI documents %s, which has no route", key)
+	}
+}
+
+func TestRouter_ServesOpenAPI(t *testing.T) {
+	r := newTestRouter(t)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(context.Background()))
+
+	// Handlers wrap payloads in the response envelope.
+	getTodoList := doc.Paths.Find("/api/v1/todolists/{id}").Get
+	data := getTodoList.Responses.Status(http.StatusOK).Value.Content.Get("application/json").Schema.Value.Properties["data"]
+	require.Equal(t, "#/components/schemas/TodoList", data.Ref)
+	require.Equal(t, "uuid", getTodoList.Parameters.GetByInAndName(openapi3.ParameterInPath, "id").Schema.Value.Format)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "swagger-ui")
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `url: "/openapi.json"`)
+}
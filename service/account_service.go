@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	userTokenBytes       = 32
+	passwordResetTTL     = time.Hour
+	emailVerificationTTL = 24 * time.Hour
+)
+
+var (
+	// ErrInvalidUserToken indicates that a reset or verification token is unknown, expired or already used.
+	ErrInvalidUserToken = errors.New("invalid or expired token")
+	// ErrEmailNotVerified indicates that the account's email address has not been verified yet.
+	ErrEmailNotVerified = errors.New("email not verified")
+)
+
+// AccountService defines email-based account recovery and verification.
+// Requests naming an email address succeed whether or not an account exists,
+// so the endpoints cannot be used to discover registered addresses.
+type AccountService interface {
+	SendVerification(ctx context.Context, user *model.User) error
+	ResendVerification(ctx context.Context, req model.ResendVerificationRequest) error
+	VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) error
+	ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error
+}
+
+type accountService struct {
+	users         repository.UserRepository
+	userTokens    repository.UserTokenRepository
+	refreshTokens repository.RefreshTokenRepository
+	mailer        mailer.Mailer
+	baseURL       string
+	clock         clock.Clock
+	logger        *zap.Logger
+}
+
+// NewAccountService constructs an AccountService implementation. Links in
+// emails point at baseURL.
+func NewAccountService(
+	users repository.UserRepository,
+	userTokens repository.UserTokenRepository,
+	refreshTokens repository.RefreshTokenRepository,
+	mailer mailer.Mailer,
+	baseURL string,
+	clock clock.Clock,
+	logger *zap.Logger,
+) AccountService {
+	return &accountService{
+		users:         users,
+		userTokens:    userTokens,
+		refreshTokens: refreshTokens,
+		mailer:        mailer,
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+// SendVerification emails a fresh verification link, invalidating earlier ones.
+func (s *accountService) SendVerification(ctx context.Context, user *model.User) error {
+	raw, err := s.issueToken(ctx, user, model.UserTokenEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return fmt.Errorf("send verification: %w", err)
+	}
+	if err := s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Confirm your email address by opening the link below:\n\n%s\n\nThe link expires in 24 hours.\n",
+			s.link("/verify-email", raw)),
+	}); err != nil {
+		s.logger.Error("send verification email failed", zap.Error(err))
+		return fmt.Errorf("send verification: %w", err)
+	}
+	s.logger.Info("verification email sent", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+func (s *accountService) ResendVerification(ctx context.Context, req model.ResendVerificationRequest) error {
+	user, err := s.users.FindByEmail(ctx, strings.ToLower(req.Email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		s.logger.Error("find user for verification failed", zap.Error(err))
+		return fmt.Errorf("resend verification: %w", err)
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.SendVerification(ctx, user)
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) error {
+	now := s.clock.Now()
+	user, err := s.consume(ctx, model.UserTokenEmailVerification, req.Token, now)
+	if err != nil {
+		return fmt.Errorf("verify email: %w", err)
+	}
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+		if err := s.users.Update(ctx, user); err != nil {
+			s.logger.Error("mark email verified failed", zap.Error(err))
+			return fmt.Errorf("verify email: %w", err)
+		}
+	}
+	s.logger.Info("email verified", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// ForgotPassword emails a reset link when the address belongs to an account.
+// It succeeds either way, and mail failures are only logged, so callers
+// cannot tell which addresses are registered.
+func (s *accountService) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error {
+	user, err := s.users.FindByEmail(ctx, strings.ToLower(req.Email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			s.logger.Info("password reset requested for unknown email")
+			return nil
+		}
+		s.logger.Error("find user for password reset failed", zap.Error(err))
+		return fmt.Errorf("forgot password: %w", err)
+	}
+
+	raw, err := s.issueToken(ctx, user, model.UserTokenPasswordReset, passwordResetTTL)
+	if err != nil {
+		return fmt.Errorf("forgot password: %w", err)
+	}
+	if err := s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Someone asked to reset the password for this account. Choose a new password by opening the link below:\n\n%s\n\nThe link expires in 1 hour. If you did not ask for this, you can ignore this email.\n",
+			s.link("/reset-password", raw)),
+	}); err != nil {
+		s.logger.Error("send password reset email failed", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return nil
+	}
+	s.logger.Info("password reset email sent", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// ResetPassword sets a new password and signs the account out everywhere.
+// Receiving the reset email also proves control of the address.
+func (s *accountService) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
+	now := s.clock.Now()
+	user, err := s.consume(ctx, model.UserTokenPasswordReset, req.Token, now)
+	if err != nil {
+		return fmt.Errorf("reset password: %w", err)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.Error("hash password failed", zap.Error(err))
+		return fmt.Errorf("reset password: %w", err)
+	}
+	user.PasswordHash = string(hash)
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.users.Update(ctx, user); err != nil {
+		s.logger.Error("update password failed", zap.Error(err))
+		return fmt.Errorf("reset password: %w", err)
+	}
+	if err := s.refreshTokens.RevokeAllForUser(ctx, user.ID, now); err != nil {
+		s.logger.Error("revoke sessions after password reset failed", zap.Error(err))
+		return fmt.Errorf("reset password: %w", err)
+	}
+	s.logger.Info("password reset", zap.String("user_id", user.ID.String()))
+	return nil
+}
+
+// issueToken stores a new token of the purpose after invalidating the
+// user's outstanding ones, and returns the raw value to email.
+func (s *accountService) issueToken(ctx context.Context, user *model.User, purpose string, ttl time.Duration) (string, error) {
+	now := s.clock.Now()
+	if err := s.userTokens.InvalidateForUser(ctx, user.ID, purpose, now); err != nil {
+		s.logger.Error("invalidate user tokens failed", zap.Error(err))
+		return "", err
+	}
+	raw, err := token.Generate(userTokenBytes)
+	if err != nil {
+		s.logger.Error("generate user token failed", zap.Error(err))
+		return "", err
+	}
+	if err := s.userTokens.Create(ctx, &model.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: token.Hash(raw),
+		ExpiresAt: now.Add(ttl),
+	}); err != nil {
+		s.logger.Error("store user token failed", zap.Error(err))
+		return "", err
+	}
+	return raw, nil
+}
+
+// consume redeems a token and loads the user it was issued to.
+func (s *accountService) consume(ctx context.Context, purpose, raw string, now time.Time) (*model.User, error) {
+	userToken, err := s.userTokens.Consume(ctx, purpose, token.Hash(raw), now)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserTokenNotFound) {
+			return nil, ErrInvalidUserToken
+		}
+		s.logger.Error("consume user token failed", zap.Error(err))
+		return nil, err
+	}
+	user, err := s.users.FindByID(ctx, userToken.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrInvalidUserToken
+		}
+		s.logger.Error("find user for token failed", zap.Error(err))
+		return nil, err
+	}
+	return user, nil
+}
+
+func (s *accountService) link(path, raw string) string {
+	return s.baseURL + path + "?token=" + url.QueryEscape(raw)
+}
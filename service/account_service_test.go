@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type accountFixture struct {
+	users         *mocks.UserRepositoryMock
+	userTokens    *mocks.UserTokenRepositoryMock
+	refreshTokens *mocks.RefreshTokenRepositoryMock
+	mailer        *mocks.MailerMock
+	clock         *mocks.FakeClock
+	svc           service.AccountService
+}
+
+func newAccountFixture(t *testing.T) accountFixture {
+	t.Helper()
+
+	f := accountFixture{
+		users:         new(mocks.UserRepositoryMock),
+		userTokens:    new(mocks.UserTokenRepositoryMock),
+		refreshTokens: new(mocks.RefreshTokenRepositoryMock),
+		mailer:        new(mocks.MailerMock),
+		clock:         mocks.NewFakeClock(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewAccountService(f.users, f.userTokens, f.refreshTokens, f.mailer, "https://todo.example.com/", f.clock, zaptest.NewLogger(t))
+	return f
+}
+
+// tokenFromMessage extracts the raw token from the link in an email body.
+func tokenFromMessage(t *testing.T, msg mailer.Message) string {
+	t.Helper()
+
+	for _, field := range strings.Fields(msg.Body) {
+		if strings.HasPrefix(field, "https://todo.example.com/") {
+			link, err := url.Parse(field)
+			require.NoError(t, err)
+			return link.Query().Get("token")
+		}
+	}
+	t.Fatalf("no link in message body %q", msg.Body)
+	return ""
+}
+
+func TestAccountService_ForgotPassword_SendsSingleResetLink(t *testing.T) {
+	f := newAccountFixture(t)
+	user := newUser(t, "correct horse")
+	now := f.clock.Now()
+
+	var stored *model.UserToken
+	var sent mailer.Message
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.userTokens.On("InvalidateForUser", mock.Anything, user.ID, model.UserTokenPasswordReset, now).Return(nil)
+	f.userTokens.On("Create", mock.Anything, mock.AnythingOfType("*model.UserToken")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(*model.UserToken) }).
+		Return(nil)
+	f.mailer.On("Send", mock.Anything, mock.AnythingOfType("mailer.Message")).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(mailer.Message) }).
+		Return(nil)
+
+	require.NoError(t, f.svc.ForgotPassword(context.Background(), model.ForgotPasswordRequest{Email: "Ada@Example.com"}))
+
+	require.Equal(t, "ada@example.com", sent.To)
+	require.Contains(t, sent.Body, "https://todo.example.com/reset-password?token=")
+	raw := tokenFromMessage(t, sent)
+	require.Equal(t, token.Hash(raw), stored.TokenHash)
+	require.Equal(t, model.UserTokenPasswordReset, stored.Purpose)
+	require.Equal(t, now.Add(time.Hour), stored.ExpiresAt)
+	f.userTokens.AssertExpectations(t)
+	f.mailer.AssertExpectations(t)
+}
+
+func TestAccountService_ForgotPassword_UnknownEmailSucceedsSilently(t *testing.T) {
+	f := newAccountFixture(t)
+	f.users.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, repository.ErrUserNotFound)
+
+	require.NoError(t, f.svc.ForgotPassword(context.Background(), model.ForgotPasswordRequest{Email: "nobody@example.com"}))
+	f.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_ForgotPassword_MailFailureSucceedsSilently(t *testing.T) {
+	f := newAccountFixture(t)
+	user := newUser(t, "correct horse")
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.userTokens.On("InvalidateForUser", mock.Anything, user.ID, model.UserTokenPasswordReset, mock.Anything).Return(nil)
+	f.userTokens.On("Create", mock.Anything, mock.AnythingOfType("*model.UserToken")).Return(nil)
+	f.mailer.On("Send", mock.Anything, mock.AnythingOfType("mailer.Message")).Return(errors.New("smtp unavailable"))
+
+	require.NoError(t, f.svc.ForgotPassword(context.Background(), model.ForgotPasswordRequest{Email: "ada@example.com"}))
+	f.mailer.AssertExpectations(t)
+}
+
+func TestAccountService_ResetPassword_UpdatesPasswordAndRevokesSessions(t *testing.T) {
+	f := newAccountFixture(t)
+	user := newUser(t, "correct horse")
+	user.EmailVerifiedAt = nil
+	now := f.clock.Now()
+
+	f.userTokens.On("Consume", mock.Anything, model.UserTokenPasswordReset, token.Hash("raw-token"), now).
+		Return(&model.UserToken{ID: uuid.New(), UserID: user.ID}, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.User) bool {
+		return bcrypt.CompareHashAndPassword([]byte(updated.PasswordHash), []byte("battery staple")) == nil &&
+			updated.EmailVerifiedAt != nil
+	})).Return(nil)
+	f.refreshTokens.On("RevokeAllForUser", mock.Anything, user.ID, now).Return(nil)
+
+	err := f.svc.ResetPassword(context.Background(), model.ResetPasswordRequest{Token: "raw-token", Password: "battery staple"})
+	require.NoError(t, err)
+	f.users.AssertExpectations(t)
+	f.refreshTokens.AssertExpectations(t)
+}
+
+func TestAccountService_ResetPassword_InvalidToken(t *testing.T) {
+	f := newAccountFixture(t)
+	f.userTokens.On("Consume", mock.Anything, model.UserTokenPasswordReset, mock.Anything, mock.Anything).
+		Return(nil, repository.ErrUserTokenNotFound)
+
+	err := f.svc.ResetPassword(context.Background(), model.ResetPasswordRequest{Token: "used", Password: "battery staple"})
+	require.ErrorIs(t, err, service.ErrInvalidUserToken)
+	f.users.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	f := newAccountFixture(t)
+	user := newUser(t, "correct horse")
+	user.EmailVerifiedAt = nil
+	now := f.clock.Now()
+
+	f.userTokens.On("Consume", mock.Anything, model.UserTokenEmailVerification, token.Hash("raw-token"), now).
+		Return(&model.UserToken{ID: uuid.New(), UserID: user.ID}, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.User) bool {
+		return updated.EmailVerifiedAt != nil && updated.EmailVerifiedAt.Equal(now)
+	})).Return(nil)
+
+	require.NoError(t, f.svc.VerifyEmail(context.Background(), model.VerifyEmailRequest{Token: "raw-token"}))
+	f.users.AssertExpectations(t)
+}
+
+func TestAccountService_ResendVerification_SkipsVerifiedAccounts(t *testing.T) {
+	f := newAccountFixture(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(newUser(t, "correct horse"), nil)
+
+	require.NoError(t, f.svc.ResendVerification(context.Background(), model.ResendVerificationRequest{Email: "ada@example.com"}))
+	f.userTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	f.mailer.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
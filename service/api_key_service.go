@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	apiKeyPrefix      = "tdl_"
+	apiKeyPrefixBytes = 6
+	apiKeySecretBytes = 32
+	apiKeyTouchEvery  = time.Minute
+)
+
+var (
+	// ErrInvalidAPIKey indicates that the presented API key is malformed, unknown or expired.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+	// ErrScopeNotGranted indicates that an API key asked for a scope its creator does not hold.
+	ErrScopeNotGranted = errors.New("scope not granted")
+)
+
+// APIKeyService defines business operations for personal API keys.
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (model.APIKeyResponse, error)
+	ListAPIKeys(ctx context.Context) ([]model.APIKeyResponse, error)
+	GetAPIKey(ctx context.Context, id uuid.UUID) (model.APIKeyResponse, error)
+	UpdateAPIKey(ctx context.Context, id uuid.UUID, req model.UpdateAPIKeyRequest) (model.APIKeyResponse, error)
+	DeleteAPIKey(ctx context.Context, id uuid.UUID) error
+	Authenticate(ctx context.Context, rawKey string) (*auth.Claims, error)
+}
+
+type apiKeyService struct {
+	repository repository.APIKeyRepository
+	users      repository.UserRepository
+	clock      clock.Clock
+	logger     *zap.Logger
+}
+
+// NewAPIKeyService constructs an APIKeyService implementation. Keys act for
+// users in users, whose current role bounds the scopes a key grants.
+func NewAPIKeyService(repository repository.APIKeyRepository, users repository.UserRepository, clock clock.Clock, logger *zap.Logger) APIKeyService {
+	return &apiKeyService{
+		repository: repository,
+		users:      users,
+		clock:      clock,
+		logger:     logger,
+	}
+}
+
+// CreateAPIKey issues a key for the caller. Sessions authenticated by an API
+// key cannot create, change or delete keys, so a leaked key cannot outlive
+// its own revocation.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (model.APIKeyResponse, error) {
+	claims, ok := keyManager(ctx)
+	if !ok {
+		return model.APIKeyResponse{}, ErrForbidden
+	}
+	if err := checkScopesGranted(claims, req.Scopes); err != nil {
+		return model.APIKeyResponse{}, err
+	}
+
+	prefix, err := token.GenerateHex(apiKeyPrefixBytes)
+	if err != nil {
+		s.logger.Error("generate api key prefix failed", zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("create api key: %w", err)
+	}
+	secret, err := token.Generate(apiKeySecretBytes)
+	if err != nil {
+		s.logger.Error("generate api key secret failed", zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("create api key: %w", err)
+	}
+
+	apiKey := &model.APIKey{
+		UserID:      claims.Subject,
+		WorkspaceID: claims.WorkspaceID,
+		Name:        req.Name,
+		Prefix:      prefix,
+		SecretHash:  token.Hash(secret),
+		Scopes:      req.Scopes,
+		MFA:         claims.MFAAuthenticated(),
+		ExpiresAt:   req.ExpiresAt,
+	}
+	if err := s.repository.Create(ctx, apiKey); err != nil {
+		s.logger.Error("create api key failed", zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("create api key: %w", err)
+	}
+	s.logger.Info("api key created", zap.String("id", apiKey.ID.String()), zap.String("user_id", apiKey.UserID))
+
+	res := apiKey.ToResponse()
+	res.Key = apiKeyPrefix + prefix + "." + secret
+	return res, nil
+}
+
+func (s *apiKeyService) ListAPIKeys(ctx context.Context) ([]model.APIKeyResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+
+	apiKeys, err := s.repository.FindByUserID(ctx, claims.Subject)
+	if err != nil {
+		s.logger.Error("list api keys failed", zap.Error(err))
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+
+	res := make([]model.APIKeyResponse, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		res = append(res, apiKey.ToResponse())
+	}
+	return res, nil
+}
+
+func (s *apiKeyService) GetAPIKey(ctx context.Context, id uuid.UUID) (model.APIKeyResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.APIKeyResponse{}, ErrForbidden
+	}
+
+	apiKey, err := s.repository.FindByID(ctx, claims.Subject, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return model.APIKeyResponse{}, err
+		}
+		s.logger.Error("retrieve api key failed", zap.String("id", id.String()), zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("get api key: %w", err)
+	}
+	return apiKey.ToResponse(), nil
+}
+
+func (s *apiKeyService) UpdateAPIKey(ctx context.Context, id uuid.UUID, req model.UpdateAPIKeyRequest) (model.APIKeyResponse, error) {
+	claims, ok := keyManager(ctx)
+	if !ok {
+		return model.APIKeyResponse{}, ErrForbidden
+	}
+	if err := checkScopesGranted(claims, req.Scopes); err != nil {
+		return model.APIKeyResponse{}, err
+	}
+
+	apiKey, err := s.repository.FindByID(ctx, claims.Subject, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return model.APIKeyResponse{}, err
+		}
+		s.logger.Error("retrieve api key for update failed", zap.String("id", id.String()), zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("get api key: %w", err)
+	}
+
+	apiKey.Name = req.Name
+	apiKey.Scopes = req.Scopes
+	if err := s.repository.Update(ctx, apiKey); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return model.APIKeyResponse{}, err
+		}
+		s.logger.Error("update api key failed", zap.String("id", id.String()), zap.Error(err))
+		return model.APIKeyResponse{}, fmt.Errorf("update api key: %w", err)
+	}
+	s.logger.Info("api key updated", zap.String("id", id.String()))
+	return apiKey.ToResponse(), nil
+}
+
+func (s *apiKeyService) DeleteAPIKey(ctx context.Context, id uuid.UUID) error {
+	claims, ok := keyManager(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+
+	if err := s.repository.Delete(ctx, claims.Subject, id); err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return err
+		}
+		s.logger.Error("delete api key failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete api key: %w", err)
+	}
+	s.logger.Info("api key deleted", zap.String("id", id.String()))
+	return nil
+}
+
+// Authenticate resolves a raw "tdl_<prefix>.<secret>" key into the claims of
+// its owner, restricted to the key's scopes that the owner's current role
+// still grants. Keys of owners without a local account are rejected, since
+// their role cannot be checked. Keys created from an MFA session satisfy
+// workspaces that require MFA.
+func (s *apiKeyService) Authenticate(ctx context.Context, rawKey string) (*auth.Claims, error) {
+	rest, ok := strings.CutPrefix(rawKey, apiKeyPrefix)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+	prefix, secret, ok := strings.Cut(rest, ".")
+	if !ok || prefix == "" || secret == "" {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repository.FindByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, repository.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("authenticate api key: %w", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey.SecretHash), []byte(token.Hash(secret))) != 1 {
+		return nil, ErrInvalidAPIKey
+	}
+
+	now := s.clock.Now()
+	if apiKey.Expired(now) {
+		return nil, ErrInvalidAPIKey
+	}
+	scopes, err := s.grantedScopes(ctx, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyTouchEvery {
+		if err := s.repository.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+			s.logger.Warn("record api key use failed", zap.String("id", apiKey.ID.String()), zap.Error(err))
+		}
+	}
+
+	claims := &auth.Claims{
+		Scopes:      scopes,
+		WorkspaceID: apiKey.WorkspaceID,
+		AMR:         []string{auth.AMRAPIKey},
+	}
+	claims.Subject = apiKey.UserID
+	if apiKey.MFA {
+		claims.AMR = append(claims.AMR, auth.AMRMultiFactor)
+	}
+	if apiKey.ExpiresAt != nil {
+		claims.ExpiresAt = jwt.NewNumericDate(*apiKey.ExpiresAt)
+	}
+	return claims, nil
+}
+
+// grantedScopes returns the key's scopes that its owner's current role still
+// grants.
+func (s *apiKeyService) grantedScopes(ctx context.Context, apiKey *model.APIKey) ([]string, error) {
+	userID, err := uuid.Parse(apiKey.UserID)
+	if err != nil {
+		return nil, ErrInvalidAPIKey
+	}
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("authenticate api key: %w", err)
+	}
+	owner := &auth.Claims{Roles: []string{user.Role}}
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		if owner.HasPermission(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// keyManager returns the caller's claims unless they were resolved from an
+// API key, which may not manage keys.
+func keyManager(ctx context.Context) (*auth.Claims, bool) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.APIKeyAuthenticated() {
+		return nil, false
+	}
+	return claims, true
+}
+
+// checkScopesGranted ensures a key never carries more than its creator holds.
+func checkScopesGranted(claims *auth.Claims, scopes []string) error {
+	for _, scope := range scopes {
+		if !claims.HasPermission(scope) {
+			return fmt.Errorf("%w: %s", ErrScopeNotGranted, scope)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type apiKeyFixture struct {
+	apiKeys *mocks.APIKeyRepositoryMock
+	users   *mocks.UserRepositoryMock
+	clock   *mocks.FakeClock
+	svc     service.APIKeyService
+}
+
+func newAPIKeyFixture(t *testing.T) apiKeyFixture {
+	t.Helper()
+
+	f := apiKeyFixture{
+		apiKeys: new(mocks.APIKeyRepositoryMock),
+		users:   new(mocks.UserRepositoryMock),
+		clock:   mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewAPIKeyService(f.apiKeys, f.users, f.clock, zaptest.NewLogger(t))
+	return f
+}
+
+func TestAPIKeyService_CreateAPIKey_Success(t *testing.T) {
+	f := newAPIKeyFixture(t)
+
+	var stored *model.APIKey
+	f.apiKeys.On("Create", mock.Anything, mock.MatchedBy(func(apiKey *model.APIKey) bool {
+		return apiKey.UserID == "user-1" && apiKey.Prefix != "" && apiKey.SecretHash != ""
+	})).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*model.APIKey)
+	})
+
+	res, err := f.svc.CreateAPIKey(claimsContext("user-1", auth.RoleMember), model.CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{auth.PermissionTodoListsRead},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(res.Key, "tdl_"+stored.Prefix+"."))
+	require.NotContains(t, res.Key, stored.SecretHash)
+	f.apiKeys.AssertExpectations(t)
+}
+
+func TestAPIKeyService_CreateAPIKey_ScopeNotGranted(t *testing.T) {
+	f := newAPIKeyFixture(t)
+
+	_, err := f.svc.CreateAPIKey(claimsContext("user-1", auth.RoleViewer), model.CreateAPIKeyRequest{
+		Name:   "ci",
+		Scopes: []string{auth.PermissionTodoListsWrite},
+	})
+	require.ErrorIs(t, err, service.ErrScopeNotGranted)
+	f.apiKeys.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_ManagingKeysRequiresAToken(t *testing.T) {
+	f := newAPIKeyFixture(t)
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{
+		Scopes: []string{auth.PermissionTodoListsRead},
+		AMR:    []string{auth.AMRAPIKey},
+	})
+
+	_, err := f.svc.CreateAPIKey(ctx, model.CreateAPIKeyRequest{Name: "ci", Scopes: []string{auth.PermissionTodoListsRead}})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	_, err = f.svc.UpdateAPIKey(ctx, uuid.New(), model.UpdateAPIKeyRequest{Name: "ci"})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	require.ErrorIs(t, f.svc.DeleteAPIKey(ctx, uuid.New()), service.ErrForbidden)
+	f.apiKeys.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	expired := now.Add(-time.Second)
+	recent := now.Add(-time.Second)
+	ownerID := uuid.New()
+	owner := ownerID.String()
+
+	tests := []struct {
+		name       string
+		rawKey     string
+		apiKey     *model.APIKey
+		role       string
+		wantScopes []string
+		wantErr    error
+		wantTouch  bool
+	}{
+		{
+			name:       "valid key",
+			rawKey:     "tdl_abc123.secret",
+			apiKey:     &model.APIKey{ID: uuid.New(), UserID: owner, Prefix: "abc123", SecretHash: token.Hash("secret"), Scopes: []string{auth.PermissionTodoListsRead}},
+			role:       auth.RoleMember,
+			wantScopes: []string{auth.PermissionTodoListsRead},
+			wantTouch:  true,
+		},
+		{
+			name:       "recently used key is not touched again",
+			rawKey:     "tdl_abc123.secret",
+			apiKey:     &model.APIKey{ID: uuid.New(), UserID: owner, Prefix: "abc123", SecretHash: token.Hash("secret"), LastUsedAt: &recent},
+			role:       auth.RoleMember,
+			wantScopes: []string{},
+		},
+		{
+			name:   "scopes the owner lost are dropped",
+			rawKey: "tdl_abc123.secret",
+			apiKey: &model.APIKey{ID: uuid.New(), UserID: owner, Prefix: "abc123", SecretHash: token.Hash("secret"), LastUsedAt: &recent,
+				Scopes: []string{auth.PermissionTodoListsRead, auth.PermissionAuditRead}},
+			role:       auth.RoleViewer,
+			wantScopes: []string{auth.PermissionTodoListsRead},
+		},
+		{
+			name:    "owner without an account",
+			rawKey:  "tdl_abc123.secret",
+			apiKey:  &model.APIKey{ID: uuid.New(), UserID: "external-1", Prefix: "abc123", SecretHash: token.Hash("secret")},
+			wantErr: service.ErrInvalidAPIKey,
+		},
+		{
+			name:    "wrong secret",
+			rawKey:  "tdl_abc123.guess",
+			apiKey:  &model.APIKey{ID: uuid.New(), Prefix: "abc123", SecretHash: token.Hash("secret")},
+			wantErr: service.ErrInvalidAPIKey,
+		},
+		{
+			name:    "expired key",
+			rawKey:  "tdl_abc123.secret",
+			apiKey:  &model.APIKey{ID: uuid.New(), Prefix: "abc123", SecretHash: token.Hash("secret"), ExpiresAt: &expired},
+			wantErr: service.ErrInvalidAPIKey,
+		},
+		{
+			name:    "malformed key",
+			rawKey:  "abc123.secret",
+			wantErr: service.ErrInvalidAPIKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newAPIKeyFixture(t)
+			if tt.apiKey != nil {
+				f.apiKeys.On("FindByPrefix", mock.Anything, "abc123").Return(tt.apiKey, nil)
+			}
+			if tt.role != "" {
+				f.users.On("FindByID", mock.Anything, ownerID).Return(&model.User{ID: ownerID, Role: tt.role}, nil)
+			} else {
+				f.users.On("FindByID", mock.Anything, mock.Anything).Return(nil, repository.ErrUserNotFound)
+			}
+			if tt.wantTouch {
+				f.apiKeys.On("TouchLastUsed", mock.Anything, tt.apiKey.ID, now).Return(nil)
+			}
+
+			claims, err := f.svc.Authenticate(context.Background(), tt.rawKey)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.apiKey.UserID, claims.Subject)
+			require.Equal(t, tt.wantScopes, claims.Scopes)
+			require.Empty(t, claims.Roles)
+			require.True(t, claims.APIKeyAuthenticated())
+			f.apiKeys.AssertExpectations(t)
+			if !tt.wantTouch {
+				f.apiKeys.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAttachmentTooLarge indicates that an upload exceeds the size limit.
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+	// ErrAttachmentTypeNotAllowed indicates that an upload's sniffed MIME type
+	// is not on the allow list.
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type not allowed")
+	// ErrDownloadLinkInvalid indicates a download URL with a bad signature or
+	// one that has expired.
+	ErrDownloadLinkInvalid = errors.New("download link is invalid or expired")
+)
+
+// AttachmentLimits bound uploads. MaxSize is in bytes. AllowedTypes are MIME
+// types matched against the type sniffed from the content.
+type AttachmentLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+// AttachmentService defines business operations for files attached to todo
+// lists and items. Anyone who can read a list can read its attachments and
+// download them through signed URLs; uploading and deleting follow the list's
+// ownership rules.
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, upload model.AttachmentUpload) (model.AttachmentResponse, error)
+	ListAttachments(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID) ([]model.AttachmentResponse, error)
+	GetAttachment(ctx context.Context, todoListID, id uuid.UUID) (model.AttachmentResponse, error)
+	DeleteAttachment(ctx context.Context, todoListID, id uuid.UUID) error
+	OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (model.Attachment, io.ReadCloser, error)
+}
+
+type attachmentService struct {
+	attachments repository.AttachmentRepository
+	todoLists   repository.TodoListRepository
+	items       repository.TodoItemRepository
+	store       blob.Store
+	limits      AttachmentLimits
+	urlSecret   []byte
+	urlTTL      time.Duration
+	clock       clock.Clock
+	logger      *zap.Logger
+}
+
+// NewAttachmentService constructs an AttachmentService implementation that
+// keeps attachment contents in store. Download URLs are signed with
+// urlSecret and stay valid for urlTTL.
+func NewAttachmentService(
+	attachments repository.AttachmentRepository,
+	todoLists repository.TodoListRepository,
+	items repository.TodoItemRepository,
+	store blob.Store,
+	limits AttachmentLimits,
+	urlSecret string,
+	urlTTL time.Duration,
+	clock clock.Clock,
+	logger *zap.Logger,
+) AttachmentService {
+	return &attachmentService{
+		attachments: attachments,
+		todoLists:   todoLists,
+		items:       items,
+		store:       store,
+		limits:      limits,
+		urlSecret:   []byte(urlSecret),
+		urlTTL:      urlTTL,
+		clock:       clock,
+		logger:      logger,
+	}
+}
+
+// UploadAttachment stores the upload and attaches it to the list or, when
+// todoItemID is set, to that item. The contents are written before the
+// record so that a stored attachment never points at a missing blob.
+func (s *attachmentService) UploadAttachment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, upload model.AttachmentUpload) (model.AttachmentResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.AttachmentResponse{}, ErrForbidden
+	}
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return model.AttachmentResponse{}, err
+	}
+	if todoItemID != nil {
+		if err := s.findItem(ctx, todoListID, *todoItemID); err != nil {
+			return model.AttachmentResponse{}, err
+		}
+	}
+	workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+	if !ok {
+		return model.AttachmentResponse{}, tenant.ErrWorkspaceRequired
+	}
+	if upload.Size > s.limits.MaxSize {
+		return model.AttachmentResponse{}, ErrAttachmentTooLarge
+	}
+
+	contentType, err := s.sniff(upload.Content)
+	if err != nil {
+		return model.AttachmentResponse{}, err
+	}
+	hash := sha256.New()
+	size, err := io.Copy(hash, io.LimitReader(upload.Content, s.limits.MaxSize+1))
+	if err != nil {
+		return model.AttachmentResponse{}, fmt.Errorf("read upload: %w", err)
+	}
+	if size > s.limits.MaxSize {
+		return model.AttachmentResponse{}, ErrAttachmentTooLarge
+	}
+	if _, err := upload.Content.Seek(0, io.SeekStart); err != nil {
+		return model.AttachmentResponse{}, fmt.Errorf("read upload: %w", err)
+	}
+
+	attachment := &model.Attachment{
+		ID:          uuid.New(),
+		TodoListID:  todoListID,
+		TodoItemID:  todoItemID,
+		UploaderID:  claims.Subject,
+		Filename:    cleanFilename(upload.Filename),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+	}
+	attachment.StorageKey = workspaceID.String() + "/" + attachment.ID.String()
+
+	if err := s.store.Put(ctx, attachment.StorageKey, upload.Content, size, contentType); err != nil {
+		s.logger.Error("store attachment failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return model.AttachmentResponse{}, fmt.Errorf("store attachment: %w", err)
+	}
+	if err := s.attachments.Create(ctx, attachment); err != nil {
+		s.logger.Error("create attachment failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		s.deleteBlob(ctx, attachment.StorageKey)
+		return model.AttachmentResponse{}, fmt.Errorf("create attachment: %w", err)
+	}
+	s.logger.Info("attachment uploaded",
+		zap.String("id", attachment.ID.String()),
+		zap.String("content_type", contentType),
+		zap.Int64("size", size),
+	)
+	return s.signedResponse(*attachment), nil
+}
+
+// ListAttachments returns the attachments of a list, including those of its
+// items, or only those of one item when todoItemID is set.
+func (s *attachmentService) ListAttachments(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID) ([]model.AttachmentResponse, error) {
+	if _, err := s.findList(ctx, todoListID); err != nil {
+		return nil, err
+	}
+	var attachments []model.Attachment
+	var err error
+	if todoItemID != nil {
+		if err := s.findItem(ctx, todoListID, *todoItemID); err != nil {
+			return nil, err
+		}
+		attachments, err = s.attachments.FindByTodoItemID(ctx, *todoItemID)
+	} else {
+		attachments, err = s.attachments.FindByTodoListID(ctx, todoListID)
+	}
+	if err != nil {
+		s.logger.Error("list attachments failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	responses := make([]model.AttachmentResponse, len(attachments))
+	for i, attachment := range attachments {
+		responses[i] = s.signedResponse(attachment)
+	}
+	return responses, nil
+}
+
+func (s *attachmentService) GetAttachment(ctx context.Context, todoListID, id uuid.UUID) (model.AttachmentResponse, error) {
+	if _, err := s.findList(ctx, todoListID); err != nil {
+		return model.AttachmentResponse{}, err
+	}
+	attachment, err := s.findAttachment(ctx, todoListID, id)
+	if err != nil {
+		return model.AttachmentResponse{}, err
+	}
+	return s.signedResponse(*attachment), nil
+}
+
+// DeleteAttachment removes the record before the contents, so a failure in
+// between leaves an unreferenced blob rather than a broken attachment.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, todoListID, id uuid.UUID) error {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return err
+	}
+	attachment, err := s.findAttachment(ctx, todoListID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.attachments.Delete(ctx, todoListID, id); err != nil {
+		if errors.Is(err, repository.ErrAttachmentNotFound) {
+			return err
+		}
+		s.logger.Error("delete attachment failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+	s.deleteBlob(ctx, attachment.StorageKey)
+	s.logger.Info("attachment deleted", zap.String("id", id.String()))
+	return nil
+}
+
+// OpenDownload checks a signed download URL and opens the attachment's
+// contents. The caller must close the returned reader.
+func (s *attachmentService) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (model.Attachment, io.ReadCloser, error) {
+	if expires < s.clock.Now().Unix() || !hmac.Equal([]byte(signature), []byte(s.sign(id, expires))) {
+		return model.Attachment{}, nil, ErrDownloadLinkInvalid
+	}
+	attachment, err := s.attachments.FindForDownload(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttachmentNotFound) {
+			return model.Attachment{}, nil, err
+		}
+		s.logger.Error("find attachment for download failed", zap.String("id", id.String()), zap.Error(err))
+		return model.Attachment{}, nil, fmt.Errorf("get attachment: %w", err)
+	}
+	content, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		s.logger.Error("open attachment failed", zap.String("id", id.String()), zap.Error(err))
+		return model.Attachment{}, nil, fmt.Errorf("open attachment: %w", err)
+	}
+	return *attachment, content, nil
+}
+
+// sniff detects the MIME type of content from its leading bytes and checks
+// it against the allow list, then rewinds content.
+func (s *attachmentService) sniff(content io.ReadSeeker) (string, error) {
+	detected, err := mimetype.DetectReader(content)
+	if err != nil {
+		return "", fmt.Errorf("read upload: %w", err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("read upload: %w", err)
+	}
+	for _, allowed := range s.limits.AllowedTypes {
+		if detected.Is(allowed) {
+			return detected.String(), nil
+		}
+	}
+	return "", ErrAttachmentTypeNotAllowed
+}
+
+// signedResponse returns the attachment's response with a download URL that
+// expires after the configured TTL.
+func (s *attachmentService) signedResponse(attachment model.Attachment) model.AttachmentResponse {
+	res := attachment.ToResponse()
+	expiresAt := s.clock.Now().Add(s.urlTTL).Truncate(time.Second)
+	expires := expiresAt.Unix()
+	res.DownloadURL = "/public/attachments/" + attachment.ID.String() + "?" + url.Values{
+		"expires":   {strconv.FormatInt(expires, 10)},
+		"signature": {s.sign(attachment.ID, expires)},
+	}.Encode()
+	res.DownloadExpiresAt = &expiresAt
+	return res
+}
+
+// sign returns the hex HMAC-SHA256 of the attachment ID and expiry time.
+func (s *attachmentService) sign(id uuid.UUID, expires int64) string {
+	mac := hmac.New(sha256.New, s.urlSecret)
+	mac.Write([]byte(id.String() + ":" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// deleteBlob removes stored contents on a best-effort basis; a leftover blob
+// is unreachable once its record is gone.
+func (s *attachmentService) deleteBlob(ctx context.Context, key string) {
+	deleteContents(ctx, s.store, []model.Attachment{{StorageKey: key}}, s.logger)
+}
+
+// deleteContents removes the contents of attachments whose records are gone.
+// A failure only leaves an unreferenced blob, so it is logged.
+func deleteContents(ctx context.Context, store blob.Store, attachments []model.Attachment, logger *zap.Logger) {
+	for _, attachment := range attachments {
+		if err := store.Delete(ctx, attachment.StorageKey); err != nil {
+			logger.Warn("delete attachment contents failed", zap.String("key", attachment.StorageKey), zap.Error(err))
+		}
+	}
+}
+
+func (s *attachmentService) authorizeList(ctx context.Context, todoListID uuid.UUID) error {
+	todoList, err := s.findList(ctx, todoListID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("attachment change denied", zap.String("todo_list_id", todoListID.String()))
+		return err
+	}
+	return nil
+}
+
+func (s *attachmentService) findList(ctx context.Context, todoListID uuid.UUID) (*model.TodoList, error) {
+	todoList, err := s.todoLists.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get todo list failed", zap.String("id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo list: %w", err)
+	}
+	return todoList, nil
+}
+
+func (s *attachmentService) findItem(ctx context.Context, todoListID, id uuid.UUID) error {
+	if _, err := s.items.FindByID(ctx, todoListID, id); err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return err
+		}
+		s.logger.Error("get todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("get todo item: %w", err)
+	}
+	return nil
+}
+
+func (s *attachmentService) findAttachment(ctx context.Context, todoListID, id uuid.UUID) (*model.Attachment, error) {
+	attachment, err := s.attachments.FindByID(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrAttachmentNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get attachment failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// cleanFilename keeps the base name of a client-supplied filename, without
+// control characters, and at most 255 bytes long.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	for len(name) > 255 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	return name
+}
@@ -0,0 +1,225 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// pngHeader is enough of a PNG for content sniffing.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01\x08\x06\x00\x00\x00")
+
+type attachmentFixture struct {
+	attachments *mocks.AttachmentRepositoryMock
+	todoLists   *mocks.TodoListRepositoryMock
+	items       *mocks.TodoItemRepositoryMock
+	dir         string
+	clock       *mocks.FakeClock
+	svc         service.AttachmentService
+}
+
+func newAttachmentFixture(t *testing.T) *attachmentFixture {
+	f := &attachmentFixture{
+		attachments: new(mocks.AttachmentRepositoryMock),
+		todoLists:   new(mocks.TodoListRepositoryMock),
+		items:       new(mocks.TodoItemRepositoryMock),
+		dir:         t.TempDir(),
+		clock:       mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewAttachmentService(
+		f.attachments,
+		f.todoLists,
+		f.items,
+		&blob.Local{Dir: f.dir},
+		service.AttachmentLimits{MaxSize: 1024, AllowedTypes: []string{"image/png", "application/pdf"}},
+		"url-secret",
+		15*time.Minute,
+		f.clock,
+		zaptest.NewLogger(t),
+	)
+	return f
+}
+
+func attachmentContext(workspaceID uuid.UUID) context.Context {
+	return tenant.WithWorkspaceID(claimsContext("owner-1"), workspaceID)
+}
+
+// storedFiles returns the blobs written under the fixture's directory.
+func (f *attachmentFixture) storedFiles(t *testing.T) []string {
+	var files []string
+	err := filepath.WalkDir(f.dir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	return files
+}
+
+// downloadParams extracts the attachment ID, expiry and signature from a
+// signed download URL.
+func downloadParams(t *testing.T, downloadURL string) (uuid.UUID, int64, string) {
+	u, err := url.Parse(downloadURL)
+	require.NoError(t, err)
+	id, err := uuid.Parse(strings.TrimPrefix(u.Path, "/public/attachments/"))
+	require.NoError(t, err)
+	expires, err := strconv.ParseInt(u.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+	return id, expires, u.Query().Get("signature")
+}
+
+func TestAttachmentService_UploadAttachment_StoresContentAndChecksum(t *testing.T) {
+	f := newAttachmentFixture(t)
+	workspaceID, todoListID := uuid.New(), uuid.New()
+	content := append(append([]byte{}, pngHeader...), "pixels"...)
+	sum := sha256.Sum256(content)
+
+	var stored *model.Attachment
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "owner-1"}, nil)
+	f.attachments.On("Create", mock.Anything, mock.AnythingOfType("*model.Attachment")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*model.Attachment)
+	})
+
+	res, err := f.svc.UploadAttachment(attachmentContext(workspaceID), todoListID, nil, model.AttachmentUpload{
+		Filename: `C:\Users\me\screen shot.png`,
+		Size:     int64(len(content)),
+		Content:  bytes.NewReader(content),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "screen shot.png", res.Filename)
+	require.Equal(t, "image/png", res.ContentType)
+	require.Equal(t, int64(len(content)), res.Size)
+	require.Equal(t, hex.EncodeToString(sum[:]), res.Checksum)
+	require.Equal(t, "owner-1", res.UploaderID)
+	require.Equal(t, workspaceID.String()+"/"+stored.ID.String(), stored.StorageKey)
+	require.Equal(t, f.clock.Now().Add(15*time.Minute), *res.DownloadExpiresAt)
+
+	written, err := os.ReadFile(filepath.Join(f.dir, workspaceID.String(), stored.ID.String()))
+	require.NoError(t, err)
+	require.Equal(t, content, written)
+	f.attachments.AssertExpectations(t)
+}
+
+func TestAttachmentService_UploadAttachment_TypeNotAllowed(t *testing.T) {
+	f := newAttachmentFixture(t)
+	todoListID := uuid.New()
+	content := []byte("<!DOCTYPE html><html><script>alert(1)</script></html>")
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "owner-1"}, nil)
+
+	// The client-supplied name does not matter; the type is sniffed.
+	_, err := f.svc.UploadAttachment(attachmentContext(uuid.New()), todoListID, nil, model.AttachmentUpload{
+		Filename: "innocent.png",
+		Size:     int64(len(content)),
+		Content:  bytes.NewReader(content),
+	})
+	require.ErrorIs(t, err, service.ErrAttachmentTypeNotAllowed)
+	require.Empty(t, f.storedFiles(t))
+	f.attachments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAttachmentService_UploadAttachment_TooLarge(t *testing.T) {
+	f := newAttachmentFixture(t)
+	todoListID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "owner-1"}, nil)
+
+	// A declared size that understates the content is not trusted.
+	content := append(append([]byte{}, pngHeader...), make([]byte, 2048)...)
+	_, err := f.svc.UploadAttachment(attachmentContext(uuid.New()), todoListID, nil, model.AttachmentUpload{
+		Filename: "big.png",
+		Size:     100,
+		Content:  bytes.NewReader(content),
+	})
+	require.ErrorIs(t, err, service.ErrAttachmentTooLarge)
+	require.Empty(t, f.storedFiles(t))
+}
+
+func TestAttachmentService_UploadAttachment_NotOwner(t *testing.T) {
+	f := newAttachmentFixture(t)
+	todoListID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "someone-else"}, nil)
+
+	_, err := f.svc.UploadAttachment(attachmentContext(uuid.New()), todoListID, nil, model.AttachmentUpload{
+		Filename: "shot.png",
+		Size:     int64(len(pngHeader)),
+		Content:  bytes.NewReader(pngHeader),
+	})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	require.Empty(t, f.storedFiles(t))
+}
+
+func TestAttachmentService_UploadAttachment_RemovesContentWhenCreateFails(t *testing.T) {
+	f := newAttachmentFixture(t)
+	todoListID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "owner-1"}, nil)
+	f.attachments.On("Create", mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+	_, err := f.svc.UploadAttachment(attachmentContext(uuid.New()), todoListID, nil, model.AttachmentUpload{
+		Filename: "shot.png",
+		Size:     int64(len(pngHeader)),
+		Content:  bytes.NewReader(pngHeader),
+	})
+	require.Error(t, err)
+	require.Empty(t, f.storedFiles(t))
+}
+
+func TestAttachmentService_OpenDownload_SignedURL(t *testing.T) {
+	f := newAttachmentFixture(t)
+	workspaceID, todoListID := uuid.New(), uuid.New()
+	attachment := model.Attachment{
+		ID:          uuid.New(),
+		WorkspaceID: workspaceID,
+		TodoListID:  todoListID,
+		Filename:    "shot.png",
+		ContentType: "image/png",
+		StorageKey:  workspaceID.String() + "/blob",
+	}
+	require.NoError(t, (&blob.Local{Dir: f.dir}).Put(context.Background(), attachment.StorageKey, bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png"))
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+	f.attachments.On("FindByID", mock.Anything, todoListID, attachment.ID).Return(&attachment, nil)
+	f.attachments.On("FindForDownload", mock.Anything, attachment.ID).Return(&attachment, nil)
+
+	res, err := f.svc.GetAttachment(attachmentContext(workspaceID), todoListID, attachment.ID)
+	require.NoError(t, err)
+	id, expires, signature := downloadParams(t, res.DownloadURL)
+	require.Equal(t, attachment.ID, id)
+
+	// Downloads carry no credentials or workspace.
+	got, content, err := f.svc.OpenDownload(context.Background(), id, expires, signature)
+	require.NoError(t, err)
+	defer content.Close()
+	body, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, pngHeader, body)
+	require.Equal(t, "shot.png", got.Filename)
+
+	_, _, err = f.svc.OpenDownload(context.Background(), id, expires+3600, signature)
+	require.ErrorIs(t, err, service.ErrDownloadLinkInvalid)
+	_, _, err = f.svc.OpenDownload(context.Background(), uuid.New(), expires, signature)
+	require.ErrorIs(t, err, service.ErrDownloadLinkInvalid)
+
+	f.clock.Advance(16 * time.Minute)
+	_, _, err = f.svc.OpenDownload(context.Background(), id, expires, signature)
+	require.ErrorIs(t, err, service.ErrDownloadLinkInvalid)
+	f.attachments.AssertNumberOfCalls(t, "FindForDownload", 1)
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/requestinfo"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// AuditService defines read access to the audit log.
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, filter model.AuditLogFilter) (model.AuditLogPage, error)
+}
+
+type auditService struct {
+	repository repository.AuditLogRepository
+	logger     *zap.Logger
+}
+
+// NewAuditService constructs an AuditService implementation.
+func NewAuditService(repository repository.AuditLogRepository, logger *zap.Logger) AuditService {
+	return &auditService{
+		repository: repository,
+		logger:     logger,
+	}
+}
+
+func (s *auditService) ListAuditLogs(ctx context.Context, filter model.AuditLogFilter) (model.AuditLogPage, error) {
+	auditLogs, total, err := s.repository.List(ctx, filter)
+	if err != nil {
+		s.logger.Error("list audit logs failed", zap.Error(err))
+		return model.AuditLogPage{}, fmt.Errorf("list audit logs: %w", err)
+	}
+	items := make([]model.AuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		items[i] = auditLog.ToResponse()
+	}
+	return model.AuditLogPage{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// newAuditLog builds an entry for a mutation of entityID by the caller in ctx.
+// before and after are snapshots of the entity (nil when it did not or no
+// longer exists); only fields whose JSON values differ are recorded.
+func newAuditLog(ctx context.Context, action, entityType string, entityID uuid.UUID, before, after interface{}) (*model.AuditLog, error) {
+	var actorID string
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		actorID = claims.Subject
+	}
+	changes, err := auditDiff(before, after)
+	if err != nil {
+		return nil, err
+	}
+	info := requestinfo.FromContext(ctx)
+	return &model.AuditLog{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		RequestID:  info.RequestID,
+		IP:         info.IP,
+	}, nil
+}
+
+func auditDiff(before, after interface{}) (map[string]model.AuditChange, error) {
+	beforeFields, err := auditFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := auditFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]model.AuditChange)
+	for name, value := range beforeFields {
+		if afterValue, ok := afterFields[name]; !ok || !reflect.DeepEqual(value, afterValue) {
+			changes[name] = model.AuditChange{Before: value, After: afterFields[name]}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = model.AuditChange{After: value}
+		}
+	}
+	return changes, nil
+}
+
+// auditFields flattens a snapshot into its top-level JSON fields, leaving out
+// the id that the entry already records.
+func auditFields(snapshot interface{}) (map[string]interface{}, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit snapshot: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshal audit snapshot: %w", err)
+	}
+	delete(fields, "id")
+	return fields, nil
+}
@@ -0,0 +1,31 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestAuditService_ListAuditLogs(t *testing.T) {
+	repo := new(mocks.AuditLogRepositoryMock)
+	svc := service.NewAuditService(repo, zaptest.NewLogger(t))
+
+	filter := model.AuditLogFilter{Action: model.AuditActionDelete, Limit: 10, Offset: 20}
+	entry := model.AuditLog{ID: uuid.New(), ActorID: "user-1", Action: model.AuditActionDelete}
+	repo.On("List", context.Background(), filter).Return([]model.AuditLog{entry}, int64(21), nil)
+
+	page, err := svc.ListAuditLogs(context.Background(), filter)
+	require.NoError(t, err)
+	require.EqualValues(t, 21, page.Total)
+	require.Equal(t, 10, page.Limit)
+	require.Equal(t, 20, page.Offset)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, entry.ID, page.Items[0].ID)
+	repo.AssertExpectations(t)
+}
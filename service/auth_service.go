@@ -0,0 +1,551 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/package/totp"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	refreshTokenBytes = 32
+
+	recoveryCodeCount = 10
+	recoveryCodeBytes = 5
+	maxMFAAttempts    = 5
+	mfaLockout        = 15 * time.Minute
+)
+
+var (
+	// ErrInvalidCredentials indicates that the email or password is wrong.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrInvalidRefreshToken indicates that the refresh token is unknown, expired or revoked.
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrInvalidMFAChallenge indicates that the MFA challenge token is invalid or expired.
+	ErrInvalidMFAChallenge = errors.New("invalid mfa challenge")
+	// ErrInvalidMFACode indicates that the TOTP or recovery code is wrong or was already used.
+	ErrInvalidMFACode = errors.New("invalid mfa code")
+	// ErrMFALocked indicates that too many wrong codes were entered recently.
+	ErrMFALocked = errors.New("mfa temporarily locked")
+	// ErrMFAAlreadyEnabled indicates that the account already has MFA enabled.
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	// ErrMFANotEnrolled indicates that MFA confirmation was attempted before enrollment.
+	ErrMFANotEnrolled = errors.New("mfa enrollment not started")
+	// ErrLocalAccountRequired indicates that the caller is not a locally registered user.
+	ErrLocalAccountRequired = errors.New("local account required")
+)
+
+// AuthService defines account, session and token operations.
+type AuthService interface {
+	Register(ctx context.Context, req model.RegisterRequest) (model.UserResponse, error)
+	Login(ctx context.Context, req model.LoginRequest) (model.TokenResponse, error)
+	Refresh(ctx context.Context, req model.RefreshRequest) (model.TokenResponse, error)
+	Logout(ctx context.Context, req model.LogoutRequest) error
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+	VerifyMFA(ctx context.Context, req model.VerifyMFARequest) (model.TokenResponse, error)
+	EnrollMFA(ctx context.Context) (model.MFAEnrollmentResponse, error)
+	ConfirmMFA(ctx context.Context, req model.ConfirmMFARequest) (model.MFARecoveryCodesResponse, error)
+	SetUsername(ctx context.Context, req model.SetUsernameRequest) (model.UserResponse, error)
+	SetArchivePolicy(ctx context.Context, req model.SetArchivePolicyRequest) (model.UserResponse, error)
+}
+
+type authService struct {
+	users         repository.UserRepository
+	workspaces    repository.WorkspaceRepository
+	refreshTokens repository.RefreshTokenRepository
+	revokedTokens repository.RevokedTokenRepository
+	recoveryCodes repository.MFARecoveryCodeRepository
+	accounts      AccountService
+	transactor    repository.Transactor
+	signer        *auth.Signer
+	refreshTTL    time.Duration
+	clock         clock.Clock
+	logger        *zap.Logger
+}
+
+// NewAuthService constructs an AuthService implementation.
+func NewAuthService(
+	users repository.UserRepository,
+	workspaces repository.WorkspaceRepository,
+	refreshTokens repository.RefreshTokenRepository,
+	revokedTokens repository.RevokedTokenRepository,
+	recoveryCodes repository.MFARecoveryCodeRepository,
+	accounts AccountService,
+	transactor repository.Transactor,
+	signer *auth.Signer,
+	refreshTTL time.Duration,
+	clock clock.Clock,
+	logger *zap.Logger,
+) AuthService {
+	return &authService{
+		users:         users,
+		workspaces:    workspaces,
+		refreshTokens: refreshTokens,
+		revokedTokens: revokedTokens,
+		recoveryCodes: recoveryCodes,
+		accounts:      accounts,
+		transactor:    transactor,
+		signer:        signer,
+		refreshTTL:    refreshTTL,
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+// Register creates an unverified account in a personal workspace of its own
+// and emails a verification link. A failed email does not undo the
+// registration; the user can ask for another link.
+func (s *authService) Register(ctx context.Context, req model.RegisterRequest) (model.UserResponse, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		s.logger.Error("hash password failed", zap.Error(err))
+		return model.UserResponse{}, fmt.Errorf("register: %w", err)
+	}
+
+	user := &model.User{
+		Email:        strings.ToLower(req.Email),
+		PasswordHash: string(hash),
+		Role:         auth.RoleMember,
+	}
+	if req.Username != "" {
+		user.Username = &req.Username
+	}
+	workspace := &model.Workspace{Name: user.Email}
+	if user.Username != nil {
+		workspace.Name = *user.Username
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.workspaces.Create(ctx, workspace); err != nil {
+			return err
+		}
+		user.WorkspaceID = &workspace.ID
+		if err := s.users.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.workspaces.AddMember(ctx, workspace.ID, user.ID.String())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrUserEmailTaken) || errors.Is(err, repository.ErrUsernameTaken) {
+			return model.UserResponse{}, err
+		}
+		s.logger.Error("create user failed", zap.Error(err))
+		return model.UserResponse{}, fmt.Errorf("register: %w", err)
+	}
+	s.logger.Info("user registered", zap.String("id", user.ID.String()))
+	if err := s.accounts.SendVerification(ctx, user); err != nil {
+		s.logger.Warn("verification email not sent", zap.String("user_id", user.ID.String()), zap.Error(err))
+	}
+	return user.ToResponse(), nil
+}
+
+func (s *authService) Login(ctx context.Context, req model.LoginRequest) (model.TokenResponse, error) {
+	user, err := s.users.FindByEmail(ctx, strings.ToLower(req.Email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return model.TokenResponse{}, ErrInvalidCredentials
+		}
+		s.logger.Error("find user for login failed", zap.Error(err))
+		return model.TokenResponse{}, fmt.Errorf("login: %w", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
+		s.logger.Info("login rejected", zap.String("user_id", user.ID.String()))
+		return model.TokenResponse{}, ErrInvalidCredentials
+	}
+	if user.EmailVerifiedAt == nil {
+		return model.TokenResponse{}, ErrEmailNotVerified
+	}
+
+	if user.MFAEnabled {
+		challenge, err := s.signer.SignMFAChallenge(user.ID.String(), s.clock.Now())
+		if err != nil {
+			s.logger.Error("sign mfa challenge failed", zap.Error(err))
+			return model.TokenResponse{}, fmt.Errorf("login: %w", err)
+		}
+		s.logger.Info("mfa challenge issued", zap.String("user_id", user.ID.String()))
+		return model.TokenResponse{MFARequired: true, MFAToken: challenge}, nil
+	}
+
+	tokens, err := s.issueTokens(ctx, user, uuid.New(), uuid.New(), false)
+	if err != nil {
+		return model.TokenResponse{}, fmt.Errorf("login: %w", err)
+	}
+	s.logger.Info("user logged in", zap.String("user_id", user.ID.String()))
+	return tokens, nil
+}
+
+// Refresh rotates a refresh token. Presenting a token that was already
+// rotated is treated as theft: the whole token family is revoked.
+func (s *authService) Refresh(ctx context.Context, req model.RefreshRequest) (model.TokenResponse, error) {
+	now := s.clock.Now()
+	current, err := s.refreshTokens.FindByTokenHash(ctx, token.Hash(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenNotFound) {
+			return model.TokenResponse{}, ErrInvalidRefreshToken
+		}
+		s.logger.Error("find refresh token failed", zap.Error(err))
+		return model.TokenResponse{}, fmt.Errorf("refresh: %w", err)
+	}
+	if current.RevokedAt != nil {
+		s.revokeFamily(ctx, current, now)
+		return model.TokenResponse{}, ErrInvalidRefreshToken
+	}
+	if !now.Before(current.ExpiresAt) {
+		return model.TokenResponse{}, ErrInvalidRefreshToken
+	}
+
+	user, err := s.users.FindByID(ctx, current.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return model.TokenResponse{}, ErrInvalidRefreshToken
+		}
+		s.logger.Error("find user for refresh failed", zap.Error(err))
+		return model.TokenResponse{}, fmt.Errorf("refresh: %w", err)
+	}
+
+	replacementID := uuid.New()
+	if err := s.refreshTokens.MarkUsed(ctx, current.ID, &replacementID, now); err != nil {
+		if errors.Is(err, repository.ErrRefreshTokenUsed) {
+			s.revokeFamily(ctx, current, now)
+			return model.TokenResponse{}, ErrInvalidRefreshToken
+		}
+		s.logger.Error("rotate refresh token failed", zap.Error(err))
+		return model.TokenResponse{}, fmt.Errorf("refresh: %w", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, user, current.FamilyID, replacementID, current.MFA)
+	if err != nil {
+		return model.TokenResponse{}, fmt.Errorf("refresh: %w", err)
+	}
+	s.logger.Info("refresh token rotated", zap.String("user_id", user.ID.String()))
+	return tokens, nil
+}
+
+// Logout revokes the presented refresh token's family and denylists the
+// access token that authenticated the request until it expires.
+func (s *authService) Logout(ctx context.Context, req model.LogoutRequest) error {
+	now := s.clock.Now()
+	if req.RefreshToken != "" {
+		current, err := s.refreshTokens.FindByTokenHash(ctx, token.Hash(req.RefreshToken))
+		switch {
+		case err == nil:
+			if err := s.refreshTokens.RevokeFamily(ctx, current.FamilyID, now); err != nil {
+				s.logger.Error("revoke refresh tokens on logout failed", zap.Error(err))
+				return fmt.Errorf("logout: %w", err)
+			}
+		case !errors.Is(err, repository.ErrRefreshTokenNotFound):
+			s.logger.Error("find refresh token for logout failed", zap.Error(err))
+			return fmt.Errorf("logout: %w", err)
+		}
+	}
+
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil
+	}
+	if err := s.revokedTokens.Create(ctx, &model.RevokedAccessToken{
+		JTI:       claims.ID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}); err != nil {
+		s.logger.Error("revoke access token failed", zap.Error(err))
+		return fmt.Errorf("logout: %w", err)
+	}
+	s.logger.Info("user logged out", zap.String("subject", claims.Subject))
+	return nil
+}
+
+func (s *authService) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	revoked, err := s.revokedTokens.Exists(ctx, jti)
+	if err != nil {
+		return false, fmt.Errorf("check access token: %w", err)
+	}
+	return revoked, nil
+}
+
+// VerifyMFA completes a login that was answered with an MFA challenge.
+// Wrong codes count towards a temporary lockout of the account's second factor.
+func (s *authService) VerifyMFA(ctx context.Context, req model.VerifyMFARequest) (model.TokenResponse, error) {
+	now := s.clock.Now()
+	subject, err := s.signer.VerifyMFAChallenge(req.MFAToken, now)
+	if err != nil {
+		return model.TokenResponse{}, ErrInvalidMFAChallenge
+	}
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return model.TokenResponse{}, ErrInvalidMFAChallenge
+	}
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return model.TokenResponse{}, ErrInvalidMFAChallenge
+		}
+		s.logger.Error("find user for mfa failed", zap.Error(err))
+		return model.TokenResponse{}, fmt.Errorf("verify mfa: %w", err)
+	}
+	if !user.MFAEnabled {
+		return model.TokenResponse{}, ErrInvalidMFAChallenge
+	}
+	if user.MFALockedUntil != nil && now.Before(*user.MFALockedUntil) {
+		return model.TokenResponse{}, ErrMFALocked
+	}
+
+	ok, err := s.checkSecondFactor(ctx, user, req.Code, now)
+	if err != nil {
+		return model.TokenResponse{}, fmt.Errorf("verify mfa: %w", err)
+	}
+	if !ok {
+		user.MFAFailedAttempts++
+		if user.MFAFailedAttempts >= maxMFAAttempts {
+			lockedUntil := now.Add(mfaLockout)
+			user.MFALockedUntil = &lockedUntil
+			user.MFAFailedAttempts = 0
+			s.logger.Warn("mfa locked after repeated failures", zap.String("user_id", user.ID.String()))
+		}
+		if err := s.users.Update(ctx, user); err != nil {
+			s.logger.Error("record mfa failure failed", zap.Error(err))
+			return model.TokenResponse{}, fmt.Errorf("verify mfa: %w", err)
+		}
+		return model.TokenResponse{}, ErrInvalidMFACode
+	}
+
+	if user.MFAFailedAttempts > 0 || user.MFALockedUntil != nil {
+		user.MFAFailedAttempts = 0
+		user.MFALockedUntil = nil
+		if err := s.users.Update(ctx, user); err != nil {
+			s.logger.Error("reset mfa failures failed", zap.Error(err))
+			return model.TokenResponse{}, fmt.Errorf("verify mfa: %w", err)
+		}
+	}
+
+	tokens, err := s.issueTokens(ctx, user, uuid.New(), uuid.New(), true)
+	if err != nil {
+		return model.TokenResponse{}, fmt.Errorf("verify mfa: %w", err)
+	}
+	s.logger.Info("user logged in with mfa", zap.String("user_id", user.ID.String()))
+	return tokens, nil
+}
+
+// EnrollMFA generates a pending TOTP secret. MFA is only enabled once
+// ConfirmMFA proves the authenticator produces matching codes.
+func (s *authService) EnrollMFA(ctx context.Context) (model.MFAEnrollmentResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return model.MFAEnrollmentResponse{}, err
+	}
+	if user.MFAEnabled {
+		return model.MFAEnrollmentResponse{}, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		s.logger.Error("generate mfa secret failed", zap.Error(err))
+		return model.MFAEnrollmentResponse{}, fmt.Errorf("enroll mfa: %w", err)
+	}
+	user.MFAPendingSecret = secret
+	if err := s.users.Update(ctx, user); err != nil {
+		s.logger.Error("store pending mfa secret failed", zap.Error(err))
+		return model.MFAEnrollmentResponse{}, fmt.Errorf("enroll mfa: %w", err)
+	}
+	s.logger.Info("mfa enrollment started", zap.String("user_id", user.ID.String()))
+
+	return model.MFAEnrollmentResponse{
+		Secret: secret,
+		URI:    totp.URI(s.signer.Issuer(), user.Email, secret),
+	}, nil
+}
+
+// ConfirmMFA enables MFA with the pending secret and returns fresh recovery
+// codes, which are shown only this once.
+func (s *authService) ConfirmMFA(ctx context.Context, req model.ConfirmMFARequest) (model.MFARecoveryCodesResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return model.MFARecoveryCodesResponse{}, err
+	}
+	if user.MFAEnabled {
+		return model.MFARecoveryCodesResponse{}, ErrMFAAlreadyEnabled
+	}
+	if user.MFAPendingSecret == "" {
+		return model.MFARecoveryCodesResponse{}, ErrMFANotEnrolled
+	}
+	step, ok := totp.Validate(user.MFAPendingSecret, req.Code, s.clock.Now())
+	if !ok {
+		return model.MFARecoveryCodesResponse{}, ErrInvalidMFACode
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw, err := token.GenerateHex(recoveryCodeBytes)
+		if err != nil {
+			s.logger.Error("generate recovery code failed", zap.Error(err))
+			return model.MFARecoveryCodesResponse{}, fmt.Errorf("confirm mfa: %w", err)
+		}
+		codes = append(codes, raw[:len(raw)/2]+"-"+raw[len(raw)/2:])
+		hashes = append(hashes, token.Hash(raw))
+	}
+	if err := s.recoveryCodes.Replace(ctx, user.ID, hashes); err != nil {
+		s.logger.Error("store recovery codes failed", zap.Error(err))
+		return model.MFARecoveryCodesResponse{}, fmt.Errorf("confirm mfa: %w", err)
+	}
+
+	user.MFASecret = user.MFAPendingSecret
+	user.MFAPendingSecret = ""
+	user.MFAEnabled = true
+	if err := s.users.Update(ctx, user); err != nil {
+		s.logger.Error("enable mfa failed", zap.Error(err))
+		return model.MFARecoveryCodesResponse{}, fmt.Errorf("confirm mfa: %w", err)
+	}
+	// The confirmation code must not be usable again for login.
+	if err := s.users.AdvanceMFAStep(ctx, user.ID, step); err != nil && !errors.Is(err, repository.ErrMFACodeReused) {
+		s.logger.Error("record mfa step failed", zap.Error(err))
+		return model.MFARecoveryCodesResponse{}, fmt.Errorf("confirm mfa: %w", err)
+	}
+	s.logger.Info("mfa enabled", zap.String("user_id", user.ID.String()))
+
+	return model.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// checkSecondFactor accepts either a TOTP code newer than the last accepted
+// one or an unused recovery code.
+func (s *authService) checkSecondFactor(ctx context.Context, user *model.User, code string, now time.Time) (bool, error) {
+	if step, ok := totp.Validate(user.MFASecret, code, now); ok {
+		err := s.users.AdvanceMFAStep(ctx, user.ID, step)
+		if errors.Is(err, repository.ErrMFACodeReused) {
+			return false, nil
+		}
+		if err != nil {
+			s.logger.Error("record mfa step failed", zap.Error(err))
+			return false, err
+		}
+		return true, nil
+	}
+
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	err := s.recoveryCodes.Use(ctx, user.ID, token.Hash(normalized), now)
+	if errors.Is(err, repository.ErrRecoveryCodeNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		s.logger.Error("use recovery code failed", zap.Error(err))
+		return false, err
+	}
+	s.logger.Info("recovery code used", zap.String("user_id", user.ID.String()))
+	return true, nil
+}
+
+// SetUsername sets or changes the caller's username, the handle others use
+// to @mention them.
+func (s *authService) SetUsername(ctx context.Context, req model.SetUsernameRequest) (model.UserResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return model.UserResponse{}, err
+	}
+	user.Username = &req.Username
+	if err := s.users.Update(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrUsernameTaken) {
+			return model.UserResponse{}, err
+		}
+		s.logger.Error("set username failed", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return model.UserResponse{}, fmt.Errorf("set username: %w", err)
+	}
+	s.logger.Info("username set", zap.String("user_id", user.ID.String()))
+	return user.ToResponse(), nil
+}
+
+// SetArchivePolicy sets or clears the number of days after which the
+// caller's finished lists are archived by the ListArchiver.
+func (s *authService) SetArchivePolicy(ctx context.Context, req model.SetArchivePolicyRequest) (model.UserResponse, error) {
+	user, err := s.currentUser(ctx)
+	if err != nil {
+		return model.UserResponse{}, err
+	}
+	user.AutoArchiveAfterDays = req.AfterDays
+	if err := s.users.Update(ctx, user); err != nil {
+		s.logger.Error("set archive policy failed", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return model.UserResponse{}, fmt.Errorf("set archive policy: %w", err)
+	}
+	s.logger.Info("archive policy set", zap.String("user_id", user.ID.String()))
+	return user.ToResponse(), nil
+}
+
+// currentUser loads the locally registered user behind the request's claims.
+func (s *authService) currentUser(ctx context.Context) (*model.User, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrLocalAccountRequired
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, ErrLocalAccountRequired
+	}
+	user, err := s.users.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrLocalAccountRequired
+		}
+		s.logger.Error("find current user failed", zap.Error(err))
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return user, nil
+}
+
+func (s *authService) issueTokens(ctx context.Context, user *model.User, familyID, refreshTokenID uuid.UUID, mfa bool) (model.TokenResponse, error) {
+	now := s.clock.Now()
+	claims := auth.Claims{Roles: []string{user.Role}, AMR: []string{auth.AMRPassword}}
+	if mfa {
+		claims.AMR = append(claims.AMR, auth.AMROTP, auth.AMRMultiFactor)
+	}
+	claims.Subject = user.ID.String()
+	if user.WorkspaceID != nil {
+		claims.WorkspaceID = user.WorkspaceID.String()
+	}
+	accessToken, err := s.signer.Sign(claims, now)
+	if err != nil {
+		s.logger.Error("sign access token failed", zap.Error(err))
+		return model.TokenResponse{}, err
+	}
+
+	rawRefreshToken, err := token.Generate(refreshTokenBytes)
+	if err != nil {
+		s.logger.Error("generate refresh token failed", zap.Error(err))
+		return model.TokenResponse{}, err
+	}
+	if err := s.refreshTokens.Create(ctx, &model.RefreshToken{
+		ID:        refreshTokenID,
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: token.Hash(rawRefreshToken),
+		ExpiresAt: now.Add(s.refreshTTL),
+		MFA:       mfa,
+	}); err != nil {
+		s.logger.Error("store refresh token failed", zap.Error(err))
+		return model.TokenResponse{}, err
+	}
+
+	return model.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: rawRefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(s.signer.TTL().Seconds()),
+	}, nil
+}
+
+func (s *authService) revokeFamily(ctx context.Context, refreshToken *model.RefreshToken, now time.Time) {
+	s.logger.Warn("refresh token reuse detected",
+		zap.String("user_id", refreshToken.UserID.String()),
+		zap.String("family_id", refreshToken.FamilyID.String()),
+	)
+	if err := s.refreshTokens.RevokeFamily(ctx, refreshToken.FamilyID, now); err != nil {
+		s.logger.Error("revoke refresh token family failed", zap.Error(err))
+	}
+}
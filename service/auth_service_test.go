@@ -0,0 +1,437 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/config"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/package/totp"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type authFixture struct {
+	users         *mocks.UserRepositoryMock
+	workspaces    *mocks.WorkspaceRepositoryMock
+	refreshTokens *mocks.RefreshTokenRepositoryMock
+	revokedTokens *mocks.RevokedTokenRepositoryMock
+	recoveryCodes *mocks.MFARecoveryCodeRepositoryMock
+	accounts      *mocks.AccountServiceMock
+	clock         *mocks.FakeClock
+	svc           service.AuthService
+}
+
+func newAuthFixture(t *testing.T) authFixture {
+	t.Helper()
+
+	f := authFixture{
+		users:         new(mocks.UserRepositoryMock),
+		workspaces:    new(mocks.WorkspaceRepositoryMock),
+		refreshTokens: new(mocks.RefreshTokenRepositoryMock),
+		revokedTokens: new(mocks.RevokedTokenRepositoryMock),
+		recoveryCodes: new(mocks.MFARecoveryCodeRepositoryMock),
+		accounts:      new(mocks.AccountServiceMock),
+		clock:         mocks.NewFakeClock(time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)),
+	}
+	signer := auth.NewSigner(config.JWTConfig{Secret: "secret", Issuer: "todolist", TTL: 900})
+	f.svc = service.NewAuthService(f.users, f.workspaces, f.refreshTokens, f.revokedTokens, f.recoveryCodes, f.accounts, mocks.FakeTransactor{}, signer, time.Hour, f.clock, zaptest.NewLogger(t))
+	return f
+}
+
+func newUser(t *testing.T, password string) *model.User {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	require.NoError(t, err)
+	verifiedAt := time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)
+	return &model.User{ID: uuid.New(), Email: "ada@example.com", PasswordHash: string(hash), Role: auth.RoleMember, EmailVerifiedAt: &verifiedAt}
+}
+
+func TestAuthService_Register_SendsVerification(t *testing.T) {
+	f := newAuthFixture(t)
+	workspaceID := uuid.New()
+	userID := uuid.New()
+	f.workspaces.On("Create", mock.Anything, mock.MatchedBy(func(workspace *model.Workspace) bool {
+		return workspace.Name == "ada@example.com"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.Workspace).ID = workspaceID
+	})
+	f.users.On("Create", mock.Anything, mock.MatchedBy(func(user *model.User) bool {
+		return user.Email == "ada@example.com" && user.EmailVerifiedAt == nil &&
+			user.WorkspaceID != nil && *user.WorkspaceID == workspaceID
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.User).ID = userID
+	})
+	f.workspaces.On("AddMember", mock.Anything, workspaceID, userID.String()).Return(nil)
+	f.accounts.On("SendVerification", mock.Anything, mock.AnythingOfType("*model.User")).Return(errors.New("smtp down"))
+
+	res, err := f.svc.Register(context.Background(), model.RegisterRequest{Email: "Ada@Example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.False(t, res.EmailVerified)
+	require.Equal(t, &workspaceID, res.WorkspaceID)
+	f.users.AssertExpectations(t)
+	f.workspaces.AssertExpectations(t)
+	f.accounts.AssertExpectations(t)
+}
+
+func TestAuthService_Login_UnverifiedEmail(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	user.EmailVerifiedAt = nil
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+
+	_, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.ErrorIs(t, err, service.ErrEmailNotVerified)
+	f.refreshTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Login_Success(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.refreshTokens.On("Create", mock.Anything, mock.MatchedBy(func(refreshToken *model.RefreshToken) bool {
+		return refreshToken.UserID == user.ID && refreshToken.FamilyID != uuid.Nil
+	})).Return(nil)
+
+	res, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "Ada@Example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.AccessToken)
+	require.NotEmpty(t, res.RefreshToken)
+	require.Equal(t, "Bearer", res.TokenType)
+	require.Equal(t, 900, res.ExpiresIn)
+	f.users.AssertExpectations(t)
+	f.refreshTokens.AssertExpectations(t)
+}
+
+func TestAuthService_Login_InvalidPassword(t *testing.T) {
+	f := newAuthFixture(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(newUser(t, "correct horse"), nil)
+
+	_, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "battery staple"})
+	require.ErrorIs(t, err, service.ErrInvalidCredentials)
+	f.refreshTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	current := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		TokenHash: token.Hash("raw-token"),
+		ExpiresAt: f.clock.Now().Add(time.Hour),
+	}
+
+	var replacementID *uuid.UUID
+	f.refreshTokens.On("FindByTokenHash", mock.Anything, token.Hash("raw-token")).Return(current, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.refreshTokens.On("MarkUsed", mock.Anything, current.ID, mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) {
+			replacementID = args.Get(2).(*uuid.UUID)
+		})
+	f.refreshTokens.On("Create", mock.Anything, mock.MatchedBy(func(refreshToken *model.RefreshToken) bool {
+		return refreshToken.FamilyID == current.FamilyID && refreshToken.TokenHash != current.TokenHash
+	})).Return(nil).Run(func(args mock.Arguments) {
+		require.Equal(t, *replacementID, args.Get(1).(*model.RefreshToken).ID)
+	})
+
+	res, err := f.svc.Refresh(context.Background(), model.RefreshRequest{RefreshToken: "raw-token"})
+	require.NoError(t, err)
+	require.NotEqual(t, "raw-token", res.RefreshToken)
+	f.refreshTokens.AssertExpectations(t)
+}
+
+func TestAuthService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	f := newAuthFixture(t)
+	revokedAt := f.clock.Now().Add(-time.Minute)
+	current := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		FamilyID:  uuid.New(),
+		ExpiresAt: f.clock.Now().Add(time.Hour),
+		RevokedAt: &revokedAt,
+	}
+
+	f.refreshTokens.On("FindByTokenHash", mock.Anything, token.Hash("stolen")).Return(current, nil)
+	f.refreshTokens.On("RevokeFamily", mock.Anything, current.FamilyID, mock.Anything).Return(nil)
+
+	_, err := f.svc.Refresh(context.Background(), model.RefreshRequest{RefreshToken: "stolen"})
+	require.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+	f.refreshTokens.AssertExpectations(t)
+	f.refreshTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_Refresh_ConcurrentRotationRevokesFamily(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	current := &model.RefreshToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		FamilyID:  uuid.New(),
+		ExpiresAt: f.clock.Now().Add(time.Hour),
+	}
+
+	f.refreshTokens.On("FindByTokenHash", mock.Anything, token.Hash("raw-token")).Return(current, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.refreshTokens.On("MarkUsed", mock.Anything, current.ID, mock.Anything, mock.Anything).Return(repository.ErrRefreshTokenUsed)
+	f.refreshTokens.On("RevokeFamily", mock.Anything, current.FamilyID, mock.Anything).Return(nil)
+
+	_, err := f.svc.Refresh(context.Background(), model.RefreshRequest{RefreshToken: "raw-token"})
+	require.ErrorIs(t, err, service.ErrInvalidRefreshToken)
+	f.refreshTokens.AssertExpectations(t)
+}
+
+func TestAuthService_Logout_DenylistsAccessToken(t *testing.T) {
+	f := newAuthFixture(t)
+	familyID := uuid.New()
+	expiresAt := f.clock.Now().Add(10 * time.Minute).Truncate(time.Second)
+
+	f.refreshTokens.On("FindByTokenHash", mock.Anything, token.Hash("raw-token")).
+		Return(&model.RefreshToken{ID: uuid.New(), FamilyID: familyID}, nil)
+	f.refreshTokens.On("RevokeFamily", mock.Anything, familyID, mock.Anything).Return(nil)
+	f.revokedTokens.On("Create", mock.Anything, mock.MatchedBy(func(revoked *model.RevokedAccessToken) bool {
+		return revoked.JTI == "token-id" && revoked.ExpiresAt.Equal(expiresAt)
+	})).Return(nil)
+
+	claims := &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{
+		ID:        "token-id",
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}}
+	ctx := auth.WithClaims(context.Background(), claims)
+
+	require.NoError(t, f.svc.Logout(ctx, model.LogoutRequest{RefreshToken: "raw-token"}))
+	f.refreshTokens.AssertExpectations(t)
+	f.revokedTokens.AssertExpectations(t)
+}
+
+func newMFAUser(t *testing.T) *model.User {
+	t.Helper()
+
+	user := newUser(t, "correct horse")
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user.MFAEnabled = true
+	user.MFASecret = secret
+	return user
+}
+
+func currentCode(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+
+	code, err := totp.Code(secret, totp.Step(now))
+	require.NoError(t, err)
+	return code
+}
+
+func TestAuthService_Login_MFARequiredReturnsChallenge(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newMFAUser(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+
+	res, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	require.True(t, res.MFARequired)
+	require.NotEmpty(t, res.MFAToken)
+	require.Empty(t, res.AccessToken)
+	f.refreshTokens.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_VerifyMFA_TOTP(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newMFAUser(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+
+	challenge, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	f.clock.Advance(2 * time.Minute)
+	now := f.clock.Now()
+	f.users.On("AdvanceMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(nil).Once()
+	f.refreshTokens.On("Create", mock.Anything, mock.MatchedBy(func(refreshToken *model.RefreshToken) bool {
+		return refreshToken.MFA
+	})).Return(nil)
+
+	res, err := f.svc.VerifyMFA(context.Background(), model.VerifyMFARequest{
+		MFAToken: challenge.MFAToken,
+		Code:     currentCode(t, user.MFASecret, now),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.AccessToken)
+
+	var claims auth.Claims
+	_, _, err = jwt.NewParser().ParseUnverified(res.AccessToken, &claims)
+	require.NoError(t, err)
+	require.True(t, claims.MFAAuthenticated())
+
+	// The same code cannot be replayed within its validity window.
+	f.users.On("AdvanceMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(repository.ErrMFACodeReused).Once()
+	f.recoveryCodes.On("Use", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(repository.ErrRecoveryCodeNotFound).Maybe()
+	f.users.On("Update", mock.Anything, user).Return(nil)
+	_, err = f.svc.VerifyMFA(context.Background(), model.VerifyMFARequest{
+		MFAToken: challenge.MFAToken,
+		Code:     currentCode(t, user.MFASecret, now),
+	})
+	require.ErrorIs(t, err, service.ErrInvalidMFACode)
+	f.users.AssertExpectations(t)
+}
+
+func TestAuthService_VerifyMFA_ChallengeExpires(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newMFAUser(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+
+	challenge, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	f.clock.Advance(6 * time.Minute)
+	_, err = f.svc.VerifyMFA(context.Background(), model.VerifyMFARequest{
+		MFAToken: challenge.MFAToken,
+		Code:     currentCode(t, user.MFASecret, f.clock.Now()),
+	})
+	require.ErrorIs(t, err, service.ErrInvalidMFAChallenge)
+	f.users.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestAuthService_VerifyMFA_LocksAfterRepeatedFailures(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newMFAUser(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, user).Return(nil)
+	f.recoveryCodes.On("Use", mock.Anything, user.ID, mock.Anything, mock.Anything).Return(repository.ErrRecoveryCodeNotFound)
+
+	challenge, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	verify := func(code string) error {
+		_, err := f.svc.VerifyMFA(context.Background(), model.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: code})
+		return err
+	}
+
+	for i := 0; i < 5; i++ {
+		require.ErrorIs(t, verify("wrong-code"), service.ErrInvalidMFACode)
+	}
+	require.NotNil(t, user.MFALockedUntil)
+
+	// Even the right code is refused while locked.
+	require.ErrorIs(t, verify(currentCode(t, user.MFASecret, f.clock.Now())), service.ErrMFALocked)
+
+	// A fresh challenge is needed once the lockout outlives the old one.
+	f.clock.Advance(15 * time.Minute)
+	challenge, err = f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+	f.users.On("AdvanceMFAStep", mock.Anything, user.ID, totp.Step(f.clock.Now())).Return(nil)
+	f.refreshTokens.On("Create", mock.Anything, mock.Anything).Return(nil)
+	require.NoError(t, verify(currentCode(t, user.MFASecret, f.clock.Now())))
+	require.Nil(t, user.MFALockedUntil)
+}
+
+func TestAuthService_VerifyMFA_RecoveryCode(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newMFAUser(t)
+	f.users.On("FindByEmail", mock.Anything, "ada@example.com").Return(user, nil)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.recoveryCodes.On("Use", mock.Anything, user.ID, token.Hash("abcde12345"), f.clock.Now()).Return(nil)
+	f.refreshTokens.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	challenge, err := f.svc.Login(context.Background(), model.LoginRequest{Email: "ada@example.com", Password: "correct horse"})
+	require.NoError(t, err)
+
+	_, err = f.svc.VerifyMFA(context.Background(), model.VerifyMFARequest{MFAToken: challenge.MFAToken, Code: "ABCDE-12345"})
+	require.NoError(t, err)
+	f.recoveryCodes.AssertExpectations(t)
+}
+
+func TestAuthService_EnrollAndConfirmMFA(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	ctx := claimsContext(user.ID.String(), auth.RoleMember)
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, user).Return(nil)
+
+	enrollment, err := f.svc.EnrollMFA(ctx)
+	require.NoError(t, err)
+	require.Equal(t, enrollment.Secret, user.MFAPendingSecret)
+	require.Contains(t, enrollment.URI, "otpauth://totp/")
+	require.False(t, user.MFAEnabled)
+
+	// A code from outside the accepted window does not enable MFA.
+	stale, err := totp.Code(enrollment.Secret, totp.Step(f.clock.Now())-5)
+	require.NoError(t, err)
+	_, err = f.svc.ConfirmMFA(ctx, model.ConfirmMFARequest{Code: stale})
+	require.ErrorIs(t, err, service.ErrInvalidMFACode)
+	require.False(t, user.MFAEnabled)
+
+	now := f.clock.Now()
+	f.recoveryCodes.On("Replace", mock.Anything, user.ID, mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == 10
+	})).Return(nil)
+	f.users.On("AdvanceMFAStep", mock.Anything, user.ID, totp.Step(now)).Return(nil)
+
+	res, err := f.svc.ConfirmMFA(ctx, model.ConfirmMFARequest{Code: currentCode(t, enrollment.Secret, now)})
+	require.NoError(t, err)
+	require.Len(t, res.RecoveryCodes, 10)
+	require.True(t, user.MFAEnabled)
+	require.Equal(t, enrollment.Secret, user.MFASecret)
+	require.Empty(t, user.MFAPendingSecret)
+
+	_, err = f.svc.EnrollMFA(ctx)
+	require.ErrorIs(t, err, service.ErrMFAAlreadyEnabled)
+}
+
+func TestAuthService_SetUsername(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+		return u.Username != nil && *u.Username == "ada"
+	})).Return(nil).Once()
+	f.users.On("Update", mock.Anything, mock.Anything).Return(repository.ErrUsernameTaken).Once()
+
+	res, err := f.svc.SetUsername(claimsContext(user.ID.String()), model.SetUsernameRequest{Username: "ada"})
+	require.NoError(t, err)
+	require.Equal(t, "ada", res.Username)
+
+	_, err = f.svc.SetUsername(claimsContext(user.ID.String()), model.SetUsernameRequest{Username: "grace"})
+	require.ErrorIs(t, err, repository.ErrUsernameTaken)
+}
+
+func TestAuthService_SetArchivePolicy(t *testing.T) {
+	f := newAuthFixture(t)
+	user := newUser(t, "correct horse")
+	days := 14
+	f.users.On("FindByID", mock.Anything, user.ID).Return(user, nil)
+	f.users.On("Update", mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+		return u.AutoArchiveAfterDays != nil && *u.AutoArchiveAfterDays == days
+	})).Return(nil).Once()
+	f.users.On("Update", mock.Anything, mock.MatchedBy(func(u *model.User) bool {
+		return u.AutoArchiveAfterDays == nil
+	})).Return(nil).Once()
+
+	res, err := f.svc.SetArchivePolicy(claimsContext(user.ID.String()), model.SetArchivePolicyRequest{AfterDays: &days})
+	require.NoError(t, err)
+	require.Equal(t, &days, res.AutoArchiveAfterDays)
+
+	res, err = f.svc.SetArchivePolicy(claimsContext(user.ID.String()), model.SetArchivePolicyRequest{})
+	require.NoError(t, err)
+	require.Nil(t, res.AutoArchiveAfterDays)
+
+	_, err = f.svc.SetArchivePolicy(claimsContext("external-subject"), model.SetArchivePolicyRequest{AfterDays: &days})
+	require.ErrorIs(t, err, service.ErrLocalAccountRequired)
+	f.users.AssertExpectations(t)
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/ical"
+	"github.com/lumoshiveacademy/todolist/package/rrule"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	calendarFeedTokenBytes = 32
+	// calendarFeedLimit caps the number of items rendered into one feed.
+	calendarFeedLimit = 1000
+	// calendarFeedHistory is how long finished items stay in the feed after
+	// their due date.
+	calendarFeedHistory = 30 * 24 * time.Hour
+	// calendarTimezoneYears is how many years ahead VTIMEZONE components list
+	// daylight saving transitions for recurring series.
+	calendarTimezoneYears = 5
+	calendarProductID     = "-//lumoshiveacademy//todolist//EN"
+)
+
+// CalendarService issues per-user calendar feeds and renders them as
+// iCalendar.
+type CalendarService interface {
+	CreateFeed(ctx context.Context) (model.CalendarFeedResponse, error)
+	DeleteFeed(ctx context.Context) error
+	RenderFeed(ctx context.Context, rawToken string) ([]byte, error)
+}
+
+type calendarService struct {
+	feeds     repository.CalendarFeedRepository
+	items     repository.TodoItemRepository
+	reminders repository.ReminderRepository
+	clock     clock.Clock
+	location  *time.Location
+	logger    *zap.Logger
+}
+
+// NewCalendarService constructs a CalendarService implementation. Recurring
+// items without their own timezone are rendered in location, the zone they
+// are expanded in.
+func NewCalendarService(
+	feeds repository.CalendarFeedRepository,
+	items repository.TodoItemRepository,
+	reminders repository.ReminderRepository,
+	clock clock.Clock,
+	location *time.Location,
+	logger *zap.Logger,
+) CalendarService {
+	return &calendarService{
+		feeds:     feeds,
+		items:     items,
+		reminders: reminders,
+		clock:     clock,
+		location:  location,
+		logger:    logger,
+	}
+}
+
+// CreateFeed issues a feed for the caller's lists in the current workspace.
+// Calling it again replaces the token, so the previous URL stops working.
+func (s *calendarService) CreateFeed(ctx context.Context) (model.CalendarFeedResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.CalendarFeedResponse{}, ErrForbidden
+	}
+
+	rawToken, err := token.Generate(calendarFeedTokenBytes)
+	if err != nil {
+		s.logger.Error("generate calendar feed token failed", zap.Error(err))
+		return model.CalendarFeedResponse{}, fmt.Errorf("create calendar feed: %w", err)
+	}
+
+	feed := &model.CalendarFeed{
+		UserID:    claims.Subject,
+		TokenHash: token.Hash(rawToken),
+	}
+	if err := s.feeds.Save(ctx, feed); err != nil {
+		s.logger.Error("save calendar feed failed", zap.String("user_id", claims.Subject), zap.Error(err))
+		return model.CalendarFeedResponse{}, fmt.Errorf("create calendar feed: %w", err)
+	}
+	s.logger.Info("calendar feed issued", zap.String("user_id", claims.Subject))
+
+	return model.CalendarFeedResponse{
+		Token:     rawToken,
+		URL:       "/calendar/" + rawToken + ".ics",
+		CreatedAt: feed.CreatedAt,
+	}, nil
+}
+
+func (s *calendarService) DeleteFeed(ctx context.Context) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	if err := s.feeds.Delete(ctx, claims.Subject); err != nil {
+		if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+			return err
+		}
+		s.logger.Error("delete calendar feed failed", zap.String("user_id", claims.Subject), zap.Error(err))
+		return fmt.Errorf("delete calendar feed: %w", err)
+	}
+	s.logger.Info("calendar feed revoked", zap.String("user_id", claims.Subject))
+	return nil
+}
+
+// RenderFeed renders the due items of the feed owner's active lists. One-off
+// items become VTODOs; each recurring series becomes a single VEVENT with
+// its RRULE, described by its latest occurrence. The owner's reminders
+// become VALARMs. Output only changes when the items or reminders do, so it
+// can be cached by its hash.
+func (s *calendarService) RenderFeed(ctx context.Context, rawToken string) ([]byte, error) {
+	feed, err := s.feeds.FindByTokenHash(ctx, token.Hash(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrCalendarFeedNotFound) {
+			return nil, err
+		}
+		s.logger.Error("find calendar feed failed", zap.Error(err))
+		return nil, fmt.Errorf("get calendar feed: %w", err)
+	}
+
+	ctx = tenant.WithWorkspaceID(ctx, feed.WorkspaceID)
+	now := s.clock.Now()
+	items, err := s.items.FindDueByOwner(ctx, feed.UserID, now.Add(-calendarFeedHistory), calendarFeedLimit)
+	if err != nil {
+		s.logger.Error("find due todo items for calendar failed", zap.String("user_id", feed.UserID), zap.Error(err))
+		return nil, fmt.Errorf("render calendar feed: %w", err)
+	}
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	reminders, err := s.reminders.FindForUser(ctx, feed.UserID, ids)
+	if err != nil {
+		s.logger.Error("find reminders for calendar failed", zap.String("user_id", feed.UserID), zap.Error(err))
+		return nil, fmt.Errorf("render calendar feed: %w", err)
+	}
+	alarms := make(map[uuid.UUID][]model.Reminder)
+	for _, reminder := range reminders {
+		alarms[reminder.TodoItemID] = append(alarms[reminder.TodoItemID], reminder)
+	}
+
+	var entries []calendarEntry
+	series := make(map[uuid.UUID]int)
+	zones := make(map[string]*calendarZone)
+	for _, item := range items {
+		entry := calendarEntry{item: item, alarms: alarms[item.ID]}
+		if item.Recurrence != "" && item.SeriesID != nil {
+			if rule, err := rrule.Parse(item.Recurrence); err == nil {
+				entry.start, entry.rule = s.seriesStart(item), rule.In(s.seriesLocation(item))
+				entry.recurring = true
+			}
+		}
+		if !entry.recurring {
+			entries = append(entries, entry)
+			continue
+		}
+
+		// Items come earliest first, so a later occurrence replaces the
+		// one seen before it.
+		if i, ok := series[*item.SeriesID]; ok {
+			entries[i] = entry
+		} else {
+			series[*item.SeriesID] = len(entries)
+			entries = append(entries, entry)
+		}
+	}
+	for _, entry := range entries {
+		if !entry.recurring || entry.start.Location() == time.UTC {
+			continue
+		}
+		name := entry.start.Location().String()
+		if zone, ok := zones[name]; !ok || entry.start.Before(zone.from) {
+			zones[name] = &calendarZone{location: entry.start.Location(), from: entry.start}
+		}
+	}
+
+	var w ical.Writer
+	w.Begin("VCALENDAR")
+	w.Property("VERSION", "2.0")
+	w.Text("PRODID", calendarProductID)
+	w.Property("CALSCALE", "GREGORIAN")
+	w.Text("X-WR-CALNAME", "Todos")
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	until := time.Date(now.Year()+calendarTimezoneYears, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range names {
+		zone := zones[name]
+		from := time.Date(zone.from.Year(), time.January, 1, 0, 0, 0, 0, zone.location)
+		w.Timezone(zone.location, from, until)
+	}
+	for _, entry := range entries {
+		if entry.recurring {
+			writeEvent(&w, entry)
+		} else {
+			writeTodo(&w, entry)
+		}
+	}
+	w.End("VCALENDAR")
+	return w.Bytes(), nil
+}
+
+// calendarEntry is one component of a feed: a one-off item, or the latest
+// occurrence of a recurring series together with its rule.
+type calendarEntry struct {
+	item      model.TodoItem
+	alarms    []model.Reminder
+	recurring bool
+	start     time.Time
+	rule      rrule.Rule
+}
+
+// calendarZone records the earliest series start rendered in a time zone.
+type calendarZone struct {
+	location *time.Location
+	from     time.Time
+}
+
+// seriesLocation returns the zone an item's series is expanded in.
+func (s *calendarService) seriesLocation(item model.TodoItem) *time.Location {
+	if item.Timezone != "" {
+		if location, err := time.LoadLocation(item.Timezone); err == nil {
+			return location
+		}
+	}
+	return s.location
+}
+
+// seriesStart returns the start of an item's series in its zone.
+func (s *calendarService) seriesStart(item model.TodoItem) time.Time {
+	start := *item.DueAt
+	if item.SeriesStartAt != nil {
+		start = *item.SeriesStartAt
+	}
+	return start.In(s.seriesLocation(item))
+}
+
+func writeTodo(w *ical.Writer, entry calendarEntry) {
+	item := entry.item
+	w.Begin("VTODO")
+	w.Text("UID", item.ID.String())
+	w.UTC("DTSTAMP", item.UpdatedAt)
+	w.UTC("CREATED", item.CreatedAt)
+	w.UTC("LAST-MODIFIED", item.UpdatedAt)
+	w.Text("SUMMARY", item.Title)
+	if item.Description != "" {
+		w.Text("DESCRIPTION", item.Description)
+	}
+	w.UTC("DUE", *item.DueAt)
+	w.Property("STATUS", todoStatus(item.Status))
+	if item.CompletedAt != nil {
+		w.UTC("COMPLETED", *item.CompletedAt)
+	}
+	w.Property("PRIORITY", todoPriority(item.Priority))
+	if item.ParentID != nil {
+		w.Text("RELATED-TO", item.ParentID.String())
+	}
+	// Offsets count back from the due date, the end of a VTODO.
+	writeAlarms(w, item.Title, entry.alarms, ical.Param("RELATED", "END"))
+	w.End("VTODO")
+}
+
+func writeEvent(w *ical.Writer, entry calendarEntry) {
+	item := entry.item
+	w.Begin("VEVENT")
+	w.Text("UID", item.SeriesID.String())
+	w.UTC("DTSTAMP", item.UpdatedAt)
+	w.UTC("LAST-MODIFIED", item.UpdatedAt)
+	w.Text("SUMMARY", item.Title)
+	if item.Description != "" {
+		w.Text("DESCRIPTION", item.Description)
+	}
+	w.Local("DTSTART", entry.start)
+	w.Property("RRULE", entry.rule.String())
+	w.Property("PRIORITY", todoPriority(item.Priority))
+	writeAlarms(w, item.Title, entry.alarms)
+	w.End("VEVENT")
+}
+
+// writeAlarms writes a display alarm for each reminder. Reminders set
+// relative to the due date trigger at that offset before it; the others at
+// their fixed time.
+func writeAlarms(w *ical.Writer, title string, reminders []model.Reminder, relative ...string) {
+	for _, reminder := range reminders {
+		if reminder.OffsetSeconds == nil && reminder.RemindAt == nil {
+			continue
+		}
+		w.Begin("VALARM")
+		w.Property("ACTION", "DISPLAY")
+		w.Text("DESCRIPTION", title)
+		if reminder.OffsetSeconds != nil {
+			w.Property("TRIGGER", ical.Duration(-time.Duration(*reminder.OffsetSeconds)*time.Second), relative...)
+		} else {
+			w.UTC("TRIGGER", *reminder.RemindAt, ical.Param("VALUE", "DATE-TIME"))
+		}
+		w.End("VALARM")
+	}
+}
+
+// todoStatus maps an item status to a VTODO STATUS.
+func todoStatus(status string) string {
+	switch status {
+	case model.StatusInProgress:
+		return "IN-PROCESS"
+	case model.StatusDone:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// todoPriority maps an item priority to the 1 (highest) to 9 (lowest) scale
+// of the PRIORITY property.
+func todoPriority(priority string) string {
+	switch priority {
+	case model.PriorityUrgent:
+		return "1"
+	case model.PriorityHigh:
+		return "3"
+	case model.PriorityLow:
+		return "9"
+	default:
+		return "5"
+	}
+}
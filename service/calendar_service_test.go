@@ -0,0 +1,191 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type calendarFixture struct {
+	feeds     *mocks.CalendarFeedRepositoryMock
+	items     *mocks.TodoItemRepositoryMock
+	reminders *mocks.ReminderRepositoryMock
+	clock     *mocks.FakeClock
+	service   service.CalendarService
+}
+
+func newCalendarFixture(t *testing.T) calendarFixture {
+	f := calendarFixture{
+		feeds:     new(mocks.CalendarFeedRepositoryMock),
+		items:     new(mocks.TodoItemRepositoryMock),
+		reminders: new(mocks.ReminderRepositoryMock),
+		clock:     mocks.NewFakeClock(time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.service = service.NewCalendarService(f.feeds, f.items, f.reminders, f.clock, time.UTC, zaptest.NewLogger(t))
+	return f
+}
+
+func TestCalendarService_CreateFeed_StoresHashedToken(t *testing.T) {
+	f := newCalendarFixture(t)
+
+	var stored *model.CalendarFeed
+	f.feeds.On("Save", mock.Anything, mock.AnythingOfType("*model.CalendarFeed")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*model.CalendarFeed)
+	})
+
+	res, err := f.service.CreateFeed(claimsContext("user-1"))
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Token)
+	require.Equal(t, "/calendar/"+res.Token+".ics", res.URL)
+	require.Equal(t, "user-1", stored.UserID)
+	require.Equal(t, token.Hash(res.Token), stored.TokenHash)
+	f.feeds.AssertExpectations(t)
+}
+
+func TestCalendarService_DeleteFeed_NotFound(t *testing.T) {
+	f := newCalendarFixture(t)
+	f.feeds.On("Delete", mock.Anything, "user-1").Return(repository.ErrCalendarFeedNotFound)
+
+	err := f.service.DeleteFeed(claimsContext("user-1"))
+	require.ErrorIs(t, err, repository.ErrCalendarFeedNotFound)
+}
+
+func TestCalendarService_RenderFeed_UnknownToken(t *testing.T) {
+	f := newCalendarFixture(t)
+	f.feeds.On("FindByTokenHash", mock.Anything, token.Hash("unknown")).Return(nil, repository.ErrCalendarFeedNotFound)
+
+	_, err := f.service.RenderFeed(context.Background(), "unknown")
+	require.ErrorIs(t, err, repository.ErrCalendarFeedNotFound)
+	f.items.AssertNotCalled(t, "FindDueByOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCalendarService_RenderFeed(t *testing.T) {
+	f := newCalendarFixture(t)
+
+	workspaceID := uuid.New()
+	f.feeds.On("FindByTokenHash", mock.Anything, token.Hash("secret")).
+		Return(&model.CalendarFeed{WorkspaceID: workspaceID, UserID: "user-1"}, nil)
+
+	updated := time.Date(2026, time.October, 1, 8, 30, 0, 0, time.UTC)
+	due := time.Date(2026, time.October, 20, 15, 0, 0, 0, time.UTC)
+	oneOff := model.TodoItem{
+		ID:          uuid.New(),
+		Title:       "Call the bank, then the plumber; today",
+		Description: "Ask about:\nfees",
+		Status:      model.StatusInProgress,
+		Priority:    model.PriorityUrgent,
+		DueAt:       &due,
+		CreatedAt:   updated,
+		UpdatedAt:   updated,
+	}
+	seriesID := uuid.New()
+	seriesStart := time.Date(2026, time.September, 7, 7, 0, 0, 0, time.UTC)
+	earlier := time.Date(2026, time.October, 12, 7, 0, 0, 0, time.UTC)
+	latest := time.Date(2026, time.October, 19, 7, 0, 0, 0, time.UTC)
+	weekly := func(id uuid.UUID, dueAt *time.Time, title string) model.TodoItem {
+		return model.TodoItem{
+			ID:            id,
+			Title:         title,
+			Status:        model.StatusOpen,
+			Priority:      model.PriorityMedium,
+			DueAt:         dueAt,
+			Recurrence:    "FREQ=WEEKLY;UNTIL=20261231T090000",
+			Timezone:      "Europe/Berlin",
+			SeriesID:      &seriesID,
+			SeriesStartAt: &seriesStart,
+			CreatedAt:     updated,
+			UpdatedAt:     updated,
+		}
+	}
+	latestID := uuid.New()
+	items := []model.TodoItem{
+		weekly(uuid.New(), &earlier, "Old title"),
+		weekly(latestID, &latest, "Water the plants"),
+		oneOff,
+	}
+	f.items.On("FindDueByOwner", inWorkspace(workspaceID), "user-1", f.clock.Now().Add(-30*24*time.Hour), 1000).Return(items, nil)
+
+	offset := int64(15 * 60)
+	remindAt := time.Date(2026, time.October, 19, 6, 0, 0, 0, time.UTC)
+	f.reminders.On("FindForUser", inWorkspace(workspaceID), "user-1", []uuid.UUID{items[0].ID, latestID, oneOff.ID}).Return([]model.Reminder{
+		{TodoItemID: oneOff.ID, OffsetSeconds: &offset},
+		{TodoItemID: latestID, RemindAt: &remindAt},
+	}, nil)
+
+	body, err := f.service.RenderFeed(context.Background(), "secret")
+	require.NoError(t, err)
+
+	out := string(body)
+	for _, line := range strings.Split(out, "\r\n") {
+		require.LessOrEqual(t, len(line), 75)
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	require.True(t, strings.HasPrefix(unfolded, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	require.True(t, strings.HasSuffix(unfolded, "END:VCALENDAR\r\n"))
+	require.Contains(t, unfolded, "BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n")
+	require.Equal(t, 1, strings.Count(unfolded, "BEGIN:VEVENT"))
+	require.Contains(t, unfolded, strings.Join([]string{
+		"BEGIN:VEVENT",
+		"UID:" + seriesID.String(),
+		"DTSTAMP:20261001T083000Z",
+		"LAST-MODIFIED:20261001T083000Z",
+		"SUMMARY:Water the plants",
+		"DTSTART;TZID=Europe/Berlin:20260907T090000",
+		"RRULE:FREQ=WEEKLY;UNTIL=20261231T080000Z",
+		"PRIORITY:5",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Water the plants",
+		"TRIGGER;VALUE=DATE-TIME:20261019T060000Z",
+		"END:VALARM",
+		"END:VEVENT",
+	}, "\r\n"))
+	require.Contains(t, unfolded, strings.Join([]string{
+		"BEGIN:VTODO",
+		"UID:" + oneOff.ID.String(),
+		"DTSTAMP:20261001T083000Z",
+		"CREATED:20261001T083000Z",
+		"LAST-MODIFIED:20261001T083000Z",
+		`SUMMARY:Call the bank\, then the plumber\; today`,
+		`DESCRIPTION:Ask about:\nfees`,
+		"DUE:20261020T150000Z",
+		"STATUS:IN-PROCESS",
+		"PRIORITY:1",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		`DESCRIPTION:Call the bank\, then the plumber\; today`,
+		"TRIGGER;RELATED=END:-PT15M",
+		"END:VALARM",
+		"END:VTODO",
+	}, "\r\n"))
+	require.NotContains(t, unfolded, "Old title")
+
+	again, err := f.service.RenderFeed(context.Background(), "secret")
+	require.NoError(t, err)
+	require.Equal(t, body, again)
+}
+
+func TestCalendarService_RenderFeed_Empty(t *testing.T) {
+	f := newCalendarFixture(t)
+
+	workspaceID := uuid.New()
+	f.feeds.On("FindByTokenHash", mock.Anything, token.Hash("secret")).
+		Return(&model.CalendarFeed{WorkspaceID: workspaceID, UserID: "user-1"}, nil)
+	f.items.On("FindDueByOwner", inWorkspace(workspaceID), "user-1", mock.Anything, mock.Anything).Return([]model.TodoItem{}, nil)
+	f.reminders.On("FindForUser", inWorkspace(workspaceID), "user-1", []uuid.UUID{}).Return(nil, nil)
+
+	body, err := f.service.RenderFeed(context.Background(), "secret")
+	require.NoError(t, err)
+	require.Equal(t, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//lumoshiveacademy//todolist//EN\r\nCALSCALE:GREGORIAN\r\nX-WR-CALNAME:Todos\r\nEND:VCALENDAR\r\n", string(body))
+}
@@ -0,0 +1,342 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/markdown"
+	"github.com/lumoshiveacademy/todolist/package/mention"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidCommentParent indicates a reply to a comment that is not a
+// top-level comment on the same list or item.
+var ErrInvalidCommentParent = errors.New("comment parent must be a top-level comment on the same list or item")
+
+// maxMentions bounds how many users one comment can notify.
+const maxMentions = 20
+
+// mentionExcerptLength is how much of a comment a mention notification quotes.
+const mentionExcerptLength = 200
+
+// CommentService defines business operations for comments on todo lists and
+// items. Anyone who can read a list can read its comments, and anyone who can
+// write to the workspace's lists can comment. Only the author can edit a
+// comment; the author or an admin can delete it.
+type CommentService interface {
+	CreateComment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, req model.CreateCommentRequest) (model.CommentResponse, error)
+	ListComments(ctx context.Context, filter model.CommentFilter) (model.CommentPage, error)
+	UpdateComment(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateCommentRequest) (model.CommentResponse, error)
+	DeleteComment(ctx context.Context, todoListID, id uuid.UUID) error
+}
+
+type commentService struct {
+	comments      repository.CommentRepository
+	todoLists     repository.TodoListRepository
+	items         repository.TodoItemRepository
+	users         repository.UserRepository
+	notifications repository.NotificationRepository
+	transactor    repository.Transactor
+	clock         clock.Clock
+	logger        *zap.Logger
+}
+
+// NewCommentService constructs a CommentService implementation. Users
+// @mentioned in a comment get an inbox notification.
+func NewCommentService(
+	comments repository.CommentRepository,
+	todoLists repository.TodoListRepository,
+	items repository.TodoItemRepository,
+	users repository.UserRepository,
+	notifications repository.NotificationRepository,
+	transactor repository.Transactor,
+	clock clock.Clock,
+	logger *zap.Logger,
+) CommentService {
+	return &commentService{
+		comments:      comments,
+		todoLists:     todoLists,
+		items:         items,
+		users:         users,
+		notifications: notifications,
+		transactor:    transactor,
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+// CreateComment posts a comment on the list or, when todoItemID is set, on
+// that item, and notifies the users it mentions in the same transaction.
+func (s *commentService) CreateComment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, req model.CreateCommentRequest) (model.CommentResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.CommentResponse{}, ErrForbidden
+	}
+	todoList, err := s.findList(ctx, todoListID)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+	if todoItemID != nil {
+		if err := s.findItem(ctx, todoListID, *todoItemID); err != nil {
+			return model.CommentResponse{}, err
+		}
+	}
+	if req.ParentID != nil {
+		if err := s.checkParent(ctx, todoListID, todoItemID, *req.ParentID); err != nil {
+			return model.CommentResponse{}, err
+		}
+	}
+
+	comment := &model.Comment{
+		TodoListID: todoListID,
+		TodoItemID: todoItemID,
+		ParentID:   req.ParentID,
+		AuthorID:   claims.Subject,
+		Body:       req.Body,
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.comments.Create(ctx, comment); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, comment, todoList, mention.Parse(comment.Body))
+	})
+	if err != nil {
+		s.logger.Error("create comment failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return model.CommentResponse{}, fmt.Errorf("create comment: %w", err)
+	}
+	s.logger.Info("comment created", zap.String("id", comment.ID.String()))
+	return renderComment(*comment), nil
+}
+
+// ListComments returns one page of threads on a list or item with all their
+// replies.
+func (s *commentService) ListComments(ctx context.Context, filter model.CommentFilter) (model.CommentPage, error) {
+	if _, err := s.findList(ctx, filter.TodoListID); err != nil {
+		return model.CommentPage{}, err
+	}
+	if filter.TodoItemID != nil {
+		if err := s.findItem(ctx, filter.TodoListID, *filter.TodoItemID); err != nil {
+			return model.CommentPage{}, err
+		}
+	}
+
+	threads, total, err := s.comments.FindThreads(ctx, filter)
+	if err != nil {
+		s.logger.Error("list comments failed", zap.String("todo_list_id", filter.TodoListID.String()), zap.Error(err))
+		return model.CommentPage{}, fmt.Errorf("list comments: %w", err)
+	}
+	ids := make([]uuid.UUID, len(threads))
+	for i, thread := range threads {
+		ids[i] = thread.ID
+	}
+	replies, err := s.comments.FindReplies(ctx, ids)
+	if err != nil {
+		s.logger.Error("list replies failed", zap.String("todo_list_id", filter.TodoListID.String()), zap.Error(err))
+		return model.CommentPage{}, fmt.Errorf("list comments: %w", err)
+	}
+	repliesByParent := make(map[uuid.UUID][]model.CommentResponse)
+	for _, reply := range replies {
+		repliesByParent[*reply.ParentID] = append(repliesByParent[*reply.ParentID], renderComment(reply))
+	}
+
+	items := make([]model.CommentResponse, len(threads))
+	for i, thread := range threads {
+		items[i] = renderComment(thread)
+		items[i].Replies = repliesByParent[thread.ID]
+	}
+	return model.CommentPage{
+		Items:  items,
+		Total:  total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}, nil
+}
+
+// UpdateComment replaces the comment's body. Only users mentioned for the
+// first time are notified.
+func (s *commentService) UpdateComment(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateCommentRequest) (model.CommentResponse, error) {
+	todoList, err := s.findList(ctx, todoListID)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+	comment, err := s.findComment(ctx, todoListID, id)
+	if err != nil {
+		return model.CommentResponse{}, err
+	}
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.Subject != comment.AuthorID {
+		s.logger.Warn("comment edit denied", zap.String("id", id.String()))
+		return model.CommentResponse{}, ErrForbidden
+	}
+
+	previous := mention.Parse(comment.Body)
+	var added []string
+	for _, name := range mention.Parse(req.Body) {
+		if !slices.Contains(previous, name) {
+			added = append(added, name)
+		}
+	}
+	now := s.clock.Now()
+	comment.Body = req.Body
+	comment.EditedAt = &now
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.comments.Update(ctx, comment); err != nil {
+			return err
+		}
+		return s.notifyMentions(ctx, comment, todoList, added)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return model.CommentResponse{}, err
+		}
+		s.logger.Error("update comment failed", zap.String("id", id.String()), zap.Error(err))
+		return model.CommentResponse{}, fmt.Errorf("update comment: %w", err)
+	}
+	s.logger.Info("comment updated", zap.String("id", id.String()))
+	return renderComment(*comment), nil
+}
+
+// DeleteComment blanks the comment and marks it deleted, keeping its replies
+// in place.
+func (s *commentService) DeleteComment(ctx context.Context, todoListID, id uuid.UUID) error {
+	if _, err := s.findList(ctx, todoListID); err != nil {
+		return err
+	}
+	comment, err := s.findComment(ctx, todoListID, id)
+	if err != nil {
+		return err
+	}
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || (claims.Subject != comment.AuthorID && !claims.IsAdmin()) {
+		s.logger.Warn("comment delete denied", zap.String("id", id.String()))
+		return ErrForbidden
+	}
+
+	now := s.clock.Now()
+	comment.Body = ""
+	comment.DeletedAt = &now
+	if err := s.comments.Update(ctx, comment); err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return err
+		}
+		s.logger.Error("delete comment failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete comment: %w", err)
+	}
+	s.logger.Info("comment deleted", zap.String("id", id.String()))
+	return nil
+}
+
+// notifyMentions adds an inbox entry for every member of the list's workspace
+// named in usernames other than the comment's author. Unknown usernames and
+// users outside the workspace are ignored.
+func (s *commentService) notifyMentions(ctx context.Context, comment *model.Comment, todoList *model.TodoList, usernames []string) error {
+	if len(usernames) > maxMentions {
+		usernames = usernames[:maxMentions]
+	}
+	if len(usernames) == 0 {
+		return nil
+	}
+	users, err := s.users.FindByUsernames(ctx, todoList.WorkspaceID, usernames)
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		if user.ID.String() == comment.AuthorID {
+			continue
+		}
+		if err := s.notifications.Create(ctx, &model.Notification{
+			UserID:     user.ID.String(),
+			TodoItemID: comment.TodoItemID,
+			Title:      fmt.Sprintf("You were mentioned in a comment on %q", todoList.Title),
+			Body:       excerpt(comment.Body, mentionExcerptLength),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkParent makes sure a reply goes to a top-level comment on the same
+// list or item.
+func (s *commentService) checkParent(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, parentID uuid.UUID) error {
+	parent, err := s.comments.FindByID(ctx, todoListID, parentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return ErrInvalidCommentParent
+		}
+		s.logger.Error("get parent comment failed", zap.String("id", parentID.String()), zap.Error(err))
+		return fmt.Errorf("get parent comment: %w", err)
+	}
+	sameTarget := (parent.TodoItemID == nil && todoItemID == nil) ||
+		(parent.TodoItemID != nil && todoItemID != nil && *parent.TodoItemID == *todoItemID)
+	if parent.ParentID != nil || !sameTarget {
+		return ErrInvalidCommentParent
+	}
+	return nil
+}
+
+func (s *commentService) findList(ctx context.Context, todoListID uuid.UUID) (*model.TodoList, error) {
+	todoList, err := s.todoLists.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get todo list failed", zap.String("id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo list: %w", err)
+	}
+	return todoList, nil
+}
+
+func (s *commentService) findItem(ctx context.Context, todoListID, id uuid.UUID) error {
+	if _, err := s.items.FindByID(ctx, todoListID, id); err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return err
+		}
+		s.logger.Error("get todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("get todo item: %w", err)
+	}
+	return nil
+}
+
+// findComment loads a comment that has not been deleted.
+func (s *commentService) findComment(ctx context.Context, todoListID, id uuid.UUID) (*model.Comment, error) {
+	comment, err := s.comments.FindByID(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrCommentNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get comment failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get comment: %w", err)
+	}
+	if comment.DeletedAt != nil {
+		return nil, repository.ErrCommentNotFound
+	}
+	return comment, nil
+}
+
+// renderComment converts a comment into its response with the body rendered to
+// sanitized HTML.
+func renderComment(comment model.Comment) model.CommentResponse {
+	res := comment.ToResponse()
+	if comment.DeletedAt == nil {
+		res.BodyHTML = markdown.Render(comment.Body)
+	}
+	return res
+}
+
+// excerpt shortens s to at most n runes, marking the cut with an ellipsis.
+func excerpt(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:n-1]) + "…"
+}
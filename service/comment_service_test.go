@@ -0,0 +1,217 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type commentFixture struct {
+	comments      *mocks.CommentRepositoryMock
+	todoLists     *mocks.TodoListRepositoryMock
+	items         *mocks.TodoItemRepositoryMock
+	users         *mocks.UserRepositoryMock
+	notifications *mocks.NotificationRepositoryMock
+	clock         *mocks.FakeClock
+	svc           service.CommentService
+}
+
+func newCommentFixture(t *testing.T) *commentFixture {
+	f := &commentFixture{
+		comments:      new(mocks.CommentRepositoryMock),
+		todoLists:     new(mocks.TodoListRepositoryMock),
+		items:         new(mocks.TodoItemRepositoryMock),
+		users:         new(mocks.UserRepositoryMock),
+		notifications: new(mocks.NotificationRepositoryMock),
+		clock:         mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewCommentService(f.comments, f.todoLists, f.items, f.users, f.notifications, mocks.FakeTransactor{}, f.clock, zaptest.NewLogger(t))
+	return f
+}
+
+func username(name string) *string {
+	return &name
+}
+
+func TestCommentService_CreateComment_NotifiesMentionedUsers(t *testing.T) {
+	f := newCommentFixture(t)
+	author, bob := uuid.New(), uuid.New()
+	workspaceID := uuid.New()
+	ctx := tenant.WithWorkspaceID(claimsContext(author.String()), workspaceID)
+	todoListID := uuid.New()
+	body := "@bob and @alice, see `@carol`. Also @nobody."
+
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, WorkspaceID: workspaceID, Title: "Groceries"}, nil)
+	f.comments.On("Create", mock.Anything, mock.AnythingOfType("*model.Comment")).Return(nil)
+	f.users.On("FindByUsernames", mock.Anything, workspaceID, []string{"bob", "alice", "nobody"}).Return([]model.User{
+		{ID: bob, Username: username("bob")},
+		{ID: author, Username: username("alice")},
+	}, nil)
+	var notified []*model.Notification
+	f.notifications.On("Create", mock.Anything, mock.AnythingOfType("*model.Notification")).Return(nil).Run(func(args mock.Arguments) {
+		notified = append(notified, args.Get(1).(*model.Notification))
+	})
+
+	res, err := f.svc.CreateComment(ctx, todoListID, nil, model.CreateCommentRequest{Body: body})
+	require.NoError(t, err)
+	require.Equal(t, author.String(), res.AuthorID)
+	require.Equal(t, body, res.Body)
+	require.Equal(t, "<p>@bob and @alice, see <code>@carol</code>. Also @nobody.</p>", res.BodyHTML)
+
+	// The author mentioning themselves is not notified.
+	require.Len(t, notified, 1)
+	require.Equal(t, bob.String(), notified[0].UserID)
+	require.Equal(t, `You were mentioned in a comment on "Groceries"`, notified[0].Title)
+	require.Equal(t, body, notified[0].Body)
+}
+
+func TestCommentService_CreateComment_ReplyMustTargetTopLevelComment(t *testing.T) {
+	todoListID, itemID, rootID := uuid.New(), uuid.New(), uuid.New()
+	tests := []struct {
+		name   string
+		itemID *uuid.UUID
+		parent *model.Comment
+		err    error
+	}{
+		{"missing parent", nil, nil, repository.ErrCommentNotFound},
+		{"reply to a reply", nil, &model.Comment{ID: uuid.New(), TodoListID: todoListID, ParentID: &rootID}, nil},
+		{"parent on an item", nil, &model.Comment{ID: uuid.New(), TodoListID: todoListID, TodoItemID: &itemID}, nil},
+		{"parent on the list", &itemID, &model.Comment{ID: uuid.New(), TodoListID: todoListID}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCommentFixture(t)
+			parentID := uuid.New()
+			f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+			f.items.On("FindByID", mock.Anything, todoListID, itemID).Return(&model.TodoItem{ID: itemID}, nil)
+			f.comments.On("FindByID", mock.Anything, todoListID, parentID).Return(tt.parent, tt.err)
+
+			_, err := f.svc.CreateComment(claimsContext("user-1"), todoListID, tt.itemID, model.CreateCommentRequest{
+				Body:     "reply",
+				ParentID: &parentID,
+			})
+			require.ErrorIs(t, err, service.ErrInvalidCommentParent)
+			f.comments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCommentService_ListComments_NestsReplies(t *testing.T) {
+	f := newCommentFixture(t)
+	todoListID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	deletedAt := f.clock.Now()
+	filter := model.CommentFilter{TodoListID: todoListID, Limit: 2, Offset: 0}
+
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+	f.comments.On("FindThreads", mock.Anything, filter).Return([]model.Comment{
+		{ID: first, TodoListID: todoListID, Body: "**first**"},
+		{ID: second, TodoListID: todoListID, DeletedAt: &deletedAt},
+	}, int64(5), nil)
+	f.comments.On("FindReplies", mock.Anything, []uuid.UUID{first, second}).Return([]model.Comment{
+		{ID: uuid.New(), ParentID: &second, Body: "reply one"},
+		{ID: uuid.New(), ParentID: &second, Body: "<script>reply two</script>"},
+	}, nil)
+
+	page, err := f.svc.ListComments(context.Background(), filter)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), page.Total)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, "<p><strong>first</strong></p>", page.Items[0].BodyHTML)
+	require.Empty(t, page.Items[0].Replies)
+	require.True(t, page.Items[1].Deleted)
+	require.Empty(t, page.Items[1].BodyHTML)
+	require.Len(t, page.Items[1].Replies, 2)
+	require.Equal(t, "<p>&lt;script&gt;reply two&lt;/script&gt;</p>", page.Items[1].Replies[1].BodyHTML)
+}
+
+func TestCommentService_UpdateComment_OnlyNotifiesNewMentions(t *testing.T) {
+	f := newCommentFixture(t)
+	todoListID, id, carol := uuid.New(), uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, Title: "Groceries"}, nil)
+	f.comments.On("FindByID", mock.Anything, todoListID, id).
+		Return(&model.Comment{ID: id, TodoListID: todoListID, AuthorID: "author", Body: "cc @bob"}, nil)
+	f.comments.On("Update", mock.Anything, mock.AnythingOfType("*model.Comment")).Return(nil)
+	f.users.On("FindByUsernames", mock.Anything, uuid.Nil, []string{"carol"}).Return([]model.User{{ID: carol}}, nil)
+	f.notifications.On("Create", mock.Anything, mock.MatchedBy(func(n *model.Notification) bool {
+		return n.UserID == carol.String()
+	})).Return(nil).Once()
+
+	res, err := f.svc.UpdateComment(claimsContext("author"), todoListID, id, model.UpdateCommentRequest{Body: "cc @bob @carol"})
+	require.NoError(t, err)
+	require.Equal(t, "cc @bob @carol", res.Body)
+	require.Equal(t, f.clock.Now(), *res.EditedAt)
+	f.notifications.AssertExpectations(t)
+}
+
+func TestCommentService_UpdateComment_AuthorOnly(t *testing.T) {
+	f := newCommentFixture(t)
+	todoListID, id := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+	f.comments.On("FindByID", mock.Anything, todoListID, id).
+		Return(&model.Comment{ID: id, TodoListID: todoListID, AuthorID: "author", Body: "original"}, nil)
+
+	_, err := f.svc.UpdateComment(claimsContext("admin", auth.RoleAdmin), todoListID, id, model.UpdateCommentRequest{Body: "rewritten"})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.comments.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCommentService_DeleteComment(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+	}{
+		{"author", claimsContext("author"), nil},
+		{"admin", claimsContext("admin", auth.RoleAdmin), nil},
+		{"someone else", claimsContext("member"), service.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCommentFixture(t)
+			todoListID, id := uuid.New(), uuid.New()
+			f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+			f.comments.On("FindByID", mock.Anything, todoListID, id).
+				Return(&model.Comment{ID: id, TodoListID: todoListID, AuthorID: "author", Body: "bye"}, nil)
+			var updated *model.Comment
+			f.comments.On("Update", mock.Anything, mock.AnythingOfType("*model.Comment")).Return(nil).Run(func(args mock.Arguments) {
+				updated = args.Get(1).(*model.Comment)
+			}).Maybe()
+
+			err := f.svc.DeleteComment(tt.ctx, todoListID, id)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				require.Nil(t, updated)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, updated.Body)
+			require.Equal(t, f.clock.Now(), *updated.DeletedAt)
+		})
+	}
+}
+
+func TestCommentService_DeleteComment_AlreadyDeleted(t *testing.T) {
+	f := newCommentFixture(t)
+	todoListID, id := uuid.New(), uuid.New()
+	deletedAt := f.clock.Now()
+	f.todoLists.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID}, nil)
+	f.comments.On("FindByID", mock.Anything, todoListID, id).
+		Return(&model.Comment{ID: id, TodoListID: todoListID, AuthorID: "author", DeletedAt: &deletedAt}, nil)
+
+	err := f.svc.DeleteComment(claimsContext("author"), todoListID, id)
+	require.ErrorIs(t, err, repository.ErrCommentNotFound)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// ListArchiver archives finished todo lists in the background for users
+// who set an auto-archive policy. A list qualifies once it has items, none
+// of them pending, and neither it nor its items have changed for the
+// policy's number of days. Unarchiving a list touches it, so it is not
+// archived again until another period has passed.
+type ListArchiver struct {
+	todoLists  repository.TodoListRepository
+	auditLogs  repository.AuditLogRepository
+	transactor repository.Transactor
+	clock      clock.Clock
+	batchSize  int
+	logger     *zap.Logger
+}
+
+// NewListArchiver constructs a ListArchiver that archives up to batchSize
+// lists per run.
+func NewListArchiver(
+	todoLists repository.TodoListRepository,
+	auditLogs repository.AuditLogRepository,
+	transactor repository.Transactor,
+	clock clock.Clock,
+	batchSize int,
+	logger *zap.Logger,
+) *ListArchiver {
+	return &ListArchiver{
+		todoLists:  todoLists,
+		auditLogs:  auditLogs,
+		transactor: transactor,
+		clock:      clock,
+		batchSize:  batchSize,
+		logger:     logger,
+	}
+}
+
+// Start archives every interval until ctx is cancelled. A run that filled
+// its batch is followed immediately by another.
+func (a *ListArchiver) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			archived, err := a.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					a.logger.Warn("auto-archive todo lists failed", zap.Error(err))
+				}
+				break
+			}
+			if archived < a.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce archives one batch of qualifying lists and returns its size. Each
+// list is archived and audited in its own transaction, within its
+// workspace.
+func (a *ListArchiver) RunOnce(ctx context.Context) (int, error) {
+	now := a.clock.Now()
+	todoLists, err := a.todoLists.FindAutoArchivable(ctx, now, a.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("run list archiver: %w", err)
+	}
+	for i := range todoLists {
+		before := todoLists[i]
+		todoList := &todoLists[i]
+		todoList.ArchivedAt = &now
+		listCtx := tenant.WithWorkspaceID(ctx, todoList.WorkspaceID)
+		if err := archiveTodoList(listCtx, a.todoLists, a.auditLogs, a.transactor, &before, todoList); err != nil {
+			return i, fmt.Errorf("auto-archive todo list %s: %w", todoList.ID, err)
+		}
+	}
+	if len(todoLists) > 0 {
+		a.logger.Info("todo lists auto-archived", zap.Int("count", len(todoLists)))
+	}
+	return len(todoLists), nil
+}
@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestListArchiver_RunOnce_ArchivesEachListInItsWorkspace(t *testing.T) {
+	todoLists := new(mocks.TodoListRepositoryMock)
+	auditLogs := new(mocks.AuditLogRepositoryMock)
+	clock := mocks.NewFakeClock(time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC))
+	archiver := service.NewListArchiver(todoLists, auditLogs, mocks.FakeTransactor{}, clock, 10, zaptest.NewLogger(t))
+
+	first, second := uuid.New(), uuid.New()
+	firstWorkspace, secondWorkspace := uuid.New(), uuid.New()
+	todoLists.On("FindAutoArchivable", mock.Anything, clock.Now(), 10).Return([]model.TodoList{
+		{ID: first, WorkspaceID: firstWorkspace, Title: "Move house"},
+		{ID: second, WorkspaceID: secondWorkspace, Title: "Q3 launch"},
+	}, nil)
+	for id, workspaceID := range map[uuid.UUID]uuid.UUID{first: firstWorkspace, second: secondWorkspace} {
+		todoLists.On("UpdateArchived", inWorkspace(workspaceID), mock.MatchedBy(func(todoList *model.TodoList) bool {
+			return todoList.ID == id && todoList.ArchivedAt != nil && todoList.ArchivedAt.Equal(clock.Now())
+		})).Return(nil).Once()
+		auditLogs.On("Create", inWorkspace(workspaceID), mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+			return auditLog.EntityID == id && auditLog.ActorID == "" && auditLog.Changes["archived_at"].Before == nil
+		})).Return(nil).Once()
+	}
+
+	archived, err := archiver.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, archived)
+	todoLists.AssertExpectations(t)
+	auditLogs.AssertExpectations(t)
+}
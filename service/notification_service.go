@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// NotificationService defines operations on the caller's in-app inbox.
+type NotificationService interface {
+	ListNotifications(ctx context.Context, unreadOnly bool) ([]model.NotificationResponse, error)
+	MarkRead(ctx context.Context, id uuid.UUID) error
+}
+
+type notificationService struct {
+	notifications repository.NotificationRepository
+	clock         clock.Clock
+	logger        *zap.Logger
+}
+
+// NewNotificationService constructs a NotificationService implementation.
+func NewNotificationService(notifications repository.NotificationRepository, clock clock.Clock, logger *zap.Logger) NotificationService {
+	return &notificationService{
+		notifications: notifications,
+		clock:         clock,
+		logger:        logger,
+	}
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, unreadOnly bool) ([]model.NotificationResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	notifications, err := s.notifications.FindByUserID(ctx, claims.Subject, unreadOnly)
+	if err != nil {
+		s.logger.Error("list notifications failed", zap.Error(err))
+		return nil, fmt.Errorf("list notifications: %w", err)
+	}
+	responses := make([]model.NotificationResponse, len(notifications))
+	for i, notification := range notifications {
+		responses[i] = notification.ToResponse()
+	}
+	return responses, nil
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, id uuid.UUID) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	if err := s.notifications.MarkRead(ctx, claims.Subject, id, s.clock.Now()); err != nil {
+		if errors.Is(err, repository.ErrNotificationNotFound) {
+			return err
+		}
+		s.logger.Error("mark notification read failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("mark notification read: %w", err)
+	}
+	return nil
+}
+
+// inboxNotifier delivers reminders to the recipient's in-app inbox.
+type inboxNotifier struct {
+	notifications repository.NotificationRepository
+}
+
+// NewInboxNotifier returns a notify.Notifier that stores reminders as inbox
+// notifications. It expects the message's workspace in the context.
+func NewInboxNotifier(notifications repository.NotificationRepository) notify.Notifier {
+	return &inboxNotifier{notifications: notifications}
+}
+
+func (n *inboxNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	var body string
+	if msg.DueAt != nil {
+		body = "Due " + msg.DueAt.UTC().Format(time.RFC1123)
+	}
+	todoItemID := msg.TodoItemID
+	return n.notifications.Create(ctx, &model.Notification{
+		UserID:     msg.UserID,
+		TodoItemID: &todoItemID,
+		Title:      truncate("Reminder: "+strings.Join(strings.Fields(msg.Title), " "), 255),
+		Body:       body,
+	})
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,52 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestNotificationService_MarkRead_UsesCallerAndClock(t *testing.T) {
+	notifications := new(mocks.NotificationRepositoryMock)
+	clock := mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	svc := service.NewNotificationService(notifications, clock, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	notifications.On("MarkRead", mock.Anything, "user-1", id, clock.Now()).Return(nil)
+
+	require.NoError(t, svc.MarkRead(claimsContext("user-1", auth.RoleMember), id))
+	notifications.AssertExpectations(t)
+
+	require.ErrorIs(t, svc.MarkRead(context.Background(), id), service.ErrForbidden)
+}
+
+func TestInboxNotifier_StoresNotificationForRecipient(t *testing.T) {
+	notifications := new(mocks.NotificationRepositoryMock)
+	notifier := service.NewInboxNotifier(notifications)
+
+	itemID := uuid.New()
+	notifications.On("Create", mock.Anything, mock.MatchedBy(func(notification *model.Notification) bool {
+		return notification.UserID == "user-1" && *notification.TodoItemID == itemID &&
+			len(notification.Title) <= 255 && utf8.ValidString(notification.Title) && strings.HasPrefix(notification.Title, "Reminder: ")
+	})).Return(nil)
+
+	err := notifier.Notify(context.Background(), notify.Message{
+		UserID:     "user-1",
+		TodoItemID: itemID,
+		Title:      strings.Repeat("é", 200),
+	})
+	require.NoError(t, err)
+	notifications.AssertExpectations(t)
+}
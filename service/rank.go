@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/rank"
+	"github.com/lumoshiveacademy/todolist/repository"
+)
+
+// errNoRoom indicates that the neighbours of a placement leave no room for a
+// rank between them: one is unranked, or two share a rank.
+var errNoRoom = errors.New("no room between ranks")
+
+// siblings is a manually ordered collection: the items of one list, or the
+// lists of a workspace.
+type siblings interface {
+	rankOf(ctx context.Context, id uuid.UUID) (string, error)
+	lastRank(ctx context.Context, excludeID uuid.UUID) (string, error)
+	adjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error)
+	rebalance(ctx context.Context) error
+}
+
+// placeRank returns the rank that puts movedID directly after afterID,
+// directly before beforeID, or last when neither is set. When the
+// neighbours leave no room the collection is rebalanced and the placement
+// retried once. Callers run it in the transaction that stores the rank.
+func placeRank(ctx context.Context, set siblings, movedID uuid.UUID, afterID, beforeID *uuid.UUID) (string, error) {
+	r, err := tryPlaceRank(ctx, set, movedID, afterID, beforeID)
+	if !errors.Is(err, errNoRoom) {
+		return r, err
+	}
+	if err := set.rebalance(ctx); err != nil {
+		return "", err
+	}
+	return tryPlaceRank(ctx, set, movedID, afterID, beforeID)
+}
+
+func tryPlaceRank(ctx context.Context, set siblings, movedID uuid.UUID, afterID, beforeID *uuid.UUID) (string, error) {
+	var prev, next string
+	var err error
+	switch {
+	case afterID != nil:
+		if prev, err = anchorRank(ctx, set, *afterID); err != nil {
+			return "", err
+		}
+		next, err = set.adjacentRank(ctx, prev, []uuid.UUID{*afterID, movedID}, true)
+	case beforeID != nil:
+		if next, err = anchorRank(ctx, set, *beforeID); err != nil {
+			return "", err
+		}
+		// An unranked predecessor sorts first anyway, so "" is a fine lower bound.
+		prev, err = set.adjacentRank(ctx, next, []uuid.UUID{*beforeID, movedID}, false)
+	default:
+		prev, err = set.lastRank(ctx, movedID)
+	}
+	if err != nil {
+		return "", err
+	}
+	r, err := rank.Between(prev, next)
+	if err != nil {
+		return "", errNoRoom
+	}
+	return r, nil
+}
+
+// anchorRank returns the rank of the record a placement is relative to. An
+// unranked anchor has no defined position to place next to.
+func anchorRank(ctx context.Context, set siblings, id uuid.UUID) (string, error) {
+	r, err := set.rankOf(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if r == "" {
+		return "", errNoRoom
+	}
+	return r, nil
+}
+
+// itemSiblings are the items of one todo list.
+type itemSiblings struct {
+	items      repository.TodoItemRepository
+	todoListID uuid.UUID
+}
+
+func (s itemSiblings) rankOf(ctx context.Context, id uuid.UUID) (string, error) {
+	item, err := s.items.FindByID(ctx, s.todoListID, id)
+	if err != nil {
+		return "", err
+	}
+	return item.Rank, nil
+}
+
+func (s itemSiblings) lastRank(ctx context.Context, excludeID uuid.UUID) (string, error) {
+	return s.items.LastRank(ctx, s.todoListID, excludeID)
+}
+
+func (s itemSiblings) adjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	return s.items.AdjacentRank(ctx, s.todoListID, rank, excludeIDs, after)
+}
+
+// rebalance gives the list's items short, evenly spaced ranks in their
+// current order.
+func (s itemSiblings) rebalance(ctx context.Context) error {
+	items, err := s.items.FindForRebalance(ctx, s.todoListID)
+	if err != nil {
+		return err
+	}
+	for i, r := range rank.Spread(len(items)) {
+		if items[i].Rank == r {
+			continue
+		}
+		if err := s.items.SetRank(ctx, items[i].ID, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSiblings are the todo lists of the current workspace.
+type listSiblings struct {
+	todoLists repository.TodoListRepository
+}
+
+func (s listSiblings) rankOf(ctx context.Context, id uuid.UUID) (string, error) {
+	todoList, err := s.todoLists.FindByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return todoList.Rank, nil
+}
+
+func (s listSiblings) lastRank(ctx context.Context, excludeID uuid.UUID) (string, error) {
+	return s.todoLists.LastRank(ctx, excludeID)
+}
+
+func (s listSiblings) adjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	return s.todoLists.AdjacentRank(ctx, rank, excludeIDs, after)
+}
+
+// rebalance gives the workspace's lists short, evenly spaced ranks in their
+// current order.
+func (s listSiblings) rebalance(ctx context.Context) error {
+	todoLists, err := s.todoLists.FindForRebalance(ctx)
+	if err != nil {
+		return err
+	}
+	for i, r := range rank.Spread(len(todoLists)) {
+		if todoLists[i].Rank == r {
+			continue
+		}
+		if err := s.todoLists.SetRank(ctx, todoLists[i].ID, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
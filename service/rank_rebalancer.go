@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// RankRebalancer rewrites the ranks of todo items and lists in the
+// background once they grow too long, and ranks rows created before manual
+// ordering existed. Each list's items, and each workspace's lists, are
+// rebalanced in their own transaction with their rows locked, so it is safe
+// to run on several replicas next to ongoing moves.
+type RankRebalancer struct {
+	items      repository.TodoItemRepository
+	todoLists  repository.TodoListRepository
+	transactor repository.Transactor
+	maxLength  int
+	batchSize  int
+	logger     *zap.Logger
+}
+
+// NewRankRebalancer constructs a RankRebalancer that rewrites ranks longer
+// than maxLength, handling up to batchSize lists and workspaces per run.
+func NewRankRebalancer(
+	items repository.TodoItemRepository,
+	todoLists repository.TodoListRepository,
+	transactor repository.Transactor,
+	maxLength int,
+	batchSize int,
+	logger *zap.Logger,
+) *RankRebalancer {
+	return &RankRebalancer{
+		items:      items,
+		todoLists:  todoLists,
+		transactor: transactor,
+		maxLength:  maxLength,
+		batchSize:  batchSize,
+		logger:     logger,
+	}
+}
+
+// Start rebalances every interval until ctx is cancelled. A run that filled
+// its batch is followed immediately by another.
+func (r *RankRebalancer) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			rebalanced, err := r.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					r.logger.Warn("rebalance ranks failed", zap.Error(err))
+				}
+				break
+			}
+			if rebalanced < r.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce rebalances one batch of lists whose items need it and one batch of
+// workspaces whose lists need it. It returns the size of the larger batch.
+func (r *RankRebalancer) RunOnce(ctx context.Context) (int, error) {
+	lists, err := r.items.FindUnbalanced(ctx, r.maxLength, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("run rank rebalancer: %w", err)
+	}
+	for _, list := range lists {
+		set := itemSiblings{items: r.items, todoListID: list.TodoListID}
+		if err := r.rebalance(ctx, list.WorkspaceID, set); err != nil {
+			return 0, fmt.Errorf("rebalance todo list %s: %w", list.TodoListID, err)
+		}
+	}
+
+	workspaceIDs, err := r.todoLists.FindUnbalancedWorkspaces(ctx, r.maxLength, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("run rank rebalancer: %w", err)
+	}
+	for _, workspaceID := range workspaceIDs {
+		if err := r.rebalance(ctx, workspaceID, listSiblings{todoLists: r.todoLists}); err != nil {
+			return 0, fmt.Errorf("rebalance workspace %s: %w", workspaceID, err)
+		}
+	}
+
+	if len(lists)+len(workspaceIDs) > 0 {
+		r.logger.Info("ranks rebalanced", zap.Int("todo_lists", len(lists)), zap.Int("workspaces", len(workspaceIDs)))
+	}
+	return max(len(lists), len(workspaceIDs)), nil
+}
+
+func (r *RankRebalancer) rebalance(ctx context.Context, workspaceID uuid.UUID, set siblings) error {
+	ctx = tenant.WithWorkspaceID(ctx, workspaceID)
+	return r.transactor.WithinTransaction(ctx, set.rebalance)
+}
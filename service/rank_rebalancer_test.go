@@ -0,0 +1,47 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func inWorkspace(workspaceID uuid.UUID) interface{} {
+	return mock.MatchedBy(func(ctx context.Context) bool {
+		id, ok := tenant.WorkspaceIDFromContext(ctx)
+		return ok && id == workspaceID
+	})
+}
+
+func TestRankRebalancer_RunOnce_RebalancesListsAndWorkspaces(t *testing.T) {
+	items := new(mocks.TodoItemRepositoryMock)
+	todoLists := new(mocks.TodoListRepositoryMock)
+	rebalancer := service.NewRankRebalancer(items, todoLists, mocks.FakeTransactor{}, 32, 10, zaptest.NewLogger(t))
+
+	workspaceID := uuid.New()
+	todoListID := uuid.New()
+	first, second := uuid.New(), uuid.New()
+	items.On("FindUnbalanced", mock.Anything, 32, 10).Return([]model.TodoItem{{WorkspaceID: workspaceID, TodoListID: todoListID}}, nil)
+	items.On("FindForRebalance", inWorkspace(workspaceID), todoListID).Return([]model.TodoItem{
+		{ID: first, Rank: "9"}, {ID: second, Rank: "9zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzi"},
+	}, nil)
+	items.On("SetRank", inWorkspace(workspaceID), first, "c").Return(nil).Once()
+	items.On("SetRank", inWorkspace(workspaceID), second, "o").Return(nil).Once()
+	todoLists.On("FindUnbalancedWorkspaces", mock.Anything, 32, 10).Return([]uuid.UUID{workspaceID}, nil)
+	todoLists.On("FindForRebalance", inWorkspace(workspaceID)).Return([]model.TodoList{{ID: todoListID}}, nil)
+	todoLists.On("SetRank", inWorkspace(workspaceID), todoListID, "i").Return(nil).Once()
+
+	rebalanced, err := rebalancer.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, rebalanced)
+	items.AssertExpectations(t)
+	todoLists.AssertExpectations(t)
+}
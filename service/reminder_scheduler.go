@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// deliveryLease is how long a claimed batch stays hidden from other replicas.
+// It must outlast the delivery of a whole batch.
+const deliveryLease = 15 * time.Minute
+
+// ReminderScheduler delivers due reminders in the background. Several
+// replicas may run it against the same database: each batch is claimed with
+// FOR UPDATE SKIP LOCKED and leased, so a reminder is delivered by only one
+// of them.
+type ReminderScheduler struct {
+	reminders   repository.ReminderRepository
+	items       repository.TodoItemRepository
+	users       repository.UserRepository
+	notifiers   map[string]notify.Notifier
+	transactor  repository.Transactor
+	clock       clock.Clock
+	batchSize   int
+	maxAttempts int
+	logger      *zap.Logger
+}
+
+// NewReminderScheduler constructs a ReminderScheduler. notifiers maps each
+// reminder channel to the Notifier that delivers it. Failed deliveries are
+// retried with a growing delay until maxAttempts is reached.
+func NewReminderScheduler(
+	reminders repository.ReminderRepository,
+	items repository.TodoItemRepository,
+	users repository.UserRepository,
+	notifiers map[string]notify.Notifier,
+	transactor repository.Transactor,
+	clock clock.Clock,
+	batchSize int,
+	maxAttempts int,
+	logger *zap.Logger,
+) *ReminderScheduler {
+	return &ReminderScheduler{
+		reminders:   reminders,
+		items:       items,
+		users:       users,
+		notifiers:   notifiers,
+		transactor:  transactor,
+		clock:       clock,
+		batchSize:   batchSize,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+	}
+}
+
+// Start polls for due reminders every interval until ctx is cancelled. A
+// full batch is followed immediately by another so that a backlog drains
+// without waiting for the next tick.
+func (s *ReminderScheduler) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			delivered, err := s.RunOnce(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Warn("deliver reminders failed", zap.Error(err))
+				}
+				break
+			}
+			if delivered < s.batchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce claims and leases one batch of due reminders in a short
+// transaction, then delivers them and records each outcome outside of it, so
+// that a database error cannot undo the record of a message already sent. It
+// returns the number of reminders processed.
+func (s *ReminderScheduler) RunOnce(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	var due []model.Reminder
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		due, err = s.reminders.ClaimDue(ctx, now, s.batchSize)
+		if err != nil || len(due) == 0 {
+			return err
+		}
+		ids := make([]uuid.UUID, len(due))
+		for i := range due {
+			ids[i] = due[i].ID
+		}
+		return s.reminders.Lease(ctx, ids, now.Add(deliveryLease))
+	})
+	if err != nil {
+		return 0, fmt.Errorf("run reminder scheduler: %w", err)
+	}
+
+	var errs []error
+	for i := range due {
+		err := s.deliver(ctx, &due[i], now)
+		if errors.Is(err, repository.ErrReminderNotFound) {
+			// Deleted or cancelled while it was being delivered.
+			s.logger.Info("reminder changed during delivery", zap.String("id", due[i].ID.String()))
+			continue
+		}
+		if err != nil {
+			s.logger.Error("record reminder delivery failed", zap.String("id", due[i].ID.String()), zap.Error(err))
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return len(due), fmt.Errorf("run reminder scheduler: %w", err)
+	}
+	return len(due), nil
+}
+
+// deliver sends one reminder and records the outcome, which releases the
+// lease. Reminders for items that were deleted or finished in the meantime
+// are cancelled.
+func (s *ReminderScheduler) deliver(ctx context.Context, reminder *model.Reminder, now time.Time) error {
+	ctx = tenant.WithWorkspaceID(ctx, reminder.WorkspaceID)
+
+	item, err := s.items.FindByID(ctx, reminder.TodoListID, reminder.TodoItemID)
+	switch {
+	case errors.Is(err, repository.ErrTodoItemNotFound):
+		reminder.Status = model.ReminderStatusCancelled
+		return s.reminders.Update(ctx, reminder)
+	case err != nil:
+		return err
+	case item.Status == model.StatusDone || item.Status == model.StatusArchived:
+		reminder.Status = model.ReminderStatusCancelled
+		return s.reminders.Update(ctx, reminder)
+	}
+
+	if err := s.notify(ctx, reminder, item); err != nil {
+		reminder.Attempts++
+		reminder.LastError = truncate(err.Error(), 1024)
+		if reminder.Attempts >= s.maxAttempts {
+			reminder.Status = model.ReminderStatusFailed
+		} else {
+			retryAt := now.Add(retryDelay(reminder.Attempts))
+			reminder.RemindAt = &retryAt
+		}
+		s.logger.Warn("reminder delivery failed",
+			zap.String("id", reminder.ID.String()),
+			zap.String("channel", reminder.Channel),
+			zap.Int("attempts", reminder.Attempts),
+			zap.Error(err))
+		return s.reminders.Update(ctx, reminder)
+	}
+
+	reminder.Status = model.ReminderStatusSent
+	reminder.SentAt = &now
+	reminder.LastError = ""
+	s.logger.Info("reminder delivered", zap.String("id", reminder.ID.String()), zap.String("channel", reminder.Channel))
+	return s.reminders.Update(ctx, reminder)
+}
+
+func (s *ReminderScheduler) notify(ctx context.Context, reminder *model.Reminder, item *model.TodoItem) error {
+	notifier, ok := s.notifiers[reminder.Channel]
+	if !ok {
+		return fmt.Errorf("unsupported reminder channel %q", reminder.Channel)
+	}
+	msg := notify.Message{
+		ReminderID:  reminder.ID,
+		WorkspaceID: reminder.WorkspaceID,
+		TodoListID:  reminder.TodoListID,
+		TodoItemID:  reminder.TodoItemID,
+		UserID:      reminder.UserID,
+		Target:      reminder.Target,
+		Title:       item.Title,
+		DueAt:       item.DueAt,
+		RemindAt:    *reminder.RemindAt,
+	}
+	if reminder.Channel == model.ReminderChannelEmail {
+		userID, err := uuid.Parse(reminder.UserID)
+		if err != nil {
+			return fmt.Errorf("reminder recipient is not a user: %w", err)
+		}
+		user, err := s.users.FindByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("find reminder recipient: %w", err)
+		}
+		msg.Email = user.Email
+	}
+	return notifier.Notify(ctx, msg)
+}
+
+// retryDelay backs off quadratically: 1, 4, 9, ... minutes.
+func retryDelay(attempts int) time.Duration {
+	return time.Duration(attempts*attempts) * time.Minute
+}
@@ -0,0 +1,250 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type schedulerFixture struct {
+	tx        *recordingTransactor
+	reminders *mocks.ReminderRepositoryMock
+	items     *mocks.TodoItemRepositoryMock
+	users     *mocks.UserRepositoryMock
+	webhook   *mocks.NotifierMock
+	email     *mocks.NotifierMock
+	clock     *mocks.FakeClock
+	scheduler *service.ReminderScheduler
+}
+
+func newSchedulerFixture(t *testing.T) schedulerFixture {
+	t.Helper()
+
+	f := schedulerFixture{
+		tx:        new(recordingTransactor),
+		reminders: new(mocks.ReminderRepositoryMock),
+		items:     new(mocks.TodoItemRepositoryMock),
+		users:     new(mocks.UserRepositoryMock),
+		webhook:   new(mocks.NotifierMock),
+		email:     new(mocks.NotifierMock),
+		clock:     mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.scheduler = service.NewReminderScheduler(
+		f.reminders,
+		f.items,
+		f.users,
+		map[string]notify.Notifier{
+			model.ReminderChannelWebhook: f.webhook,
+			model.ReminderChannelEmail:   f.email,
+		},
+		f.tx,
+		f.clock,
+		10,
+		3,
+		zaptest.NewLogger(t),
+	)
+	return f
+}
+
+// recordingTransactor runs fn directly and records whether a transaction is
+// open, so tests can check what happens inside one.
+type recordingTransactor struct {
+	open atomic.Bool
+}
+
+func (t *recordingTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.open.Store(true)
+	defer t.open.Store(false)
+	return fn(ctx)
+}
+
+func dueReminder(channel string, remindAt time.Time) model.Reminder {
+	return model.Reminder{
+		ID:          uuid.New(),
+		WorkspaceID: uuid.New(),
+		TodoListID:  uuid.New(),
+		TodoItemID:  uuid.New(),
+		UserID:      uuid.NewString(),
+		Channel:     channel,
+		Target:      "https://hooks.example.com/todo",
+		RemindAt:    &remindAt,
+		Status:      model.ReminderStatusPending,
+	}
+}
+
+func TestReminderScheduler_RunOnce_DeliversDueReminders(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	reminder := dueReminder(model.ReminderChannelWebhook, f.clock.Now().Add(-time.Minute))
+	f.reminders.On("ClaimDue", mock.Anything, f.clock.Now(), 10).Return([]model.Reminder{reminder}, nil)
+	f.reminders.On("Lease", mock.Anything, []uuid.UUID{reminder.ID}, mock.Anything).Return(nil)
+	f.items.On("FindByID", mock.MatchedBy(func(ctx context.Context) bool {
+		workspaceID, ok := tenant.WorkspaceIDFromContext(ctx)
+		return ok && workspaceID == reminder.WorkspaceID
+	}), reminder.TodoListID, reminder.TodoItemID).Return(&model.TodoItem{ID: reminder.TodoItemID, Title: "Pay rent", Status: model.StatusOpen}, nil)
+	f.webhook.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+		return msg.ReminderID == reminder.ID && msg.Title == "Pay rent" && msg.Target == reminder.Target
+	})).Return(nil)
+	f.reminders.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Reminder) bool {
+		return updated.Status == model.ReminderStatusSent && updated.SentAt.Equal(f.clock.Now())
+	})).Return(nil)
+
+	processed, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+	f.webhook.AssertExpectations(t)
+	f.reminders.AssertExpectations(t)
+}
+
+func TestReminderScheduler_RunOnce_DeliversOutsideTheClaim(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	reminder := dueReminder(model.ReminderChannelWebhook, f.clock.Now())
+	f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]model.Reminder{reminder}, nil)
+	f.reminders.On("Lease", mock.Anything, []uuid.UUID{reminder.ID}, f.clock.Now().Add(15*time.Minute)).
+		Run(func(mock.Arguments) { require.True(t, f.tx.open.Load(), "lease inside the claim transaction") }).
+		Return(nil).Once()
+	f.items.On("FindByID", mock.Anything, reminder.TodoListID, reminder.TodoItemID).Return(&model.TodoItem{Title: "Pay rent", Status: model.StatusOpen}, nil)
+	f.webhook.On("Notify", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { require.False(t, f.tx.open.Load(), "deliver after the claim commits") }).
+		Return(nil).Once()
+	f.reminders.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Reminder) bool {
+		return updated.Status == model.ReminderStatusSent && updated.RemindAt.Equal(*reminder.RemindAt)
+	})).Return(errors.New("connection reset"))
+
+	processed, err := f.scheduler.RunOnce(context.Background())
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, 1, processed)
+	f.webhook.AssertExpectations(t)
+	f.reminders.AssertExpectations(t)
+}
+
+func TestReminderScheduler_RunOnce_ResolvesEmailRecipient(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	reminder := dueReminder(model.ReminderChannelEmail, f.clock.Now())
+	userID := uuid.MustParse(reminder.UserID)
+	f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]model.Reminder{reminder}, nil)
+	f.reminders.On("Lease", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	f.items.On("FindByID", mock.Anything, reminder.TodoListID, reminder.TodoItemID).Return(&model.TodoItem{Title: "Pay rent", Status: model.StatusOpen}, nil)
+	f.users.On("FindByID", mock.Anything, userID).Return(&model.User{ID: userID, Email: "alice@example.com"}, nil)
+	f.email.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+		return msg.Email == "alice@example.com"
+	})).Return(nil)
+	f.reminders.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	f.email.AssertExpectations(t)
+}
+
+func TestReminderScheduler_RunOnce_RetriesWithBackoffThenFails(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	reminder := dueReminder(model.ReminderChannelWebhook, f.clock.Now())
+	f.items.On("FindByID", mock.Anything, reminder.TodoListID, reminder.TodoItemID).Return(&model.TodoItem{Title: "Pay rent", Status: model.StatusOpen}, nil)
+	f.webhook.On("Notify", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+	var saved model.Reminder
+	f.reminders.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = *args.Get(1).(*model.Reminder)
+	}).Return(nil)
+
+	// The first failure retries a minute later, the second four minutes
+	// later, and the third exhausts the attempts.
+	claim := f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]model.Reminder{reminder}, nil)
+	f.reminders.On("Lease", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	_, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.ReminderStatusPending, saved.Status)
+	require.Equal(t, 1, saved.Attempts)
+	require.Equal(t, "connection refused", saved.LastError)
+	require.True(t, saved.RemindAt.Equal(f.clock.Now().Add(time.Minute)))
+
+	f.clock.Advance(time.Minute)
+	claim.Return([]model.Reminder{saved}, nil)
+	_, err = f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, saved.Attempts)
+	require.True(t, saved.RemindAt.Equal(f.clock.Now().Add(4*time.Minute)))
+
+	f.clock.Advance(4 * time.Minute)
+	claim.Return([]model.Reminder{saved}, nil)
+	_, err = f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, model.ReminderStatusFailed, saved.Status)
+	require.Equal(t, 3, saved.Attempts)
+}
+
+func TestReminderScheduler_RunOnce_CancelsFinishedOrDeletedItems(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	done := dueReminder(model.ReminderChannelWebhook, f.clock.Now())
+	deleted := dueReminder(model.ReminderChannelWebhook, f.clock.Now())
+	f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]model.Reminder{done, deleted}, nil)
+	f.reminders.On("Lease", mock.Anything, []uuid.UUID{done.ID, deleted.ID}, f.clock.Now().Add(15*time.Minute)).Return(nil)
+	f.items.On("FindByID", mock.Anything, done.TodoListID, done.TodoItemID).Return(&model.TodoItem{Status: model.StatusDone}, nil)
+	f.items.On("FindByID", mock.Anything, deleted.TodoListID, deleted.TodoItemID).Return(nil, repository.ErrTodoItemNotFound)
+	f.reminders.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.Reminder) bool {
+		return updated.Status == model.ReminderStatusCancelled
+	})).Return(nil).Twice()
+
+	processed, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, processed)
+	f.webhook.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	f.reminders.AssertExpectations(t)
+}
+
+func TestReminderScheduler_RunOnce_IgnoresRemindersRemovedDuringDelivery(t *testing.T) {
+	f := newSchedulerFixture(t)
+
+	reminder := dueReminder(model.ReminderChannelWebhook, f.clock.Now())
+	f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).Return([]model.Reminder{reminder}, nil)
+	f.reminders.On("Lease", mock.Anything, []uuid.UUID{reminder.ID}, mock.Anything).Return(nil)
+	f.items.On("FindByID", mock.Anything, reminder.TodoListID, reminder.TodoItemID).Return(&model.TodoItem{Title: "Pay rent", Status: model.StatusOpen}, nil)
+	f.webhook.On("Notify", mock.Anything, mock.Anything).Return(nil)
+	f.reminders.On("Update", mock.Anything, mock.Anything).Return(repository.ErrReminderNotFound)
+
+	processed, err := f.scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, processed)
+}
+
+func TestReminderScheduler_Start_StopsWhenContextIsCancelled(t *testing.T) {
+	f := newSchedulerFixture(t)
+	var polls atomic.Int32
+	f.reminders.On("ClaimDue", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { polls.Add(1) }).
+		Return([]model.Reminder{}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		f.scheduler.Start(ctx, time.Millisecond)
+	}()
+
+	require.Eventually(t, func() bool {
+		return polls.Load() > 1
+	}, time.Second, time.Millisecond)
+	cancel()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+}
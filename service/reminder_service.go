@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// ErrNoDueDate indicates that a reminder relative to the due date was
+// requested for an item without one.
+var ErrNoDueDate = errors.New("todo item has no due date")
+
+// ReminderService defines business operations for reminders on todo items.
+// Reminders are personal: callers see and delete only their own unless they
+// are admins.
+type ReminderService interface {
+	CreateReminder(ctx context.Context, todoListID, todoItemID uuid.UUID, req model.CreateReminderRequest) (model.ReminderResponse, error)
+	ListReminders(ctx context.Context, todoListID, todoItemID uuid.UUID) ([]model.ReminderResponse, error)
+	DeleteReminder(ctx context.Context, todoListID, todoItemID, id uuid.UUID) error
+}
+
+type reminderService struct {
+	reminders repository.ReminderRepository
+	items     repository.TodoItemRepository
+	logger    *zap.Logger
+}
+
+// NewReminderService constructs a ReminderService implementation.
+func NewReminderService(reminders repository.ReminderRepository, items repository.TodoItemRepository, logger *zap.Logger) ReminderService {
+	return &reminderService{
+		reminders: reminders,
+		items:     items,
+		logger:    logger,
+	}
+}
+
+func (s *reminderService) CreateReminder(ctx context.Context, todoListID, todoItemID uuid.UUID, req model.CreateReminderRequest) (model.ReminderResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.ReminderResponse{}, ErrForbidden
+	}
+	item, err := s.findItem(ctx, todoListID, todoItemID)
+	if err != nil {
+		return model.ReminderResponse{}, err
+	}
+
+	reminder := &model.Reminder{
+		TodoListID: todoListID,
+		TodoItemID: todoItemID,
+		UserID:     claims.Subject,
+		Channel:    req.Channel,
+		Target:     req.Target,
+		RemindAt:   req.RemindAt,
+	}
+	if req.OffsetMinutes != nil {
+		if item.DueAt == nil {
+			return model.ReminderResponse{}, ErrNoDueDate
+		}
+		offset := time.Duration(*req.OffsetMinutes) * time.Minute
+		seconds := int64(offset.Seconds())
+		remindAt := item.DueAt.Add(-offset)
+		reminder.OffsetSeconds = &seconds
+		reminder.RemindAt = &remindAt
+	}
+
+	if err := s.reminders.Create(ctx, reminder); err != nil {
+		s.logger.Error("create reminder failed", zap.String("todo_item_id", todoItemID.String()), zap.Error(err))
+		return model.ReminderResponse{}, fmt.Errorf("create reminder: %w", err)
+	}
+	s.logger.Info("reminder created", zap.String("id", reminder.ID.String()), zap.String("channel", reminder.Channel))
+	return reminder.ToResponse(), nil
+}
+
+func (s *reminderService) ListReminders(ctx context.Context, todoListID, todoItemID uuid.UUID) ([]model.ReminderResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	if _, err := s.findItem(ctx, todoListID, todoItemID); err != nil {
+		return nil, err
+	}
+	reminders, err := s.reminders.FindByTodoItemID(ctx, todoItemID)
+	if err != nil {
+		s.logger.Error("list reminders failed", zap.String("todo_item_id", todoItemID.String()), zap.Error(err))
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+	responses := make([]model.ReminderResponse, 0, len(reminders))
+	for _, reminder := range reminders {
+		if claims.IsAdmin() || reminder.UserID == claims.Subject {
+			responses = append(responses, reminder.ToResponse())
+		}
+	}
+	return responses, nil
+}
+
+func (s *reminderService) DeleteReminder(ctx context.Context, todoListID, todoItemID, id uuid.UUID) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	if _, err := s.findItem(ctx, todoListID, todoItemID); err != nil {
+		return err
+	}
+	reminders, err := s.reminders.FindByTodoItemID(ctx, todoItemID)
+	if err != nil {
+		s.logger.Error("retrieve reminders failed", zap.String("todo_item_id", todoItemID.String()), zap.Error(err))
+		return fmt.Errorf("delete reminder: %w", err)
+	}
+	var reminder *model.Reminder
+	for i := range reminders {
+		if reminders[i].ID == id {
+			reminder = &reminders[i]
+		}
+	}
+	// Other users' reminders are reported as missing rather than forbidden
+	// so that their existence is not revealed.
+	if reminder == nil || !(claims.IsAdmin() || reminder.UserID == claims.Subject) {
+		return repository.ErrReminderNotFound
+	}
+
+	if err := s.reminders.Delete(ctx, todoItemID, id); err != nil {
+		if errors.Is(err, repository.ErrReminderNotFound) {
+			return err
+		}
+		s.logger.Error("delete reminder failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete reminder: %w", err)
+	}
+	s.logger.Info("reminder deleted", zap.String("id", id.String()))
+	return nil
+}
+
+func (s *reminderService) findItem(ctx context.Context, todoListID, id uuid.UUID) (*model.TodoItem, error) {
+	item, err := s.items.FindByID(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get todo item for reminders failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo item: %w", err)
+	}
+	return item, nil
+}
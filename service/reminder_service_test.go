@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestReminderService_CreateReminder_OffsetBeforeDueDate(t *testing.T) {
+	reminders := new(mocks.ReminderRepositoryMock)
+	items := new(mocks.TodoItemRepositoryMock)
+	svc := service.NewReminderService(reminders, items, zaptest.NewLogger(t))
+
+	listID, itemID := uuid.New(), uuid.New()
+	dueAt := time.Date(2026, 11, 1, 9, 0, 0, 0, time.UTC)
+	items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID, DueAt: &dueAt}, nil)
+	reminders.On("Create", mock.Anything, mock.MatchedBy(func(reminder *model.Reminder) bool {
+		return reminder.UserID == "user-2" && *reminder.OffsetSeconds == 5400 &&
+			reminder.RemindAt.Equal(dueAt.Add(-90*time.Minute))
+	})).Return(nil)
+
+	offset := 90
+	res, err := svc.CreateReminder(claimsContext("user-2", auth.RoleMember), listID, itemID, model.CreateReminderRequest{
+		OffsetMinutes: &offset,
+		Channel:       model.ReminderChannelInbox,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 90, *res.OffsetMinutes)
+	reminders.AssertExpectations(t)
+}
+
+func TestReminderService_CreateReminder_OffsetNeedsDueDate(t *testing.T) {
+	reminders := new(mocks.ReminderRepositoryMock)
+	items := new(mocks.TodoItemRepositoryMock)
+	svc := service.NewReminderService(reminders, items, zaptest.NewLogger(t))
+
+	listID, itemID := uuid.New(), uuid.New()
+	items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID}, nil)
+
+	offset := 10
+	_, err := svc.CreateReminder(claimsContext("user-2", auth.RoleMember), listID, itemID, model.CreateReminderRequest{
+		OffsetMinutes: &offset,
+		Channel:       model.ReminderChannelInbox,
+	})
+	require.ErrorIs(t, err, service.ErrNoDueDate)
+	reminders.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestReminderService_DeleteReminder_HidesOtherUsersReminders(t *testing.T) {
+	reminders := new(mocks.ReminderRepositoryMock)
+	items := new(mocks.TodoItemRepositoryMock)
+	svc := service.NewReminderService(reminders, items, zaptest.NewLogger(t))
+
+	listID, itemID, reminderID := uuid.New(), uuid.New(), uuid.New()
+	items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID}, nil)
+	reminders.On("FindByTodoItemID", mock.Anything, itemID).Return([]model.Reminder{{ID: reminderID, UserID: "user-1"}}, nil)
+	reminders.On("Delete", mock.Anything, itemID, reminderID).Return(nil)
+
+	err := svc.DeleteReminder(claimsContext("user-2", auth.RoleMember), listID, itemID, reminderID)
+	require.ErrorIs(t, err, repository.ErrReminderNotFound)
+	reminders.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, svc.DeleteReminder(claimsContext("user-3", auth.RoleAdmin), listID, itemID, reminderID))
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+const shareLinkTokenBytes = 32
+
+// ErrShareLinkInactive indicates that a share link has expired or been revoked.
+var ErrShareLinkInactive = errors.New("share link is no longer active")
+
+// ShareLinkService defines business operations for public share links.
+type ShareLinkService interface {
+	CreateShareLink(ctx context.Context, todoListID uuid.UUID, req model.CreateShareLinkRequest) (model.ShareLinkResponse, error)
+	ListShareLinks(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, todoListID, id uuid.UUID) error
+	GetSharedTodoList(ctx context.Context, rawToken string) (model.TodoListResponse, error)
+}
+
+type shareLinkService struct {
+	repository         repository.ShareLinkRepository
+	todoListRepository repository.TodoListRepository
+	clock              clock.Clock
+	logger             *zap.Logger
+}
+
+// NewShareLinkService constructs a ShareLinkService implementation.
+func NewShareLinkService(repository repository.ShareLinkRepository, todoListRepository repository.TodoListRepository, clock clock.Clock, logger *zap.Logger) ShareLinkService {
+	return &shareLinkService{
+		repository:         repository,
+		todoListRepository: todoListRepository,
+		clock:              clock,
+		logger:             logger,
+	}
+}
+
+// CreateShareLink shares a list publicly. Only the list's owner or an admin
+// may share it.
+func (s *shareLinkService) CreateShareLink(ctx context.Context, todoListID uuid.UUID, req model.CreateShareLinkRequest) (model.ShareLinkResponse, error) {
+	todoList, err := s.todoListRepository.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.ShareLinkResponse{}, err
+		}
+		s.logger.Error("retrieve todo list for share link failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return model.ShareLinkResponse{}, fmt.Errorf("get todo list: %w", err)
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("share todo list denied", zap.String("todo_list_id", todoListID.String()))
+		return model.ShareLinkResponse{}, err
+	}
+
+	rawToken, err := token.Generate(shareLinkTokenBytes)
+	if err != nil {
+		s.logger.Error("generate share link token failed", zap.Error(err))
+		return model.ShareLinkResponse{}, fmt.Errorf("create share link: %w", err)
+	}
+
+	shareLink := &model.ShareLink{
+		TodoListID: todoListID,
+		TokenHash:  token.Hash(rawToken),
+		ExpiresAt:  req.ExpiresAt,
+	}
+	if err := s.repository.Create(ctx, shareLink); err != nil {
+		s.logger.Error("create share link failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return model.ShareLinkResponse{}, fmt.Errorf("create share link: %w", err)
+	}
+	s.logger.Info("share link created",
+		zap.String("id", shareLink.ID.String()),
+		zap.String("todo_list_id", todoListID.String()),
+	)
+
+	res := shareLink.ToResponse()
+	res.Token = rawToken
+	res.URL = "/public/lists/" + rawToken
+	return res, nil
+}
+
+func (s *shareLinkService) ListShareLinks(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLinkResponse, error) {
+	if _, err := s.todoListRepository.FindByID(ctx, todoListID); err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return nil, err
+		}
+		s.logger.Error("retrieve todo list for share links failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo list: %w", err)
+	}
+
+	shareLinks, err := s.repository.FindByTodoListID(ctx, todoListID)
+	if err != nil {
+		s.logger.Error("list share links failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("list share links: %w", err)
+	}
+	responses := make([]model.ShareLinkResponse, len(shareLinks))
+	for i, shareLink := range shareLinks {
+		responses[i] = shareLink.ToResponse()
+	}
+	return responses, nil
+}
+
+// RevokeShareLink disables a share link. Only the list's owner or an admin
+// may revoke it.
+func (s *shareLinkService) RevokeShareLink(ctx context.Context, todoListID, id uuid.UUID) error {
+	todoList, err := s.todoListRepository.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return err
+		}
+		s.logger.Error("retrieve todo list for share link revoke failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return fmt.Errorf("get todo list: %w", err)
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("revoke share link denied", zap.String("id", id.String()))
+		return err
+	}
+
+	shareLink, err := s.repository.FindByID(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return err
+		}
+		s.logger.Error("retrieve share link for revoke failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("get share link: %w", err)
+	}
+	if shareLink.RevokedAt != nil {
+		return nil
+	}
+
+	now := s.clock.Now()
+	shareLink.RevokedAt = &now
+	if err := s.repository.Update(ctx, shareLink); err != nil {
+		s.logger.Error("revoke share link failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("revoke share link: %w", err)
+	}
+	s.logger.Info("share link revoked", zap.String("id", id.String()))
+	return nil
+}
+
+func (s *shareLinkService) GetSharedTodoList(ctx context.Context, rawToken string) (model.TodoListResponse, error) {
+	shareLink, err := s.repository.FindByTokenHash(ctx, token.Hash(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrShareLinkNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("find share link failed", zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("get share link: %w", err)
+	}
+	if !shareLink.Active(s.clock.Now()) {
+		return model.TodoListResponse{}, ErrShareLinkInactive
+	}
+
+	ctx = tenant.WithWorkspaceID(ctx, shareLink.WorkspaceID)
+	todoList, err := s.todoListRepository.FindByID(ctx, shareLink.TodoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("get shared todo list failed", zap.String("todo_list_id", shareLink.TodoListID.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("get todo list: %w", err)
+	}
+	return todoList.ToPublicResponse(), nil
+}
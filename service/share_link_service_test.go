@@ -0,0 +1,132 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
+	"github.com/lumoshiveacademy/todolist/package/token"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+var shareLinkNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+func shareLinkClock() *mocks.FakeClock {
+	return mocks.NewFakeClock(shareLinkNow)
+}
+
+func TestShareLinkService_CreateShareLink_StoresHashedToken(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), logger)
+
+	todoListID := uuid.New()
+	var stored *model.ShareLink
+	mockTodoListRepo.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "user-1"}, nil)
+	mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*model.ShareLink")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*model.ShareLink)
+		stored.ID = uuid.New()
+	})
+
+	res, err := svc.CreateShareLink(claimsContext("user-1", auth.RoleMember), todoListID, model.CreateShareLinkRequest{})
+	require.NoError(t, err)
+	require.NotEmpty(t, res.Token)
+	require.Equal(t, "/public/lists/"+res.Token, res.URL)
+	require.Equal(t, token.Hash(res.Token), stored.TokenHash)
+	require.NotEqual(t, res.Token, stored.TokenHash)
+	mockRepo.AssertExpectations(t)
+	mockTodoListRepo.AssertExpectations(t)
+}
+
+func TestShareLinkService_RequiresOwner(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), zaptest.NewLogger(t))
+
+	todoListID := uuid.New()
+	mockTodoListRepo.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "user-1"}, nil)
+
+	_, err := svc.CreateShareLink(claimsContext("user-2", auth.RoleMember), todoListID, model.CreateShareLinkRequest{})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	err = svc.RevokeShareLink(claimsContext("user-2", auth.RoleMember), todoListID, uuid.New())
+	require.ErrorIs(t, err, service.ErrForbidden)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestShareLinkService_GetSharedTodoList_Expired(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), logger)
+
+	expiredAt := shareLinkNow.Add(-time.Minute)
+	mockRepo.On("FindByTokenHash", mock.Anything, token.Hash("secret")).
+		Return(&model.ShareLink{ID: uuid.New(), TodoListID: uuid.New(), ExpiresAt: &expiredAt}, nil)
+
+	_, err := svc.GetSharedTodoList(context.Background(), "secret")
+	require.ErrorIs(t, err, service.ErrShareLinkInactive)
+	mockTodoListRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
+
+func TestShareLinkService_RevokeShareLink_NotFound(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), logger)
+
+	todoListID, id := uuid.New(), uuid.New()
+	mockTodoListRepo.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "user-1"}, nil)
+	mockRepo.On("FindByID", mock.Anything, todoListID, id).Return(nil, repository.ErrShareLinkNotFound)
+
+	err := svc.RevokeShareLink(claimsContext("user-1", auth.RoleMember), todoListID, id)
+	require.ErrorIs(t, err, repository.ErrShareLinkNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestShareLinkService_RevokeShareLink_UsesClock(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), zaptest.NewLogger(t))
+
+	todoListID, id := uuid.New(), uuid.New()
+	mockTodoListRepo.On("FindByID", mock.Anything, todoListID).Return(&model.TodoList{ID: todoListID, OwnerID: "user-1"}, nil)
+	mockRepo.On("FindByID", mock.Anything, todoListID, id).Return(&model.ShareLink{ID: id, TodoListID: todoListID}, nil)
+	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(shareLink *model.ShareLink) bool {
+		return shareLink.RevokedAt != nil && shareLink.RevokedAt.Equal(shareLinkNow)
+	})).Return(nil)
+
+	err := svc.RevokeShareLink(claimsContext("user-1", auth.RoleMember), todoListID, id)
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestShareLinkService_GetSharedTodoList_UsesLinkWorkspace(t *testing.T) {
+	mockRepo := new(mocks.ShareLinkRepositoryMock)
+	mockTodoListRepo := new(mocks.TodoListRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewShareLinkService(mockRepo, mockTodoListRepo, shareLinkClock(), logger)
+
+	workspaceID, todoListID := uuid.New(), uuid.New()
+	mockRepo.On("FindByTokenHash", mock.Anything, token.Hash("secret")).
+		Return(&model.ShareLink{ID: uuid.New(), WorkspaceID: workspaceID, TodoListID: todoListID}, nil)
+	mockTodoListRepo.On("FindByID", mock.MatchedBy(func(ctx context.Context) bool {
+		id, ok := tenant.WorkspaceIDFromContext(ctx)
+		return ok && id == workspaceID
+	}), todoListID).Return(&model.TodoList{ID: todoListID, WorkspaceID: workspaceID, Title: "Groceries"}, nil)
+
+	res, err := svc.GetSharedTodoList(context.Background(), "secret")
+	require.NoError(t, err)
+	require.Equal(t, "Groceries", res.Title)
+	mockTodoListRepo.AssertExpectations(t)
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// TagService defines business operations for tags and for tagging todo
+// lists. Any member may create tags; renaming and deleting a tag is left to
+// its creator and admins, and tagging a list to the list's owner.
+type TagService interface {
+	CreateTag(ctx context.Context, req model.CreateTagRequest) (model.TagResponse, error)
+	ListTags(ctx context.Context) ([]model.TagResponse, error)
+	UpdateTag(ctx context.Context, id uuid.UUID, req model.UpdateTagRequest) (model.TagResponse, error)
+	DeleteTag(ctx context.Context, id uuid.UUID) error
+	AttachTag(ctx context.Context, todoListID, tagID uuid.UUID) error
+	DetachTag(ctx context.Context, todoListID, tagID uuid.UUID) error
+}
+
+type tagService struct {
+	tags       repository.TagRepository
+	todoLists  repository.TodoListRepository
+	auditLogs  repository.AuditLogRepository
+	transactor repository.Transactor
+	logger     *zap.Logger
+}
+
+// NewTagService constructs a TagService implementation. Tag changes are
+// audited as tag entries; tagging and untagging a list as an update of the
+// list's tags.
+func NewTagService(
+	tags repository.TagRepository,
+	todoLists repository.TodoListRepository,
+	auditLogs repository.AuditLogRepository,
+	transactor repository.Transactor,
+	logger *zap.Logger,
+) TagService {
+	return &tagService{
+		tags:       tags,
+		todoLists:  todoLists,
+		auditLogs:  auditLogs,
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+func (s *tagService) CreateTag(ctx context.Context, req model.CreateTagRequest) (model.TagResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.TagResponse{}, ErrForbidden
+	}
+	tag := &model.Tag{
+		OwnerID: claims.Subject,
+		Name:    req.Name,
+		Color:   strings.ToLower(req.Color),
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tags.Create(ctx, tag); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionCreate, model.AuditEntityTag, tag.ID, nil, tag.ToResponse())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTagNameTaken) {
+			return model.TagResponse{}, err
+		}
+		s.logger.Error("create tag failed", zap.Error(err))
+		return model.TagResponse{}, fmt.Errorf("create tag: %w", err)
+	}
+	s.logger.Info("tag created", zap.String("id", tag.ID.String()))
+	return tag.ToResponse(), nil
+}
+
+func (s *tagService) ListTags(ctx context.Context) ([]model.TagResponse, error) {
+	tags, err := s.tags.FindAll(ctx)
+	if err != nil {
+		s.logger.Error("list tags failed", zap.Error(err))
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+	responses := make([]model.TagResponse, len(tags))
+	for i, tag := range tags {
+		responses[i] = tag.ToResponse()
+	}
+	return responses, nil
+}
+
+func (s *tagService) UpdateTag(ctx context.Context, id uuid.UUID, req model.UpdateTagRequest) (model.TagResponse, error) {
+	tag, err := s.findOwnTag(ctx, id)
+	if err != nil {
+		return model.TagResponse{}, err
+	}
+	before := tag.ToResponse()
+	tag.Name = req.Name
+	if req.Color != "" {
+		tag.Color = strings.ToLower(req.Color)
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tags.Update(ctx, tag); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionUpdate, model.AuditEntityTag, tag.ID, before, tag.ToResponse())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTagNotFound) || errors.Is(err, repository.ErrTagNameTaken) {
+			return model.TagResponse{}, err
+		}
+		s.logger.Error("update tag failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TagResponse{}, fmt.Errorf("update tag: %w", err)
+	}
+	s.logger.Info("tag updated", zap.String("id", id.String()))
+	return tag.ToResponse(), nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.findOwnTag(ctx, id)
+	if err != nil {
+		return err
+	}
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.tags.Delete(ctx, id); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionDelete, model.AuditEntityTag, id, tag.ToResponse(), nil)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTagNotFound) {
+			return err
+		}
+		s.logger.Error("delete tag failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete tag: %w", err)
+	}
+	s.logger.Info("tag deleted", zap.String("id", id.String()))
+	return nil
+}
+
+// AttachTag tags the list. Attaching a tag the list already carries changes
+// nothing and is not audited.
+func (s *tagService) AttachTag(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	return s.retag(ctx, todoListID, tagID, true)
+}
+
+// DetachTag removes the tag from the list. Detaching a tag the list does not
+// carry changes nothing and is not audited.
+func (s *tagService) DetachTag(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	return s.retag(ctx, todoListID, tagID, false)
+}
+
+func (s *tagService) retag(ctx context.Context, todoListID, tagID uuid.UUID, attach bool) error {
+	todoList, err := s.todoLists.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return err
+		}
+		s.logger.Error("retrieve todo list for tagging failed", zap.String("id", todoListID.String()), zap.Error(err))
+		return fmt.Errorf("get todo list: %w", err)
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("tag todo list denied", zap.String("id", todoListID.String()))
+		return err
+	}
+	tag, err := s.findTag(ctx, tagID)
+	if err != nil {
+		return err
+	}
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.tags.FindByTodoListIDs(ctx, []uuid.UUID{todoListID})
+		if err != nil {
+			return err
+		}
+		before := tagNames(current[todoListID])
+		after := make([]string, 0, len(before)+1)
+		for _, name := range before {
+			if name != tag.Name {
+				after = append(after, name)
+			}
+		}
+		if attach {
+			after = append(after, tag.Name)
+		}
+		if len(after) == len(before) {
+			return nil
+		}
+
+		if attach {
+			err = s.tags.Attach(ctx, todoListID, tagID)
+		} else {
+			err = s.tags.Detach(ctx, todoListID, tagID)
+		}
+		if err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionUpdate, model.AuditEntityTodoList, todoListID,
+			map[string][]string{"tags": before}, map[string][]string{"tags": after})
+	})
+	if err != nil {
+		s.logger.Error("tag todo list failed", zap.String("id", todoListID.String()), zap.String("tag_id", tagID.String()), zap.Error(err))
+		return fmt.Errorf("tag todo list: %w", err)
+	}
+	s.logger.Info("todo list tags changed", zap.String("id", todoListID.String()), zap.String("tag_id", tagID.String()), zap.Bool("attached", attach))
+	return nil
+}
+
+func (s *tagService) findTag(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	tag, err := s.tags.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTagNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get tag failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get tag: %w", err)
+	}
+	return tag, nil
+}
+
+// findOwnTag loads a tag the caller may change: one they created, or any
+// tag for admins.
+func (s *tagService) findOwnTag(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	tag, err := s.findTag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !claims.IsAdmin() && tag.OwnerID != claims.Subject {
+		s.logger.Warn("change tag denied", zap.String("id", id.String()))
+		return nil, ErrForbidden
+	}
+	return tag, nil
+}
+
+func (s *tagService) audit(ctx context.Context, action, entityType string, id uuid.UUID, before, after interface{}) error {
+	auditLog, err := newAuditLog(ctx, action, entityType, id, before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditLogs.Create(ctx, auditLog)
+}
+
+// tagNames lists the names of tags in their stored order.
+func tagNames(tags []model.Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
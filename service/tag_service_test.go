@@ -0,0 +1,132 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type tagFixture struct {
+	tags      *mocks.TagRepositoryMock
+	todoLists *mocks.TodoListRepositoryMock
+	auditLogs *mocks.AuditLogRepositoryMock
+	svc       service.TagService
+}
+
+func newTagFixture(t *testing.T) tagFixture {
+	t.Helper()
+
+	f := tagFixture{
+		tags:      new(mocks.TagRepositoryMock),
+		todoLists: new(mocks.TodoListRepositoryMock),
+		auditLogs: new(mocks.AuditLogRepositoryMock),
+	}
+	f.svc = service.NewTagService(f.tags, f.todoLists, f.auditLogs, mocks.FakeTransactor{}, zaptest.NewLogger(t))
+	return f
+}
+
+func TestTagService_CreateTag_NormalizesColor(t *testing.T) {
+	f := newTagFixture(t)
+
+	f.tags.On("Create", mock.Anything, mock.MatchedBy(func(tag *model.Tag) bool {
+		return tag.Name == "home" && tag.Color == "#4caf50" && tag.OwnerID == "user-1"
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.EntityType == model.AuditEntityTag && auditLog.Action == model.AuditActionCreate
+	})).Return(nil)
+
+	res, err := f.svc.CreateTag(claimsContext("user-1", auth.RoleMember), model.CreateTagRequest{Name: "home", Color: "#4CAF50"})
+	require.NoError(t, err)
+	require.Equal(t, "#4caf50", res.Color)
+	f.tags.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTagService_CreateTag_NameTaken(t *testing.T) {
+	f := newTagFixture(t)
+
+	f.tags.On("Create", mock.Anything, mock.Anything).Return(repository.ErrTagNameTaken)
+
+	_, err := f.svc.CreateTag(claimsContext("user-1", auth.RoleMember), model.CreateTagRequest{Name: "home"})
+	require.ErrorIs(t, err, repository.ErrTagNameTaken)
+	f.auditLogs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTagService_UpdateTag_ForbiddenForOtherMembers(t *testing.T) {
+	f := newTagFixture(t)
+
+	id := uuid.New()
+	f.tags.On("FindByID", mock.Anything, id).Return(&model.Tag{ID: id, OwnerID: "user-1", Name: "home"}, nil)
+
+	_, err := f.svc.UpdateTag(claimsContext("user-2", auth.RoleMember), id, model.UpdateTagRequest{Name: "house"})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.tags.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTagService_AttachTag_AuditsListTags(t *testing.T) {
+	f := newTagFixture(t)
+
+	listID, tagID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.tags.On("FindByID", mock.Anything, tagID).Return(&model.Tag{ID: tagID, Name: "urgent"}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{listID}).Return(map[uuid.UUID][]model.Tag{
+		listID: {{ID: uuid.New(), Name: "home"}},
+	}, nil)
+	f.tags.On("Attach", mock.Anything, listID, tagID).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		change := auditLog.Changes["tags"]
+		return auditLog.EntityType == model.AuditEntityTodoList && auditLog.EntityID == listID &&
+			auditLog.Action == model.AuditActionUpdate &&
+			len(change.Before.([]interface{})) == 1 && len(change.After.([]interface{})) == 2
+	})).Return(nil)
+
+	require.NoError(t, f.svc.AttachTag(claimsContext("user-1", auth.RoleMember), listID, tagID))
+	f.tags.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTagService_AttachTag_AlreadyAttachedIsNoop(t *testing.T) {
+	f := newTagFixture(t)
+
+	listID, tagID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.tags.On("FindByID", mock.Anything, tagID).Return(&model.Tag{ID: tagID, Name: "home"}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{listID}).Return(map[uuid.UUID][]model.Tag{
+		listID: {{ID: tagID, Name: "home"}},
+	}, nil)
+
+	require.NoError(t, f.svc.AttachTag(claimsContext("user-1", auth.RoleMember), listID, tagID))
+	f.tags.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything, mock.Anything)
+	f.auditLogs.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTagService_DetachTag_RequiresListOwner(t *testing.T) {
+	f := newTagFixture(t)
+
+	listID, tagID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+
+	err := f.svc.DetachTag(claimsContext("user-2", auth.RoleMember), listID, tagID)
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.tags.AssertNotCalled(t, "Detach", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTagService_AttachTag_TagFromOtherWorkspace(t *testing.T) {
+	f := newTagFixture(t)
+
+	listID, tagID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.tags.On("FindByID", mock.Anything, tagID).Return(nil, repository.ErrTagNotFound)
+
+	err := f.svc.AttachTag(claimsContext("user-1", auth.RoleMember), listID, tagID)
+	require.ErrorIs(t, err, repository.ErrTagNotFound)
+	f.tags.AssertNotCalled(t, "Attach", mock.Anything, mock.Anything, mock.Anything)
+}
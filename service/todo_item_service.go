@@ -0,0 +1,701 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/rrule"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// ErrNotRecurring indicates that an operation needs a recurring todo item.
+var ErrNotRecurring = errors.New("todo item is not recurring")
+
+// ErrSubtaskTooDeep indicates that a subtask would nest deeper than
+// model.MaxSubtaskDepth.
+var ErrSubtaskTooDeep = errors.New("subtask nested too deeply")
+
+// TodoItemService defines business operations for the items of a todo list.
+// Anyone who can read a list can read its items; changing them follows the
+// list's ownership rules. Items may have subtasks; a done item never has
+// pending subtasks.
+type TodoItemService interface {
+	CreateTodoItem(ctx context.Context, todoListID uuid.UUID, req model.CreateTodoItemRequest) (model.TodoItemResponse, error)
+	ListTodoItems(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItemResponse, error)
+	GetTodoItem(ctx context.Context, todoListID, id uuid.UUID) (model.TodoItemResponse, error)
+	UpdateTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateTodoItemRequest) (model.TodoItemResponse, error)
+	DeleteTodoItem(ctx context.Context, todoListID, id uuid.UUID) error
+	MaterializeOccurrences(ctx context.Context, todoListID, id uuid.UUID, count int) ([]model.TodoItemResponse, error)
+	MoveTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.MoveTodoItemRequest) (model.TodoItemResponse, error)
+}
+
+type todoItemService struct {
+	items       repository.TodoItemRepository
+	todoLists   repository.TodoListRepository
+	reminders   repository.ReminderRepository
+	attachments repository.AttachmentRepository
+	comments    repository.CommentRepository
+	auditLogs   repository.AuditLogRepository
+	store       blob.Store
+	transactor  repository.Transactor
+	clock       clock.Clock
+	location    *time.Location
+	logger      *zap.Logger
+}
+
+// NewTodoItemService constructs a TodoItemService implementation. Every
+// mutation is recorded in the audit log within the same transaction.
+// Recurring items without their own timezone are expanded in location.
+func NewTodoItemService(
+	items repository.TodoItemRepository,
+	todoLists repository.TodoListRepository,
+	reminders repository.ReminderRepository,
+	attachments repository.AttachmentRepository,
+	comments repository.CommentRepository,
+	auditLogs repository.AuditLogRepository,
+	store blob.Store,
+	transactor repository.Transactor,
+	clock clock.Clock,
+	location *time.Location,
+	logger *zap.Logger,
+) TodoItemService {
+	return &todoItemService{
+		items:       items,
+		todoLists:   todoLists,
+		reminders:   reminders,
+		attachments: attachments,
+		comments:    comments,
+		auditLogs:   auditLogs,
+		store:       store,
+		transactor:  transactor,
+		clock:       clock,
+		location:    location,
+		logger:      logger,
+	}
+}
+
+func (s *todoItemService) CreateTodoItem(ctx context.Context, todoListID uuid.UUID, req model.CreateTodoItemRequest) (model.TodoItemResponse, error) {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return model.TodoItemResponse{}, err
+	}
+
+	var ancestors []model.TodoItem
+	if req.ParentID != nil {
+		var err error
+		if ancestors, err = s.findParentChain(ctx, todoListID, *req.ParentID); err != nil {
+			return model.TodoItemResponse{}, err
+		}
+	}
+
+	item := &model.TodoItem{
+		TodoListID:  todoListID,
+		ParentID:    req.ParentID,
+		Title:       req.Title,
+		Description: req.Description,
+		Priority:    req.Priority,
+		DueAt:       req.DueAt,
+		Timezone:    req.Timezone,
+	}
+	if req.Recurrence != "" {
+		// The first occurrence names the series so that later ones can be
+		// traced back to it.
+		item.ID = uuid.New()
+		item.SeriesID = &item.ID
+		if err := s.setRecurrence(item, req.Recurrence); err != nil {
+			return model.TodoItemResponse{}, err
+		}
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.create(ctx, item); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, model.AuditActionCreate, item.ID, nil, item.ToResponse()); err != nil {
+			return err
+		}
+		return s.reopen(ctx, ancestors)
+	})
+	if err != nil {
+		s.logger.Error("create todo item failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return model.TodoItemResponse{}, fmt.Errorf("create todo item: %w", err)
+	}
+	s.logger.Info("todo item created", zap.String("id", item.ID.String()))
+	return item.ToResponse(), nil
+}
+
+func (s *todoItemService) ListTodoItems(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItemResponse, error) {
+	if _, err := s.findList(ctx, todoListID); err != nil {
+		return nil, err
+	}
+	items, err := s.items.FindByTodoListID(ctx, todoListID, filter)
+	if err != nil {
+		s.logger.Error("list todo items failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("list todo items: %w", err)
+	}
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	progress, err := s.items.CountSubtasks(ctx, ids)
+	if err != nil {
+		s.logger.Error("count subtasks failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("list todo items: %w", err)
+	}
+	responses := make([]model.TodoItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = item.ToResponse()
+		if p, ok := progress[item.ID]; ok {
+			responses[i].Progress = &p
+		}
+	}
+	return responses, nil
+}
+
+// GetTodoItem returns the item with its subtasks nested below it.
+func (s *todoItemService) GetTodoItem(ctx context.Context, todoListID, id uuid.UUID) (model.TodoItemResponse, error) {
+	if _, err := s.findList(ctx, todoListID); err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	items, err := s.findSubtree(ctx, todoListID, id)
+	if err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	return buildTree(items), nil
+}
+
+func (s *todoItemService) UpdateTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateTodoItemRequest) (model.TodoItemResponse, error) {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	item, err := s.findItem(ctx, todoListID, id)
+	if err != nil {
+		return model.TodoItemResponse{}, err
+	}
+
+	before := *item
+	item.Title = req.Title
+	item.Description = req.Description
+	item.DueAt = req.DueAt
+	if req.Priority != "" {
+		item.Priority = req.Priority
+	}
+	if req.Status != "" {
+		s.setStatus(item, req.Status)
+	}
+	item.Timezone = req.Timezone
+	if err := s.setRecurrence(item, req.Recurrence); err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	completed := before.Status != model.StatusDone && item.Status == model.StatusDone
+	reopened := !pending(before.Status) && pending(item.Status)
+
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.items.Update(ctx, item); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, model.AuditActionUpdate, item.ID, before.ToResponse(), item.ToResponse()); err != nil {
+			return err
+		}
+		if completed {
+			if err := s.completeSubtasks(ctx, item); err != nil {
+				return err
+			}
+		}
+		if reopened && item.ParentID != nil {
+			ancestors, err := s.items.FindAncestors(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.reopen(ctx, ancestors); err != nil {
+				return err
+			}
+		}
+		if !sameTime(before.DueAt, item.DueAt) {
+			if err := s.reminders.Reschedule(ctx, item.ID, item.DueAt); err != nil {
+				return err
+			}
+		}
+		if completed && item.Recurrence != "" {
+			return s.createNextOccurrence(ctx, item)
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return model.TodoItemResponse{}, err
+		}
+		s.logger.Error("update todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoItemResponse{}, fmt.Errorf("update todo item: %w", err)
+	}
+	s.logger.Info("todo item updated", zap.String("id", id.String()))
+	return item.ToResponse(), nil
+}
+
+// DeleteTodoItem deletes the item together with all of its subtasks and their
+// attachments and comments. The attachments' contents are removed after the commit.
+func (s *todoItemService) DeleteTodoItem(ctx context.Context, todoListID, id uuid.UUID) error {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return err
+	}
+
+	var attachments []model.Attachment
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := s.items.FindSubtree(ctx, todoListID, id)
+		if err != nil {
+			return err
+		}
+		// Subtasks go first so that no item outlives its parent.
+		for i := len(items) - 1; i >= 0; i-- {
+			item := items[i]
+			if err := s.items.Delete(ctx, todoListID, item.ID); err != nil {
+				return err
+			}
+			if err := s.reminders.DeleteByTodoItemID(ctx, item.ID); err != nil {
+				return err
+			}
+			itemAttachments, err := s.attachments.FindByTodoItemID(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+			if err := s.attachments.DeleteByTodoItemID(ctx, item.ID); err != nil {
+				return err
+			}
+			if err := s.comments.DeleteByTodoItemID(ctx, item.ID); err != nil {
+				return err
+			}
+			attachments = append(attachments, itemAttachments...)
+			if err := s.audit(ctx, model.AuditActionDelete, item.ID, item.ToResponse(), nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return err
+		}
+		s.logger.Error("delete todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("delete todo item: %w", err)
+	}
+	deleteContents(ctx, s.store, attachments, s.logger)
+	s.logger.Info("todo item deleted", zap.String("id", id.String()))
+	return nil
+}
+
+// MaterializeOccurrences creates the next count occurrences after the last
+// one of the item's series, so that upcoming work shows up ahead of time.
+func (s *todoItemService) MaterializeOccurrences(ctx context.Context, todoListID, id uuid.UUID, count int) ([]model.TodoItemResponse, error) {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return nil, err
+	}
+	item, err := s.findItem(ctx, todoListID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Recurrence == "" || item.SeriesID == nil || item.DueAt == nil {
+		return nil, ErrNotRecurring
+	}
+
+	var created []model.TodoItemResponse
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		latest, err := s.items.FindLatestInSeries(ctx, *item.SeriesID)
+		if err != nil {
+			return err
+		}
+		occurrences, err := s.occurrencesAfter(latest, latest.DueAt, count)
+		if err != nil {
+			return err
+		}
+		for _, dueAt := range occurrences {
+			occurrence := newOccurrence(latest, dueAt)
+			if err := s.create(ctx, occurrence); err != nil {
+				return err
+			}
+			if err := s.audit(ctx, model.AuditActionCreate, occurrence.ID, nil, occurrence.ToResponse()); err != nil {
+				return err
+			}
+			created = append(created, occurrence.ToResponse())
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("materialize todo item occurrences failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("materialize todo item occurrences: %w", err)
+	}
+	s.logger.Info("todo item occurrences materialized", zap.String("id", id.String()), zap.Int("count", len(created)))
+	return created, nil
+}
+
+// MoveTodoItem repositions the item within its list or, when req names
+// another list, at a position in that one. Moving across lists needs the
+// right to change both; the item's subtasks follow it and a subtask becomes a
+// top-level item of the target list. Only the moved item's row is rewritten
+// unless its new neighbours leave no room for a rank.
+func (s *todoItemService) MoveTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.MoveTodoItemRequest) (model.TodoItemResponse, error) {
+	if err := s.authorizeList(ctx, todoListID); err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	item, err := s.findItem(ctx, todoListID, id)
+	if err != nil {
+		return model.TodoItemResponse{}, err
+	}
+	target := todoListID
+	if req.TodoListID != nil && *req.TodoListID != todoListID {
+		target = *req.TodoListID
+		if err := s.authorizeList(ctx, target); err != nil {
+			return model.TodoItemResponse{}, err
+		}
+	}
+
+	before := *item
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		rank, err := placeRank(ctx, itemSiblings{items: s.items, todoListID: target}, item.ID, req.AfterID, req.BeforeID)
+		if err != nil {
+			return err
+		}
+		var subtree []model.TodoItem
+		if target != todoListID {
+			if subtree, err = s.items.FindSubtree(ctx, todoListID, item.ID); err != nil {
+				return err
+			}
+			item.ParentID = nil
+		}
+		item.TodoListID = target
+		item.Rank = rank
+		if err := s.items.Move(ctx, item); err != nil {
+			return err
+		}
+		if target != todoListID {
+			if err := s.moveSubtree(ctx, subtree, target); err != nil {
+				return err
+			}
+		}
+		return s.audit(ctx, model.AuditActionUpdate, item.ID, before.ToResponse(), item.ToResponse())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return model.TodoItemResponse{}, err
+		}
+		s.logger.Error("move todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoItemResponse{}, fmt.Errorf("move todo item: %w", err)
+	}
+	s.logger.Info("todo item moved", zap.String("id", id.String()), zap.String("todo_list_id", target.String()))
+	return item.ToResponse(), nil
+}
+
+// setRecurrence stores the rule in canonical form. Changing the rule restarts
+// the series expansion from the item's due date.
+func (s *todoItemService) setRecurrence(item *model.TodoItem, recurrence string) error {
+	if recurrence == "" {
+		item.Recurrence = ""
+		return nil
+	}
+	rule, err := rrule.Parse(recurrence)
+	if err != nil {
+		return err
+	}
+	canonical := rule.String()
+	if item.SeriesID == nil {
+		item.SeriesID = &item.ID
+	}
+	if canonical != item.Recurrence || item.SeriesStartAt == nil {
+		item.SeriesStartAt = item.DueAt
+	}
+	item.Recurrence = canonical
+	return nil
+}
+
+// createNextOccurrence adds the occurrence that follows a completed item,
+// unless it has already been materialized.
+func (s *todoItemService) createNextOccurrence(ctx context.Context, item *model.TodoItem) error {
+	occurrences, err := s.occurrencesAfter(item, item.DueAt, 1)
+	if err != nil || len(occurrences) == 0 {
+		return err
+	}
+	exists, err := s.items.ExistsInSeries(ctx, *item.SeriesID, occurrences[0])
+	if err != nil || exists {
+		return err
+	}
+	next := newOccurrence(item, occurrences[0])
+	if err := s.create(ctx, next); err != nil {
+		return err
+	}
+	return s.audit(ctx, model.AuditActionCreate, next.ID, nil, next.ToResponse())
+}
+
+// occurrencesAfter expands the item's series in its timezone and returns up
+// to count occurrences strictly after t. Items without a due date have no
+// occurrences.
+func (s *todoItemService) occurrencesAfter(item *model.TodoItem, t *time.Time, count int) ([]time.Time, error) {
+	if t == nil {
+		return nil, nil
+	}
+	rule, err := rrule.Parse(item.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+	location := s.location
+	if item.Timezone != "" {
+		if location, err = time.LoadLocation(item.Timezone); err != nil {
+			return nil, err
+		}
+	}
+	start := item.DueAt
+	if item.SeriesStartAt != nil {
+		start = item.SeriesStartAt
+	}
+
+	var occurrences []time.Time
+	it := rule.Iterator(start.In(location))
+	for len(occurrences) < count {
+		occurrence, ok := it.Next()
+		if !ok {
+			break
+		}
+		if occurrence.After(*t) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences, nil
+}
+
+// newOccurrence copies the recurring parts of item into an open item due at
+// dueAt.
+func newOccurrence(item *model.TodoItem, dueAt time.Time) *model.TodoItem {
+	return &model.TodoItem{
+		TodoListID:    item.TodoListID,
+		ParentID:      item.ParentID,
+		Title:         item.Title,
+		Description:   item.Description,
+		Priority:      item.Priority,
+		DueAt:         &dueAt,
+		Recurrence:    item.Recurrence,
+		Timezone:      item.Timezone,
+		SeriesID:      item.SeriesID,
+		SeriesStartAt: item.SeriesStartAt,
+	}
+}
+
+// sameTime reports whether a and b are both unset or the same instant.
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// completeSubtasks marks the pending subtasks of a completed item done. Only
+// the item itself spawns a next occurrence when it recurs.
+func (s *todoItemService) completeSubtasks(ctx context.Context, item *model.TodoItem) error {
+	items, err := s.items.FindSubtree(ctx, item.TodoListID, item.ID)
+	if err != nil {
+		return err
+	}
+	for _, subtask := range items[1:] {
+		if !pending(subtask.Status) {
+			continue
+		}
+		before := subtask
+		s.setStatus(&subtask, model.StatusDone)
+		if err := s.items.Update(ctx, &subtask); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, model.AuditActionUpdate, subtask.ID, before.ToResponse(), subtask.ToResponse()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reopen marks the done items among ancestors open again once a pending
+// subtask appears below them.
+func (s *todoItemService) reopen(ctx context.Context, ancestors []model.TodoItem) error {
+	for _, ancestor := range ancestors {
+		if ancestor.Status != model.StatusDone {
+			continue
+		}
+		before := ancestor
+		s.setStatus(&ancestor, model.StatusOpen)
+		if err := s.items.Update(ctx, &ancestor); err != nil {
+			return err
+		}
+		if err := s.audit(ctx, model.AuditActionUpdate, ancestor.ID, before.ToResponse(), ancestor.ToResponse()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// moveSubtree moves the subtasks of an item that has moved to another list,
+// and the reminders, attachments and comments of the item and its subtasks,
+// after it. subtree is the item followed by its subtasks as returned by
+// FindSubtree.
+func (s *todoItemService) moveSubtree(ctx context.Context, subtree []model.TodoItem, target uuid.UUID) error {
+	ids := make([]uuid.UUID, len(subtree))
+	for i, item := range subtree {
+		ids[i] = item.ID
+	}
+	if len(ids) > 1 {
+		if err := s.items.MoveToTodoList(ctx, ids[1:], target); err != nil {
+			return err
+		}
+	}
+	for _, id := range ids {
+		if err := s.reminders.MoveToTodoList(ctx, id, target); err != nil {
+			return err
+		}
+		if err := s.attachments.MoveToTodoList(ctx, id, target); err != nil {
+			return err
+		}
+		if err := s.comments.MoveToTodoList(ctx, id, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findParentChain returns the item a new subtask goes under followed by its
+// ancestors, nearest first, and checks that the subtask stays within
+// model.MaxSubtaskDepth.
+func (s *todoItemService) findParentChain(ctx context.Context, todoListID, parentID uuid.UUID) ([]model.TodoItem, error) {
+	parent, err := s.findItem(ctx, todoListID, parentID)
+	if err != nil {
+		return nil, err
+	}
+	ancestors, err := s.items.FindAncestors(ctx, parentID)
+	if err != nil {
+		s.logger.Error("find todo item ancestors failed", zap.String("id", parentID.String()), zap.Error(err))
+		return nil, fmt.Errorf("find todo item ancestors: %w", err)
+	}
+	if len(ancestors)+1 > model.MaxSubtaskDepth {
+		return nil, ErrSubtaskTooDeep
+	}
+	return append([]model.TodoItem{*parent}, ancestors...), nil
+}
+
+// buildTree nests the items returned by FindSubtree under their root and
+// rolls up the progress of every item with subtasks.
+func buildTree(items []model.TodoItem) model.TodoItemResponse {
+	children := make(map[uuid.UUID][]model.TodoItem)
+	for _, item := range items[1:] {
+		children[*item.ParentID] = append(children[*item.ParentID], item)
+	}
+	var build func(item model.TodoItem) model.TodoItemResponse
+	build = func(item model.TodoItem) model.TodoItemResponse {
+		response := item.ToResponse()
+		if len(children[item.ID]) == 0 {
+			return response
+		}
+		progress := model.Progress{}
+		for _, child := range children[item.ID] {
+			subtask := build(child)
+			if child.Status != model.StatusArchived {
+				progress.Total++
+			}
+			if child.Status == model.StatusDone {
+				progress.Done++
+			}
+			if subtask.Progress != nil {
+				progress.Total += subtask.Progress.Total
+				progress.Done += subtask.Progress.Done
+			}
+			response.Subtasks = append(response.Subtasks, subtask)
+		}
+		response.Progress = &progress
+		return response
+	}
+	return build(items[0])
+}
+
+// pending reports whether status is one of model.PendingStatuses.
+func pending(status string) bool {
+	return slices.Contains(model.PendingStatuses, status)
+}
+
+// setStatus changes the item's status and keeps CompletedAt in step with it.
+func (s *todoItemService) setStatus(item *model.TodoItem, status string) {
+	if status == item.Status {
+		return
+	}
+	item.Status = status
+	if status == model.StatusDone {
+		now := s.clock.Now()
+		item.CompletedAt = &now
+	} else if status != model.StatusArchived {
+		item.CompletedAt = nil
+	}
+}
+
+func (s *todoItemService) findList(ctx context.Context, todoListID uuid.UUID) (*model.TodoList, error) {
+	todoList, err := s.todoLists.FindByID(ctx, todoListID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return nil, err
+		}
+		s.logger.Error("retrieve todo list for items failed", zap.String("todo_list_id", todoListID.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo list: %w", err)
+	}
+	return todoList, nil
+}
+
+// authorizeList checks that the caller may change the items of the list.
+func (s *todoItemService) authorizeList(ctx context.Context, todoListID uuid.UUID) error {
+	todoList, err := s.findList(ctx, todoListID)
+	if err != nil {
+		return err
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("todo item change denied", zap.String("todo_list_id", todoListID.String()))
+		return err
+	}
+	return nil
+}
+
+func (s *todoItemService) findSubtree(ctx context.Context, todoListID, id uuid.UUID) ([]model.TodoItem, error) {
+	items, err := s.items.FindSubtree(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get todo item subtree failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo item: %w", err)
+	}
+	return items, nil
+}
+
+func (s *todoItemService) findItem(ctx context.Context, todoListID, id uuid.UUID) (*model.TodoItem, error) {
+	item, err := s.items.FindByID(ctx, todoListID, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoItemNotFound) {
+			return nil, err
+		}
+		s.logger.Error("get todo item failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo item: %w", err)
+	}
+	return item, nil
+}
+
+// create stores a new item at the end of its list.
+func (s *todoItemService) create(ctx context.Context, item *model.TodoItem) error {
+	rank, err := placeRank(ctx, itemSiblings{items: s.items, todoListID: item.TodoListID}, item.ID, nil, nil)
+	if err != nil {
+		return err
+	}
+	item.Rank = rank
+	return s.items.Create(ctx, item)
+}
+
+func (s *todoItemService) audit(ctx context.Context, action string, id uuid.UUID, before, after interface{}) error {
+	auditLog, err := newAuditLog(ctx, action, model.AuditEntityTodoItem, id, before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditLogs.Create(ctx, auditLog)
+}
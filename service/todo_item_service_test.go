@@ -0,0 +1,528 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type todoItemFixture struct {
+	dir         string
+	items       *mocks.TodoItemRepositoryMock
+	todoLists   *mocks.TodoListRepositoryMock
+	reminders   *mocks.ReminderRepositoryMock
+	attachments *mocks.AttachmentRepositoryMock
+	comments    *mocks.CommentRepositoryMock
+	auditLogs   *mocks.AuditLogRepositoryMock
+	clock       *mocks.FakeClock
+	svc         service.TodoItemService
+}
+
+func newTodoItemFixture(t *testing.T) todoItemFixture {
+	t.Helper()
+
+	f := todoItemFixture{
+		dir:         t.TempDir(),
+		items:       new(mocks.TodoItemRepositoryMock),
+		todoLists:   new(mocks.TodoListRepositoryMock),
+		reminders:   new(mocks.ReminderRepositoryMock),
+		attachments: new(mocks.AttachmentRepositoryMock),
+		comments:    new(mocks.CommentRepositoryMock),
+		auditLogs:   new(mocks.AuditLogRepositoryMock),
+		clock:       mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewTodoItemService(f.items, f.todoLists, f.reminders, f.attachments, f.comments, f.auditLogs, &blob.Local{Dir: f.dir}, mocks.FakeTransactor{}, f.clock, time.UTC, zaptest.NewLogger(t))
+	return f
+}
+
+func TestTodoItemService_CreateTodoItem_Success(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	dueAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("LastRank", mock.Anything, listID, mock.Anything).Return("i", nil)
+	f.items.On("Create", mock.Anything, mock.MatchedBy(func(item *model.TodoItem) bool {
+		return item.TodoListID == listID && item.Title == "Milk" && item.Priority == model.PriorityHigh && item.DueAt.Equal(dueAt)
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionCreate && auditLog.EntityType == model.AuditEntityTodoItem
+	})).Return(nil)
+
+	res, err := f.svc.CreateTodoItem(claimsContext("user-1", auth.RoleMember), listID, model.CreateTodoItemRequest{
+		Title:    "Milk",
+		Priority: model.PriorityHigh,
+		DueAt:    &dueAt,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Milk", res.Title)
+	require.Equal(t, "r", res.Rank, "new items go last")
+	f.items.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoItemService_CreateTodoItem_ForbiddenForNonOwner(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+
+	_, err := f.svc.CreateTodoItem(claimsContext("user-2", auth.RoleMember), listID, model.CreateTodoItemRequest{Title: "Milk"})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.items.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTodoItemService_UpdateTodoItem_CompletionTracksStatus(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	item := &model.TodoItem{ID: itemID, TodoListID: listID, Title: "Milk", Status: model.StatusOpen, Priority: model.PriorityLow}
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, itemID).Return(item, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, itemID).Return([]model.TodoItem{*item}, nil)
+	f.items.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+	ctx := claimsContext("user-1", auth.RoleMember)
+
+	res, err := f.svc.UpdateTodoItem(ctx, listID, itemID, model.UpdateTodoItemRequest{Title: "Milk", Status: model.StatusDone})
+	require.NoError(t, err)
+	require.Equal(t, model.StatusDone, res.Status)
+	require.Equal(t, model.PriorityLow, res.Priority)
+	require.NotNil(t, res.CompletedAt)
+	require.True(t, res.CompletedAt.Equal(f.clock.Now()))
+
+	res, err = f.svc.UpdateTodoItem(ctx, listID, itemID, model.UpdateTodoItemRequest{Title: "Milk", Status: model.StatusOpen})
+	require.NoError(t, err)
+	require.Nil(t, res.CompletedAt)
+}
+
+func TestTodoItemService_GetTodoItem_NotFound(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID}, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, itemID).Return(nil, repository.ErrTodoItemNotFound)
+
+	_, err := f.svc.GetTodoItem(claimsContext("user-2", auth.RoleViewer), listID, itemID)
+	require.ErrorIs(t, err, repository.ErrTodoItemNotFound)
+}
+
+func TestTodoItemService_ListTodoItems_PassesFilter(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	filter := model.TodoItemFilter{DueFilter: model.DueFilter{Overdue: true, Priority: model.PriorityUrgent}}
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID}, nil)
+	f.items.On("FindByTodoListID", mock.Anything, listID, filter).Return([]model.TodoItem{{ID: uuid.New(), Title: "Taxes"}}, nil)
+	f.items.On("CountSubtasks", mock.Anything, mock.Anything).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	items, err := f.svc.ListTodoItems(claimsContext("user-2", auth.RoleViewer), listID, filter)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	f.items.AssertExpectations(t)
+}
+
+func recurringItem(listID uuid.UUID, dueAt time.Time, recurrence, timezone string) *model.TodoItem {
+	id := uuid.New()
+	return &model.TodoItem{
+		ID:            id,
+		TodoListID:    listID,
+		Title:         "Take out the bins",
+		Status:        model.StatusOpen,
+		Priority:      model.PriorityMedium,
+		DueAt:         &dueAt,
+		Recurrence:    recurrence,
+		Timezone:      timezone,
+		SeriesID:      &id,
+		SeriesStartAt: &dueAt,
+	}
+}
+
+func TestTodoItemService_CreateTodoItem_StartsSeries(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	dueAt := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("LastRank", mock.Anything, listID, mock.Anything).Return("", nil)
+	f.items.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := f.svc.CreateTodoItem(claimsContext("user-1", auth.RoleMember), listID, model.CreateTodoItemRequest{
+		Title:      "Take out the bins",
+		DueAt:      &dueAt,
+		Recurrence: "RRULE:FREQ=WEEKLY;WKST=MO;BYDAY=MO",
+		Timezone:   "America/New_York",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "FREQ=WEEKLY;BYDAY=MO", res.Recurrence)
+	require.NotNil(t, res.SeriesID)
+	require.Equal(t, res.ID, *res.SeriesID)
+}
+
+func TestTodoItemService_UpdateTodoItem_CompletionCreatesNextOccurrenceAcrossDST(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	// 09:00 in New York on the Monday before daylight saving time ends.
+	item := recurringItem(listID, time.Date(2026, 10, 26, 13, 0, 0, 0, time.UTC), "FREQ=WEEKLY;BYDAY=MO", "America/New_York")
+	nextDueAt := time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC)
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, item.ID).Return(item, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, item.ID).Return([]model.TodoItem{*item}, nil)
+	f.items.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.items.On("ExistsInSeries", mock.Anything, *item.SeriesID, mock.MatchedBy(nextDueAt.Equal)).Return(false, nil)
+	f.items.On("LastRank", mock.Anything, listID, mock.Anything).Return("", nil)
+	f.items.On("Create", mock.Anything, mock.MatchedBy(func(next *model.TodoItem) bool {
+		return next.DueAt.Equal(nextDueAt) && *next.SeriesID == *item.SeriesID &&
+			next.Status == "" && next.CompletedAt == nil && next.Recurrence == item.Recurrence
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := f.svc.UpdateTodoItem(claimsContext("user-1", auth.RoleMember), listID, item.ID, model.UpdateTodoItemRequest{
+		Title:      item.Title,
+		Status:     model.StatusDone,
+		DueAt:      item.DueAt,
+		Recurrence: item.Recurrence,
+		Timezone:   item.Timezone,
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.StatusDone, res.Status)
+	f.items.AssertExpectations(t)
+	f.auditLogs.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestTodoItemService_UpdateTodoItem_CompletionSkipsMaterializedOccurrence(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	item := recurringItem(listID, time.Date(2026, 10, 20, 7, 0, 0, 0, time.UTC), "FREQ=DAILY", "")
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, item.ID).Return(item, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, item.ID).Return([]model.TodoItem{*item}, nil)
+	f.items.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.items.On("ExistsInSeries", mock.Anything, *item.SeriesID, mock.Anything).Return(true, nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := f.svc.UpdateTodoItem(claimsContext("user-1", auth.RoleMember), listID, item.ID, model.UpdateTodoItemRequest{
+		Title:      item.Title,
+		Status:     model.StatusDone,
+		DueAt:      item.DueAt,
+		Recurrence: item.Recurrence,
+	})
+	require.NoError(t, err)
+	f.items.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTodoItemService_MaterializeOccurrences_UsesDefaultLocation(t *testing.T) {
+	items := new(mocks.TodoItemRepositoryMock)
+	todoLists := new(mocks.TodoListRepositoryMock)
+	auditLogs := new(mocks.AuditLogRepositoryMock)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	svc := service.NewTodoItemService(items, todoLists, new(mocks.ReminderRepositoryMock), new(mocks.AttachmentRepositoryMock), new(mocks.CommentRepositoryMock), auditLogs, &blob.Local{Dir: t.TempDir()}, mocks.FakeTransactor{},
+		mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)), berlin, zaptest.NewLogger(t))
+
+	listID := uuid.New()
+	// 08:00 in Berlin on the Saturday before daylight saving time ends.
+	item := recurringItem(listID, time.Date(2026, 10, 24, 6, 0, 0, 0, time.UTC), "FREQ=DAILY;COUNT=3", "")
+	todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	items.On("FindByID", mock.Anything, listID, item.ID).Return(item, nil)
+	items.On("FindLatestInSeries", mock.Anything, *item.SeriesID).Return(item, nil)
+	items.On("LastRank", mock.Anything, listID, mock.Anything).Return("", nil)
+	items.On("Create", mock.Anything, mock.Anything).Return(nil)
+	auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	created, err := svc.MaterializeOccurrences(claimsContext("user-1", auth.RoleMember), listID, item.ID, 5)
+	require.NoError(t, err)
+	require.Len(t, created, 2, "COUNT=3 leaves two occurrences after the first")
+	require.True(t, created[0].DueAt.Equal(time.Date(2026, 10, 25, 7, 0, 0, 0, time.UTC)))
+	require.True(t, created[1].DueAt.Equal(time.Date(2026, 10, 26, 7, 0, 0, 0, time.UTC)))
+}
+
+func TestTodoItemService_MaterializeOccurrences_NotRecurring(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID, TodoListID: listID}, nil)
+
+	_, err := f.svc.MaterializeOccurrences(claimsContext("user-1", auth.RoleMember), listID, itemID, 4)
+	require.ErrorIs(t, err, service.ErrNotRecurring)
+}
+
+func TestTodoItemService_UpdateTodoItem_ReschedulesRemindersWhenDueDateMoves(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	dueAt := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	movedTo := dueAt.Add(48 * time.Hour)
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID, TodoListID: listID, Title: "Milk", DueAt: &dueAt}, nil)
+	f.items.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.reminders.On("Reschedule", mock.Anything, itemID, &movedTo).Return(nil).Once()
+	ctx := claimsContext("user-1", auth.RoleMember)
+
+	_, err := f.svc.UpdateTodoItem(ctx, listID, itemID, model.UpdateTodoItemRequest{Title: "Milk", DueAt: &movedTo})
+	require.NoError(t, err)
+	f.reminders.AssertExpectations(t)
+}
+
+func TestTodoItemService_MoveTodoItem_AcrossLists(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	itemID := uuid.New()
+	afterID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, fromID).Return(&model.TodoList{ID: fromID, OwnerID: "user-1"}, nil)
+	f.todoLists.On("FindByID", mock.Anything, toID).Return(&model.TodoList{ID: toID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, fromID, itemID).Return(&model.TodoItem{ID: itemID, TodoListID: fromID, Title: "Milk", Rank: "i"}, nil)
+	f.items.On("FindByID", mock.Anything, toID, afterID).Return(&model.TodoItem{ID: afterID, TodoListID: toID, Rank: "a"}, nil)
+	f.items.On("AdjacentRank", mock.Anything, toID, "a", []uuid.UUID{afterID, itemID}, true).Return("b", nil)
+	subtaskID := uuid.New()
+	f.items.On("FindSubtree", mock.Anything, fromID, itemID).Return([]model.TodoItem{
+		{ID: itemID, TodoListID: fromID},
+		{ID: subtaskID, TodoListID: fromID, ParentID: &itemID},
+	}, nil)
+	f.items.On("Move", mock.Anything, mock.MatchedBy(func(item *model.TodoItem) bool {
+		return item.TodoListID == toID && item.Rank == "ai"
+	})).Return(nil)
+	f.items.On("MoveToTodoList", mock.Anything, []uuid.UUID{subtaskID}, toID).Return(nil).Once()
+	f.reminders.On("MoveToTodoList", mock.Anything, itemID, toID).Return(nil).Once()
+	f.reminders.On("MoveToTodoList", mock.Anything, subtaskID, toID).Return(nil).Once()
+	f.attachments.On("MoveToTodoList", mock.Anything, itemID, toID).Return(nil).Once()
+	f.attachments.On("MoveToTodoList", mock.Anything, subtaskID, toID).Return(nil).Once()
+	f.comments.On("MoveToTodoList", mock.Anything, itemID, toID).Return(nil).Once()
+	f.comments.On("MoveToTodoList", mock.Anything, subtaskID, toID).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := f.svc.MoveTodoItem(claimsContext("user-1", auth.RoleMember), fromID, itemID, model.MoveTodoItemRequest{
+		TodoListID: &toID,
+		AfterID:    &afterID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, toID, res.TodoListID)
+	require.Equal(t, "ai", res.Rank)
+	f.items.AssertExpectations(t)
+	f.reminders.AssertExpectations(t)
+	f.attachments.AssertExpectations(t)
+	f.comments.AssertExpectations(t)
+}
+
+func TestTodoItemService_MoveTodoItem_RebalancesWhenNoRoom(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	itemID := uuid.New()
+	beforeID := uuid.New()
+	otherID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, itemID).Return(&model.TodoItem{ID: itemID, TodoListID: listID, Rank: "i"}, nil)
+	// The anchor predates manual ordering, so the list is rebalanced first.
+	f.items.On("FindByID", mock.Anything, listID, beforeID).Return(&model.TodoItem{ID: beforeID, TodoListID: listID}, nil).Once()
+	f.items.On("FindForRebalance", mock.Anything, listID).Return([]model.TodoItem{
+		{ID: otherID}, {ID: beforeID}, {ID: itemID, Rank: "i"},
+	}, nil)
+	f.items.On("SetRank", mock.Anything, otherID, "9").Return(nil).Once()
+	f.items.On("SetRank", mock.Anything, beforeID, "i").Return(nil).Once()
+	f.items.On("SetRank", mock.Anything, itemID, "r").Return(nil).Once()
+	f.items.On("FindByID", mock.Anything, listID, beforeID).Return(&model.TodoItem{ID: beforeID, TodoListID: listID, Rank: "i"}, nil).Once()
+	f.items.On("AdjacentRank", mock.Anything, listID, "i", []uuid.UUID{beforeID, itemID}, false).Return("9", nil)
+	f.items.On("Move", mock.Anything, mock.MatchedBy(func(item *model.TodoItem) bool {
+		return item.Rank == "e"
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := f.svc.MoveTodoItem(claimsContext("user-1", auth.RoleMember), listID, itemID, model.MoveTodoItemRequest{BeforeID: &beforeID})
+	require.NoError(t, err)
+	require.Equal(t, "e", res.Rank)
+	f.items.AssertExpectations(t)
+	f.reminders.AssertNotCalled(t, "MoveToTodoList", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoItemService_MoveTodoItem_ForbiddenOnTargetList(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	fromID := uuid.New()
+	toID := uuid.New()
+	itemID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, fromID).Return(&model.TodoList{ID: fromID, OwnerID: "user-1"}, nil)
+	f.todoLists.On("FindByID", mock.Anything, toID).Return(&model.TodoList{ID: toID, OwnerID: "user-2"}, nil)
+	f.items.On("FindByID", mock.Anything, fromID, itemID).Return(&model.TodoItem{ID: itemID, TodoListID: fromID}, nil)
+
+	_, err := f.svc.MoveTodoItem(claimsContext("user-1", auth.RoleMember), fromID, itemID, model.MoveTodoItemRequest{TodoListID: &toID})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.items.AssertNotCalled(t, "Move", mock.Anything, mock.Anything)
+}
+
+func TestTodoItemService_CreateTodoItem_SubtaskReopensDoneAncestors(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	rootID := uuid.New()
+	completedAt := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	parent := &model.TodoItem{ID: uuid.New(), TodoListID: listID, ParentID: &rootID, Status: model.StatusDone, CompletedAt: &completedAt}
+	root := model.TodoItem{ID: rootID, TodoListID: listID, Status: model.StatusDone, CompletedAt: &completedAt}
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, parent.ID).Return(parent, nil)
+	f.items.On("FindAncestors", mock.Anything, parent.ID).Return([]model.TodoItem{root}, nil)
+	f.items.On("LastRank", mock.Anything, listID, mock.Anything).Return("", nil)
+	f.items.On("Create", mock.Anything, mock.MatchedBy(func(item *model.TodoItem) bool {
+		return *item.ParentID == parent.ID
+	})).Return(nil)
+	f.items.On("Update", mock.Anything, mock.MatchedBy(func(item *model.TodoItem) bool {
+		return item.Status == model.StatusOpen && item.CompletedAt == nil
+	})).Return(nil).Twice()
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	res, err := f.svc.CreateTodoItem(claimsContext("user-1", auth.RoleMember), listID, model.CreateTodoItemRequest{
+		ParentID: &parent.ID,
+		Title:    "Buy oat milk",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &parent.ID, res.ParentID)
+	f.items.AssertExpectations(t)
+	f.auditLogs.AssertNumberOfCalls(t, "Create", 3)
+}
+
+func TestTodoItemService_CreateTodoItem_SubtaskTooDeep(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	parentID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, parentID).Return(&model.TodoItem{ID: parentID, TodoListID: listID}, nil)
+	f.items.On("FindAncestors", mock.Anything, parentID).Return(make([]model.TodoItem, model.MaxSubtaskDepth), nil)
+
+	_, err := f.svc.CreateTodoItem(claimsContext("user-1", auth.RoleMember), listID, model.CreateTodoItemRequest{
+		ParentID: &parentID,
+		Title:    "Too deep",
+	})
+	require.ErrorIs(t, err, service.ErrSubtaskTooDeep)
+	f.items.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestTodoItemService_UpdateTodoItem_CompletionCompletesPendingSubtasks(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	item := &model.TodoItem{ID: uuid.New(), TodoListID: listID, Title: "Move house", Status: model.StatusInProgress}
+	pendingID, doneID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindByID", mock.Anything, listID, item.ID).Return(item, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, item.ID).Return([]model.TodoItem{
+		*item,
+		{ID: pendingID, TodoListID: listID, ParentID: &item.ID, Status: model.StatusOpen},
+		{ID: doneID, TodoListID: listID, ParentID: &item.ID, Status: model.StatusDone},
+	}, nil)
+	f.items.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.TodoItem) bool {
+		return updated.ID == item.ID && updated.Status == model.StatusDone
+	})).Return(nil).Once()
+	f.items.On("Update", mock.Anything, mock.MatchedBy(func(updated *model.TodoItem) bool {
+		return updated.ID == pendingID && updated.Status == model.StatusDone && updated.CompletedAt != nil
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := f.svc.UpdateTodoItem(claimsContext("user-1", auth.RoleMember), listID, item.ID, model.UpdateTodoItemRequest{
+		Title:  item.Title,
+		Status: model.StatusDone,
+	})
+	require.NoError(t, err)
+	f.items.AssertExpectations(t)
+	f.items.AssertNumberOfCalls(t, "Update", 2)
+	f.auditLogs.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestTodoItemService_GetTodoItem_NestsSubtasksWithProgress(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	rootID, childID, grandchildID := uuid.New(), uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID}, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, rootID).Return([]model.TodoItem{
+		{ID: rootID, TodoListID: listID, Title: "Move house", Status: model.StatusOpen},
+		{ID: childID, TodoListID: listID, ParentID: &rootID, Title: "Pack", Status: model.StatusOpen},
+		{ID: uuid.New(), TodoListID: listID, ParentID: &rootID, Title: "Old flat", Status: model.StatusArchived},
+		{ID: grandchildID, TodoListID: listID, ParentID: &childID, Title: "Books", Status: model.StatusDone},
+	}, nil)
+
+	res, err := f.svc.GetTodoItem(claimsContext("user-2", auth.RoleViewer), listID, rootID)
+	require.NoError(t, err)
+	require.Equal(t, &model.Progress{Done: 1, Total: 2}, res.Progress)
+	require.Len(t, res.Subtasks, 2)
+	require.Equal(t, "Pack", res.Subtasks[0].Title)
+	require.Equal(t, &model.Progress{Done: 1, Total: 1}, res.Subtasks[0].Progress)
+	require.Len(t, res.Subtasks[0].Subtasks, 1)
+	require.Nil(t, res.Subtasks[1].Progress)
+}
+
+func TestTodoItemService_DeleteTodoItem_DeletesSubtasksFirst(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID := uuid.New()
+	rootID, childID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, rootID).Return([]model.TodoItem{
+		{ID: rootID, TodoListID: listID},
+		{ID: childID, TodoListID: listID, ParentID: &rootID},
+	}, nil)
+	var deleted []uuid.UUID
+	f.items.On("Delete", mock.Anything, listID, mock.Anything).Run(func(args mock.Arguments) {
+		deleted = append(deleted, args.Get(2).(uuid.UUID))
+	}).Return(nil)
+	f.reminders.On("DeleteByTodoItemID", mock.Anything, mock.Anything).Return(nil)
+	f.attachments.On("FindByTodoItemID", mock.Anything, mock.Anything).Return([]model.Attachment{}, nil)
+	f.attachments.On("DeleteByTodoItemID", mock.Anything, mock.Anything).Return(nil)
+	f.comments.On("DeleteByTodoItemID", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := f.svc.DeleteTodoItem(claimsContext("user-1", auth.RoleMember), listID, rootID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{childID, rootID}, deleted)
+	f.reminders.AssertNumberOfCalls(t, "DeleteByTodoItemID", 2)
+	f.attachments.AssertNumberOfCalls(t, "DeleteByTodoItemID", 2)
+	f.comments.AssertNumberOfCalls(t, "DeleteByTodoItemID", 2)
+	f.auditLogs.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestTodoItemService_DeleteTodoItem_DeletesAttachmentContents(t *testing.T) {
+	f := newTodoItemFixture(t)
+
+	listID, id := uuid.New(), uuid.New()
+	attachment := model.Attachment{ID: uuid.New(), TodoListID: listID, TodoItemID: &id, StorageKey: "workspace/attachment"}
+	require.NoError(t, (&blob.Local{Dir: f.dir}).Put(context.Background(), attachment.StorageKey, bytes.NewReader([]byte("milk")), 4, "text/plain"))
+	f.todoLists.On("FindByID", mock.Anything, listID).Return(&model.TodoList{ID: listID, OwnerID: "user-1"}, nil)
+	f.items.On("FindSubtree", mock.Anything, listID, id).Return([]model.TodoItem{{ID: id, TodoListID: listID}}, nil)
+	f.items.On("Delete", mock.Anything, listID, id).Return(nil)
+	f.reminders.On("DeleteByTodoItemID", mock.Anything, id).Return(nil)
+	f.attachments.On("FindByTodoItemID", mock.Anything, id).Return([]model.Attachment{attachment}, nil)
+	f.attachments.On("DeleteByTodoItemID", mock.Anything, id).Return(nil).Once()
+	f.comments.On("DeleteByTodoItemID", mock.Anything, id).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := f.svc.DeleteTodoItem(claimsContext("user-1", auth.RoleMember), listID, id)
+	require.NoError(t, err)
+	f.attachments.AssertExpectations(t)
+	_, err = os.Stat(filepath.Join(f.dir, "workspace", "attachment"))
+	require.True(t, os.IsNotExist(err), "contents are deleted with the item")
+}
@@ -2,63 +2,197 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/clock"
+	"github.com/lumoshiveacademy/todolist/package/placeholder"
+	"github.com/lumoshiveacademy/todolist/package/rank"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
 	"github.com/lumoshiveacademy/todolist/repository"
 	"go.uber.org/zap"
 )
 
+var (
+	// ErrForbidden indicates that the caller may not act on the requested resource.
+	ErrForbidden = errors.New("forbidden")
+	// ErrNotTemplate indicates that a list was instantiated without being a template.
+	ErrNotTemplate = errors.New("todo list is not a template")
+	// ErrMissingTemplateVariables indicates that a template was instantiated
+	// without values for all of its placeholders.
+	ErrMissingTemplateVariables = errors.New("missing template variables")
+)
+
+const (
+	// maxTitleLength is the longest title a list or item can have.
+	maxTitleLength = 255
+	// copySuffix is appended to the title of a duplicated list.
+	copySuffix = " (copy)"
+)
+
 // TodoListService defines business operations for todo lists.
 type TodoListService interface {
 	CreateTodoList(ctx context.Context, req model.CreateTodoListRequest) (model.TodoListResponse, error)
 	GetTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error)
-	ListTodoLists(ctx context.Context) ([]model.TodoListResponse, error)
+	GetTodoLists(ctx context.Context, ids []uuid.UUID) ([]model.TodoListResponse, error)
+	ListTodoLists(ctx context.Context, filter model.TodoListFilter) ([]model.TodoListResponse, error)
 	UpdateTodoList(ctx context.Context, id uuid.UUID, req model.UpdateTodoListRequest) (model.TodoListResponse, error)
 	DeleteTodoList(ctx context.Context, id uuid.UUID) error
+	ListRevisions(ctx context.Context, id uuid.UUID) ([]model.TodoListRevisionResponse, error)
+	ListRevisionsByTodoLists(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]model.TodoListRevisionResponse, error)
+	GetRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListRevisionResponse, error)
+	RestoreRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListResponse, error)
+	MoveTodoList(ctx context.Context, id uuid.UUID, req model.MoveTodoListRequest) (model.TodoListResponse, error)
+	DuplicateTodoList(ctx context.Context, id uuid.UUID, req model.DuplicateTodoListRequest) (model.TodoListResponse, error)
+	SetTemplate(ctx context.Context, id uuid.UUID, isTemplate bool) (model.TodoListResponse, error)
+	InstantiateTemplate(ctx context.Context, id uuid.UUID, req model.InstantiateTemplateRequest) (model.TodoListResponse, error)
+	ArchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error)
+	UnarchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error)
+}
+
+// TodoListPublisher receives the changes TodoListService commits, such as the
+// pubsub.Broker behind GraphQL subscriptions.
+type TodoListPublisher interface {
+	Publish(event model.TodoListEvent)
 }
 
 type todoListService struct {
-	repository repository.TodoListRepository
-	logger     *zap.Logger
+	repository  repository.TodoListRepository
+	items       repository.TodoItemRepository
+	revisions   repository.TodoListRevisionRepository
+	tags        repository.TagRepository
+	attachments repository.AttachmentRepository
+	comments    repository.CommentRepository
+	shareLinks  repository.ShareLinkRepository
+	auditLogs   repository.AuditLogRepository
+	store       blob.Store
+	transactor  repository.Transactor
+	events      TodoListPublisher
+	clock       clock.Clock
+	logger      *zap.Logger
 }
 
-// NewTodoListService constructs a TodoListService implementation.
-func NewTodoListService(repository repository.TodoListRepository, logger *zap.Logger) TodoListService {
+// NewTodoListService constructs a TodoListService implementation. Every
+// mutation is recorded in the audit log, and every written version in the
+// list's revision history, within the same transaction. Committed changes
+// are then published to events.
+func NewTodoListService(
+	repository repository.TodoListRepository,
+	items repository.TodoItemRepository,
+	revisions repository.TodoListRevisionRepository,
+	tags repository.TagRepository,
+	attachments repository.AttachmentRepository,
+	comments repository.CommentRepository,
+	shareLinks repository.ShareLinkRepository,
+	auditLogs repository.AuditLogRepository,
+	store blob.Store,
+	transactor repository.Transactor,
+	events TodoListPublisher,
+	clock clock.Clock,
+	logger *zap.Logger,
+) TodoListService {
 	return &todoListService{
-		repository: repository,
-		logger:     logger,
+		repository:  repository,
+		items:       items,
+		revisions:   revisions,
+		tags:        tags,
+		attachments: attachments,
+		comments:    comments,
+		shareLinks:  shareLinks,
+		auditLogs:   auditLogs,
+		store:       store,
+		transactor:  transactor,
+		events:      events,
+		clock:       clock,
+		logger:      logger,
 	}
 }
 
 func (s *todoListService) CreateTodoList(ctx context.Context, req model.CreateTodoListRequest) (model.TodoListResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.TodoListResponse{}, ErrForbidden
+	}
 	todoList := &model.TodoList{
+		OwnerID:     claims.Subject,
 		Title:       req.Title,
 		Description: req.Description,
+		Priority:    req.Priority,
+		DueAt:       req.DueAt,
 	}
-	if err := s.repository.Create(ctx, todoList); err != nil {
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// New lists go on top of the sidebar.
+		first, err := s.repository.FirstRank(ctx)
+		if err != nil {
+			return err
+		}
+		if todoList.Rank, err = rank.Between("", first); err != nil {
+			return err
+		}
+		if err := s.repository.Create(ctx, todoList); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, nil, todoList, nil); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionCreate, todoList.ID, nil, todoList.ToResponse())
+	})
+	if err != nil {
 		s.logger.Error("create todo list failed", zap.Error(err))
 		return model.TodoListResponse{}, fmt.Errorf("create todo list: %w", err)
 	}
 	s.logger.Info("todo list created", zap.String("id", todoList.ID.String()))
+	s.publish(ctx, model.TodoListEventCreated, todoList.ToResponse())
 	return todoList.ToResponse(), nil
 }
 
 func (s *todoListService) GetTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
-	todoList, err := s.repository.FindByID(ctx, id)
+	todoList, err := s.findTodoList(ctx, id)
 	if err != nil {
-		if err == repository.ErrTodoListNotFound {
-			return model.TodoListResponse{}, err
-		}
-		s.logger.Error("get todo list failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, err
+	}
+	responses := []model.TodoListResponse{todoList.ToResponse()}
+	if err := s.loadDetails(ctx, responses); err != nil {
 		return model.TodoListResponse{}, fmt.Errorf("get todo list: %w", err)
 	}
-	return todoList.ToResponse(), nil
+	return responses[0], nil
+}
+
+// GetTodoLists returns the lists with the given IDs in the order of ids,
+// loading them and their details with one query each. IDs of lists that do
+// not exist are skipped.
+func (s *todoListService) GetTodoLists(ctx context.Context, ids []uuid.UUID) ([]model.TodoListResponse, error) {
+	todoLists, err := s.repository.FindByIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("get todo lists failed", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, fmt.Errorf("get todo lists: %w", err)
+	}
+	byID := make(map[uuid.UUID]model.TodoList, len(todoLists))
+	for _, todoList := range todoLists {
+		byID[todoList.ID] = todoList
+	}
+	responses := make([]model.TodoListResponse, 0, len(todoLists))
+	for _, id := range ids {
+		if todoList, ok := byID[id]; ok {
+			responses = append(responses, todoList.ToResponse())
+			delete(byID, id)
+		}
+	}
+	if err := s.loadDetails(ctx, responses); err != nil {
+		return nil, fmt.Errorf("get todo lists: %w", err)
+	}
+	return responses, nil
 }
 
-func (s *todoListService) ListTodoLists(ctx context.Context) ([]model.TodoListResponse, error) {
-	todoLists, err := s.repository.FindAll(ctx)
+func (s *todoListService) ListTodoLists(ctx context.Context, filter model.TodoListFilter) ([]model.TodoListResponse, error) {
+	todoLists, err := s.repository.FindAll(ctx, filter)
 	if err != nil {
 		s.logger.Error("list todo lists failed", zap.Error(err))
 		return nil, fmt.Errorf("list todo lists: %w", err)
@@ -67,6 +201,9 @@ func (s *todoListService) ListTodoLists(ctx context.Context) ([]model.TodoListRe
 	for i, todoList := range todoLists {
 		responses[i] = todoList.ToResponse()
 	}
+	if err := s.loadDetails(ctx, responses); err != nil {
+		return nil, fmt.Errorf("list todo lists: %w", err)
+	}
 	return responses, nil
 }
 
@@ -79,26 +216,626 @@ func (s *todoListService) UpdateTodoList(ctx context.Context, id uuid.UUID, req
 		s.logger.Error("retrieve todo list for update failed", zap.String("id", id.String()), zap.Error(err))
 		return model.TodoListResponse{}, fmt.Errorf("get todo list: %w", err)
 	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("update todo list denied", zap.String("id", id.String()))
+		return model.TodoListResponse{}, err
+	}
 
-	todoList.Title = req.Title
-	todoList.Description = req.Description
-
-	if err := s.repository.Update(ctx, todoList); err != nil {
+	apply := func(todoList *model.TodoList) {
+		todoList.Title = req.Title
+		todoList.Description = req.Description
+		todoList.DueAt = req.DueAt
+		if req.Status != "" {
+			todoList.Status = req.Status
+		}
+		if req.Priority != "" {
+			todoList.Priority = req.Priority
+		}
+	}
+	if err := s.applyUpdate(ctx, todoList, apply, nil); err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
 		s.logger.Error("update todo list failed", zap.String("id", id.String()), zap.Error(err))
 		return model.TodoListResponse{}, fmt.Errorf("update todo list: %w", err)
 	}
 	s.logger.Info("todo list updated", zap.String("id", id.String()))
-	return todoList.ToResponse(), nil
+	return s.publishWithDetails(ctx, model.TodoListEventUpdated, todoList), nil
 }
 
 func (s *todoListService) DeleteTodoList(ctx context.Context, id uuid.UUID) error {
-	if err := s.repository.Delete(ctx, id); err != nil {
+	todoList, err := s.repository.FindByID(ctx, id)
+	if err != nil {
 		if err == repository.ErrTodoListNotFound {
 			return err
 		}
+		s.logger.Error("retrieve todo list for delete failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("get todo list: %w", err)
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("delete todo list denied", zap.String("id", id.String()))
+		return err
+	}
+
+	var attachments []model.Attachment
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Delete(ctx, id); err != nil {
+			return err
+		}
+		if err := s.items.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.revisions.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.tags.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		found, err := s.attachments.FindByTodoListID(ctx, id)
+		if err != nil {
+			return err
+		}
+		attachments = found
+		if err := s.attachments.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.comments.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.shareLinks.DeleteByTodoListID(ctx, id); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionDelete, id, todoList.ToResponse(), nil)
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return err
+		}
 		s.logger.Error("delete todo list failed", zap.String("id", id.String()), zap.Error(err))
 		return fmt.Errorf("delete todo list: %w", err)
 	}
+	// Contents go once the records are committed away, so a rollback never
+	// leaves an attachment without them.
+	deleteContents(ctx, s.store, attachments, s.logger)
 	s.logger.Info("todo list deleted", zap.String("id", id.String()))
+	s.publish(ctx, model.TodoListEventDeleted, todoList.ToResponse())
+	return nil
+}
+
+func (s *todoListService) ListRevisions(ctx context.Context, id uuid.UUID) ([]model.TodoListRevisionResponse, error) {
+	if _, err := s.findTodoList(ctx, id); err != nil {
+		return nil, err
+	}
+	revisions, err := s.revisions.FindByTodoListID(ctx, id)
+	if err != nil {
+		s.logger.Error("list revisions failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("list revisions: %w", err)
+	}
+	responses := make([]model.TodoListRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		responses[i] = revision.ToResponse()
+	}
+	return responses, nil
+}
+
+// ListRevisionsByTodoLists returns the revisions of several lists, newest
+// first, with one query. Unlike ListRevisions it does not look the lists up,
+// so callers pass the IDs of lists they already loaded.
+func (s *todoListService) ListRevisionsByTodoLists(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]model.TodoListRevisionResponse, error) {
+	revisions, err := s.revisions.FindByTodoListIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("list revisions by todo lists failed", zap.Int("count", len(ids)), zap.Error(err))
+		return nil, fmt.Errorf("list revisions: %w", err)
+	}
+	responses := make(map[uuid.UUID][]model.TodoListRevisionResponse, len(ids))
+	for _, revision := range revisions {
+		responses[revision.TodoListID] = append(responses[revision.TodoListID], revision.ToResponse())
+	}
+	return responses, nil
+}
+
+func (s *todoListService) GetRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListRevisionResponse, error) {
+	if _, err := s.findTodoList(ctx, id); err != nil {
+		return model.TodoListRevisionResponse{}, err
+	}
+	revision, err := s.revisions.FindByNumber(ctx, id, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			return model.TodoListRevisionResponse{}, err
+		}
+		s.logger.Error("get revision failed", zap.String("id", id.String()), zap.Int("number", number), zap.Error(err))
+		return model.TodoListRevisionResponse{}, fmt.Errorf("get revision: %w", err)
+	}
+	return revision.ToResponse(), nil
+}
+
+// RestoreRevision writes the content of an earlier revision as the list's
+// current version. History is kept: the restore appends a new revision.
+func (s *todoListService) RestoreRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListResponse, error) {
+	todoList, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		if err == repository.ErrTodoListNotFound {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("retrieve todo list for restore failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("get todo list: %w", err)
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("restore todo list denied", zap.String("id", id.String()))
+		return model.TodoListResponse{}, err
+	}
+	revision, err := s.revisions.FindByNumber(ctx, id, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("retrieve revision for restore failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("get revision: %w", err)
+	}
+
+	apply := func(todoList *model.TodoList) {
+		todoList.Title = revision.Title
+		todoList.Description = revision.Description
+	}
+	if err := s.applyUpdate(ctx, todoList, apply, &number); err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("restore todo list failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("restore todo list: %w", err)
+	}
+	s.logger.Info("todo list restored", zap.String("id", id.String()), zap.Int("revision", number))
+	return s.publishWithDetails(ctx, model.TodoListEventUpdated, todoList), nil
+}
+
+// MoveTodoList repositions the list in the workspace's sidebar order. Only
+// the list's row is rewritten unless its new neighbours leave no room for a
+// rank.
+func (s *todoListService) MoveTodoList(ctx context.Context, id uuid.UUID, req model.MoveTodoListRequest) (model.TodoListResponse, error) {
+	todoList, err := s.findTodoList(ctx, id)
+	if err != nil {
+		return model.TodoListResponse{}, err
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("move todo list denied", zap.String("id", id.String()))
+		return model.TodoListResponse{}, err
+	}
+
+	before := *todoList
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if todoList.Rank, err = placeRank(ctx, listSiblings{todoLists: s.repository}, id, req.AfterID, req.BeforeID); err != nil {
+			return err
+		}
+		if err := s.repository.UpdateRank(ctx, todoList); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionUpdate, id, before.ToResponse(), todoList.ToResponse())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("move todo list failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("move todo list: %w", err)
+	}
+	s.logger.Info("todo list moved", zap.String("id", id.String()))
+	return s.publishWithDetails(ctx, model.TodoListEventUpdated, todoList), nil
+}
+
+// DuplicateTodoList copies a list, its items, subtasks and tags into a new
+// list owned by the caller. Every workspace member who can read a list can
+// duplicate it.
+func (s *todoListService) DuplicateTodoList(ctx context.Context, id uuid.UUID, req model.DuplicateTodoListRequest) (model.TodoListResponse, error) {
+	source, err := s.findTodoList(ctx, id)
+	if err != nil {
+		return model.TodoListResponse{}, err
+	}
+
+	todoList, err := s.copyTodoList(ctx, source, func(todoList *model.TodoList, _ []model.TodoItem) error {
+		todoList.Title = req.Title
+		if todoList.Title == "" {
+			todoList.Title = clip(source.Title, maxTitleLength-utf8.RuneCountInString(copySuffix)) + copySuffix
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrForbidden) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("duplicate todo list failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("duplicate todo list: %w", err)
+	}
+	s.logger.Info("todo list duplicated", zap.String("id", id.String()), zap.String("copy_id", todoList.ID.String()))
+	return s.publishWithDetails(ctx, model.TodoListEventCreated, todoList), nil
+}
+
+// SetTemplate adds the list to the workspace's template library or moves it
+// back to the workspace's lists.
+func (s *todoListService) SetTemplate(ctx context.Context, id uuid.UUID, isTemplate bool) (model.TodoListResponse, error) {
+	todoList, err := s.findTodoList(ctx, id)
+	if err != nil {
+		return model.TodoListResponse{}, err
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("set todo list template denied", zap.String("id", id.String()))
+		return model.TodoListResponse{}, err
+	}
+	if todoList.IsTemplate == isTemplate {
+		return s.responseWithDetails(ctx, todoList), nil
+	}
+
+	before := *todoList
+	todoList.IsTemplate = isTemplate
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.UpdateTemplate(ctx, todoList); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionUpdate, id, before.ToResponse(), todoList.ToResponse())
+	})
+	if err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("set todo list template failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("set todo list template: %w", err)
+	}
+	s.logger.Info("todo list template updated", zap.String("id", id.String()), zap.Bool("is_template", isTemplate))
+	return s.publishWithDetails(ctx, model.TodoListEventUpdated, todoList), nil
+}
+
+// InstantiateTemplate creates a list owned by the caller from a template,
+// replacing the {{name}} placeholders in its titles and descriptions with
+// req.Variables. Templates are shared with the whole workspace, and the new
+// list and its items start open.
+func (s *todoListService) InstantiateTemplate(ctx context.Context, id uuid.UUID, req model.InstantiateTemplateRequest) (model.TodoListResponse, error) {
+	template, err := s.findTodoList(ctx, id)
+	if err != nil {
+		return model.TodoListResponse{}, err
+	}
+	if !template.IsTemplate {
+		return model.TodoListResponse{}, ErrNotTemplate
+	}
+
+	todoList, err := s.copyTodoList(ctx, template, func(todoList *model.TodoList, items []model.TodoItem) error {
+		if req.Title != "" {
+			todoList.Title = req.Title
+		}
+		texts := []string{todoList.Title, todoList.Description}
+		for _, item := range items {
+			texts = append(texts, item.Title, item.Description)
+		}
+		var missing []string
+		for _, name := range placeholder.Names(texts...) {
+			if _, ok := req.Variables[name]; !ok {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%w: %s", ErrMissingTemplateVariables, strings.Join(missing, ", "))
+		}
+
+		todoList.Title = clip(placeholder.Expand(todoList.Title, req.Variables), maxTitleLength)
+		todoList.Description = placeholder.Expand(todoList.Description, req.Variables)
+		todoList.Status = model.StatusOpen
+		todoList.IsTemplate = false
+		for i := range items {
+			items[i].Title = clip(placeholder.Expand(items[i].Title, req.Variables), maxTitleLength)
+			items[i].Description = placeholder.Expand(items[i].Description, req.Variables)
+			items[i].Status = model.StatusOpen
+			items[i].CompletedAt = nil
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrForbidden) || errors.Is(err, ErrMissingTemplateVariables) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("instantiate template failed", zap.String("id", id.String()), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("instantiate template: %w", err)
+	}
+	s.logger.Info("template instantiated", zap.String("id", id.String()), zap.String("todo_list_id", todoList.ID.String()))
+	return s.publishWithDetails(ctx, model.TodoListEventCreated, todoList), nil
+}
+
+// ArchiveTodoList hides the list from default listings. Archiving an
+// archived list keeps its original archive time.
+func (s *todoListService) ArchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+	return s.setArchived(ctx, id, true)
+}
+
+// UnarchiveTodoList returns an archived list to default listings.
+func (s *todoListService) UnarchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+	return s.setArchived(ctx, id, false)
+}
+
+func (s *todoListService) setArchived(ctx context.Context, id uuid.UUID, archive bool) (model.TodoListResponse, error) {
+	todoList, err := s.findTodoList(ctx, id)
+	if err != nil {
+		return model.TodoListResponse{}, err
+	}
+	if err := authorizeOwner(ctx, todoList); err != nil {
+		s.logger.Warn("archive todo list denied", zap.String("id", id.String()))
+		return model.TodoListResponse{}, err
+	}
+	if (todoList.ArchivedAt != nil) == archive {
+		return s.responseWithDetails(ctx, todoList), nil
+	}
+
+	before := *todoList
+	todoList.ArchivedAt = nil
+	if archive {
+		now := s.clock.Now()
+		todoList.ArchivedAt = &now
+	}
+	if err := archiveTodoList(ctx, s.repository, s.auditLogs, s.transactor, &before, todoList); err != nil {
+		if errors.Is(err, repository.ErrTodoListNotFound) {
+			return model.TodoListResponse{}, err
+		}
+		s.logger.Error("archive todo list failed", zap.String("id", id.String()), zap.Bool("archive", archive), zap.Error(err))
+		return model.TodoListResponse{}, fmt.Errorf("archive todo list: %w", err)
+	}
+	s.logger.Info("todo list archive state changed", zap.String("id", id.String()), zap.Bool("archived", archive))
+	return s.publishWithDetails(ctx, model.TodoListEventUpdated, todoList), nil
+}
+
+// copyTodoList creates a copy of source owned by the caller on top of the
+// sidebar, together with its items and tags, in one transaction. prepare
+// adjusts the copy and its items before anything is written.
+func (s *todoListService) copyTodoList(ctx context.Context, source *model.TodoList, prepare func(*model.TodoList, []model.TodoItem) error) (*model.TodoList, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, ErrForbidden
+	}
+	todoList := &model.TodoList{
+		ID:          uuid.New(),
+		OwnerID:     claims.Subject,
+		Title:       source.Title,
+		Description: source.Description,
+		Status:      source.Status,
+		Priority:    source.Priority,
+		DueAt:       source.DueAt,
+		IsTemplate:  source.IsTemplate,
+	}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		sourceItems, err := s.items.FindByTodoListID(ctx, source.ID, model.TodoItemFilter{})
+		if err != nil {
+			return err
+		}
+		items := copyItems(sourceItems, todoList.ID)
+		if err := prepare(todoList, items); err != nil {
+			return err
+		}
+		tags, err := s.tags.FindByTodoListIDs(ctx, []uuid.UUID{source.ID})
+		if err != nil {
+			return err
+		}
+
+		first, err := s.repository.FirstRank(ctx)
+		if err != nil {
+			return err
+		}
+		if todoList.Rank, err = rank.Between("", first); err != nil {
+			return err
+		}
+		if err := s.repository.Create(ctx, todoList); err != nil {
+			return err
+		}
+		if err := s.items.CreateAll(ctx, items); err != nil {
+			return err
+		}
+		for _, tag := range tags[source.ID] {
+			if err := s.tags.Attach(ctx, todoList.ID, tag.ID); err != nil {
+				return err
+			}
+		}
+		if err := s.recordRevision(ctx, nil, todoList, nil); err != nil {
+			return err
+		}
+		return s.audit(ctx, model.AuditActionCreate, todoList.ID, nil, todoList.ToResponse())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return todoList, nil
+}
+
+// copyItems copies items, given in display order, into the list todoListID
+// under new IDs. Subtasks point at the copies of their parents, every
+// recurring series gets a new series ID, and fresh evenly spaced ranks keep
+// the original order.
+func copyItems(items []model.TodoItem, todoListID uuid.UUID) []model.TodoItem {
+	ids := make(map[uuid.UUID]uuid.UUID, len(items))
+	for _, item := range items {
+		ids[item.ID] = uuid.New()
+	}
+	series := make(map[uuid.UUID]uuid.UUID)
+	ranks := rank.Spread(len(items))
+
+	copies := make([]model.TodoItem, len(items))
+	for i, item := range items {
+		item.ID = ids[item.ID]
+		item.TodoListID = todoListID
+		item.Rank = ranks[i]
+		item.CreatedAt = time.Time{}
+		item.UpdatedAt = time.Time{}
+		if item.ParentID != nil {
+			parentID := ids[*item.ParentID]
+			item.ParentID = &parentID
+		}
+		if item.SeriesID != nil {
+			seriesID, ok := series[*item.SeriesID]
+			if !ok {
+				seriesID = uuid.New()
+				series[*item.SeriesID] = seriesID
+			}
+			item.SeriesID = &seriesID
+		}
+		copies[i] = item
+	}
+	return copies
+}
+
+// clip shortens s to at most n characters.
+func clip(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// applyUpdate applies changes to todoList and writes it together with its
+// revision and audit entry. restoredFrom is set when the content comes from
+// an old revision.
+func (s *todoListService) applyUpdate(ctx context.Context, todoList *model.TodoList, apply func(*model.TodoList), restoredFrom *int) error {
+	before := *todoList
+	apply(todoList)
+
+	action := model.AuditActionUpdate
+	if restoredFrom != nil {
+		action = model.AuditActionRestore
+	}
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// Updating the list first locks its row, which serializes revision numbering.
+		if err := s.repository.Update(ctx, todoList); err != nil {
+			return err
+		}
+		if err := s.recordRevision(ctx, &before, todoList, restoredFrom); err != nil {
+			return err
+		}
+		return s.audit(ctx, action, todoList.ID, before.ToResponse(), todoList.ToResponse())
+	})
+}
+
+// recordRevision appends after to the list's history. Lists created before
+// revisions existed first get their previous version recorded as revision 1.
+func (s *todoListService) recordRevision(ctx context.Context, before, after *model.TodoList, restoredFrom *int) error {
+	if before != nil {
+		latest, err := s.revisions.LatestNumber(ctx, after.ID)
+		if err != nil {
+			return err
+		}
+		if latest == 0 {
+			if err := s.revisions.Create(ctx, &model.TodoListRevision{
+				TodoListID:  before.ID,
+				Title:       before.Title,
+				Description: before.Description,
+				AuthorID:    before.OwnerID,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	var authorID string
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		authorID = claims.Subject
+	}
+	return s.revisions.Create(ctx, &model.TodoListRevision{
+		TodoListID:   after.ID,
+		Title:        after.Title,
+		Description:  after.Description,
+		AuthorID:     authorID,
+		RestoredFrom: restoredFrom,
+	})
+}
+
+func (s *todoListService) findTodoList(ctx context.Context, id uuid.UUID) (*model.TodoList, error) {
+	todoList, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		if err == repository.ErrTodoListNotFound {
+			return nil, err
+		}
+		s.logger.Error("get todo list failed", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("get todo list: %w", err)
+	}
+	return todoList, nil
+}
+
+// loadDetails fills in the tags and item progress of every response with one
+// query each.
+func (s *todoListService) loadDetails(ctx context.Context, responses []model.TodoListResponse) error {
+	ids := make([]uuid.UUID, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+	tags, err := s.tags.FindByTodoListIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("load todo list tags failed", zap.Error(err))
+		return err
+	}
+	progress, err := s.items.CountByTodoListIDs(ctx, ids)
+	if err != nil {
+		s.logger.Error("count todo list items failed", zap.Error(err))
+		return err
+	}
+	for i := range responses {
+		for _, tag := range tags[responses[i].ID] {
+			responses[i].Tags = append(responses[i].Tags, tag.ToResponse())
+		}
+		if p, ok := progress[responses[i].ID]; ok {
+			responses[i].Progress = &p
+		}
+	}
 	return nil
 }
+
+// responseWithDetails returns the response for a list that has just been
+// written. The write has already succeeded, so failing to load its tags or
+// progress is only logged.
+func (s *todoListService) responseWithDetails(ctx context.Context, todoList *model.TodoList) model.TodoListResponse {
+	responses := []model.TodoListResponse{todoList.ToResponse()}
+	_ = s.loadDetails(ctx, responses)
+	return responses[0]
+}
+
+// publishWithDetails publishes a committed change to todoList and returns
+// its response.
+func (s *todoListService) publishWithDetails(ctx context.Context, eventType string, todoList *model.TodoList) model.TodoListResponse {
+	response := s.responseWithDetails(ctx, todoList)
+	s.publish(ctx, eventType, response)
+	return response
+}
+
+func (s *todoListService) publish(ctx context.Context, eventType string, todoList model.TodoListResponse) {
+	workspaceID, _ := tenant.WorkspaceIDFromContext(ctx)
+	s.events.Publish(model.TodoListEvent{
+		Type:        eventType,
+		WorkspaceID: workspaceID,
+		TodoList:    todoList,
+	})
+}
+
+// archiveTodoList stores the archive state of after and audits the change
+// from before in one transaction. It is shared with the ListArchiver.
+func archiveTodoList(ctx context.Context, todoLists repository.TodoListRepository, auditLogs repository.AuditLogRepository, transactor repository.Transactor, before, after *model.TodoList) error {
+	return transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := todoLists.UpdateArchived(ctx, after); err != nil {
+			return err
+		}
+		auditLog, err := newAuditLog(ctx, model.AuditActionUpdate, model.AuditEntityTodoList, after.ID, before.ToResponse(), after.ToResponse())
+		if err != nil {
+			return err
+		}
+		return auditLogs.Create(ctx, auditLog)
+	})
+}
+
+func (s *todoListService) audit(ctx context.Context, action string, id uuid.UUID, before, after interface{}) error {
+	auditLog, err := newAuditLog(ctx, action, model.AuditEntityTodoList, id, before, after)
+	if err != nil {
+		return err
+	}
+	return s.auditLogs.Create(ctx, auditLog)
+}
+
+// authorizeOwner lets admins manage every list and everyone else only the lists they own.
+func authorizeOwner(ctx context.Context, todoList *model.TodoList) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrForbidden
+	}
+	if claims.IsAdmin() || todoList.OwnerID == claims.Subject {
+		return nil
+	}
+	return ErrForbidden
+}
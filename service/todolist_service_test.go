@@ -1,12 +1,21 @@
 package service_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/package/blob"
+	"github.com/lumoshiveacademy/todolist/package/requestinfo"
+	"github.com/lumoshiveacademy/todolist/package/tenant"
 	"github.com/lumoshiveacademy/todolist/repository"
 	"github.com/lumoshiveacademy/todolist/service"
 	"github.com/lumoshiveacademy/todolist/test/mocks"
@@ -15,68 +24,578 @@ import (
 	"go.uber.org/zap/zaptest"
 )
 
+func claimsContext(subject string, roles ...string) context.Context {
+	return auth.WithClaims(context.Background(), &auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{Subject: subject},
+		Roles:            roles,
+	})
+}
+
+// recordingPublisher records the events a service publishes.
+type recordingPublisher struct {
+	events []model.TodoListEvent
+}
+
+func (p *recordingPublisher) Publish(event model.TodoListEvent) {
+	p.events = append(p.events, event)
+}
+
+type todoListFixture struct {
+	dir         string
+	todoLists   *mocks.TodoListRepositoryMock
+	items       *mocks.TodoItemRepositoryMock
+	revisions   *mocks.TodoListRevisionRepositoryMock
+	tags        *mocks.TagRepositoryMock
+	attachments *mocks.AttachmentRepositoryMock
+	comments    *mocks.CommentRepositoryMock
+	shareLinks  *mocks.ShareLinkRepositoryMock
+	auditLogs   *mocks.AuditLogRepositoryMock
+	events      *recordingPublisher
+	clock       *mocks.FakeClock
+	svc         service.TodoListService
+}
+
+func newTodoListFixture(t *testing.T) todoListFixture {
+	t.Helper()
+
+	f := todoListFixture{
+		dir:         t.TempDir(),
+		todoLists:   new(mocks.TodoListRepositoryMock),
+		items:       new(mocks.TodoItemRepositoryMock),
+		revisions:   new(mocks.TodoListRevisionRepositoryMock),
+		tags:        new(mocks.TagRepositoryMock),
+		attachments: new(mocks.AttachmentRepositoryMock),
+		comments:    new(mocks.CommentRepositoryMock),
+		shareLinks:  new(mocks.ShareLinkRepositoryMock),
+		auditLogs:   new(mocks.AuditLogRepositoryMock),
+		events:      new(recordingPublisher),
+		clock:       mocks.NewFakeClock(time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)),
+	}
+	f.svc = service.NewTodoListService(f.todoLists, f.items, f.revisions, f.tags, f.attachments, f.comments, f.shareLinks, f.auditLogs, &blob.Local{Dir: f.dir}, mocks.FakeTransactor{}, f.events, f.clock, zaptest.NewLogger(t))
+	return f
+}
+
 func TestTodoListService_CreateTodoList_Success(t *testing.T) {
-	mockRepo := new(mocks.TodoListRepositoryMock)
-	logger := zaptest.NewLogger(t)
-	svc := service.NewTodoListService(mockRepo, logger)
+	f := newTodoListFixture(t)
 
-	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
-		return todoList.Title == "Groceries"
+	f.todoLists.On("FirstRank", mock.Anything).Return("i", nil)
+	f.todoLists.On("Create", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.Title == "Groceries" && todoList.OwnerID == "user-1" && todoList.Rank == "9"
 	})).Return(nil).Run(func(args mock.Arguments) {
 		todoList := args.Get(1).(*model.TodoList)
 		todoList.ID = uuid.MustParse("11111111-1111-1111-1111-111111111111")
 		todoList.CreatedAt = time.Now()
 		todoList.UpdatedAt = time.Now()
 	})
+	f.revisions.On("Create", mock.Anything, mock.MatchedBy(func(revision *model.TodoListRevision) bool {
+		return revision.Title == "Groceries" && revision.AuthorID == "user-1" && revision.RestoredFrom == nil
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionCreate && auditLog.ActorID == "user-1" &&
+			auditLog.Changes["title"] == model.AuditChange{After: "Groceries"}
+	})).Return(nil)
 
-	res, err := svc.CreateTodoList(context.Background(), model.CreateTodoListRequest{Title: "Groceries"})
+	res, err := f.svc.CreateTodoList(claimsContext("user-1", auth.RoleMember), model.CreateTodoListRequest{Title: "Groceries"})
 	require.NoError(t, err)
 	require.Equal(t, "Groceries", res.Title)
 	require.Equal(t, uuid.MustParse("11111111-1111-1111-1111-111111111111"), res.ID)
-	mockRepo.AssertExpectations(t)
+	require.Equal(t, []model.TodoListEvent{{Type: model.TodoListEventCreated, TodoList: res}}, f.events.events)
+	f.todoLists.AssertExpectations(t)
+	f.revisions.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
 }
 
 func TestTodoListService_GetTodoList_NotFound(t *testing.T) {
-	mockRepo := new(mocks.TodoListRepositoryMock)
-	logger := zaptest.NewLogger(t)
-	svc := service.NewTodoListService(mockRepo, logger)
+	f := newTodoListFixture(t)
 
 	id := uuid.New()
-	mockRepo.On("FindByID", mock.Anything, id).Return(nil, repository.ErrTodoListNotFound)
+	f.todoLists.On("FindByID", mock.Anything, id).Return(nil, repository.ErrTodoListNotFound)
 
-	_, err := svc.GetTodoList(context.Background(), id)
+	_, err := f.svc.GetTodoList(context.Background(), id)
 	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
-	mockRepo.AssertExpectations(t)
+	f.todoLists.AssertExpectations(t)
+}
+
+func TestTodoListService_ListTodoLists_LoadsDetailsInOneQueryEach(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	tagged, untagged := uuid.New(), uuid.New()
+	filter := model.TodoListFilter{Tags: []string{"home"}}
+	f.todoLists.On("FindAll", mock.Anything, filter).Return([]model.TodoList{{ID: tagged, Title: "Chores"}, {ID: untagged, Title: "Errands"}}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{tagged, untagged}).Return(map[uuid.UUID][]model.Tag{
+		tagged: {{ID: uuid.New(), Name: "home", Color: "#4caf50"}, {ID: uuid.New(), Name: "weekly", Color: "#2196f3"}},
+	}, nil).Once()
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{tagged, untagged}).Return(map[uuid.UUID]model.Progress{
+		tagged: {Done: 1, Total: 3},
+	}, nil).Once()
+
+	res, err := f.svc.ListTodoLists(claimsContext("user-1", auth.RoleMember), filter)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Len(t, res[0].Tags, 2)
+	require.Equal(t, "home", res[0].Tags[0].Name)
+	require.Empty(t, res[1].Tags)
+	require.Equal(t, &model.Progress{Done: 1, Total: 3}, res[0].Progress)
+	require.Nil(t, res[1].Progress, "lists without items have no progress")
+	f.tags.AssertExpectations(t)
+	f.items.AssertExpectations(t)
+}
+
+func TestTodoListService_GetTodoLists_KeepsRequestedOrder(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	first, second, missing := uuid.New(), uuid.New(), uuid.New()
+	ids := []uuid.UUID{first, missing, second, first}
+	f.todoLists.On("FindByIDs", mock.Anything, ids).Return([]model.TodoList{
+		{ID: second, Title: "Chores"},
+		{ID: first, Title: "Groceries"},
+	}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{first, second}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{first, second}).Return(map[uuid.UUID]model.Progress{
+		second: {Done: 1, Total: 2},
+	}, nil)
+
+	res, err := f.svc.GetTodoLists(claimsContext("user-1", auth.RoleMember), ids)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.Equal(t, "Groceries", res[0].Title)
+	require.Equal(t, "Chores", res[1].Title)
+	require.Equal(t, &model.Progress{Done: 1, Total: 2}, res[1].Progress)
+}
+
+func TestTodoListService_ListRevisionsByTodoLists_GroupsByList(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	first, second := uuid.New(), uuid.New()
+	f.revisions.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{first, second}).Return([]model.TodoListRevision{
+		{TodoListID: first, Number: 2, Title: "Groceries"},
+		{TodoListID: first, Number: 1, Title: "Shopping"},
+	}, nil)
+
+	res, err := f.svc.ListRevisionsByTodoLists(claimsContext("user-1", auth.RoleMember), []uuid.UUID{first, second})
+	require.NoError(t, err)
+	require.Len(t, res[first], 2)
+	require.Equal(t, 2, res[first][0].Number)
+	require.Empty(t, res[second])
+	f.todoLists.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
 }
 
 func TestTodoListService_UpdateTodoList_Success(t *testing.T) {
-	mockRepo := new(mocks.TodoListRepositoryMock)
-	logger := zaptest.NewLogger(t)
-	svc := service.NewTodoListService(mockRepo, logger)
+	f := newTodoListFixture(t)
 
 	id := uuid.New()
-	existing := &model.TodoList{ID: id, Title: "Old", Description: "old"}
+	existing := &model.TodoList{ID: id, OwnerID: "user-1", Title: "Old", Description: "old"}
 
-	mockRepo.On("FindByID", mock.Anything, id).Return(existing, nil)
-	mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+	f.todoLists.On("FindByID", mock.Anything, id).Return(existing, nil)
+	f.todoLists.On("Update", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
 		return todoList.Title == "New"
 	})).Return(nil)
+	f.revisions.On("LatestNumber", mock.Anything, id).Return(3, nil)
+	f.revisions.On("Create", mock.Anything, mock.MatchedBy(func(revision *model.TodoListRevision) bool {
+		return revision.TodoListID == id && revision.Title == "New" && revision.AuthorID == "user-1"
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		_, descriptionChanged := auditLog.Changes["description"]
+		return auditLog.Action == model.AuditActionUpdate && auditLog.EntityID == id &&
+			auditLog.Changes["title"] == model.AuditChange{Before: "Old", After: "New"} &&
+			descriptionChanged && len(auditLog.Changes) == 2 &&
+			auditLog.RequestID == "req-1" && auditLog.IP == "203.0.113.7"
+	})).Return(nil)
 
-	res, err := svc.UpdateTodoList(context.Background(), id, model.UpdateTodoListRequest{Title: "New"})
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	ctx := requestinfo.WithInfo(claimsContext("user-1", auth.RoleMember), requestinfo.Info{RequestID: "req-1", IP: "203.0.113.7"})
+	res, err := f.svc.UpdateTodoList(ctx, id, model.UpdateTodoListRequest{Title: "New"})
 	require.NoError(t, err)
 	require.Equal(t, "New", res.Title)
-	mockRepo.AssertExpectations(t)
+	f.todoLists.AssertExpectations(t)
+	f.revisions.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_UpdateTodoList_KeepsStatusAndPriorityWhenOmitted(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	dueAt := time.Date(2026, 12, 1, 9, 0, 0, 0, time.UTC)
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{
+		ID: id, OwnerID: "user-1", Title: "Old", Status: model.StatusInProgress, Priority: model.PriorityHigh,
+	}, nil)
+	f.todoLists.On("Update", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.Status == model.StatusInProgress && todoList.Priority == model.PriorityHigh &&
+			todoList.DueAt != nil && todoList.DueAt.Equal(dueAt)
+	})).Return(nil)
+	f.revisions.On("LatestNumber", mock.Anything, id).Return(1, nil)
+	f.revisions.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		_, statusChanged := auditLog.Changes["status"]
+		_, dueChanged := auditLog.Changes["due_at"]
+		return !statusChanged && dueChanged
+	})).Return(nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	res, err := f.svc.UpdateTodoList(claimsContext("user-1", auth.RoleMember), id, model.UpdateTodoListRequest{Title: "Old", DueAt: &dueAt})
+	require.NoError(t, err)
+	require.Equal(t, model.StatusInProgress, res.Status)
+	f.todoLists.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_UpdateTodoList_BackfillsFirstRevision(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1", Title: "Old"}, nil)
+	f.todoLists.On("Update", mock.Anything, mock.Anything).Return(nil)
+	f.revisions.On("LatestNumber", mock.Anything, id).Return(0, nil)
+	f.revisions.On("Create", mock.Anything, mock.MatchedBy(func(revision *model.TodoListRevision) bool {
+		return revision.Title == "Old"
+	})).Return(nil).Once()
+	f.revisions.On("Create", mock.Anything, mock.MatchedBy(func(revision *model.TodoListRevision) bool {
+		return revision.Title == "New"
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	_, err := f.svc.UpdateTodoList(claimsContext("user-1", auth.RoleMember), id, model.UpdateTodoListRequest{Title: "New"})
+	require.NoError(t, err)
+	f.revisions.AssertExpectations(t)
 }
 
 func TestTodoListService_DeleteTodoList_Error(t *testing.T) {
-	mockRepo := new(mocks.TodoListRepositoryMock)
-	logger := zaptest.NewLogger(t)
-	svc := service.NewTodoListService(mockRepo, logger)
+	f := newTodoListFixture(t)
 
 	id := uuid.New()
-	mockRepo.On("Delete", mock.Anything, id).Return(repository.ErrTodoListNotFound)
+	f.todoLists.On("FindByID", mock.Anything, id).Return(nil, repository.ErrTodoListNotFound)
 
-	err := svc.DeleteTodoList(context.Background(), id)
+	err := f.svc.DeleteTodoList(claimsContext("user-1", auth.RoleMember), id)
 	require.ErrorIs(t, err, repository.ErrTodoListNotFound)
-	mockRepo.AssertExpectations(t)
+	f.todoLists.AssertExpectations(t)
+}
+
+func TestTodoListService_UpdateTodoList_ForbiddenForNonOwner(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1", Title: "Old"}, nil)
+
+	_, err := f.svc.UpdateTodoList(claimsContext("user-2", auth.RoleMember), id, model.UpdateTodoListRequest{Title: "New"})
+	require.ErrorIs(t, err, service.ErrForbidden)
+	f.todoLists.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	require.Empty(t, f.events.events)
+}
+
+func TestTodoListService_DeleteTodoList_AdminManagesAnyList(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id, workspaceID := uuid.New(), uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1"}, nil)
+	f.todoLists.On("Delete", mock.Anything, id).Return(nil)
+	f.items.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.revisions.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.tags.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.attachments.On("FindByTodoListID", mock.Anything, id).Return([]model.Attachment{}, nil)
+	f.attachments.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.comments.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.shareLinks.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionDelete && auditLog.ActorID == "admin-1" &&
+			auditLog.Changes["owner_id"] == model.AuditChange{Before: "user-1"}
+	})).Return(nil)
+
+	ctx := tenant.WithWorkspaceID(claimsContext("admin-1", auth.RoleAdmin), workspaceID)
+	err := f.svc.DeleteTodoList(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, f.events.events, 1)
+	require.Equal(t, model.TodoListEventDeleted, f.events.events[0].Type)
+	require.Equal(t, workspaceID, f.events.events[0].WorkspaceID)
+	require.Equal(t, id, f.events.events[0].TodoList.ID)
+	f.todoLists.AssertExpectations(t)
+	f.items.AssertExpectations(t)
+	f.revisions.AssertExpectations(t)
+	f.tags.AssertExpectations(t)
+	f.attachments.AssertExpectations(t)
+	f.comments.AssertExpectations(t)
+	f.shareLinks.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_DeleteTodoList_DeletesAttachmentContents(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	attachment := model.Attachment{ID: uuid.New(), TodoListID: id, StorageKey: "workspace/attachment"}
+	require.NoError(t, (&blob.Local{Dir: f.dir}).Put(context.Background(), attachment.StorageKey, bytes.NewReader([]byte("milk")), 4, "text/plain"))
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1"}, nil)
+	f.todoLists.On("Delete", mock.Anything, id).Return(nil)
+	f.items.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.revisions.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.tags.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.attachments.On("FindByTodoListID", mock.Anything, id).Return([]model.Attachment{attachment}, nil)
+	f.attachments.On("DeleteByTodoListID", mock.Anything, id).Return(nil).Once()
+	f.comments.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.shareLinks.On("DeleteByTodoListID", mock.Anything, id).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	err := f.svc.DeleteTodoList(claimsContext("user-1", auth.RoleMember), id)
+	require.NoError(t, err)
+	f.attachments.AssertExpectations(t)
+	_, err = os.Stat(filepath.Join(f.dir, "workspace", "attachment"))
+	require.True(t, os.IsNotExist(err), "contents are deleted with the list")
+}
+
+func TestTodoListService_CreateTodoList_FailsWhenAuditFails(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	f.todoLists.On("FirstRank", mock.Anything).Return("", nil)
+	f.todoLists.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.revisions.On("Create", mock.Anything, mock.Anything).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.Anything).Return(errors.New("insert failed"))
+
+	_, err := f.svc.CreateTodoList(claimsContext("user-1", auth.RoleMember), model.CreateTodoListRequest{Title: "Groceries"})
+	require.Error(t, err)
+	require.Empty(t, f.events.events)
+}
+
+func TestTodoListService_RestoreRevision_AppendsRevision(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1", Title: "Current"}, nil)
+	f.revisions.On("FindByNumber", mock.Anything, id, 2).
+		Return(&model.TodoListRevision{TodoListID: id, Number: 2, Title: "Earlier", Description: "from rev 2"}, nil)
+	f.todoLists.On("Update", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.Title == "Earlier" && todoList.Description == "from rev 2"
+	})).Return(nil)
+	f.revisions.On("LatestNumber", mock.Anything, id).Return(4, nil)
+	f.revisions.On("Create", mock.Anything, mock.MatchedBy(func(revision *model.TodoListRevision) bool {
+		return revision.Title == "Earlier" && revision.RestoredFrom != nil && *revision.RestoredFrom == 2
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionRestore
+	})).Return(nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	res, err := f.svc.RestoreRevision(claimsContext("user-1", auth.RoleMember), id, 2)
+	require.NoError(t, err)
+	require.Equal(t, "Earlier", res.Title)
+	f.todoLists.AssertExpectations(t)
+	f.revisions.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_RestoreRevision_NotFound(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1"}, nil)
+	f.revisions.On("FindByNumber", mock.Anything, id, 9).Return(nil, repository.ErrRevisionNotFound)
+
+	_, err := f.svc.RestoreRevision(claimsContext("user-1", auth.RoleMember), id, 9)
+	require.ErrorIs(t, err, repository.ErrRevisionNotFound)
+	f.todoLists.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTodoListService_MoveTodoList_RewritesOnlyItsRank(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	afterID := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "user-1", Rank: "9"}, nil)
+	f.todoLists.On("FindByID", mock.Anything, afterID).Return(&model.TodoList{ID: afterID, Rank: "i"}, nil)
+	f.todoLists.On("AdjacentRank", mock.Anything, "i", []uuid.UUID{afterID, id}, true).Return("", nil)
+	f.todoLists.On("UpdateRank", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.ID == id && todoList.Rank == "r"
+	})).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionUpdate && auditLog.Changes["rank"] == model.AuditChange{Before: "9", After: "r"}
+	})).Return(nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	res, err := f.svc.MoveTodoList(claimsContext("user-1", auth.RoleMember), id, model.MoveTodoListRequest{AfterID: &afterID})
+	require.NoError(t, err)
+	require.Equal(t, "r", res.Rank)
+	f.todoLists.AssertExpectations(t)
+	f.todoLists.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_DuplicateTodoList_CopiesItemsAndTags(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id, tagID := uuid.New(), uuid.New()
+	parentID, childID, seriesID := uuid.New(), uuid.New(), uuid.New()
+	dueAt := time.Now().Add(24 * time.Hour)
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "owner", Title: "Onboarding", Status: model.StatusDone}, nil)
+	f.items.On("FindByTodoListID", mock.Anything, id, model.TodoItemFilter{}).Return([]model.TodoItem{
+		{ID: parentID, TodoListID: id, Title: "Laptop", Rank: ""},
+		{ID: childID, TodoListID: id, ParentID: &parentID, Title: "Install tools", Status: model.StatusDone, Rank: ""},
+		{ID: uuid.New(), TodoListID: id, Title: "Weekly 1:1", Recurrence: "FREQ=WEEKLY", SeriesID: &seriesID, DueAt: &dueAt, Rank: "i"},
+	}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{id: {{ID: tagID, Name: "hr"}}}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID]model.Progress{}, nil)
+	f.todoLists.On("FirstRank", mock.Anything).Return("i", nil)
+	var created *model.TodoList
+	f.todoLists.On("Create", mock.Anything, mock.AnythingOfType("*model.TodoList")).Return(nil).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*model.TodoList)
+	})
+	var items []model.TodoItem
+	f.items.On("CreateAll", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		items = args.Get(1).([]model.TodoItem)
+	})
+	f.tags.On("Attach", mock.Anything, mock.Anything, tagID).Return(nil).Once()
+	f.revisions.On("Create", mock.Anything, mock.AnythingOfType("*model.TodoListRevision")).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionCreate
+	})).Return(nil)
+
+	res, err := f.svc.DuplicateTodoList(claimsContext("member"), id, model.DuplicateTodoListRequest{})
+	require.NoError(t, err)
+	require.Equal(t, created.ID, res.ID)
+	require.NotEqual(t, id, res.ID)
+	require.Equal(t, "Onboarding (copy)", res.Title)
+	require.Equal(t, "member", res.OwnerID)
+	require.Equal(t, model.StatusDone, res.Status)
+	require.Equal(t, "9", res.Rank)
+	f.tags.AssertCalled(t, "Attach", mock.Anything, created.ID, tagID)
+
+	require.Len(t, items, 3)
+	for _, item := range items {
+		require.Equal(t, created.ID, item.TodoListID)
+		require.NotContains(t, []uuid.UUID{parentID, childID}, item.ID)
+	}
+	require.Equal(t, items[0].ID, *items[1].ParentID)
+	require.Equal(t, model.StatusDone, items[1].Status)
+	require.NotEqual(t, seriesID, *items[2].SeriesID)
+	require.True(t, items[0].Rank < items[1].Rank && items[1].Rank < items[2].Rank, "copies keep the display order")
+}
+
+func TestTodoListService_InstantiateTemplate_SubstitutesVariables(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	completedAt := time.Now()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, Title: "Onboard {{name}}", Description: "Welcome to {{ team }}", IsTemplate: true}, nil)
+	f.items.On("FindByTodoListID", mock.Anything, id, model.TodoItemFilter{}).Return([]model.TodoItem{
+		{ID: uuid.New(), TodoListID: id, Title: "Order a laptop for {{name}}", Status: model.StatusDone, CompletedAt: &completedAt},
+	}, nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, mock.Anything).Return(map[uuid.UUID]model.Progress{}, nil)
+	f.todoLists.On("FirstRank", mock.Anything).Return("", nil)
+	f.todoLists.On("Create", mock.Anything, mock.AnythingOfType("*model.TodoList")).Return(nil)
+	var items []model.TodoItem
+	f.items.On("CreateAll", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		items = args.Get(1).([]model.TodoItem)
+	})
+	f.revisions.On("Create", mock.Anything, mock.AnythingOfType("*model.TodoListRevision")).Return(nil)
+	f.auditLogs.On("Create", mock.Anything, mock.AnythingOfType("*model.AuditLog")).Return(nil)
+
+	_, err := f.svc.InstantiateTemplate(claimsContext("member"), id, model.InstantiateTemplateRequest{
+		Variables: map[string]string{"name": "Ada"},
+	})
+	require.ErrorIs(t, err, service.ErrMissingTemplateVariables)
+	require.ErrorContains(t, err, "team")
+	f.todoLists.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	res, err := f.svc.InstantiateTemplate(claimsContext("member"), id, model.InstantiateTemplateRequest{
+		Variables: map[string]string{"name": "Ada", "team": "Platform"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Onboard Ada", res.Title)
+	require.Equal(t, "Welcome to Platform", res.Description)
+	require.False(t, res.IsTemplate)
+	require.Len(t, items, 1)
+	require.Equal(t, "Order a laptop for Ada", items[0].Title)
+	require.Equal(t, model.StatusOpen, items[0].Status)
+	require.Nil(t, items[0].CompletedAt)
+}
+
+func TestTodoListService_InstantiateTemplate_RequiresTemplate(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, Title: "Groceries"}, nil)
+
+	_, err := f.svc.InstantiateTemplate(claimsContext("member"), id, model.InstantiateTemplateRequest{})
+	require.ErrorIs(t, err, service.ErrNotTemplate)
+	f.items.AssertNotCalled(t, "FindByTodoListID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTodoListService_SetTemplate(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "owner", Title: "Onboarding"}, nil)
+	f.todoLists.On("UpdateTemplate", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.ID == id && todoList.IsTemplate
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Changes["is_template"] == model.AuditChange{Before: false, After: true}
+	})).Return(nil)
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	_, err := f.svc.SetTemplate(claimsContext("member"), id, true)
+	require.ErrorIs(t, err, service.ErrForbidden)
+
+	res, err := f.svc.SetTemplate(claimsContext("owner"), id, true)
+	require.NoError(t, err)
+	require.True(t, res.IsTemplate)
+	f.todoLists.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_ArchiveTodoList(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "owner", Title: "Move house"}, nil)
+	f.todoLists.On("UpdateArchived", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.ArchivedAt != nil && todoList.ArchivedAt.Equal(f.clock.Now())
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.MatchedBy(func(auditLog *model.AuditLog) bool {
+		return auditLog.Action == model.AuditActionUpdate && auditLog.ActorID == "owner"
+	})).Return(nil).Once()
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	_, err := f.svc.ArchiveTodoList(claimsContext("member"), id)
+	require.ErrorIs(t, err, service.ErrForbidden)
+
+	res, err := f.svc.ArchiveTodoList(claimsContext("owner"), id)
+	require.NoError(t, err)
+	require.Equal(t, f.clock.Now(), *res.ArchivedAt)
+
+	// Archiving again keeps the original archive time.
+	f.clock.Advance(time.Hour)
+	res, err = f.svc.ArchiveTodoList(claimsContext("owner"), id)
+	require.NoError(t, err)
+	require.Equal(t, f.clock.Now().Add(-time.Hour), *res.ArchivedAt)
+	f.todoLists.AssertExpectations(t)
+	f.auditLogs.AssertExpectations(t)
+}
+
+func TestTodoListService_UnarchiveTodoList(t *testing.T) {
+	f := newTodoListFixture(t)
+
+	id := uuid.New()
+	archivedAt := f.clock.Now().Add(-48 * time.Hour)
+	f.todoLists.On("FindByID", mock.Anything, id).Return(&model.TodoList{ID: id, OwnerID: "owner", ArchivedAt: &archivedAt}, nil)
+	f.todoLists.On("UpdateArchived", mock.Anything, mock.MatchedBy(func(todoList *model.TodoList) bool {
+		return todoList.ArchivedAt == nil
+	})).Return(nil).Once()
+	f.auditLogs.On("Create", mock.Anything, mock.AnythingOfType("*model.AuditLog")).Return(nil).Once()
+	f.tags.On("FindByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID][]model.Tag{}, nil)
+	f.items.On("CountByTodoListIDs", mock.Anything, []uuid.UUID{id}).Return(map[uuid.UUID]model.Progress{}, nil)
+
+	res, err := f.svc.UnarchiveTodoList(claimsContext("admin", auth.RoleAdmin), id)
+	require.NoError(t, err)
+	require.Nil(t, res.ArchivedAt)
+	f.todoLists.AssertExpectations(t)
 }
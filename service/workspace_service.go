@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"go.uber.org/zap"
+)
+
+// WorkspaceService defines business operations for workspaces.
+type WorkspaceService interface {
+	CreateWorkspace(ctx context.Context, req model.CreateWorkspaceRequest) (model.WorkspaceResponse, error)
+	GetWorkspace(ctx context.Context, id uuid.UUID) (model.WorkspaceResponse, error)
+	UpdateWorkspace(ctx context.Context, id uuid.UUID, req model.UpdateWorkspaceRequest) (model.WorkspaceResponse, error)
+	AddMember(ctx context.Context, id uuid.UUID, subject string) error
+	RemoveMember(ctx context.Context, id uuid.UUID, subject string) error
+	IsMember(ctx context.Context, id uuid.UUID, subject string) (bool, error)
+}
+
+type workspaceService struct {
+	repository repository.WorkspaceRepository
+	transactor repository.Transactor
+	logger     *zap.Logger
+}
+
+// NewWorkspaceService constructs a WorkspaceService implementation.
+func NewWorkspaceService(repository repository.WorkspaceRepository, transactor repository.Transactor, logger *zap.Logger) WorkspaceService {
+	return &workspaceService{
+		repository: repository,
+		transactor: transactor,
+		logger:     logger,
+	}
+}
+
+// CreateWorkspace creates the workspace and makes the caller its first
+// member.
+func (s *workspaceService) CreateWorkspace(ctx context.Context, req model.CreateWorkspaceRequest) (model.WorkspaceResponse, error) {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return model.WorkspaceResponse{}, ErrForbidden
+	}
+
+	workspace := &model.Workspace{Name: req.Name, RequireMFA: req.RequireMFA}
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repository.Create(ctx, workspace); err != nil {
+			return err
+		}
+		return s.repository.AddMember(ctx, workspace.ID, claims.Subject)
+	})
+	if err != nil {
+		s.logger.Error("create workspace failed", zap.Error(err))
+		return model.WorkspaceResponse{}, fmt.Errorf("create workspace: %w", err)
+	}
+	s.logger.Info("workspace created", zap.String("id", workspace.ID.String()))
+	return workspace.ToResponse(), nil
+}
+
+func (s *workspaceService) GetWorkspace(ctx context.Context, id uuid.UUID) (model.WorkspaceResponse, error) {
+	workspace, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			return model.WorkspaceResponse{}, err
+		}
+		s.logger.Error("get workspace failed", zap.String("id", id.String()), zap.Error(err))
+		return model.WorkspaceResponse{}, fmt.Errorf("get workspace: %w", err)
+	}
+	return workspace.ToResponse(), nil
+}
+
+func (s *workspaceService) UpdateWorkspace(ctx context.Context, id uuid.UUID, req model.UpdateWorkspaceRequest) (model.WorkspaceResponse, error) {
+	workspace, err := s.repository.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			return model.WorkspaceResponse{}, err
+		}
+		s.logger.Error("retrieve workspace for update failed", zap.String("id", id.String()), zap.Error(err))
+		return model.WorkspaceResponse{}, fmt.Errorf("get workspace: %w", err)
+	}
+
+	workspace.Name = req.Name
+	workspace.RequireMFA = req.RequireMFA
+	if err := s.repository.Update(ctx, workspace); err != nil {
+		if errors.Is(err, repository.ErrWorkspaceNotFound) {
+			return model.WorkspaceResponse{}, err
+		}
+		s.logger.Error("update workspace failed", zap.String("id", id.String()), zap.Error(err))
+		return model.WorkspaceResponse{}, fmt.Errorf("update workspace: %w", err)
+	}
+	s.logger.Info("workspace updated", zap.String("id", id.String()), zap.Bool("require_mfa", workspace.RequireMFA))
+	return workspace.ToResponse(), nil
+}
+
+// AddMember grants subject access to the workspace.
+func (s *workspaceService) AddMember(ctx context.Context, id uuid.UUID, subject string) error {
+	if _, err := s.GetWorkspace(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repository.AddMember(ctx, id, subject); err != nil {
+		s.logger.Error("add workspace member failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("add workspace member: %w", err)
+	}
+	s.logger.Info("workspace member added", zap.String("id", id.String()), zap.String("subject", subject))
+	return nil
+}
+
+// RemoveMember revokes subject's access to the workspace. Tokens that carry
+// the workspace as their claim stop working for it too.
+func (s *workspaceService) RemoveMember(ctx context.Context, id uuid.UUID, subject string) error {
+	if _, err := s.GetWorkspace(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repository.RemoveMember(ctx, id, subject); err != nil {
+		s.logger.Error("remove workspace member failed", zap.String("id", id.String()), zap.Error(err))
+		return fmt.Errorf("remove workspace member: %w", err)
+	}
+	s.logger.Info("workspace member removed", zap.String("id", id.String()), zap.String("subject", subject))
+	return nil
+}
+
+func (s *workspaceService) IsMember(ctx context.Context, id uuid.UUID, subject string) (bool, error) {
+	member, err := s.repository.IsMember(ctx, id, subject)
+	if err != nil {
+		s.logger.Error("check workspace member failed", zap.String("id", id.String()), zap.Error(err))
+		return false, fmt.Errorf("check workspace member: %w", err)
+	}
+	return member, nil
+}
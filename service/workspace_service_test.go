@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/lumoshiveacademy/todolist/repository"
+	"github.com/lumoshiveacademy/todolist/service"
+	"github.com/lumoshiveacademy/todolist/test/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestWorkspaceService_CreateWorkspace_Success(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewWorkspaceService(mockRepo, mocks.FakeTransactor{}, logger)
+
+	id := uuid.New()
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(workspace *model.Workspace) bool {
+		return workspace.Name == "Platform"
+	})).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*model.Workspace).ID = id
+	})
+	mockRepo.On("AddMember", mock.Anything, id, "user-1").Return(nil)
+
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}})
+	res, err := svc.CreateWorkspace(ctx, model.CreateWorkspaceRequest{Name: "Platform"})
+	require.NoError(t, err)
+	require.Equal(t, id, res.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWorkspaceService_GetWorkspace_NotFound(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryMock)
+	logger := zaptest.NewLogger(t)
+	svc := service.NewWorkspaceService(mockRepo, mocks.FakeTransactor{}, logger)
+
+	id := uuid.New()
+	mockRepo.On("FindByID", mock.Anything, id).Return(nil, repository.ErrWorkspaceNotFound)
+
+	_, err := svc.GetWorkspace(context.Background(), id)
+	require.ErrorIs(t, err, repository.ErrWorkspaceNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWorkspaceService_AddMember_NotFound(t *testing.T) {
+	mockRepo := new(mocks.WorkspaceRepositoryMock)
+	svc := service.NewWorkspaceService(mockRepo, mocks.FakeTransactor{}, zaptest.NewLogger(t))
+
+	id := uuid.New()
+	mockRepo.On("FindByID", mock.Anything, id).Return(nil, repository.ErrWorkspaceNotFound)
+
+	err := svc.AddMember(context.Background(), id, "user-1")
+	require.ErrorIs(t, err, repository.ErrWorkspaceNotFound)
+	mockRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything)
+}
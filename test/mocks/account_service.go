@@ -0,0 +1,38 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AccountServiceMock is a testify mock for service.AccountService.
+type AccountServiceMock struct {
+	mock.Mock
+}
+
+func (m *AccountServiceMock) SendVerification(ctx context.Context, user *model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *AccountServiceMock) ResendVerification(ctx context.Context, req model.ResendVerificationRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *AccountServiceMock) VerifyEmail(ctx context.Context, req model.VerifyEmailRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *AccountServiceMock) ForgotPassword(ctx context.Context, req model.ForgotPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *AccountServiceMock) ResetPassword(ctx context.Context, req model.ResetPasswordRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
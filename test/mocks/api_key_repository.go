@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepositoryMock is a testify mock for repository.APIKeyRepository.
+type APIKeyRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *APIKeyRepositoryMock) Create(ctx context.Context, apiKey *model.APIKey) error {
+	args := m.Called(ctx, apiKey)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepositoryMock) FindByID(ctx context.Context, userID string, id uuid.UUID) (*model.APIKey, error) {
+	args := m.Called(ctx, userID, id)
+	if val, ok := args.Get(0).(*model.APIKey); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) FindByPrefix(ctx context.Context, prefix string) (*model.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if val, ok := args.Get(0).(*model.APIKey); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) FindByUserID(ctx context.Context, userID string) ([]model.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if val, ok := args.Get(0).([]model.APIKey); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyRepositoryMock) Update(ctx context.Context, apiKey *model.APIKey) error {
+	args := m.Called(ctx, apiKey)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepositoryMock) Delete(ctx context.Context, userID string, id uuid.UUID) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *APIKeyRepositoryMock) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
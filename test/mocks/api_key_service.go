@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/lumoshiveacademy/todolist/package/auth"
+	"github.com/stretchr/testify/mock"
+)
+
+// APIKeyServiceMock is a testify mock for service.APIKeyService.
+type APIKeyServiceMock struct {
+	mock.Mock
+}
+
+func (m *APIKeyServiceMock) CreateAPIKey(ctx context.Context, req model.CreateAPIKeyRequest) (model.APIKeyResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.APIKeyResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.APIKeyResponse{}, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) ListAPIKeys(ctx context.Context) ([]model.APIKeyResponse, error) {
+	args := m.Called(ctx)
+	if resp, ok := args.Get(0).([]model.APIKeyResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) GetAPIKey(ctx context.Context, id uuid.UUID) (model.APIKeyResponse, error) {
+	args := m.Called(ctx, id)
+	if resp, ok := args.Get(0).(model.APIKeyResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.APIKeyResponse{}, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) UpdateAPIKey(ctx context.Context, id uuid.UUID, req model.UpdateAPIKeyRequest) (model.APIKeyResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.APIKeyResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.APIKeyResponse{}, args.Error(1)
+}
+
+func (m *APIKeyServiceMock) DeleteAPIKey(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *APIKeyServiceMock) Authenticate(ctx context.Context, rawKey string) (*auth.Claims, error) {
+	args := m.Called(ctx, rawKey)
+	if claims, ok := args.Get(0).(*auth.Claims); ok {
+		return claims, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
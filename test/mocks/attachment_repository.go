@@ -0,0 +1,71 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AttachmentRepositoryMock is a testify mock for repository.AttachmentRepository.
+type AttachmentRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentRepositoryMock) Create(ctx context.Context, attachment *model.Attachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *AttachmentRepositoryMock) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Attachment, error) {
+	args := m.Called(ctx, todoListID, id)
+	if val, ok := args.Get(0).(*model.Attachment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AttachmentRepositoryMock) FindForDownload(ctx context.Context, id uuid.UUID) (*model.Attachment, error) {
+	args := m.Called(ctx, id)
+	if val, ok := args.Get(0).(*model.Attachment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AttachmentRepositoryMock) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.Attachment, error) {
+	args := m.Called(ctx, todoListID)
+	if val, ok := args.Get(0).([]model.Attachment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AttachmentRepositoryMock) FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Attachment, error) {
+	args := m.Called(ctx, todoItemID)
+	if val, ok := args.Get(0).([]model.Attachment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AttachmentRepositoryMock) Delete(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
+
+func (m *AttachmentRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
+
+func (m *AttachmentRepositoryMock) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID)
+	return args.Error(0)
+}
+
+func (m *AttachmentRepositoryMock) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID, todoListID)
+	return args.Error(0)
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AttachmentServiceMock is a testify mock for service.AttachmentService.
+type AttachmentServiceMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentServiceMock) UploadAttachment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, upload model.AttachmentUpload) (model.AttachmentResponse, error) {
+	args := m.Called(ctx, todoListID, todoItemID, upload)
+	if resp, ok := args.Get(0).(model.AttachmentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.AttachmentResponse{}, args.Error(1)
+}
+
+func (m *AttachmentServiceMock) ListAttachments(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID) ([]model.AttachmentResponse, error) {
+	args := m.Called(ctx, todoListID, todoItemID)
+	if resp, ok := args.Get(0).([]model.AttachmentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *AttachmentServiceMock) GetAttachment(ctx context.Context, todoListID, id uuid.UUID) (model.AttachmentResponse, error) {
+	args := m.Called(ctx, todoListID, id)
+	if resp, ok := args.Get(0).(model.AttachmentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.AttachmentResponse{}, args.Error(1)
+}
+
+func (m *AttachmentServiceMock) DeleteAttachment(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
+
+func (m *AttachmentServiceMock) OpenDownload(ctx context.Context, id uuid.UUID, expires int64, signature string) (model.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, id, expires, signature)
+	attachment, _ := args.Get(0).(model.Attachment)
+	content, _ := args.Get(1).(io.ReadCloser)
+	return attachment, content, args.Error(2)
+}
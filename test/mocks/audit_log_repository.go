@@ -0,0 +1,26 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AuditLogRepositoryMock is a testify mock for repository.AuditLogRepository.
+type AuditLogRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AuditLogRepositoryMock) Create(ctx context.Context, auditLog *model.AuditLog) error {
+	args := m.Called(ctx, auditLog)
+	return args.Error(0)
+}
+
+func (m *AuditLogRepositoryMock) List(ctx context.Context, filter model.AuditLogFilter) ([]model.AuditLog, int64, error) {
+	args := m.Called(ctx, filter)
+	if val, ok := args.Get(0).([]model.AuditLog); ok {
+		return val, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AuditServiceMock is a testify mock for service.AuditService.
+type AuditServiceMock struct {
+	mock.Mock
+}
+
+func (m *AuditServiceMock) ListAuditLogs(ctx context.Context, filter model.AuditLogFilter) (model.AuditLogPage, error) {
+	args := m.Called(ctx, filter)
+	if resp, ok := args.Get(0).(model.AuditLogPage); ok {
+		return resp, args.Error(1)
+	}
+	return model.AuditLogPage{}, args.Error(1)
+}
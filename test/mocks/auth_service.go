@@ -0,0 +1,87 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// AuthServiceMock is a testify mock for service.AuthService.
+type AuthServiceMock struct {
+	mock.Mock
+}
+
+func (m *AuthServiceMock) Register(ctx context.Context, req model.RegisterRequest) (model.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.UserResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.UserResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) Login(ctx context.Context, req model.LoginRequest) (model.TokenResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.TokenResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TokenResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) Refresh(ctx context.Context, req model.RefreshRequest) (model.TokenResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.TokenResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TokenResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) Logout(ctx context.Context, req model.LogoutRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *AuthServiceMock) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AuthServiceMock) VerifyMFA(ctx context.Context, req model.VerifyMFARequest) (model.TokenResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.TokenResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TokenResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) EnrollMFA(ctx context.Context) (model.MFAEnrollmentResponse, error) {
+	args := m.Called(ctx)
+	if resp, ok := args.Get(0).(model.MFAEnrollmentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.MFAEnrollmentResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) ConfirmMFA(ctx context.Context, req model.ConfirmMFARequest) (model.MFARecoveryCodesResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.MFARecoveryCodesResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.MFARecoveryCodesResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) SetUsername(ctx context.Context, req model.SetUsernameRequest) (model.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.UserResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.UserResponse{}, args.Error(1)
+}
+
+func (m *AuthServiceMock) SetArchivePolicy(ctx context.Context, req model.SetArchivePolicyRequest) (model.UserResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.UserResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.UserResponse{}, args.Error(1)
+}
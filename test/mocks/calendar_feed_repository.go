@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// CalendarFeedRepositoryMock is a testify mock for repository.CalendarFeedRepository.
+type CalendarFeedRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CalendarFeedRepositoryMock) Save(ctx context.Context, feed *model.CalendarFeed) error {
+	args := m.Called(ctx, feed)
+	return args.Error(0)
+}
+
+func (m *CalendarFeedRepositoryMock) FindByTokenHash(ctx context.Context, tokenHash string) (*model.CalendarFeed, error) {
+	args := m.Called(ctx, tokenHash)
+	if val, ok := args.Get(0).(*model.CalendarFeed); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *CalendarFeedRepositoryMock) Delete(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
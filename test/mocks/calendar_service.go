@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// CalendarServiceMock is a testify mock for service.CalendarService.
+type CalendarServiceMock struct {
+	mock.Mock
+}
+
+func (m *CalendarServiceMock) CreateFeed(ctx context.Context) (model.CalendarFeedResponse, error) {
+	args := m.Called(ctx)
+	if resp, ok := args.Get(0).(model.CalendarFeedResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.CalendarFeedResponse{}, args.Error(1)
+}
+
+func (m *CalendarServiceMock) DeleteFeed(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *CalendarServiceMock) RenderFeed(ctx context.Context, rawToken string) ([]byte, error) {
+	args := m.Called(ctx, rawToken)
+	if body, ok := args.Get(0).([]byte); ok {
+		return body, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,63 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// CommentRepositoryMock is a testify mock for repository.CommentRepository.
+type CommentRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *CommentRepositoryMock) Create(ctx context.Context, comment *model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.Comment, error) {
+	args := m.Called(ctx, todoListID, id)
+	if val, ok := args.Get(0).(*model.Comment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *CommentRepositoryMock) FindThreads(ctx context.Context, filter model.CommentFilter) ([]model.Comment, int64, error) {
+	args := m.Called(ctx, filter)
+	if val, ok := args.Get(0).([]model.Comment); ok {
+		return val, args.Get(1).(int64), args.Error(2)
+	}
+	return nil, 0, args.Error(2)
+}
+
+func (m *CommentRepositoryMock) FindReplies(ctx context.Context, parentIDs []uuid.UUID) ([]model.Comment, error) {
+	args := m.Called(ctx, parentIDs)
+	if val, ok := args.Get(0).([]model.Comment); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *CommentRepositoryMock) Update(ctx context.Context, comment *model.Comment) error {
+	args := m.Called(ctx, comment)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID, todoListID)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
+
+func (m *CommentRepositoryMock) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID)
+	return args.Error(0)
+}
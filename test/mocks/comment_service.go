@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// CommentServiceMock is a testify mock for service.CommentService.
+type CommentServiceMock struct {
+	mock.Mock
+}
+
+func (m *CommentServiceMock) CreateComment(ctx context.Context, todoListID uuid.UUID, todoItemID *uuid.UUID, req model.CreateCommentRequest) (model.CommentResponse, error) {
+	args := m.Called(ctx, todoListID, todoItemID, req)
+	if resp, ok := args.Get(0).(model.CommentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.CommentResponse{}, args.Error(1)
+}
+
+func (m *CommentServiceMock) ListComments(ctx context.Context, filter model.CommentFilter) (model.CommentPage, error) {
+	args := m.Called(ctx, filter)
+	if resp, ok := args.Get(0).(model.CommentPage); ok {
+		return resp, args.Error(1)
+	}
+	return model.CommentPage{}, args.Error(1)
+}
+
+func (m *CommentServiceMock) UpdateComment(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateCommentRequest) (model.CommentResponse, error) {
+	args := m.Called(ctx, todoListID, id, req)
+	if resp, ok := args.Get(0).(model.CommentResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.CommentResponse{}, args.Error(1)
+}
+
+func (m *CommentServiceMock) DeleteComment(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
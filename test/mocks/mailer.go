@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/package/mailer"
+	"github.com/stretchr/testify/mock"
+)
+
+// MailerMock is a testify mock for mailer.Mailer.
+type MailerMock struct {
+	mock.Mock
+}
+
+func (m *MailerMock) Send(ctx context.Context, msg mailer.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+// MFARecoveryCodeRepositoryMock is a testify mock for repository.MFARecoveryCodeRepository.
+type MFARecoveryCodeRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *MFARecoveryCodeRepositoryMock) Replace(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	args := m.Called(ctx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MFARecoveryCodeRepositoryMock) Use(ctx context.Context, userID uuid.UUID, codeHash string, at time.Time) error {
+	args := m.Called(ctx, userID, codeHash, at)
+	return args.Error(0)
+}
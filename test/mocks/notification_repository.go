@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// NotificationRepositoryMock is a testify mock for repository.NotificationRepository.
+type NotificationRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *NotificationRepositoryMock) Create(ctx context.Context, notification *model.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *NotificationRepositoryMock) FindByUserID(ctx context.Context, userID string, unreadOnly bool) ([]model.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly)
+	if val, ok := args.Get(0).([]model.Notification); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *NotificationRepositoryMock) MarkRead(ctx context.Context, userID string, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, id, at)
+	return args.Error(0)
+}
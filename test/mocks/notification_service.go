@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// NotificationServiceMock is a testify mock for service.NotificationService.
+type NotificationServiceMock struct {
+	mock.Mock
+}
+
+func (m *NotificationServiceMock) ListNotifications(ctx context.Context, unreadOnly bool) ([]model.NotificationResponse, error) {
+	args := m.Called(ctx, unreadOnly)
+	if resp, ok := args.Get(0).([]model.NotificationResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *NotificationServiceMock) MarkRead(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,18 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/lumoshiveacademy/todolist/package/notify"
+	"github.com/stretchr/testify/mock"
+)
+
+// NotifierMock is a testify mock for notify.Notifier.
+type NotifierMock struct {
+	mock.Mock
+}
+
+func (m *NotifierMock) Notify(ctx context.Context, msg notify.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
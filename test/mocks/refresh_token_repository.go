@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// RefreshTokenRepositoryMock is a testify mock for repository.RefreshTokenRepository.
+type RefreshTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RefreshTokenRepositoryMock) Create(ctx context.Context, refreshToken *model.RefreshToken) error {
+	args := m.Called(ctx, refreshToken)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) FindByTokenHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if val, ok := args.Get(0).(*model.RefreshToken); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *RefreshTokenRepositoryMock) MarkUsed(ctx context.Context, id uuid.UUID, replacedByID *uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, replacedByID, at)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, familyID, at)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeAllForUser(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, userID, at)
+	return args.Error(0)
+}
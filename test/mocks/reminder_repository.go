@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReminderRepositoryMock is a testify mock for repository.ReminderRepository.
+type ReminderRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ReminderRepositoryMock) Create(ctx context.Context, reminder *model.Reminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) FindByTodoItemID(ctx context.Context, todoItemID uuid.UUID) ([]model.Reminder, error) {
+	args := m.Called(ctx, todoItemID)
+	if val, ok := args.Get(0).([]model.Reminder); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReminderRepositoryMock) FindForUser(ctx context.Context, userID string, todoItemIDs []uuid.UUID) ([]model.Reminder, error) {
+	args := m.Called(ctx, userID, todoItemIDs)
+	if val, ok := args.Get(0).([]model.Reminder); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReminderRepositoryMock) Update(ctx context.Context, reminder *model.Reminder) error {
+	args := m.Called(ctx, reminder)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) Delete(ctx context.Context, todoItemID, id uuid.UUID) error {
+	args := m.Called(ctx, todoItemID, id)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) DeleteByTodoItemID(ctx context.Context, todoItemID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) Reschedule(ctx context.Context, todoItemID uuid.UUID, dueAt *time.Time) error {
+	args := m.Called(ctx, todoItemID, dueAt)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) MoveToTodoList(ctx context.Context, todoItemID, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoItemID, todoListID)
+	return args.Error(0)
+}
+
+func (m *ReminderRepositoryMock) ClaimDue(ctx context.Context, now time.Time, limit int) ([]model.Reminder, error) {
+	args := m.Called(ctx, now, limit)
+	if val, ok := args.Get(0).([]model.Reminder); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReminderRepositoryMock) Lease(ctx context.Context, ids []uuid.UUID, until time.Time) error {
+	args := m.Called(ctx, ids, until)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReminderServiceMock is a testify mock for service.ReminderService.
+type ReminderServiceMock struct {
+	mock.Mock
+}
+
+func (m *ReminderServiceMock) CreateReminder(ctx context.Context, todoListID, todoItemID uuid.UUID, req model.CreateReminderRequest) (model.ReminderResponse, error) {
+	args := m.Called(ctx, todoListID, todoItemID, req)
+	if resp, ok := args.Get(0).(model.ReminderResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.ReminderResponse{}, args.Error(1)
+}
+
+func (m *ReminderServiceMock) ListReminders(ctx context.Context, todoListID, todoItemID uuid.UUID) ([]model.ReminderResponse, error) {
+	args := m.Called(ctx, todoListID, todoItemID)
+	if resp, ok := args.Get(0).([]model.ReminderResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ReminderServiceMock) DeleteReminder(ctx context.Context, todoListID, todoItemID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, todoItemID, id)
+	return args.Error(0)
+}
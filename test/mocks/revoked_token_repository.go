@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// RevokedTokenRepositoryMock is a testify mock for repository.RevokedTokenRepository.
+type RevokedTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RevokedTokenRepositoryMock) Create(ctx context.Context, revoked *model.RevokedAccessToken) error {
+	args := m.Called(ctx, revoked)
+	return args.Error(0)
+}
+
+func (m *RevokedTokenRepositoryMock) Exists(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *RevokedTokenRepositoryMock) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
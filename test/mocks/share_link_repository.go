@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// ShareLinkRepositoryMock is a testify mock for repository.ShareLinkRepository.
+type ShareLinkRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ShareLinkRepositoryMock) Create(ctx context.Context, shareLink *model.ShareLink) error {
+	args := m.Called(ctx, shareLink)
+	return args.Error(0)
+}
+
+func (m *ShareLinkRepositoryMock) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.ShareLink, error) {
+	args := m.Called(ctx, todoListID, id)
+	if val, ok := args.Get(0).(*model.ShareLink); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ShareLinkRepositoryMock) FindByTokenHash(ctx context.Context, tokenHash string) (*model.ShareLink, error) {
+	args := m.Called(ctx, tokenHash)
+	if val, ok := args.Get(0).(*model.ShareLink); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ShareLinkRepositoryMock) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLink, error) {
+	args := m.Called(ctx, todoListID)
+	if val, ok := args.Get(0).([]model.ShareLink); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ShareLinkRepositoryMock) Update(ctx context.Context, shareLink *model.ShareLink) error {
+	args := m.Called(ctx, shareLink)
+	return args.Error(0)
+}
+
+func (m *ShareLinkRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
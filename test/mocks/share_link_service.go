@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// ShareLinkServiceMock is a testify mock for service.ShareLinkService.
+type ShareLinkServiceMock struct {
+	mock.Mock
+}
+
+func (m *ShareLinkServiceMock) CreateShareLink(ctx context.Context, todoListID uuid.UUID, req model.CreateShareLinkRequest) (model.ShareLinkResponse, error) {
+	args := m.Called(ctx, todoListID, req)
+	if resp, ok := args.Get(0).(model.ShareLinkResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.ShareLinkResponse{}, args.Error(1)
+}
+
+func (m *ShareLinkServiceMock) ListShareLinks(ctx context.Context, todoListID uuid.UUID) ([]model.ShareLinkResponse, error) {
+	args := m.Called(ctx, todoListID)
+	if resp, ok := args.Get(0).([]model.ShareLinkResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *ShareLinkServiceMock) RevokeShareLink(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
+
+func (m *ShareLinkServiceMock) GetSharedTodoList(ctx context.Context, rawToken string) (model.TodoListResponse, error) {
+	args := m.Called(ctx, rawToken)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
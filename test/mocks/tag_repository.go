@@ -0,0 +1,68 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TagRepositoryMock is a testify mock for repository.TagRepository.
+type TagRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TagRepositoryMock) Create(ctx context.Context, tag *model.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) FindAll(ctx context.Context) ([]model.Tag, error) {
+	args := m.Called(ctx)
+	if val, ok := args.Get(0).([]model.Tag); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TagRepositoryMock) FindByID(ctx context.Context, id uuid.UUID) (*model.Tag, error) {
+	args := m.Called(ctx, id)
+	if val, ok := args.Get(0).(*model.Tag); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TagRepositoryMock) FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID][]model.Tag, error) {
+	args := m.Called(ctx, todoListIDs)
+	if val, ok := args.Get(0).(map[uuid.UUID][]model.Tag); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TagRepositoryMock) Update(ctx context.Context, tag *model.Tag) error {
+	args := m.Called(ctx, tag)
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) Attach(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	args := m.Called(ctx, todoListID, tagID)
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) Detach(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	args := m.Called(ctx, todoListID, tagID)
+	return args.Error(0)
+}
+
+func (m *TagRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TagServiceMock is a testify mock for service.TagService.
+type TagServiceMock struct {
+	mock.Mock
+}
+
+func (m *TagServiceMock) CreateTag(ctx context.Context, req model.CreateTagRequest) (model.TagResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.TagResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TagResponse{}, args.Error(1)
+}
+
+func (m *TagServiceMock) ListTags(ctx context.Context) ([]model.TagResponse, error) {
+	args := m.Called(ctx)
+	if resp, ok := args.Get(0).([]model.TagResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TagServiceMock) UpdateTag(ctx context.Context, id uuid.UUID, req model.UpdateTagRequest) (model.TagResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.TagResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TagResponse{}, args.Error(1)
+}
+
+func (m *TagServiceMock) DeleteTag(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *TagServiceMock) AttachTag(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	args := m.Called(ctx, todoListID, tagID)
+	return args.Error(0)
+}
+
+func (m *TagServiceMock) DetachTag(ctx context.Context, todoListID, tagID uuid.UUID) error {
+	args := m.Called(ctx, todoListID, tagID)
+	return args.Error(0)
+}
@@ -0,0 +1,150 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TodoItemRepositoryMock is a testify mock for repository.TodoItemRepository.
+type TodoItemRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TodoItemRepositoryMock) Create(ctx context.Context, item *model.TodoItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) CreateAll(ctx context.Context, items []model.TodoItem) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) FindByID(ctx context.Context, todoListID, id uuid.UUID) (*model.TodoItem, error) {
+	args := m.Called(ctx, todoListID, id)
+	if val, ok := args.Get(0).(*model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) FindByTodoListID(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItem, error) {
+	args := m.Called(ctx, todoListID, filter)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) Update(ctx context.Context, item *model.TodoItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) Delete(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) FindDueByOwner(ctx context.Context, ownerID string, since time.Time, limit int) ([]model.TodoItem, error) {
+	args := m.Called(ctx, ownerID, since, limit)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) FindLatestInSeries(ctx context.Context, seriesID uuid.UUID) (*model.TodoItem, error) {
+	args := m.Called(ctx, seriesID)
+	if val, ok := args.Get(0).(*model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) ExistsInSeries(ctx context.Context, seriesID uuid.UUID, dueAt time.Time) (bool, error) {
+	args := m.Called(ctx, seriesID, dueAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) LastRank(ctx context.Context, todoListID, excludeID uuid.UUID) (string, error) {
+	args := m.Called(ctx, todoListID, excludeID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) AdjacentRank(ctx context.Context, todoListID uuid.UUID, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	args := m.Called(ctx, todoListID, rank, excludeIDs, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) Move(ctx context.Context, item *model.TodoItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) FindForRebalance(ctx context.Context, todoListID uuid.UUID) ([]model.TodoItem, error) {
+	args := m.Called(ctx, todoListID)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) SetRank(ctx context.Context, id uuid.UUID, rank string) error {
+	args := m.Called(ctx, id, rank)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) FindUnbalanced(ctx context.Context, maxLength, limit int) ([]model.TodoItem, error) {
+	args := m.Called(ctx, maxLength, limit)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) FindSubtree(ctx context.Context, todoListID, id uuid.UUID) ([]model.TodoItem, error) {
+	args := m.Called(ctx, todoListID, id)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) FindAncestors(ctx context.Context, id uuid.UUID) ([]model.TodoItem, error) {
+	args := m.Called(ctx, id)
+	if val, ok := args.Get(0).([]model.TodoItem); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) MoveToTodoList(ctx context.Context, ids []uuid.UUID, todoListID uuid.UUID) error {
+	args := m.Called(ctx, ids, todoListID)
+	return args.Error(0)
+}
+
+func (m *TodoItemRepositoryMock) CountSubtasks(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error) {
+	args := m.Called(ctx, parentIDs)
+	if val, ok := args.Get(0).(map[uuid.UUID]model.Progress); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemRepositoryMock) CountByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) (map[uuid.UUID]model.Progress, error) {
+	args := m.Called(ctx, todoListIDs)
+	if val, ok := args.Get(0).(map[uuid.UUID]model.Progress); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TodoItemServiceMock is a testify mock for service.TodoItemService.
+type TodoItemServiceMock struct {
+	mock.Mock
+}
+
+func (m *TodoItemServiceMock) CreateTodoItem(ctx context.Context, todoListID uuid.UUID, req model.CreateTodoItemRequest) (model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, req)
+	if resp, ok := args.Get(0).(model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoItemResponse{}, args.Error(1)
+}
+
+func (m *TodoItemServiceMock) ListTodoItems(ctx context.Context, todoListID uuid.UUID, filter model.TodoItemFilter) ([]model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, filter)
+	if resp, ok := args.Get(0).([]model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemServiceMock) GetTodoItem(ctx context.Context, todoListID, id uuid.UUID) (model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, id)
+	if resp, ok := args.Get(0).(model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoItemResponse{}, args.Error(1)
+}
+
+func (m *TodoItemServiceMock) UpdateTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.UpdateTodoItemRequest) (model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, id, req)
+	if resp, ok := args.Get(0).(model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoItemResponse{}, args.Error(1)
+}
+
+func (m *TodoItemServiceMock) DeleteTodoItem(ctx context.Context, todoListID, id uuid.UUID) error {
+	args := m.Called(ctx, todoListID, id)
+	return args.Error(0)
+}
+
+func (m *TodoItemServiceMock) MaterializeOccurrences(ctx context.Context, todoListID, id uuid.UUID, count int) ([]model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, id, count)
+	if resp, ok := args.Get(0).([]model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoItemServiceMock) MoveTodoItem(ctx context.Context, todoListID, id uuid.UUID, req model.MoveTodoItemRequest) (model.TodoItemResponse, error) {
+	args := m.Called(ctx, todoListID, id, req)
+	if resp, ok := args.Get(0).(model.TodoItemResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoItemResponse{}, args.Error(1)
+}
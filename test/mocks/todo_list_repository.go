@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/lumoshiveacademy/todolist/model"
@@ -26,8 +27,16 @@ func (m *TodoListRepositoryMock) FindByID(ctx context.Context, id uuid.UUID) (*m
 	return nil, args.Error(1)
 }
 
-func (m *TodoListRepositoryMock) FindAll(ctx context.Context) ([]model.TodoList, error) {
-	args := m.Called(ctx)
+func (m *TodoListRepositoryMock) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]model.TodoList, error) {
+	args := m.Called(ctx, ids)
+	if val, ok := args.Get(0).([]model.TodoList); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) FindAll(ctx context.Context, filter model.TodoListFilter) ([]model.TodoList, error) {
+	args := m.Called(ctx, filter)
 	if val, ok := args.Get(0).([]model.TodoList); ok {
 		return val, args.Error(1)
 	}
@@ -43,3 +52,62 @@ func (m *TodoListRepositoryMock) Delete(ctx context.Context, id uuid.UUID) error
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *TodoListRepositoryMock) FirstRank(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) LastRank(ctx context.Context, excludeID uuid.UUID) (string, error) {
+	args := m.Called(ctx, excludeID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) AdjacentRank(ctx context.Context, rank string, excludeIDs []uuid.UUID, after bool) (string, error) {
+	args := m.Called(ctx, rank, excludeIDs, after)
+	return args.String(0), args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) UpdateRank(ctx context.Context, todoList *model.TodoList) error {
+	args := m.Called(ctx, todoList)
+	return args.Error(0)
+}
+
+func (m *TodoListRepositoryMock) UpdateTemplate(ctx context.Context, todoList *model.TodoList) error {
+	args := m.Called(ctx, todoList)
+	return args.Error(0)
+}
+
+func (m *TodoListRepositoryMock) UpdateArchived(ctx context.Context, todoList *model.TodoList) error {
+	args := m.Called(ctx, todoList)
+	return args.Error(0)
+}
+
+func (m *TodoListRepositoryMock) FindAutoArchivable(ctx context.Context, now time.Time, limit int) ([]model.TodoList, error) {
+	args := m.Called(ctx, now, limit)
+	if val, ok := args.Get(0).([]model.TodoList); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) FindForRebalance(ctx context.Context) ([]model.TodoList, error) {
+	args := m.Called(ctx)
+	if val, ok := args.Get(0).([]model.TodoList); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRepositoryMock) SetRank(ctx context.Context, id uuid.UUID, rank string) error {
+	args := m.Called(ctx, id, rank)
+	return args.Error(0)
+}
+
+func (m *TodoListRepositoryMock) FindUnbalancedWorkspaces(ctx context.Context, maxLength, limit int) ([]uuid.UUID, error) {
+	args := m.Called(ctx, maxLength, limit)
+	if val, ok := args.Get(0).([]uuid.UUID); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// TodoListRevisionRepositoryMock is a testify mock for repository.TodoListRevisionRepository.
+type TodoListRevisionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TodoListRevisionRepositoryMock) Create(ctx context.Context, revision *model.TodoListRevision) error {
+	args := m.Called(ctx, revision)
+	return args.Error(0)
+}
+
+func (m *TodoListRevisionRepositoryMock) LatestNumber(ctx context.Context, todoListID uuid.UUID) (int, error) {
+	args := m.Called(ctx, todoListID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *TodoListRevisionRepositoryMock) FindByTodoListID(ctx context.Context, todoListID uuid.UUID) ([]model.TodoListRevision, error) {
+	args := m.Called(ctx, todoListID)
+	if val, ok := args.Get(0).([]model.TodoListRevision); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRevisionRepositoryMock) FindByTodoListIDs(ctx context.Context, todoListIDs []uuid.UUID) ([]model.TodoListRevision, error) {
+	args := m.Called(ctx, todoListIDs)
+	if val, ok := args.Get(0).([]model.TodoListRevision); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRevisionRepositoryMock) FindByNumber(ctx context.Context, todoListID uuid.UUID, number int) (*model.TodoListRevision, error) {
+	args := m.Called(ctx, todoListID, number)
+	if val, ok := args.Get(0).(*model.TodoListRevision); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListRevisionRepositoryMock) DeleteByTodoListID(ctx context.Context, todoListID uuid.UUID) error {
+	args := m.Called(ctx, todoListID)
+	return args.Error(0)
+}
@@ -29,8 +29,24 @@ func (m *TodoListServiceMock) GetTodoList(ctx context.Context, id uuid.UUID) (mo
 	return model.TodoListResponse{}, args.Error(1)
 }
 
-func (m *TodoListServiceMock) ListTodoLists(ctx context.Context) ([]model.TodoListResponse, error) {
-	args := m.Called(ctx)
+func (m *TodoListServiceMock) GetTodoLists(ctx context.Context, ids []uuid.UUID) ([]model.TodoListResponse, error) {
+	args := m.Called(ctx, ids)
+	if resp, ok := args.Get(0).([]model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListServiceMock) ListRevisionsByTodoLists(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]model.TodoListRevisionResponse, error) {
+	args := m.Called(ctx, ids)
+	if resp, ok := args.Get(0).(map[uuid.UUID][]model.TodoListRevisionResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListServiceMock) ListTodoLists(ctx context.Context, filter model.TodoListFilter) ([]model.TodoListResponse, error) {
+	args := m.Called(ctx, filter)
 	if resp, ok := args.Get(0).([]model.TodoListResponse); ok {
 		return resp, args.Error(1)
 	}
@@ -49,3 +65,75 @@ func (m *TodoListServiceMock) DeleteTodoList(ctx context.Context, id uuid.UUID)
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (m *TodoListServiceMock) ListRevisions(ctx context.Context, id uuid.UUID) ([]model.TodoListRevisionResponse, error) {
+	args := m.Called(ctx, id)
+	if resp, ok := args.Get(0).([]model.TodoListRevisionResponse); ok {
+		return resp, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *TodoListServiceMock) GetRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListRevisionResponse, error) {
+	args := m.Called(ctx, id, number)
+	if resp, ok := args.Get(0).(model.TodoListRevisionResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListRevisionResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) RestoreRevision(ctx context.Context, id uuid.UUID, number int) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id, number)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) MoveTodoList(ctx context.Context, id uuid.UUID, req model.MoveTodoListRequest) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) DuplicateTodoList(ctx context.Context, id uuid.UUID, req model.DuplicateTodoListRequest) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) SetTemplate(ctx context.Context, id uuid.UUID, isTemplate bool) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id, isTemplate)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) InstantiateTemplate(ctx context.Context, id uuid.UUID, req model.InstantiateTemplateRequest) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) ArchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
+
+func (m *TodoListServiceMock) UnarchiveTodoList(ctx context.Context, id uuid.UUID) (model.TodoListResponse, error) {
+	args := m.Called(ctx, id)
+	if resp, ok := args.Get(0).(model.TodoListResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.TodoListResponse{}, args.Error(1)
+}
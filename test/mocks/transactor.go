@@ -0,0 +1,12 @@
+package mocks
+
+import "context"
+
+// FakeTransactor is a repository.Transactor that runs fn directly, without a
+// database transaction.
+type FakeTransactor struct{}
+
+// WithinTransaction calls fn with ctx and returns its error.
+func (FakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// UserRepositoryMock is a testify mock for repository.UserRepository.
+type UserRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UserRepositoryMock) Create(ctx context.Context, user *model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) FindByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	args := m.Called(ctx, id)
+	if val, ok := args.Get(0).(*model.User); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *UserRepositoryMock) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	args := m.Called(ctx, email)
+	if val, ok := args.Get(0).(*model.User); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *UserRepositoryMock) FindByUsernames(ctx context.Context, workspaceID uuid.UUID, usernames []string) ([]model.User, error) {
+	args := m.Called(ctx, workspaceID, usernames)
+	if val, ok := args.Get(0).([]model.User); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *UserRepositoryMock) Update(ctx context.Context, user *model.User) error {
+	args := m.Called(ctx, user)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) AdvanceMFAStep(ctx context.Context, id uuid.UUID, step int64) error {
+	args := m.Called(ctx, id, step)
+	return args.Error(0)
+}
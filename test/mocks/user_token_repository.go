@@ -0,0 +1,33 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// UserTokenRepositoryMock is a testify mock for repository.UserTokenRepository.
+type UserTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UserTokenRepositoryMock) Create(ctx context.Context, userToken *model.UserToken) error {
+	args := m.Called(ctx, userToken)
+	return args.Error(0)
+}
+
+func (m *UserTokenRepositoryMock) Consume(ctx context.Context, purpose, tokenHash string, at time.Time) (*model.UserToken, error) {
+	args := m.Called(ctx, purpose, tokenHash, at)
+	if val, ok := args.Get(0).(*model.UserToken); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *UserTokenRepositoryMock) InvalidateForUser(ctx context.Context, userID uuid.UUID, purpose string, at time.Time) error {
+	args := m.Called(ctx, userID, purpose, at)
+	return args.Error(0)
+}
@@ -0,0 +1,47 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// WorkspaceRepositoryMock is a testify mock for repository.WorkspaceRepository.
+type WorkspaceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WorkspaceRepositoryMock) Create(ctx context.Context, workspace *model.Workspace) error {
+	args := m.Called(ctx, workspace)
+	return args.Error(0)
+}
+
+func (m *WorkspaceRepositoryMock) FindByID(ctx context.Context, id uuid.UUID) (*model.Workspace, error) {
+	args := m.Called(ctx, id)
+	if val, ok := args.Get(0).(*model.Workspace); ok {
+		return val, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *WorkspaceRepositoryMock) Update(ctx context.Context, workspace *model.Workspace) error {
+	args := m.Called(ctx, workspace)
+	return args.Error(0)
+}
+
+func (m *WorkspaceRepositoryMock) AddMember(ctx context.Context, workspaceID uuid.UUID, subject string) error {
+	args := m.Called(ctx, workspaceID, subject)
+	return args.Error(0)
+}
+
+func (m *WorkspaceRepositoryMock) RemoveMember(ctx context.Context, workspaceID uuid.UUID, subject string) error {
+	args := m.Called(ctx, workspaceID, subject)
+	return args.Error(0)
+}
+
+func (m *WorkspaceRepositoryMock) IsMember(ctx context.Context, workspaceID uuid.UUID, subject string) (bool, error) {
+	args := m.Called(ctx, workspaceID, subject)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lumoshiveacademy/todolist/model"
+	"github.com/stretchr/testify/mock"
+)
+
+// WorkspaceServiceMock is a testify mock for service.WorkspaceService.
+type WorkspaceServiceMock struct {
+	mock.Mock
+}
+
+func (m *WorkspaceServiceMock) CreateWorkspace(ctx context.Context, req model.CreateWorkspaceRequest) (model.WorkspaceResponse, error) {
+	args := m.Called(ctx, req)
+	if resp, ok := args.Get(0).(model.WorkspaceResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.WorkspaceResponse{}, args.Error(1)
+}
+
+func (m *WorkspaceServiceMock) GetWorkspace(ctx context.Context, id uuid.UUID) (model.WorkspaceResponse, error) {
+	args := m.Called(ctx, id)
+	if resp, ok := args.Get(0).(model.WorkspaceResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.WorkspaceResponse{}, args.Error(1)
+}
+
+func (m *WorkspaceServiceMock) UpdateWorkspace(ctx context.Context, id uuid.UUID, req model.UpdateWorkspaceRequest) (model.WorkspaceResponse, error) {
+	args := m.Called(ctx, id, req)
+	if resp, ok := args.Get(0).(model.WorkspaceResponse); ok {
+		return resp, args.Error(1)
+	}
+	return model.WorkspaceResponse{}, args.Error(1)
+}
+
+func (m *WorkspaceServiceMock) AddMember(ctx context.Context, id uuid.UUID, subject string) error {
+	args := m.Called(ctx, id, subject)
+	return args.Error(0)
+}
+
+func (m *WorkspaceServiceMock) RemoveMember(ctx context.Context, id uuid.UUID, subject string) error {
+	args := m.Called(ctx, id, subject)
+	return args.Error(0)
+}
+
+func (m *WorkspaceServiceMock) IsMember(ctx context.Context, id uuid.UUID, subject string) (bool, error) {
+	args := m.Called(ctx, id, subject)
+	return args.Bool(0), args.Error(1)
+}